@@ -18,22 +18,42 @@
 package main
 
 import (
+	"context"
+	"expvar"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/kubeslice/router-sidecar/pkg/metrics"
 	"github.com/kubeslice/router-sidecar/pkg/server"
 	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// grpcShutdownTimeout bounds the wait for the GRPC requests in flight on shutdown.
+const grpcShutdownTimeout = 10 * time.Second
+
+// newGrpcServer creates the GRPC server to communicate to Slice Controller
+func newGrpcServer() *grpc.Server {
+	srv := grpc.NewServer(server.ServerOptions()...)
+	sidecar.RegisterSliceRouterSidecarServiceServer(srv, &server.SliceRouterSidecar{})
+
+	// TODO: only for debug purpose please revert
+	reflection.Register(srv)
+	return srv
+}
+
 // startGrpcServer shall start the GRPC server to communicate to Slice Controller
-func startGrpcServer(grpcPort string) error {
+func startGrpcServer(srv *grpc.Server, grpcPort string) error {
 	address := fmt.Sprintf(":%s", grpcPort)
 	logger.GlobalLogger.Infof("Starting GRPC Server for SLICEROUTER_POD Pod at %v", address)
 
@@ -43,13 +63,11 @@ func startGrpcServer(grpcPort string) error {
 		return err
 	}
 
-	srv := grpc.NewServer()
-	sidecar.RegisterSliceRouterSidecarServiceServer(srv, &server.SliceRouterSidecar{})
-
-	// TODO: only for debug purpose please revert
-	reflection.Register(srv)
-
 	err = srv.Serve(lis)
+	// The server may be stopped by the shutdown before it is started.
+	if err == grpc.ErrServerStopped {
+		err = nil
+	}
 	if err != nil {
 		logger.GlobalLogger.Errorf("Start GRPC Server Failed with %v", err.Error())
 		return err
@@ -60,8 +78,71 @@ func startGrpcServer(grpcPort string) error {
 	return nil
 }
 
-// shutdownHandler triggers application shutdown.
-func shutdownHandler(wg *sync.WaitGroup) {
+// startMetricsServer shall start the HTTP server exposing the sidecar metrics, along with the internal
+// state of the sidecar at /debug/vars
+func startMetricsServer(metricCollectorPort string) error {
+	address := fmt.Sprintf(":%s", metricCollectorPort)
+	logger.GlobalLogger.Infof("Starting metrics server at %v", address)
+
+	server.PublishDebugVars()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	err := http.ListenAndServe(address, mux)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Start metrics server failed with %v", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// dumpSupportBundle fetches the support bundle from the sidecar running in this pod and writes it
+// to stdout in JSON.
+func dumpSupportBundle(grpcPort string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	conn, err := server.NewSidecarConn(fmt.Sprintf("localhost:%s", grpcPort))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// The connection does not block, wait for the sidecar to be reachable until the timeout expires.
+	bundle, err := sidecar.NewSliceRouterSidecarServiceClient(conn).GetSupportBundle(ctx, &emptypb.Empty{},
+		grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	b, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(b))
+	return err
+}
+
+// stopGrpcServer stops accepting GRPC requests and waits for the requests in flight, which are
+// cancelled once the timeout expires.
+func stopGrpcServer(srv *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		logger.GlobalLogger.Warnf("GRPC requests still running after %v, cancelling them", timeout)
+		srv.Stop()
+	}
+}
+
+// shutdownHandler triggers application shutdown. The process exits with an error if the slice routes
+// could not be handled.
+func shutdownHandler(wg *sync.WaitGroup, srv *grpc.Server) {
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
@@ -72,8 +153,16 @@ func shutdownHandler(wg *sync.WaitGroup) {
 	sig := <-signChan
 	logger.GlobalLogger.Infof("Teardown started with ", sig, "signal")
 
+	// No route injection may land once the routes are flushed.
+	stopGrpcServer(srv, grpcShutdownTimeout)
+
+	err := server.ShutdownSliceRouterPod()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to clean up slice routes on shutdown: %v", err)
+		os.Exit(1)
+	}
+
 	wg.Done()
-	os.Exit(1)
 }
 
 func main() {
@@ -94,25 +183,45 @@ func main() {
 		logLevel = "INFO"
 	}
 
+	// "kubeslice-router-sidecar dump" collects the state of the running sidecar for support bundles.
+	if len(os.Args) > 1 && os.Args[1] == "dump" {
+		if err := dumpSupportBundle(grpcPort); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to dump support bundle: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Create a Logger Module
 	logger.GlobalLogger = logger.NewLogger(logLevel)
 
-	err := server.BootstrapSliceRouterPod()
+	bootstrap, err := server.BootstrapSliceRouterPod()
 	if err != nil {
 		logger.GlobalLogger.Errorf("Failed to bootstrap Kubeslice-router-sidecar pod")
 	}
+	logger.GlobalLogger.Infof("Bootstrap applied: %+v", *bootstrap)
 
-	// Start the GRPC Server to communicate with slice controller.
+	// Start the GRPC Server to communicate with slice controller. The bootstrap waited for the dataplane
+	// to be ready, so that early route injections do not fail.
+	srv := newGrpcServer()
 	go func() {
-		err := startGrpcServer(grpcPort)
+		err := startGrpcServer(srv, grpcPort)
 		if err != nil {
 			logger.GlobalLogger.Errorf("Failed to bootstrap startGrpcServer")
 		}
 	}()
 
+	// Start the metrics server to expose the sidecar metrics.
+	go func() {
+		err := startMetricsServer(metricCollectorPort)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to bootstrap startMetricsServer")
+		}
+	}()
+
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
-	go shutdownHandler(wg)
+	go shutdownHandler(wg, srv)
 
 	wg.Wait()
 	logger.GlobalLogger.Infof("kubeslice-router-sidecar exited")
@@ -0,0 +1,258 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package kube is a minimal client of the Kubernetes API, enough for the sidecar to patch the status of
+// a custom resource and record events without carrying the Kubernetes client libraries.
+package kube
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Files of the service account mounted in the pods.
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	tokenFile         = "token"
+	caFile            = "ca.crt"
+	namespaceFile     = "namespace"
+)
+
+// ErrNotInCluster is returned by InClusterConfig when the process does not run in a pod.
+var ErrNotInCluster = errors.New("not running in a Kubernetes cluster")
+
+// Config tells how to reach and authenticate to the API server.
+type Config struct {
+	// Host is the URL of the API server.
+	Host string
+	// BearerTokenFile holds the token authenticating the requests. It is read again for every request
+	// so that rotated tokens are picked up.
+	BearerTokenFile string
+	// TLSConfig verifies the API server.
+	TLSConfig *tls.Config
+	// Namespace is the namespace of the pod, empty if unknown.
+	Namespace string
+}
+
+// InClusterConfig returns the config of the service account of the pod the process runs in.
+func InClusterConfig() (*Config, error) {
+	return inClusterConfig(serviceAccountDir)
+}
+
+func inClusterConfig(dir string) (*Config, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, ErrNotInCluster
+	}
+	tokenPath := filepath.Join(dir, tokenFile)
+	if _, err := os.Stat(tokenPath); err != nil {
+		return nil, err
+	}
+	ca, err := os.ReadFile(filepath.Join(dir, caFile))
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificate found in %v", filepath.Join(dir, caFile))
+	}
+	config := &Config{
+		Host:            "https://" + net.JoinHostPort(host, port),
+		BearerTokenFile: tokenPath,
+		TLSConfig:       &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	}
+	if namespace, err := os.ReadFile(filepath.Join(dir, namespaceFile)); err == nil {
+		config.Namespace = strings.TrimSpace(string(namespace))
+	}
+	return config, nil
+}
+
+// ResourceRef identifies a namespaced resource.
+type ResourceRef struct {
+	// APIVersion is the group and version of the resource, like kubeslice.io/v1beta1, or v1 for the
+	// core group.
+	APIVersion string
+	// Resource is the plural name of the resource kind.
+	Resource  string
+	Namespace string
+	Name      string
+}
+
+// path returns the API path of the resource.
+func (r ResourceRef) path() string {
+	prefix := "/apis/" + r.APIVersion
+	if !strings.Contains(r.APIVersion, "/") {
+		prefix = "/api/" + r.APIVersion
+	}
+	return fmt.Sprintf("%v/namespaces/%v/%v/%v", prefix, r.Namespace, r.Resource, r.Name)
+}
+
+func (r ResourceRef) String() string {
+	return fmt.Sprintf("%v %v/%v", r.Resource, r.Namespace, r.Name)
+}
+
+// Client sends requests to the API server.
+type Client struct {
+	config *Config
+	client *http.Client
+}
+
+// NewClient creates a client of the API server, each request being bounded by the timeout.
+func NewClient(config *Config, timeout time.Duration) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config.TLSConfig
+	return &Client{config: config, client: &http.Client{Transport: transport, Timeout: timeout}}
+}
+
+// StatusError is returned when the API server rejects a request.
+type StatusError struct {
+	Code    int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API server returned status %v: %v", e.Code, e.Message)
+}
+
+// PatchStatus merges the status into the status subresource of the resource. The resource must exist
+// and its kind must have the status subresource enabled.
+func (c *Client) PatchStatus(ctx context.Context, ref ResourceRef, status interface{}) error {
+	return c.do(ctx, http.MethodPatch, ref.path()+"/status", "application/merge-patch+json",
+		map[string]interface{}{"status": status})
+}
+
+// Event types.
+const (
+	EventTypeNormal  = "Normal"
+	EventTypeWarning = "Warning"
+)
+
+// ObjectReference identifies the object an event is about.
+type ObjectReference struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// EventMetadata is the object metadata of an event.
+type EventMetadata struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// EventSource is the component reporting an event.
+type EventSource struct {
+	Component string `json:"component,omitempty"`
+	Host      string `json:"host,omitempty"`
+}
+
+// Event is the part of a core v1 event the client sets.
+type Event struct {
+	Metadata       EventMetadata   `json:"metadata"`
+	InvolvedObject ObjectReference `json:"involvedObject"`
+	// Reason is a short CamelCase reason of the event, Message the human readable details.
+	Reason         string      `json:"reason"`
+	Message        string      `json:"message"`
+	Type           string      `json:"type"`
+	Source         EventSource `json:"source"`
+	FirstTimestamp time.Time   `json:"firstTimestamp"`
+	LastTimestamp  time.Time   `json:"lastTimestamp"`
+	Count          int32       `json:"count"`
+}
+
+// CreateEvent creates the event in the namespace of its metadata. The client does not aggregate events,
+// each call creates a new event.
+func (c *Client) CreateEvent(ctx context.Context, event *Event) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/api/v1/namespaces/%v/events", event.Metadata.Namespace),
+		"application/json", event)
+}
+
+// PatchEvent updates the count, message and last timestamp of an event created before, so that an event
+// occurring again is recorded on the existing event.
+func (c *Client) PatchEvent(ctx context.Context, event *Event) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/api/v1/namespaces/%v/events/%v", event.Metadata.Namespace, event.Metadata.Name),
+		"application/merge-patch+json", map[string]interface{}{
+			"count":         event.Count,
+			"message":       event.Message,
+			"lastTimestamp": event.LastTimestamp,
+		})
+}
+
+// IsNotFound returns true if the API server rejected the request because the object does not exist.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound
+}
+
+// do sends the object as JSON to the API path and returns an error if the request is rejected.
+func (c *Client) do(ctx context.Context, method, path, contentType string, object interface{}) error {
+	body, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(c.config.Host, "/") + path
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	if c.config.BearerTokenFile != "" {
+		data, err := os.ReadFile(c.config.BearerTokenFile)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(data)))
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{Code: resp.StatusCode, Message: statusMessage(resp.Body)}
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// statusMessage returns the message of the Status object returned by the API server on errors, or the
+// raw body if it is not one.
+func statusMessage(body io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(body, 4096))
+	status := struct {
+		Message string `json:"message"`
+	}{}
+	if err := json.Unmarshal(data, &status); err == nil && status.Message != "" {
+		return status.Message
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,270 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// patch is a request received by the fake API server.
+type patch struct {
+	method        string
+	path          string
+	contentType   string
+	authorization string
+	body          map[string]interface{}
+}
+
+// newFakeAPIServer starts a TLS API server recording the requests and answering with the status code.
+func newFakeAPIServer(t *testing.T, code int, patches *[]patch) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, _ := io.ReadAll(req.Body)
+		p := patch{method: req.Method, path: req.URL.Path, contentType: req.Header.Get("Content-Type"),
+			authorization: req.Header.Get("Authorization")}
+		json.Unmarshal(data, &p.body)
+		*patches = append(*patches, p)
+		w.WriteHeader(code)
+		if code != http.StatusOK {
+			w.Write([]byte(`{"kind":"Status","message":"routestatuses.kubeslice.io \"red\" not found"}`))
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// writeFile writes the file in the test dir and returns its path.
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func serverCA(srv *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+func TestResourceRefPath(t *testing.T) {
+	tests := []struct {
+		ref      ResourceRef
+		expected string
+	}{
+		{ResourceRef{"kubeslice.io/v1beta1", "routestatuses", "kubeslice-system", "red"},
+			"/apis/kubeslice.io/v1beta1/namespaces/kubeslice-system/routestatuses/red"},
+		{ResourceRef{"v1", "configmaps", "default", "routes"}, "/api/v1/namespaces/default/configmaps/routes"},
+	}
+	for _, tt := range tests {
+		if path := tt.ref.path(); path != tt.expected {
+			t.Error("expected", tt.expected, "received", path)
+		}
+	}
+}
+
+func TestPatchStatus(t *testing.T) {
+	ref := ResourceRef{"kubeslice.io/v1beta1", "routestatuses", "kubeslice-system", "red"}
+	status := map[string]interface{}{"routeCount": 2}
+
+	tests := []struct {
+		testName    string
+		code        int
+		expectedErr error
+	}{
+		{"status patched", http.StatusOK, nil},
+		{"resource missing", http.StatusNotFound,
+			&StatusError{Code: http.StatusNotFound, Message: `routestatuses.kubeslice.io "red" not found`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			patches := []patch{}
+			srv := newFakeAPIServer(t, tt.code, &patches)
+			dir := t.TempDir()
+			tokenPath := writeFile(t, dir, "token", []byte("first\n"))
+			config, err := inClusterConfigFor(t, srv, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config.BearerTokenFile = tokenPath
+			client := NewClient(config, time.Second)
+
+			err = client.PatchStatus(context.Background(), ref, status)
+			var statusErr *StatusError
+			if tt.expectedErr == nil && err != nil {
+				t.Fatal("expected no error, received", err)
+			}
+			if tt.expectedErr != nil && (!errors.As(err, &statusErr) || *statusErr != *tt.expectedErr.(*StatusError)) {
+				t.Fatal("expected", tt.expectedErr, "received", err)
+			}
+			// The rotated token is used by the next request.
+			writeFile(t, dir, "token", []byte("second\n"))
+			client.PatchStatus(context.Background(), ref, status)
+
+			if len(patches) != 2 {
+				t.Fatal("expected 2 requests, received", patches)
+			}
+			p := patches[0]
+			if p.method != http.MethodPatch || p.path != ref.path()+"/status" || p.contentType != "application/merge-patch+json" {
+				t.Error("unexpected request", p)
+			}
+			if p.authorization != "Bearer first" || patches[1].authorization != "Bearer second" {
+				t.Error("unexpected authorization", p.authorization, patches[1].authorization)
+			}
+			if body, _ := json.Marshal(p.body); string(body) != `{"status":{"routeCount":2}}` {
+				t.Error("unexpected body", string(body))
+			}
+		})
+	}
+}
+
+// inClusterConfigFor returns the in-cluster config of a pod whose API server is srv.
+func inClusterConfigFor(t *testing.T, srv *httptest.Server, dir string) (*Config, error) {
+	t.Helper()
+	u, _ := url.Parse(srv.URL)
+	t.Setenv("KUBERNETES_SERVICE_HOST", u.Hostname())
+	t.Setenv("KUBERNETES_SERVICE_PORT", u.Port())
+	writeFile(t, dir, "ca.crt", serverCA(srv))
+	writeFile(t, dir, "token", []byte("first\n"))
+	writeFile(t, dir, "namespace", []byte("kubeslice-system\n"))
+	return inClusterConfig(dir)
+}
+
+func TestCreateEvent(t *testing.T) {
+	patches := []patch{}
+	srv := newFakeAPIServer(t, http.StatusCreated, &patches)
+	config, err := inClusterConfigFor(t, srv, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(config, time.Second)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := &Event{
+		Metadata:       EventMetadata{Name: "vl3-slice-router-0.18a2b3c4d5e6f708", Namespace: "kubeslice-system"},
+		InvolvedObject: ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "kubeslice-system", Name: "vl3-slice-router-0"},
+		Reason:         "NextHopChanged",
+		Message:        "Route to 10.2.0.0/16 moved",
+		Type:           EventTypeNormal,
+		Source:         EventSource{Component: "router-sidecar"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if err := client.CreateEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(patches) != 1 {
+		t.Fatal("expected 1 request, received", patches)
+	}
+	p := patches[0]
+	if p.method != http.MethodPost || p.path != "/api/v1/namespaces/kubeslice-system/events" || p.contentType != "application/json" {
+		t.Error("unexpected request", p)
+	}
+	involved, _ := p.body["involvedObject"].(map[string]interface{})
+	if p.body["reason"] != "NextHopChanged" || p.body["type"] != EventTypeNormal || involved["kind"] != "Pod" ||
+		involved["name"] != "vl3-slice-router-0" || p.body["firstTimestamp"] != "2026-01-02T03:04:05Z" {
+		t.Error("unexpected body", p.body)
+	}
+
+	// Status codes other than 200 and 201 are errors.
+	srv = newFakeAPIServer(t, http.StatusForbidden, &patches)
+	if config, err = inClusterConfigFor(t, srv, t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	var statusErr *StatusError
+	if err := NewClient(config, time.Second).CreateEvent(context.Background(), event); !errors.As(err, &statusErr) ||
+		statusErr.Code != http.StatusForbidden {
+		t.Error("expected a status error, received", err)
+	}
+}
+
+func TestInClusterConfig(t *testing.T) {
+	patches := []patch{}
+	srv := newFakeAPIServer(t, http.StatusOK, &patches)
+	config, err := inClusterConfigFor(t, srv, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Host != srv.URL || config.Namespace != "kubeslice-system" {
+		t.Error("unexpected config", config)
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if _, err := inClusterConfig(t.TempDir()); err != ErrNotInCluster {
+		t.Error("expected", ErrNotInCluster, "received", err)
+	}
+}
+
+func TestPatchEvent(t *testing.T) {
+	patches := []patch{}
+	srv := newFakeAPIServer(t, http.StatusOK, &patches)
+	config, err := inClusterConfigFor(t, srv, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(config, time.Second)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := &Event{
+		Metadata:      EventMetadata{Name: "vl3-slice-router-0.18a2b3c4d5e6f708", Namespace: "kubeslice-system"},
+		Reason:        "RouteCorrectionFailed",
+		Message:       "Failed to reconcile route to 10.2.0.0/16",
+		LastTimestamp: now,
+		Count:         3,
+	}
+	if err := client.PatchEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 {
+		t.Fatal("expected 1 request, received", patches)
+	}
+	p := patches[0]
+	if p.method != http.MethodPatch || p.path != "/api/v1/namespaces/kubeslice-system/events/vl3-slice-router-0.18a2b3c4d5e6f708" ||
+		p.contentType != "application/merge-patch+json" {
+		t.Error("unexpected request", p)
+	}
+	if body, _ := json.Marshal(p.body); string(body) !=
+		`{"count":3,"lastTimestamp":"2026-01-02T03:04:05Z","message":"Failed to reconcile route to 10.2.0.0/16"}` {
+		t.Error("unexpected body", string(body))
+	}
+
+	// The event expired from the API server.
+	srv = newFakeAPIServer(t, http.StatusNotFound, &patches)
+	if config, err = inClusterConfigFor(t, srv, t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewClient(config, time.Second).PatchEvent(context.Background(), event); !IsNotFound(err) {
+		t.Error("expected a not found error, received", err)
+	}
+}
@@ -18,7 +18,9 @@
 package logger
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -29,6 +31,39 @@ var GlobalLogger *Logger
 // Logger : Logger type
 type Logger struct {
 	handle *zap.SugaredLogger
+	// level is the level of the console output, changed at runtime by SetLevel.
+	level zap.AtomicLevel
+}
+
+// logLevelMap maps the log level names to the zap levels.
+var logLevelMap = map[string]zapcore.Level{
+	"DEBUG": zapcore.DebugLevel,
+	"INFO":  zapcore.InfoLevel,
+	"ERROR": zapcore.ErrorLevel,
+	"WARN":  zapcore.WarnLevel,
+	"FATAL": zapcore.FatalLevel,
+	"PANIC": zapcore.PanicLevel}
+
+// Level returns the name of the level messages are logged from.
+func (logger *Logger) Level() string {
+	level := logger.level.Level()
+	for name, l := range logLevelMap {
+		if l == level {
+			return name
+		}
+	}
+	return strings.ToUpper(level.String())
+}
+
+// SetLevel changes the level messages are logged from. The level name is not case sensitive, an error
+// is returned if it is unknown. Error messages are recorded regardless of the level.
+func (logger *Logger) SetLevel(logLevel string) error {
+	level, ok := logLevelMap[strings.ToUpper(logLevel)]
+	if !ok {
+		return fmt.Errorf("unknown log level %q", logLevel)
+	}
+	logger.level.SetLevel(level)
+	return nil
 }
 
 // Debugf : Log level type Debugf
@@ -41,6 +76,11 @@ func (logger *Logger) Infof(format string, args ...interface{}) {
 	logger.handle.Infof(format, args...)
 }
 
+// Warnf : Log level type Warnf
+func (logger *Logger) Warnf(format string, args ...interface{}) {
+	logger.handle.Warnf(format, args...)
+}
+
 // Errorf : Log level type Errorf
 func (logger *Logger) Errorf(format string, args ...interface{}) {
 	logger.handle.Errorf(format, args...)
@@ -88,15 +128,7 @@ func (logger *Logger) Panic(args ...interface{}) {
 
 // NewLogger creates the new logger object.
 func NewLogger(logLevel string) *Logger {
-	logLevelMap := map[string]zapcore.Level{
-		"DEBUG": zapcore.DebugLevel,
-		"INFO":  zapcore.InfoLevel,
-		"ERROR": zapcore.ErrorLevel,
-		"WARN":  zapcore.WarnLevel,
-		"FATAL": zapcore.FatalLevel,
-		"PANIC": zapcore.PanicLevel}
-
-	logLvl := logLevelMap[logLevel]
+	logLvl := zap.NewAtomicLevelAt(logLevelMap[logLevel])
 
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
@@ -105,10 +137,11 @@ func NewLogger(logLevel string) *Logger {
 	consoleEncoder := zapcore.NewConsoleEncoder(encoderConfig)
 	core := zapcore.NewTee(
 		zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), logLvl),
+		errorRecorder{zapcore.ErrorLevel},
 	)
 	logger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)).Sugar()
 
 	defer logger.Sync()
 
-	return &Logger{logger}
+	return &Logger{handle: logger, level: logLvl}
 }
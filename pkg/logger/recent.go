@@ -0,0 +1,90 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// recentErrorsSize is the number of error messages kept for support bundles.
+const recentErrorsSize = 100
+
+// ErrorEntry is an error message logged by the sidecar.
+type ErrorEntry struct {
+	Time    time.Time
+	Message string
+}
+
+// recentErrors keeps the last error messages logged by any logger.
+var recentErrors = &errorRing{}
+
+type errorRing struct {
+	mu      sync.Mutex
+	entries []ErrorEntry
+	next    int
+}
+
+func (r *errorRing) add(entry ErrorEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) < recentErrorsSize {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % recentErrorsSize
+}
+
+func (r *errorRing) list() []ErrorEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append(append([]ErrorEntry{}, r.entries[r.next:]...), r.entries[:r.next]...)
+}
+
+// RecentErrors returns the last error messages logged, oldest first.
+func RecentErrors() []ErrorEntry {
+	return recentErrors.list()
+}
+
+// errorRecorder is a zap core that records error and higher level messages in recentErrors.
+type errorRecorder struct {
+	zapcore.LevelEnabler
+}
+
+func (c errorRecorder) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+func (c errorRecorder) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c errorRecorder) Write(entry zapcore.Entry, _ []zapcore.Field) error {
+	recentErrors.add(ErrorEntry{Time: entry.Time, Message: entry.Message})
+	return nil
+}
+
+func (c errorRecorder) Sync() error {
+	return nil
+}
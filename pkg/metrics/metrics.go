@@ -0,0 +1,366 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package metrics implements the Prometheus metrics exported by the router sidecar.
+// Metrics are registered on a registry and served in the Prometheus text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultRegistry is the registry the metrics of the sidecar are registered on.
+var DefaultRegistry = NewRegistry()
+
+// DefBuckets are the default histogram buckets, in seconds.
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry holds a set of metrics and writes them in the Prometheus text format.
+type Registry struct {
+	mu          sync.Mutex
+	metrics     []*metricVec
+	constLabels map[string]string
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{constLabels: map[string]string{}}
+}
+
+// SetConstLabels sets labels that are attached to every metric of the registry.
+func (r *Registry) SetConstLabels(labels map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.constLabels = map[string]string{}
+	for name, value := range labels {
+		r.constLabels[name] = value
+	}
+}
+
+func (r *Registry) register(m *metricVec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, registered := range r.metrics {
+		if registered.name == m.name {
+			panic(fmt.Sprintf("metric %s registered twice", m.name))
+		}
+	}
+	r.metrics = append(r.metrics, m)
+}
+
+// Write writes all metrics of the registry to w in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	metrics := append([]*metricVec{}, r.metrics...)
+	constLabels := []labelPair{}
+	for name, value := range r.constLabels {
+		constLabels = append(constLabels, labelPair{name, value})
+	}
+	r.mu.Unlock()
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].name < metrics[j].name })
+	sort.Slice(constLabels, func(i, j int) bool { return constLabels[i].name < constLabels[j].name })
+
+	for _, m := range metrics {
+		if err := m.write(w, constLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler returns an http handler serving the metrics of the registry.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := r.Write(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// Handler returns an http handler serving the metrics of the default registry.
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+type labelPair struct {
+	name  string
+	value string
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	// Histogram only
+	bucketCounts []uint64
+	count        uint64
+}
+
+type metricVec struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+func newMetricVec(r *Registry, kind, name, help string, labelNames []string) *metricVec {
+	m := &metricVec{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		series:     map[string]*series{},
+	}
+	r.register(m)
+	return m
+}
+
+func (m *metricVec) get(labelValues []string) *series {
+	if len(labelValues) != len(m.labelNames) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", m.name, len(m.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string{}, labelValues...)}
+		if m.kind == "histogram" {
+			s.bucketCounts = make([]uint64, len(m.buckets))
+		}
+		m.series[key] = s
+	}
+	return s
+}
+
+func (m *metricVec) value(labelValues []string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.series[strings.Join(labelValues, "\xff")]
+	if !ok {
+		return 0
+	}
+	if m.kind == "histogram" {
+		return float64(s.count)
+	}
+	return s.value
+}
+
+func (m *metricVec) delete(labelValues []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.series, strings.Join(labelValues, "\xff"))
+}
+
+func (m *metricVec) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.series = map[string]*series{}
+}
+
+func (m *metricVec) write(w io.Writer, constLabels []labelPair) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, escapeHelp(m.help), m.name, m.kind); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := m.series[key]
+		labels := append([]labelPair{}, constLabels...)
+		for i, name := range m.labelNames {
+			labels = append(labels, labelPair{name, s.labelValues[i]})
+		}
+		if m.kind != "histogram" {
+			if _, err := fmt.Fprintf(w, "%s%s %s\n", m.name, formatLabels(labels), formatValue(s.value)); err != nil {
+				return err
+			}
+			continue
+		}
+		for i, upperBound := range m.buckets {
+			bucketLabels := append(append([]labelPair{}, labels...), labelPair{"le", formatValue(upperBound)})
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", m.name, formatLabels(bucketLabels), s.bucketCounts[i]); err != nil {
+				return err
+			}
+		}
+		infLabels := append(append([]labelPair{}, labels...), labelPair{"le", "+Inf"})
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			m.name, formatLabels(infLabels), s.count,
+			m.name, formatLabels(labels), formatValue(s.value),
+			m.name, formatLabels(labels), s.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatLabels(labels []labelPair) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, label.name+`="`+escapeLabelValue(label.value)+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// escapeLabelValue escapes a label value for the text exposition format, which only knows the \\, \"
+// and \n escapes. Other characters, tabs and non ASCII ones included, are written as is.
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer("\\", `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer("\\", `\\`, "\n", `\n`).Replace(help)
+}
+
+// CounterVec is a counter partitioned by label values.
+type CounterVec struct {
+	m *metricVec
+}
+
+// NewCounterVec creates a counter on the default registry.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{newMetricVec(DefaultRegistry, "counter", name, help, labelNames)}
+}
+
+// Inc increments the counter with the given label values by one.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v to the counter with the given label values. v must not be negative.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic(fmt.Sprintf("counter %s cannot decrease", c.m.name))
+	}
+	c.m.mu.Lock()
+	defer c.m.mu.Unlock()
+	c.m.get(labelValues).value += v
+}
+
+// Value returns the current value of the counter with the given label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	return c.m.value(labelValues)
+}
+
+// Reset removes all label values of the counter.
+func (c *CounterVec) Reset() {
+	c.m.reset()
+}
+
+// GaugeVec is a gauge partitioned by label values.
+type GaugeVec struct {
+	m *metricVec
+}
+
+// NewGaugeVec creates a gauge on the default registry.
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{newMetricVec(DefaultRegistry, "gauge", name, help, labelNames)}
+}
+
+// Set sets the gauge with the given label values to v.
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	g.m.mu.Lock()
+	defer g.m.mu.Unlock()
+	g.m.get(labelValues).value = v
+}
+
+// Add adds v to the gauge with the given label values.
+func (g *GaugeVec) Add(v float64, labelValues ...string) {
+	g.m.mu.Lock()
+	defer g.m.mu.Unlock()
+	g.m.get(labelValues).value += v
+}
+
+// Value returns the current value of the gauge with the given label values.
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	return g.m.value(labelValues)
+}
+
+// Delete removes the gauge with the given label values.
+func (g *GaugeVec) Delete(labelValues ...string) {
+	g.m.delete(labelValues)
+}
+
+// Reset removes all label values of the gauge.
+func (g *GaugeVec) Reset() {
+	g.m.reset()
+}
+
+// HistogramVec is a histogram partitioned by label values.
+type HistogramVec struct {
+	m *metricVec
+}
+
+// NewHistogramVec creates a histogram on the default registry. If buckets is nil, DefBuckets is used.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefBuckets
+	}
+	m := newMetricVec(DefaultRegistry, "histogram", name, help, labelNames)
+	m.buckets = append([]float64{}, buckets...)
+	sort.Float64s(m.buckets)
+	return &HistogramVec{m}
+}
+
+// Observe adds an observation to the histogram with the given label values.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	h.m.mu.Lock()
+	defer h.m.mu.Unlock()
+	s := h.m.get(labelValues)
+	for i, upperBound := range h.m.buckets {
+		if v <= upperBound {
+			s.bucketCounts[i]++
+		}
+	}
+	s.count++
+	s.value += v
+}
+
+// Count returns the number of observations of the histogram with the given label values.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	return uint64(h.m.value(labelValues))
+}
@@ -0,0 +1,99 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+	counter := &CounterVec{newMetricVec(r, "counter", "test_requests_total", "Requests.", []string{"method"})}
+	gauge := &GaugeVec{newMetricVec(r, "gauge", "test_routes", "Routes.", nil)}
+	histogram := &HistogramVec{newMetricVec(r, "histogram", "test_latency_seconds", "Latency.", []string{"method"})}
+	histogram.m.buckets = []float64{0.1, 1}
+
+	counter.Inc("get")
+	counter.Add(2, "get")
+	counter.Inc("set")
+	gauge.Set(5)
+	gauge.Add(-2)
+	histogram.Observe(0.05, "get")
+	histogram.Observe(0.5, "get")
+	r.SetConstLabels(map[string]string{"slice": "red"})
+
+	expected := `# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{slice="red",method="get",le="0.1"} 1
+test_latency_seconds_bucket{slice="red",method="get",le="1"} 2
+test_latency_seconds_bucket{slice="red",method="get",le="+Inf"} 2
+test_latency_seconds_sum{slice="red",method="get"} 0.55
+test_latency_seconds_count{slice="red",method="get"} 2
+# HELP test_requests_total Requests.
+# TYPE test_requests_total counter
+test_requests_total{slice="red",method="get"} 3
+test_requests_total{slice="red",method="set"} 1
+# HELP test_routes Routes.
+# TYPE test_routes gauge
+test_routes{slice="red"} 3
+`
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Errorf("metrics: expected\n%s\nreceived\n%s", expected, buf.String())
+	}
+
+	if counter.Value("get") != 3 || gauge.Value() != 3 || histogram.Count("get") != 2 {
+		t.Error("unexpected metric values", counter.Value("get"), gauge.Value(), histogram.Count("get"))
+	}
+
+	gauge.Reset()
+	if gauge.Value() != 0 {
+		t.Error("gauge: expected reset, received", gauge.Value())
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	labels := []labelPair{
+		{"slice", "red"},
+		{"quoted", `a "b" \c`},
+		{"multiline", "a\nb"},
+		{"tab", "a\tb"},
+		{"unicode", "rød\u00e9"},
+	}
+	expected := `{slice="red",quoted="a \"b\" \\c",multiline="a\nb",tab="a` + "\t" + `b",unicode="rødé"}`
+	if formatted := formatLabels(labels); formatted != expected {
+		t.Errorf("labels: expected %s, received %s", expected, formatted)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	counter := NewCounterVec("test_handler_total", "Handler test.")
+	counter.Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), "test_handler_total 1\n") {
+		t.Error("metrics: expected test_handler_total, received", rec.Body.String())
+	}
+}
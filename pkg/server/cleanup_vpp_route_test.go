@@ -0,0 +1,195 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// interVrfRoutes returns the inter vrf vpp entries of the slice route vrf to the remote subnet via the
+// next hops.
+func interVrfRoutes(remoteSubnet string, nextHopIPs ...string) []*vpp.Route {
+	routes := []*vpp.Route{}
+	vrf := getVppSliceRouteVrf()
+	for _, nextHopIP := range nextHopIPs {
+		routes = append(routes, &vpp.Route{Type: vpp_l3.Route_INTER_VRF, VrfId: vrf, ViaVrfId: vrf, DstNetwork: remoteSubnet, NextHopAddr: nextHopIP})
+	}
+	return routes
+}
+
+func TestCleanupVppRoute(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_SLICE_ROUTE_VRF", "5")
+	resetRouteMap(t)
+	vppRouteDeletesCounter.Reset()
+
+	fakeVpp := newFakeVppAgent()
+	// Next hop changes left the entries via 192.168.0.2 and 192.168.0.10 behind.
+	fakeVpp.config.Routes = append(interVrfRoutes("10.1.0.0/16", "192.168.0.2", "192.168.0.6", "192.168.0.10"),
+		interVrfRoutes("10.2.0.0/16", "192.168.0.2")...)
+	useFakeVppAgent(t, fakeVpp)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.6"})
+	remoteSubnetRouteMap.Store("10.2.0.0/16", []string{"192.168.0.2"})
+	remoteSubnetRouteMap.Store("10.3.0.0/16", []string{"192.168.0.6"})
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	t.Run("stale entries are deleted", func(t *testing.T) {
+		response, err := client.CleanupVppRoute(ctx, &pb.VppRouteCleanupRequest{RemoteSubnet: "10.1.0.0/16"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(response.GetRemovedNextHopIPList(), []string{"192.168.0.2", "192.168.0.10"}) {
+			t.Error("removed: expected 192.168.0.2 and 192.168.0.10, received", response.GetRemovedNextHopIPList())
+		}
+		if !reflect.DeepEqual(response.GetKeptNextHopIPList(), []string{"192.168.0.6"}) || len(response.GetAddedNextHopIPList()) != 0 {
+			t.Error("expected 192.168.0.6 kept and none added, received", response)
+		}
+		if state := vppRouteState(fakeVpp, "10.1.0.0/16"); !reflect.DeepEqual(state, []string{"192.168.0.6"}) {
+			t.Error("expected a single entry via 192.168.0.6, received", state)
+		}
+		if state := vppRouteState(fakeVpp, "10.2.0.0/16"); !reflect.DeepEqual(state, []string{"192.168.0.2"}) {
+			t.Error("expected the other prefixes untouched, received", state)
+		}
+		if count := vppRouteDeletesCounter.Value(vppRouteDeleteStale); count != 2 {
+			t.Errorf("expected 2 stale deletions counted, received %v", count)
+		}
+	})
+
+	t.Run("missing entries are added", func(t *testing.T) {
+		// The prefix is looked up in its canonical form.
+		response, err := client.CleanupVppRoute(ctx, &pb.VppRouteCleanupRequest{RemoteSubnet: "10.3.0.5/16"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.GetRemoteSubnet() != "10.3.0.0/16" {
+			t.Error("remote subnet: expected 10.3.0.0/16, received", response.GetRemoteSubnet())
+		}
+		if !reflect.DeepEqual(response.GetAddedNextHopIPList(), []string{"192.168.0.6"}) {
+			t.Error("added: expected 192.168.0.6, received", response.GetAddedNextHopIPList())
+		}
+		if state := vppRouteState(fakeVpp, "10.3.0.0/16"); !reflect.DeepEqual(state, []string{"192.168.0.6"}) {
+			t.Error("expected an entry via 192.168.0.6, received", state)
+		}
+	})
+
+	t.Run("failed deletion", func(t *testing.T) {
+		fakeVpp.mu.Lock()
+		fakeVpp.config.Routes = append(fakeVpp.config.Routes, interVrfRoutes("10.2.0.0/16", "192.168.0.6")...)
+		fakeVpp.deleteErr = errors.New("vpp-agent unavailable")
+		fakeVpp.mu.Unlock()
+		defer func() {
+			fakeVpp.mu.Lock()
+			fakeVpp.deleteErr = nil
+			fakeVpp.mu.Unlock()
+		}()
+		t.Setenv("VPP_AGENT_MAX_RETRIES", "0")
+		_, err := client.CleanupVppRoute(ctx, &pb.VppRouteCleanupRequest{RemoteSubnet: "10.2.0.0/16"})
+		if status.Code(err) != codes.Unavailable {
+			t.Error("expected Unavailable, received", err)
+		}
+	})
+
+	t.Run("entries of vrf 0 are not stale", func(t *testing.T) {
+		// Other agents may configure entries of the slice shape in vrf 0.
+		t.Setenv("VPP_SLICE_ROUTE_VRF", "")
+		fakeVpp.mu.Lock()
+		fakeVpp.config.Routes = append(fakeVpp.config.Routes, interVrfRoutes("10.4.0.0/16", "192.168.0.2", "192.168.0.6")...)
+		fakeVpp.mu.Unlock()
+		remoteSubnetRouteMap.Store("10.4.0.0/16", []string{"192.168.0.6"})
+		response, err := client.CleanupVppRoute(ctx, &pb.VppRouteCleanupRequest{RemoteSubnet: "10.4.0.0/16"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(response.GetRemovedNextHopIPList()) != 0 {
+			t.Error("expected none removed, received", response.GetRemovedNextHopIPList())
+		}
+		if state := vppRouteState(fakeVpp, "10.4.0.0/16"); !reflect.DeepEqual(state, []string{"192.168.0.2", "192.168.0.6"}) {
+			t.Error("expected the entries kept, received", state)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := client.CleanupVppRoute(ctx, &pb.VppRouteCleanupRequest{RemoteSubnet: "10.1.0.0"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Error("invalid prefix: expected InvalidArgument, received", err)
+		}
+		t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+		_, err = client.CleanupVppRoute(ctx, &pb.VppRouteCleanupRequest{RemoteSubnet: "10.1.0.0/16"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Error("kernel mode: expected FailedPrecondition, received", err)
+		}
+	})
+}
+
+func TestReconcileRoutesInVpp(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_SLICE_ROUTE_VRF", "5")
+	resetRouteMap(t)
+
+	fakeVpp := newFakeVppAgent()
+	fakeVpp.config.Routes = append(interVrfRoutes("10.1.0.0/16", "192.168.0.2", "192.168.0.6"),
+		interVrfRoutes("10.2.0.0/16", "192.168.0.2", "192.168.0.6")...)
+	useFakeVppAgent(t, fakeVpp)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.6"})
+	remoteSubnetRouteMap.Store("10.2.0.0/16", []string{"192.168.0.6"})
+
+	// The correction limit leaves the second route to the next cycle.
+	t.Setenv("RECONCILE_MAX_CORRECTIONS", "1")
+	budget := newReconcileBudget()
+	budget.corrections = map[string]reconcileCorrection{}
+	if err := reconcileRoutingTableWithBudget(context.Background(), nil, budget); err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := budget.corrections["10.1.0.0/16"]; !ok || c.correction != routeCorrectionStale || c.err != nil {
+		t.Error("expected the stale entry of 10.1.0.0/16 corrected, received", budget.corrections)
+	}
+	if state := vppRouteState(fakeVpp, "10.2.0.0/16"); len(state) != 2 {
+		t.Error("expected 10.2.0.0/16 deferred, received", state)
+	}
+
+	if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, remoteSubnet := range []string{"10.1.0.0/16", "10.2.0.0/16"} {
+		if state := vppRouteState(fakeVpp, remoteSubnet); !reflect.DeepEqual(state, []string{"192.168.0.6"}) {
+			t.Errorf("%v: expected a single entry via 192.168.0.6, received %v", remoteSubnet, state)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestEnsureRoutes(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+	t.Cleanup(func() {
+		lastAppliedGeneration = 0
+	})
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+
+	steps := []struct {
+		testName   string
+		generation uint64
+		routes     []*pb.RouteInfo
+		stale      bool
+		errCode    codes.Code
+		results    []string
+		expected   map[string][]string
+	}{
+		{
+			"initial routes are added",
+			1,
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+				{RemoteSubnet: "10.2.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			false,
+			codes.OK,
+			[]string{"10.1.0.0/16", "10.2.0.0/16"},
+			map[string][]string{"10.1.0.0/16": {"192.168.0.2"}, "10.2.0.0/16": {"192.168.0.2"}},
+		},
+		{
+			"routes not in the desired set are removed",
+			2,
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+			},
+			false,
+			codes.OK,
+			[]string{"10.1.0.0/16", "10.3.0.0/16", "10.2.0.0/16"},
+			map[string][]string{"10.1.0.0/16": {"192.168.0.6"}, "10.3.0.0/16": {"192.168.0.6"}},
+		},
+		{
+			"stale generation is ignored",
+			1,
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			true,
+			codes.OK,
+			nil,
+			map[string][]string{"10.1.0.0/16": {"192.168.0.6"}, "10.3.0.0/16": {"192.168.0.6"}},
+		},
+		{
+			"same generation is re-applied",
+			2,
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+			},
+			false,
+			codes.OK,
+			[]string{"10.1.0.0/16", "10.3.0.0/16"},
+			map[string][]string{"10.1.0.0/16": {"192.168.0.6"}, "10.3.0.0/16": {"192.168.0.6"}},
+		},
+		{
+			"duplicate routes are rejected",
+			3,
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			false,
+			codes.InvalidArgument,
+			nil,
+			map[string][]string{"10.1.0.0/16": {"192.168.0.6"}, "10.3.0.0/16": {"192.168.0.6"}},
+		},
+		{
+			"non canonical subnets are the recorded routes",
+			3,
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.5/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+			},
+			false,
+			codes.OK,
+			[]string{"10.1.0.0/16", "10.3.0.0/16"},
+			map[string][]string{"10.1.0.0/16": {"192.168.0.6"}, "10.3.0.0/16": {"192.168.0.6"}},
+		},
+		{
+			"duplicate non canonical routes are rejected",
+			4,
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.1.0.1/16", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			false,
+			codes.InvalidArgument,
+			nil,
+			map[string][]string{"10.1.0.0/16": {"192.168.0.6"}, "10.3.0.0/16": {"192.168.0.6"}},
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, step := range steps {
+		t.Run(step.testName, func(t *testing.T) {
+			response, err := client.EnsureRoutes(ctx, &pb.EnsureRoutesRequest{Routes: step.routes, Generation: step.generation})
+			if status.Code(err) != step.errCode {
+				t.Fatal("error code: expected", step.errCode, "received", err)
+			}
+			if err == nil {
+				if response.GetStale() != step.stale {
+					t.Error("stale: expected", step.stale, "received", response.GetStale())
+				}
+				if len(response.GetResults()) != len(step.results) {
+					t.Fatal("results: expected", step.results, "received", response.GetResults())
+				}
+				for i, result := range response.GetResults() {
+					if result.GetRemoteSubnet() != step.results[i] || !result.GetIsInstalled() {
+						t.Error("result: expected", step.results[i], "received", result)
+					}
+				}
+			}
+
+			routes, _ := fake.RouteList(nil, 0)
+			cached := []string{}
+			remoteSubnetRouteMap.Range(func(key, value any) bool {
+				cached = append(cached, key.(string))
+				return true
+			})
+			sort.Strings(cached)
+			if len(cached) != len(step.expected) {
+				t.Error("cached routes: expected", step.expected, "received", cached)
+			}
+			for remoteSubnet, nextHops := range step.expected {
+				if installed := getRouteNextHops(routes, remoteSubnet); !sameNextHops(installed, nextHops) {
+					t.Error(remoteSubnet, "next hops: expected", nextHops, "received", installed)
+				}
+			}
+			if installed := getRouteNextHops(routes, "10.2.0.0/16"); step.generation > 1 && len(installed) != 0 {
+				t.Error("removed route is still installed", installed)
+			}
+		})
+	}
+}
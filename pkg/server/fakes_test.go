@@ -0,0 +1,490 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeNetlink is an in-memory kernel used to exercise the kernel dataplane code in tests.
+type fakeNetlink struct {
+	mu     sync.Mutex
+	links  []netlink.Link
+	addrs  map[int][]netlink.Addr
+	routes []netlink.Route
+	rules  []netlink.Rule
+	neighs []netlink.Neigh
+
+	// routeReplaceErr is returned by RouteReplace when set.
+	routeReplaceErr error
+	// routeListCalls counts the RouteList calls.
+	routeListCalls int
+	// routeListHook is called at the start of every RouteList call when set, outside of the lock.
+	routeListHook func()
+	// metricKeyed keeps the routes to the same destination with different metrics apart, like the
+	// kernel does.
+	metricKeyed bool
+}
+
+func newFakeNetlink() *fakeNetlink {
+	return &fakeNetlink{addrs: map[int][]netlink.Addr{}}
+}
+
+// useFakeNetlink swaps the netlink handle for the fake for the duration of the test.
+func useFakeNetlink(t *testing.T, f *fakeNetlink) {
+	t.Helper()
+	orig := nlHandle
+	nlHandle = f
+	t.Cleanup(func() {
+		nlHandle = orig
+	})
+}
+
+func (f *fakeNetlink) LinkList() ([]netlink.Link, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]netlink.Link{}, f.links...), nil
+}
+
+func (f *fakeNetlink) LinkByIndex(index int) (netlink.Link, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, link := range f.links {
+		if link.Attrs().Index == index {
+			return link, nil
+		}
+	}
+	return nil, fmt.Errorf("Link not found")
+}
+
+func (f *fakeNetlink) AddrList(link netlink.Link, family int) ([]netlink.Addr, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	addrs := []netlink.Addr{}
+	for _, addr := range f.addrs[link.Attrs().Index] {
+		if inFamily(family, addr.IP) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, nil
+}
+
+// inFamily returns true if the IP belongs to the address family, FAMILY_ALL matching any IP.
+func inFamily(family int, ip net.IP) bool {
+	if family == netlink.FAMILY_ALL || ip == nil {
+		return true
+	}
+	if ip.To4() != nil {
+		return family == netlink.FAMILY_V4
+	}
+	return family == netlink.FAMILY_V6
+}
+
+func (f *fakeNetlink) RouteList(link netlink.Link, family int) ([]netlink.Route, error) {
+	if f.routeListHook != nil {
+		f.routeListHook()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.routeListCalls++
+	routes := []netlink.Route{}
+	for _, route := range f.routes {
+		if link != nil && route.LinkIndex != link.Attrs().Index {
+			continue
+		}
+		// Like the kernel, routes of other tables are only listed with a table filter.
+		if routeTable(route) != unix.RT_TABLE_MAIN {
+			continue
+		}
+		if route.Dst != nil && !inFamily(family, route.Dst.IP) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func (f *fakeNetlink) RouteListFiltered(family int, filter *netlink.Route, filterMask uint64) ([]netlink.Route, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	routes := []netlink.Route{}
+	for _, route := range f.routes {
+		if filterMask&netlink.RT_FILTER_TABLE != 0 && routeTable(route) != routeTable(*filter) {
+			continue
+		}
+		if route.Dst != nil && !inFamily(family, route.Dst.IP) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func (f *fakeNetlink) RouteAdd(route *netlink.Route) error {
+	f.mu.Lock()
+	for _, existing := range f.routes {
+		if f.sameRoute(existing, *route) {
+			f.mu.Unlock()
+			return unix.EEXIST
+		}
+	}
+	f.mu.Unlock()
+	return f.RouteReplace(route)
+}
+
+func (f *fakeNetlink) RouteReplace(route *netlink.Route) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.routeReplaceErr != nil {
+		return f.routeReplaceErr
+	}
+	// The kernel stores a multipath route with a single next hop as a regular route.
+	if len(route.MultiPath) == 1 {
+		r := *route
+		r.LinkIndex = r.MultiPath[0].LinkIndex
+		r.Gw = r.MultiPath[0].Gw
+		r.Flags = r.MultiPath[0].Flags
+		r.MultiPath = nil
+		route = &r
+	}
+	for i := range f.routes {
+		if f.sameRoute(f.routes[i], *route) {
+			f.routes[i] = *route
+			return nil
+		}
+	}
+	f.routes = append(f.routes, *route)
+	return nil
+}
+
+func (f *fakeNetlink) RouteDel(route *netlink.Route) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.routes {
+		if f.sameRoute(f.routes[i], *route) {
+			f.routes = append(f.routes[:i], f.routes[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("no such process")
+}
+
+func (f *fakeNetlink) RuleList(family int) ([]netlink.Rule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]netlink.Rule{}, f.rules...), nil
+}
+
+func (f *fakeNetlink) RuleAdd(rule *netlink.Rule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = append(f.rules, *rule)
+	return nil
+}
+
+func (f *fakeNetlink) RuleDel(rule *netlink.Rule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.rules {
+		if ruleMatches(f.rules[i], rule) {
+			f.rules = append(f.rules[:i], f.rules[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("no such file or directory")
+}
+
+func (f *fakeNetlink) NeighList(linkIndex int, family int) ([]netlink.Neigh, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	neighs := []netlink.Neigh{}
+	for _, neigh := range f.neighs {
+		if (linkIndex == 0 || neigh.LinkIndex == linkIndex) && inFamily(family, neigh.IP) {
+			neighs = append(neighs, neigh)
+		}
+	}
+	return neighs, nil
+}
+
+// setNeighState sets the state of the neighbor entry of the IP on the link.
+func (f *fakeNetlink) setNeighState(linkIndex int, ip string, state int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.neighs {
+		if f.neighs[i].LinkIndex == linkIndex && f.neighs[i].IP.String() == ip {
+			f.neighs[i].State = state
+			return
+		}
+	}
+	f.neighs = append(f.neighs, netlink.Neigh{LinkIndex: linkIndex, IP: net.ParseIP(ip), State: state})
+}
+
+// routeTable returns the table of the route, zero meaning the main table.
+func routeTable(route netlink.Route) int {
+	if route.Table == 0 {
+		return unix.RT_TABLE_MAIN
+	}
+	return route.Table
+}
+
+// sameRoute returns true if the routes have the same key in the fake kernel.
+func (f *fakeNetlink) sameRoute(a, b netlink.Route) bool {
+	return sameDst(a, b) && (!f.metricKeyed || a.Priority == b.Priority)
+}
+
+func sameDst(a, b netlink.Route) bool {
+	if routeTable(a) != routeTable(b) {
+		return false
+	}
+	if a.Dst == nil || b.Dst == nil {
+		return a.Dst == nil && b.Dst == nil
+	}
+	return a.Dst.String() == b.Dst.String()
+}
+
+// resetRouteMap clears the slice route map before and after the test.
+func resetRouteMap(t *testing.T) {
+	t.Helper()
+	clear := func() {
+		remoteSubnetRouteMap.Range(func(key, value any) bool {
+			remoteSubnetRouteMap.Delete(key)
+			return true
+		})
+		remoteSubnetSrcMap.Range(func(key, value any) bool {
+			remoteSubnetSrcMap.Delete(key)
+			return true
+		})
+		remoteSubnetDescriptionMap.Range(func(key, value any) bool {
+			remoteSubnetDescriptionMap.Delete(key)
+			return true
+		})
+		remoteSubnetReconcilePriorityMap.Range(func(key, value any) bool {
+			remoteSubnetReconcilePriorityMap.Delete(key)
+			return true
+		})
+		remoteSubnetVppAttrsMap.Range(func(key, value any) bool {
+			remoteSubnetVppAttrsMap.Delete(key)
+			return true
+		})
+		unresolvedRouteSubnets.Range(func(key, value any) bool {
+			unresolvedRouteSubnets.Delete(key)
+			return true
+		})
+		suspendedRoutes.Range(func(key, value any) bool {
+			suspendedRoutes.Delete(key)
+			return true
+		})
+		routeDebounce.reset()
+		aggregateRoutesMu.Lock()
+		aggregateRoutes = map[string]*aggregateRoute{}
+		aggregateRoutesMu.Unlock()
+		routeGroupsMu.Lock()
+		routeGroups = map[string]*routeGroup{}
+		routeGroupsMu.Unlock()
+	}
+	clear()
+	t.Cleanup(clear)
+}
+
+// mustParseCIDR returns the network of a CIDR string and panics if it is invalid.
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return ipNet
+}
+
+// fakeVppAgent is an in-memory vpp-agent configurator used to exercise the vpp dataplane code in tests.
+type fakeVppAgent struct {
+	configurator.ConfiguratorServiceClient
+
+	mu     sync.Mutex
+	config *vpp.ConfigData
+
+	// updateErr is returned by Update when set, only by the first updateErrCalls calls if non-zero.
+	// updateCalls counts the Update calls.
+	updateErr      error
+	updateErrCalls int
+	updateCalls    int
+	// getErr is returned by Get when set, getCalls counts the Get calls.
+	getErr   error
+	getCalls int
+	// deleteErr is returned by Delete when set.
+	deleteErr error
+	// slowRoutes holds the destinations whose updates take updateDelay to complete, or until the
+	// call times out.
+	slowRoutes  map[string]bool
+	updateDelay time.Duration
+	// unappliedRoutes holds the destinations whose updates are accepted but never show up in the
+	// config, like routes vpp fails to program.
+	unappliedRoutes map[string]bool
+	// rejectedRoutes holds the destinations whose updates fail.
+	rejectedRoutes map[string]bool
+}
+
+func newFakeVppAgent() *fakeVppAgent {
+	return &fakeVppAgent{config: &vpp.ConfigData{}}
+}
+
+// useFakeVppAgent makes the sidecar talk to the fake vpp-agent for the duration of the test.
+func useFakeVppAgent(t *testing.T, f *fakeVppAgent) {
+	t.Helper()
+	orig := dialVppAgent
+	dialVppAgent = func() (configurator.ConfiguratorServiceClient, func(), error) {
+		return f, func() {}, nil
+	}
+	t.Cleanup(func() {
+		dialVppAgent = orig
+	})
+}
+
+func (f *fakeVppAgent) Get(ctx context.Context, in *configurator.GetRequest, opts ...grpc.CallOption) (*configurator.GetResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getCalls++
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	return &configurator.GetResponse{
+		Config: &configurator.Config{VppConfig: proto.Clone(f.config).(*vpp.ConfigData)},
+	}, nil
+}
+
+func (f *fakeVppAgent) Update(ctx context.Context, in *configurator.UpdateRequest, opts ...grpc.CallOption) (*configurator.UpdateResponse, error) {
+	for _, route := range in.GetUpdate().GetVppConfig().GetRoutes() {
+		if f.slowRoutes[route.GetDstNetwork()] {
+			select {
+			case <-time.After(f.updateDelay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.updateCalls++
+	if f.updateErr != nil && (f.updateErrCalls == 0 || f.updateCalls <= f.updateErrCalls) {
+		return nil, f.updateErr
+	}
+	for _, route := range in.GetUpdate().GetVppConfig().GetRoutes() {
+		if f.rejectedRoutes[route.GetDstNetwork()] {
+			return nil, errors.New("vpp rejected the route")
+		}
+	}
+	for _, route := range in.GetUpdate().GetVppConfig().GetRoutes() {
+		if f.unappliedRoutes[route.GetDstNetwork()] {
+			continue
+		}
+		// Like the vpp-agent, an update of a keyed entry modifies it.
+		replaced := false
+		for i, applied := range f.config.Routes {
+			if sameVppRoute(applied, route) {
+				f.config.Routes[i] = route
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			f.config.Routes = append(f.config.Routes, route)
+		}
+	}
+	f.config.Interfaces = append(f.config.Interfaces, in.GetUpdate().GetVppConfig().GetInterfaces()...)
+	return &configurator.UpdateResponse{}, nil
+}
+
+func (f *fakeVppAgent) Delete(ctx context.Context, in *configurator.DeleteRequest, opts ...grpc.CallOption) (*configurator.DeleteResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	for _, deleted := range in.GetDelete().GetVppConfig().GetRoutes() {
+		for i, route := range f.config.Routes {
+			if sameVppRoute(route, deleted) {
+				f.config.Routes = append(f.config.Routes[:i], f.config.Routes[i+1:]...)
+				break
+			}
+		}
+	}
+	return &configurator.DeleteResponse{}, nil
+}
+
+// addConnectedRoute adds the /32 route to an NSM peer on the given link, which is how the kernel
+// resolves the link of a next hop. The link is created as an NSM interface if it does not exist.
+func (f *fakeNetlink) addConnectedRoute(nextHopIP string, linkIndex int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.routes = append(f.routes, netlink.Route{Dst: mustParseCIDR(nextHopIP + "/32"), LinkIndex: linkIndex})
+	for _, link := range f.links {
+		if link.Attrs().Index == linkIndex {
+			return
+		}
+	}
+	f.links = append(f.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: linkIndex, Name: fmt.Sprintf("vl3-%d", linkIndex)}})
+}
+
+// fakeSysctl is an in-memory set of kernel parameters.
+type fakeSysctl struct {
+	values map[string]string
+	// readOnly holds the parameters whose writes are silently ignored.
+	readOnly map[string]bool
+	// setErr is returned by Set when set.
+	setErr error
+}
+
+// useFakeSysctl makes the sidecar use the fake kernel parameters for the duration of the test.
+func useFakeSysctl(t *testing.T, f *fakeSysctl) {
+	t.Helper()
+	orig := sysctlHandle
+	sysctlHandle = f
+	t.Cleanup(func() {
+		sysctlHandle = orig
+	})
+}
+
+func (f *fakeSysctl) Get(name string) (string, error) {
+	val, ok := f.values[name]
+	if !ok {
+		return "", errors.New("no such file or directory")
+	}
+	return val, nil
+}
+
+func (f *fakeSysctl) Set(name string, value string) error {
+	if f.setErr != nil {
+		return f.setErr
+	}
+	if !f.readOnly[name] {
+		f.values[name] = value
+	}
+	return nil
+}
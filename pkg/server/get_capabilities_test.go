@@ -0,0 +1,96 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName string
+		env      map[string]string
+		expected *pb.Capabilities
+	}{
+		{
+			"kernel mode",
+			map[string]string{"DATAPLANE": SliceRouterDataplaneKernel},
+			&pb.Capabilities{DataplaneMode: SliceRouterDataplaneKernel, Ecmp: true, PolicyRouting: true,
+				InterfaceNextHops: true, PodNextHops: true, PreferredSource: true, AtomicBatches: true,
+				RouteReconcile: true, RouteStatistics: true, KernelRoutes: true},
+		},
+		{
+			"vpp mode",
+			map[string]string{"DATAPLANE": SliceRouterDataplaneVpp},
+			&pb.Capabilities{DataplaneMode: SliceRouterDataplaneVpp, Ecmp: true, AtomicBatches: true,
+				VppConfigSummary: true},
+		},
+		{
+			"kernel mode with feature flags",
+			map[string]string{"DATAPLANE": SliceRouterDataplaneKernel, "ENABLE_IPV6": "true",
+				"NEXTHOP_WEIGHT_DECAY": "true", "NEXTHOP_HEALTH_CHECK": nextHopHealthCheckICMP,
+				"DEFAULT_NEXTHOPS": "192.168.0.1"},
+			&pb.Capabilities{DataplaneMode: SliceRouterDataplaneKernel, Ecmp: true, Ipv6: true, PolicyRouting: true,
+				InterfaceNextHops: true, PodNextHops: true, PreferredSource: true, AtomicBatches: true,
+				RouteReconcile: true, NextHopWeightDecay: true, RouteStatistics: true, KernelRoutes: true,
+				NextHopHealthCheck: nextHopHealthCheckICMP, DefaultNextHopPrefix: "0.0.0.0/0"},
+		},
+		{
+			// Weight decay updates the kernel neighbor weights, it does not apply to vpp.
+			"vpp mode with feature flags",
+			map[string]string{"DATAPLANE": SliceRouterDataplaneVpp, "ENABLE_IPV6": "true",
+				"NEXTHOP_WEIGHT_DECAY": "true", "NEXTHOP_HEALTH_CHECK": nextHopHealthCheckICMP,
+				"DEFAULT_NEXTHOPS": "fd00::1"},
+			&pb.Capabilities{DataplaneMode: SliceRouterDataplaneVpp, Ecmp: true, Ipv6: true, AtomicBatches: true,
+				VppConfigSummary: true, NextHopHealthCheck: nextHopHealthCheckICMP, DefaultNextHopPrefix: "::/0"},
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			response, err := client.GetCapabilities(ctx, &emptypb.Empty{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(response, tt.expected) {
+				t.Errorf("Expected capabilities %v, got %v", tt.expected, response)
+			}
+		})
+	}
+}
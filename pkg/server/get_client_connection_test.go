@@ -0,0 +1,266 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetClientConnection(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2", Alias: "iperf-server"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 3, Name: "eth0", Alias: "other-pod"}},
+	}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addrs[2] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.6"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	fake.addConnectedRoute("10.1.1.5", 2)
+	useFakeNetlink(t, fake)
+
+	vppAgent := newFakeVppAgent()
+	vppAgent.config.Interfaces = []*vpp.Interface{
+		{Name: "iperf-client", IpAddresses: []string{"10.1.1.2/30"}},
+		{Name: "iperf-server"},
+	}
+	useFakeVppAgent(t, vppAgent)
+
+	tests := []struct {
+		testName  string
+		dataplane string
+		podName   string
+		expected  *pb.ConnectionInfo
+		errCode   codes.Code
+	}{
+		{
+			"kernel connection found by alias",
+			SliceRouterDataplaneKernel,
+			"iperf-server",
+			&pb.ConnectionInfo{PodName: "iperf-server", NsmInterface: "nsm0", NsmIP: "10.1.1.5", NsmPeerIP: "10.1.1.6"},
+			codes.OK,
+		},
+		{
+			"kernel link without nsm prefix is ignored",
+			SliceRouterDataplaneKernel,
+			"other-pod",
+			nil,
+			codes.NotFound,
+		},
+		{
+			"vpp connection found by interface name",
+			SliceRouterDataplaneVpp,
+			"iperf-client",
+			&pb.ConnectionInfo{PodName: "iperf-client", NsmInterface: "nsm0", NsmIP: "10.1.1.1", NsmPeerIP: "10.1.1.2"},
+			codes.OK,
+		},
+		{
+			"vpp interface without address",
+			SliceRouterDataplaneVpp,
+			"iperf-server",
+			nil,
+			codes.NotFound,
+		},
+		{
+			"empty pod name",
+			SliceRouterDataplaneKernel,
+			"",
+			nil,
+			codes.InvalidArgument,
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			response, err := client.GetClientConnection(ctx, &pb.ClientConnectionRequest{PodName: tt.podName})
+			if status.Code(err) != tt.errCode {
+				t.Fatal("error code: expected", tt.errCode, "received", err)
+			}
+			if err != nil {
+				return
+			}
+			if response.GetPodName() != tt.expected.PodName || response.GetNsmInterface() != tt.expected.NsmInterface ||
+				response.GetNsmIP() != tt.expected.NsmIP || response.GetNsmPeerIP() != tt.expected.NsmPeerIP {
+				t.Error("expected", tt.expected, "received", response)
+			}
+		})
+	}
+}
+
+func TestNsmIPSource(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}},
+	}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	useFakeNetlink(t, fake)
+
+	tests := []struct {
+		testName          string
+		source            string
+		expectedNsmIP     string
+		expectedNsmPeerIP string
+	}{
+		{"default reads the client IP from the route", "", "10.1.1.1", "10.1.1.2"},
+		{"route source", nsmIPSourceRoute, "10.1.1.1", "10.1.1.2"},
+		{"address source", nsmIPSourceAddress, "10.1.1.2", "10.1.1.1"},
+		{"invalid source falls back to route", "peer", "10.1.1.1", "10.1.1.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("NSM_IP_SOURCE", tt.source)
+
+			connList, err := vl3GetNsmInterfacesInKernel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			connInfo, err := vl3GetNsmInterfaceInKernel("iperf-client")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(connList) != 1 {
+				t.Fatal("connections: expected 1, received", connList)
+			}
+			for _, conn := range []*pb.ConnectionInfo{connList[0], connInfo} {
+				if conn.NsmIP != tt.expectedNsmIP || conn.NsmPeerIP != tt.expectedNsmPeerIP {
+					t.Errorf("expected NsmIP %v and NsmPeerIP %v, received %v", tt.expectedNsmIP, tt.expectedNsmPeerIP, conn)
+				}
+			}
+		})
+	}
+}
+
+func TestIPv6ConnectionInfo(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2", Alias: "iperf-server"}},
+	}
+	for index, ips := range map[int][]string{
+		1: {"10.1.1.2/32", "fe80::2/64", "fd00:1::2/128"},
+		2: {"10.1.1.6/32", "fe80::6/64"},
+	} {
+		for _, ip := range ips {
+			addr, _ := netlink.ParseAddr(ip)
+			fake.addrs[index] = append(fake.addrs[index], *addr)
+		}
+	}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	fake.addConnectedRoute("10.1.1.5", 2)
+	fake.routes = append(fake.routes,
+		netlink.Route{Dst: mustParseCIDR("fe80::/64"), LinkIndex: 1},
+		netlink.Route{Dst: mustParseCIDR("fd00:1::1/128"), LinkIndex: 1},
+		netlink.Route{Dst: mustParseCIDR("fe80::/64"), LinkIndex: 2},
+	)
+	fake.setNeighState(1, "fd00:1::1", netlink.NUD_REACHABLE)
+	useFakeNetlink(t, fake)
+
+	tests := []struct {
+		testName string
+		ipv6     string
+		source   string
+		expected []*pb.ConnectionInfo
+	}{
+		{
+			"IPv6 disabled",
+			"",
+			"",
+			[]*pb.ConnectionInfo{
+				{PodName: "iperf-client", NsmIP: "10.1.1.1", NsmPeerIP: "10.1.1.2"},
+				{PodName: "iperf-server", NsmIP: "10.1.1.5", NsmPeerIP: "10.1.1.6"},
+			},
+		},
+		{
+			"IPv6 enabled",
+			"true",
+			"",
+			[]*pb.ConnectionInfo{
+				{PodName: "iperf-client", NsmIP: "10.1.1.1", NsmPeerIP: "10.1.1.2",
+					NsmIPv6: "fd00:1::1", NsmPeerIPv6: "fd00:1::2", NsmIPv6Reachable: true},
+				{PodName: "iperf-server", NsmIP: "10.1.1.5", NsmPeerIP: "10.1.1.6"},
+			},
+		},
+		{
+			"IPv6 enabled with the client IP on the address",
+			"true",
+			nsmIPSourceAddress,
+			[]*pb.ConnectionInfo{
+				{PodName: "iperf-client", NsmIP: "10.1.1.2", NsmPeerIP: "10.1.1.1",
+					NsmIPv6: "fd00:1::2", NsmPeerIPv6: "fd00:1::1"},
+				{PodName: "iperf-server", NsmIP: "10.1.1.6", NsmPeerIP: "10.1.1.5"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("ENABLE_IPV6", tt.ipv6)
+			t.Setenv("NSM_IP_SOURCE", tt.source)
+
+			connList, err := vl3GetNsmInterfacesInKernel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(connList) != len(tt.expected) {
+				t.Fatal("connections: expected", tt.expected, "received", connList)
+			}
+			for i, expected := range tt.expected {
+				connInfo, err := vl3GetNsmInterfaceInKernel(expected.PodName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, conn := range []*pb.ConnectionInfo{connList[i], connInfo} {
+					if conn.PodName != expected.PodName || conn.NsmIP != expected.NsmIP || conn.NsmPeerIP != expected.NsmPeerIP ||
+						conn.NsmIPv6 != expected.NsmIPv6 || conn.NsmPeerIPv6 != expected.NsmPeerIPv6 ||
+						conn.NsmIPv6Reachable != expected.NsmIPv6Reachable {
+						t.Error("expected", expected, "received", conn)
+					}
+				}
+			}
+		})
+	}
+}
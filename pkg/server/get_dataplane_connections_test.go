@@ -0,0 +1,101 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetDataplaneConnections(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("NSM_IP_SOURCE", "")
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}},
+	}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	useFakeNetlink(t, fake)
+
+	kernelConn := &pb.ConnectionInfo{PodName: "iperf-client", NsmInterface: "nsm0", NsmIP: "10.1.1.1", NsmPeerIP: "10.1.1.2"}
+	vppConn := &pb.ConnectionInfo{PodName: "iperf-server", NsmInterface: "nsm0", NsmIP: "10.1.1.5", NsmPeerIP: "10.1.1.6"}
+
+	tests := []struct {
+		testName  string
+		dataplane string
+		vppErr    error
+		expected  *pb.DataplaneConnections
+	}{
+		{
+			"both dataplanes in kernel mode",
+			SliceRouterDataplaneKernel,
+			nil,
+			&pb.DataplaneConnections{KernelConnections: []*pb.ConnectionInfo{kernelConn}, VppConnections: []*pb.ConnectionInfo{vppConn}},
+		},
+		{
+			"both dataplanes in vpp mode",
+			SliceRouterDataplaneVpp,
+			nil,
+			&pb.DataplaneConnections{KernelConnections: []*pb.ConnectionInfo{kernelConn}, VppConnections: []*pb.ConnectionInfo{vppConn}},
+		},
+		{
+			"vpp agent unreachable",
+			SliceRouterDataplaneKernel,
+			errors.New("connection refused"),
+			&pb.DataplaneConnections{KernelConnections: []*pb.ConnectionInfo{kernelConn}, Errors: []string{"vpp: connection refused"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			vppAgent := newFakeVppAgent()
+			vppAgent.config.Interfaces = []*vpp.Interface{{Name: "iperf-server", IpAddresses: []string{"10.1.1.6/30"}}}
+			vppAgent.getErr = tt.vppErr
+			useFakeVppAgent(t, vppAgent)
+
+			ctx := context.Background()
+			conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			resp, err := pb.NewSliceRouterSidecarServiceClient(conn).GetDataplaneConnections(ctx, &emptypb.Empty{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(resp, tt.expected) {
+				t.Error("expected", tt.expected, "received", resp)
+			}
+		})
+	}
+}
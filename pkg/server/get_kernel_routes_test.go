@@ -0,0 +1,113 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetKernelRoutes(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	fake.routes = append(fake.routes,
+		netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), Table: unix.RT_TABLE_MAIN, Protocol: unix.RTPROT_BOOT,
+			Type: unix.RTN_UNICAST, Priority: 100, MultiPath: []*netlink.NexthopInfo{
+				{Gw: net.ParseIP("192.168.0.2"), LinkIndex: 1, Flags: int(netlink.FLAG_ONLINK)},
+				{Gw: net.ParseIP("192.168.0.6"), LinkIndex: 2, Hops: 2},
+			}},
+		// The default route egresses a link the sidecar does not know about.
+		netlink.Route{Gw: net.ParseIP("172.16.0.1"), LinkIndex: 9, Table: unix.RT_TABLE_MAIN, Protocol: unix.RTPROT_DHCP,
+			Scope: netlink.SCOPE_UNIVERSE, Type: unix.RTN_UNICAST, Src: net.ParseIP("172.16.0.5")},
+		netlink.Route{Dst: mustParseCIDR("10.3.0.0/16"), Table: 100, Protocol: 42, Type: unix.RTN_BLACKHOLE},
+	)
+	useFakeNetlink(t, fake)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	tests := []struct {
+		testName string
+		table    uint32
+		expected []*pb.KernelRoute
+	}{
+		{
+			"main table",
+			0,
+			[]*pb.KernelRoute{
+				{Dst: "192.168.0.2/32", LinkIndex: 1, LinkName: "vl3-1", Protocol: "unspec", Scope: "global", Type: "unspec"},
+				{Dst: "192.168.0.6/32", LinkIndex: 2, LinkName: "vl3-2", Protocol: "unspec", Scope: "global", Type: "unspec"},
+				{Dst: "10.1.0.0/16", Table: unix.RT_TABLE_MAIN, Priority: 100, Protocol: "boot", Scope: "global", Type: "unicast",
+					MultiPath: []*pb.KernelNextHop{
+						{Gw: "192.168.0.2", LinkIndex: 1, LinkName: "vl3-1", Flags: []string{"onlink"}},
+						{Gw: "192.168.0.6", LinkIndex: 2, LinkName: "vl3-2", Hops: 2},
+					}},
+				{Gw: "172.16.0.1", Src: "172.16.0.5", LinkIndex: 9, Table: unix.RT_TABLE_MAIN, Protocol: "dhcp", Scope: "global", Type: "unicast"},
+			},
+		},
+		{
+			"policy table",
+			100,
+			[]*pb.KernelRoute{
+				{Dst: "10.3.0.0/16", Table: 100, Protocol: "42", Scope: "global", Type: "blackhole"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			response, err := client.GetKernelRoutes(ctx, &pb.KernelRoutesRequest{Table: tt.table})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(response.GetRoutes()) != len(tt.expected) {
+				t.Fatal("expected", tt.expected, "received", response.GetRoutes())
+			}
+			for i, route := range response.GetRoutes() {
+				if !proto.Equal(route, tt.expected[i]) {
+					t.Error("expected", tt.expected[i], "received", route)
+				}
+			}
+		})
+	}
+
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	if _, err := client.GetKernelRoutes(ctx, &pb.KernelRoutesRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Error("vpp mode: expected", codes.FailedPrecondition, "received", err)
+	}
+}
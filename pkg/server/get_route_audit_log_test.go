@@ -0,0 +1,270 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// useRouteAuditLog swaps the route audit log for an empty one for the duration of the test.
+func useRouteAuditLog(t *testing.T, log *routeAuditLog) {
+	t.Helper()
+	orig := routeAudit
+	routeAudit = log
+	t.Cleanup(func() {
+		routeAudit = orig
+	})
+}
+
+func TestRouteAuditLogRollover(t *testing.T) {
+	tests := []struct {
+		testName string
+		size     int
+		envSize  string
+		records  int
+		expected []string
+	}{
+		{"log not full", 3, "", 2, []string{"10.0.0.0/24", "10.0.1.0/24"}},
+		{"oldest operations are dropped", 3, "", 5, []string{"10.0.2.0/24", "10.0.3.0/24", "10.0.4.0/24"}},
+		{"size read from env", 0, "2", 3, []string{"10.0.1.0/24", "10.0.2.0/24"}},
+		{"invalid env size uses the default", 0, "-1", 2, []string{"10.0.0.0/24", "10.0.1.0/24"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("ROUTE_AUDIT_SIZE", tt.envSize)
+			log := &routeAuditLog{size: tt.size}
+			for i := 0; i < tt.records; i++ {
+				log.record(routeAuditInject, fmt.Sprintf("10.0.%d.0/24", i), []string{"192.168.0.2"}, nil)
+			}
+			subnets := []string{}
+			for _, entry := range log.list() {
+				subnets = append(subnets, entry.GetRemoteSubnet())
+			}
+			if !reflect.DeepEqual(subnets, tt.expected) {
+				t.Error("entries: expected", tt.expected, "received", subnets)
+			}
+		})
+	}
+}
+
+func TestGetRouteAuditLog(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	resetRouteMap(t)
+	skipReconcile(t)
+	useRouteAuditLog(t, &routeAuditLog{size: 10})
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.1.2", 2)
+	useFakeNetlink(t, fake)
+
+	steps := []struct {
+		remoteSubnet string
+		nextHops     []string
+		replaceErr   error
+	}{
+		{"10.1.0.0/16", []string{"192.168.0.2"}, nil},
+		// Same next hops, nothing reaches the dataplane.
+		{"10.1.0.0/16", []string{"192.168.0.2"}, nil},
+		{"10.1.0.0/16", []string{"192.168.1.2"}, nil},
+		{"10.2.0.0/16", []string{"192.168.0.2"}, errors.New("netlink failure")},
+		{"10.1.0.0/16", nil, nil},
+	}
+	for _, step := range steps {
+		fake.routeReplaceErr = step.replaceErr
+		_ = sliceRouterInjectRoute(step.remoteSubnet, step.nextHops)
+	}
+	fake.routeReplaceErr = nil
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	auditLog, err := pb.NewSliceRouterSidecarServiceClient(conn).GetRouteAuditLog(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		operation    string
+		remoteSubnet string
+		nextHops     []string
+		outcome      string
+	}{
+		{routeAuditInject, "10.1.0.0/16", []string{"192.168.0.2"}, routeAuditSuccess},
+		{routeAuditInject, "10.1.0.0/16", []string{"192.168.1.2"}, routeAuditSuccess},
+		{routeAuditInject, "10.2.0.0/16", []string{"192.168.0.2"}, routeAuditFailed},
+		{routeAuditDelete, "10.1.0.0/16", nil, routeAuditSuccess},
+	}
+	entries := auditLog.GetEntries()
+	if len(entries) != len(expected) {
+		t.Fatalf("entries: expected %v, received %v", len(expected), entries)
+	}
+	for i, e := range expected {
+		entry := entries[i]
+		if entry.GetOperation() != e.operation || entry.GetRemoteSubnet() != e.remoteSubnet ||
+			len(entry.GetNextHopIPList()) != len(e.nextHops) || entry.GetOutcome() != e.outcome {
+			t.Errorf("entry %v: expected %v, received %v", i, e, entry)
+		}
+		if entry.GetTime() == nil {
+			t.Errorf("entry %v: missing time", i)
+		}
+		if (e.outcome == routeAuditFailed) != (entry.GetError() != "") {
+			t.Errorf("entry %v: unexpected error %q", i, entry.GetError())
+		}
+	}
+}
+
+func TestRouteDeletionReason(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	skipReconcile(t)
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName string
+		delete   func() error
+		expected pb.DeletionReason
+	}{
+		{
+			"explicit delete",
+			func() error { return sliceRouterInjectRoute("10.1.0.0/16", nil) },
+			pb.DeletionReason_DELETION_EXPLICIT,
+		},
+		{
+			"route not in the desired set",
+			func() error {
+				_, err := sliceRouterEnsureRoutes(context.Background(), []routeRequest{{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"192.168.0.2"}}}, 0)
+				return err
+			},
+			pb.DeletionReason_DELETION_NOT_DESIRED,
+		},
+		{
+			"shutdown flush",
+			sliceRouterFlushRoutes,
+			pb.DeletionReason_DELETION_SHUTDOWN_FLUSH,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			log := &routeAuditLog{size: 10}
+			useRouteAuditLog(t, log)
+			t.Cleanup(func() {
+				lastAppliedGeneration = 0
+			})
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.delete(); err != nil {
+				t.Fatal(err)
+			}
+
+			deletions := []*pb.RouteAuditEntry{}
+			for _, entry := range log.list() {
+				if entry.GetOperation() == routeAuditDelete {
+					deletions = append(deletions, entry)
+				} else if entry.GetDeletionReason() != pb.DeletionReason_DELETION_NONE {
+					t.Error("deletion reason recorded for", entry.GetOperation(), entry.GetDeletionReason())
+				}
+			}
+			if len(deletions) != 1 || deletions[0].GetRemoteSubnet() != "10.1.0.0/16" {
+				t.Fatal("deletions: expected 10.1.0.0/16, received", deletions)
+			}
+			if deletions[0].GetDeletionReason() != tt.expected || deletions[0].GetOutcome() != routeAuditSuccess {
+				t.Error("deletion: expected", tt.expected, "received", deletions[0])
+			}
+		})
+	}
+}
+
+func TestRouteReconcileCorrection(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	skipReconcile(t)
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName string
+		drift    func(route *netlink.Route) bool
+		expected string
+	}{
+		{"route removed", func(route *netlink.Route) bool { return false }, routeCorrectionMissing},
+		{"metric changed", func(route *netlink.Route) bool { route.Priority = 100; return true }, routeAttrMetric},
+		{"table changed", func(route *netlink.Route) bool { route.Table = 200; return true }, routeCorrectionMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			log := &routeAuditLog{size: 10}
+			useRouteAuditLog(t, log)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+
+			fake.mu.Lock()
+			routes := []netlink.Route{}
+			for _, route := range fake.routes {
+				if route.Dst.String() != "10.1.0.0/16" || tt.drift(&route) {
+					routes = append(routes, route)
+				}
+			}
+			fake.routes = routes
+			fake.mu.Unlock()
+			if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+				t.Fatal(err)
+			}
+
+			entries := log.list()
+			if len(entries) != 2 {
+				t.Fatal("expected the inject and the correction, received", entries)
+			}
+			correction := entries[1]
+			if correction.GetOperation() != routeAuditReconcile || correction.GetCorrection() != tt.expected ||
+				correction.GetOutcome() != routeAuditSuccess {
+				t.Error("expected a successful", tt.expected, "correction, received", correction)
+			}
+			if entries[0].GetCorrection() != "" || correction.GetSequence() != entries[0].GetSequence()+1 {
+				t.Error("unexpected sequence or correction of the inject:", entries)
+			}
+		})
+	}
+}
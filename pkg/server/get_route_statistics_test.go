@@ -0,0 +1,98 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetRouteStatistics(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "gw-a",
+			Statistics: &netlink.LinkStatistics{TxBytes: 1000, TxPackets: 10, RxBytes: 2000, RxPackets: 20}}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2", Alias: "gw-b",
+			Statistics: &netlink.LinkStatistics{TxBytes: 3000, TxPackets: 30, RxBytes: 4000, RxPackets: 40}}},
+		// No statistics reported for the link.
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 3, Name: "vl3-3", Alias: "gw-c"}},
+	}
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	fake.addConnectedRoute("192.168.0.10", 3)
+	useFakeNetlink(t, fake)
+
+	for remoteSubnet, nextHops := range map[string][]string{
+		"10.1.0.0/16": {"192.168.0.2"},
+		"10.2.0.0/16": {"192.168.0.2", "192.168.0.6"},
+		"10.3.0.0/16": {"192.168.0.10"},
+	} {
+		if err := sliceRouterInjectRoute(remoteSubnet, nextHops); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	vl3One := &pb.InterfaceCounters{InterfaceName: "vl3-1", TxBytes: 1000, TxPackets: 10, RxBytes: 2000, RxPackets: 20, RouteCount: 2}
+	vl3Two := &pb.InterfaceCounters{InterfaceName: "vl3-2", TxBytes: 3000, TxPackets: 30, RxBytes: 4000, RxPackets: 40, RouteCount: 1}
+	vl3Three := &pb.InterfaceCounters{InterfaceName: "vl3-3", RouteCount: 1}
+	expected := []*pb.RouteStatistics{
+		{RemoteSubnet: "10.1.0.0/16", Interfaces: []*pb.InterfaceCounters{vl3One}},
+		{RemoteSubnet: "10.2.0.0/16", Interfaces: []*pb.InterfaceCounters{vl3One, vl3Two}},
+		{RemoteSubnet: "10.3.0.0/16", Interfaces: []*pb.InterfaceCounters{vl3Three}},
+	}
+
+	response, err := client.GetRouteStatistics(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.GetRoutes()) != len(expected) {
+		t.Fatal("expected", expected, "received", response.GetRoutes())
+	}
+	for i, routeStats := range response.GetRoutes() {
+		if !proto.Equal(routeStats, expected[i]) {
+			t.Error("expected", expected[i], "received", routeStats)
+		}
+	}
+
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	if _, err := client.GetRouteStatistics(ctx, &emptypb.Empty{}); status.Code(err) != codes.FailedPrecondition {
+		t.Error("vpp mode: expected", codes.FailedPrecondition, "received", err)
+	}
+}
@@ -0,0 +1,245 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetRouteStatus(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.routes = []netlink.Route{
+		{Dst: mustParseCIDR("10.1.0.0/16"), MultiPath: []*netlink.NexthopInfo{{Gw: net.ParseIP("192.168.0.2")}}},
+		{Dst: mustParseCIDR("10.2.0.0/16"), Gw: net.ParseIP("192.168.0.6")},
+	}
+	useFakeNetlink(t, fake)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2"})
+	remoteSubnetRouteMap.Store("10.2.0.0/16", []string{"192.168.0.10"})
+
+	tests := []struct {
+		testName  string
+		req       *pb.RouteStatusRequest
+		state     pb.RouteState
+		installed []string
+		errCode   codes.Code
+	}{
+		{
+			"route present with expected next hop",
+			&pb.RouteStatusRequest{RemoteSubnet: "10.1.0.0/16", NextHopIP: "192.168.0.2"},
+			pb.RouteState_ROUTE_INSTALLED,
+			[]string{"192.168.0.2"},
+			codes.OK,
+		},
+		{
+			"route present matching the cached next hops",
+			&pb.RouteStatusRequest{RemoteSubnet: "10.1.0.0/16"},
+			pb.RouteState_ROUTE_INSTALLED,
+			[]string{"192.168.0.2"},
+			codes.OK,
+		},
+		{
+			"non canonical remote subnet",
+			&pb.RouteStatusRequest{RemoteSubnet: "10.1.2.3/16"},
+			pb.RouteState_ROUTE_INSTALLED,
+			[]string{"192.168.0.2"},
+			codes.OK,
+		},
+		{
+			"route absent",
+			&pb.RouteStatusRequest{RemoteSubnet: "10.3.0.0/16", NextHopIP: "192.168.0.2"},
+			pb.RouteState_ROUTE_ABSENT,
+			[]string{},
+			codes.OK,
+		},
+		{
+			"route drifted from the cached next hops",
+			&pb.RouteStatusRequest{RemoteSubnet: "10.2.0.0/16"},
+			pb.RouteState_ROUTE_DRIFTED,
+			[]string{"192.168.0.6"},
+			codes.OK,
+		},
+		{
+			"route drifted from the requested next hop",
+			&pb.RouteStatusRequest{RemoteSubnet: "10.1.0.0/16", NextHopIP: "192.168.0.6"},
+			pb.RouteState_ROUTE_DRIFTED,
+			[]string{"192.168.0.2"},
+			codes.OK,
+		},
+		{
+			"invalid remote subnet",
+			&pb.RouteStatusRequest{RemoteSubnet: "10.1.0.0"},
+			pb.RouteState_ROUTE_ABSENT,
+			nil,
+			codes.InvalidArgument,
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			response, err := client.GetRouteStatus(ctx, tt.req)
+			if status.Code(err) != tt.errCode {
+				t.Fatal("error code: expected", tt.errCode, "received", err)
+			}
+			if err != nil {
+				return
+			}
+			if response.GetState() != tt.state {
+				t.Error("state: expected", tt.state, "received", response.GetState())
+			}
+			if response.GetIsInstalled() != (tt.state == pb.RouteState_ROUTE_INSTALLED) {
+				t.Error("isInstalled does not match state", response)
+			}
+			if !sameNextHops(response.GetInstalledNextHopIPList(), tt.installed) {
+				t.Error("installed next hops: expected", tt.installed, "received", response.GetInstalledNextHopIPList())
+			}
+		})
+	}
+}
+
+func TestGetRouteStatusNeighborState(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.routes = []netlink.Route{
+		{Dst: mustParseCIDR("10.1.0.0/16"), MultiPath: []*netlink.NexthopInfo{
+			{LinkIndex: 1, Gw: net.ParseIP("192.168.0.2")},
+			{LinkIndex: 2, Gw: net.ParseIP("192.168.1.2")},
+		}},
+		{Dst: mustParseCIDR("10.2.0.0/16"), LinkIndex: 3, Gw: net.ParseIP("192.168.2.2")},
+	}
+	useFakeNetlink(t, fake)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	mac, _ := net.ParseMAC("02:00:00:00:00:01")
+	tests := []struct {
+		testName      string
+		state         int
+		expectedState pb.NeighborState
+	}{
+		{"reachable", netlink.NUD_REACHABLE, pb.NeighborState_NEIGHBOR_REACHABLE},
+		{"stale", netlink.NUD_STALE, pb.NeighborState_NEIGHBOR_STALE},
+		{"failed", netlink.NUD_FAILED, pb.NeighborState_NEIGHBOR_FAILED},
+		{"incomplete", netlink.NUD_INCOMPLETE, pb.NeighborState_NEIGHBOR_INCOMPLETE},
+		{"delay", netlink.NUD_DELAY, pb.NeighborState_NEIGHBOR_PROBE},
+		{"permanent", netlink.NUD_PERMANENT, pb.NeighborState_NEIGHBOR_PERMANENT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			// The first next hop has a neighbor entry in the state under test, the second has none.
+			fake.mu.Lock()
+			fake.neighs = []netlink.Neigh{{LinkIndex: 1, IP: net.ParseIP("192.168.0.2"), State: tt.state, HardwareAddr: mac}}
+			fake.mu.Unlock()
+
+			response, err := client.GetRouteStatus(ctx, &pb.RouteStatusRequest{RemoteSubnet: "10.1.0.0/16", IncludeNeighborState: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			neighbors := response.GetNextHopNeighbors()
+			if len(neighbors) != 2 {
+				t.Fatal("neighbors: expected 2, received", neighbors)
+			}
+			if neighbors[0].GetNextHopIP() != "192.168.0.2" || neighbors[0].GetState() != tt.expectedState ||
+				neighbors[0].GetHardwareAddr() != mac.String() {
+				t.Error("first next hop: expected", tt.expectedState, mac, "received", neighbors[0])
+			}
+			if neighbors[1].GetNextHopIP() != "192.168.1.2" || neighbors[1].GetState() != pb.NeighborState_NEIGHBOR_NONE {
+				t.Error("second next hop: expected no neighbor entry, received", neighbors[1])
+			}
+		})
+	}
+
+	t.Run("single path route", func(t *testing.T) {
+		fake.setNeighState(3, "192.168.2.2", netlink.NUD_STALE)
+		response, err := client.GetRouteStatus(ctx, &pb.RouteStatusRequest{RemoteSubnet: "10.2.0.0/16", IncludeNeighborState: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		neighbors := response.GetNextHopNeighbors()
+		if len(neighbors) != 1 || neighbors[0].GetState() != pb.NeighborState_NEIGHBOR_STALE {
+			t.Error("neighbors: expected 192.168.2.2 stale, received", neighbors)
+		}
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		response, err := client.GetRouteStatus(ctx, &pb.RouteStatusRequest{RemoteSubnet: "10.1.0.0/16"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(response.GetNextHopNeighbors()) != 0 {
+			t.Error("neighbors: expected none, received", response.GetNextHopNeighbors())
+		}
+	})
+}
+
+func TestGetRouteStatusNonCanonicalSubnet(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	if err := sliceRouterInjectRoute("10.5.1.5/16", []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := remoteSubnetRouteMap.Load("10.5.0.0/16"); !ok {
+		t.Fatal("route not recorded under its canonical subnet")
+	}
+	for _, remoteSubnet := range []string{"10.5.0.0/16", "10.5.1.5/16"} {
+		response, err := sliceRouterGetRouteStatus(remoteSubnet, "", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.GetState() != pb.RouteState_ROUTE_INSTALLED {
+			t.Error("state of", remoteSubnet, ": expected", pb.RouteState_ROUTE_INSTALLED, "received", response.GetState())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetRoutesByInterface(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "gw-a"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2", Alias: "gw-b"}},
+	}
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+
+	vppAgent := newFakeVppAgent()
+	vppAgent.config.Interfaces = []*vpp.Interface{
+		{Name: "gw-a", IpAddresses: []string{"192.168.0.1/30"}},
+		{Name: "gw-b", IpAddresses: []string{"192.168.0.5/30"}},
+	}
+	for _, route := range [][2]string{
+		{"10.1.0.0/16", "192.168.0.2"},
+		{"10.2.0.0/16", "192.168.0.2"},
+		{"10.2.0.0/16", "192.168.0.6"},
+		{"10.3.0.0/16", "192.168.0.6"},
+	} {
+		vppAgent.config.Routes = append(vppAgent.config.Routes, getVppConfig(route[0], route[1], vppRouteAttrs{}).Routes...)
+	}
+	// Routes of other agents are not slice routes.
+	vppAgent.config.Routes = append(vppAgent.config.Routes, &vpp.Route{DstNetwork: "10.1.0.0/16", NextHopAddr: "192.168.0.6"})
+	useFakeVppAgent(t, vppAgent)
+
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	for remoteSubnet, nextHops := range map[string][]string{
+		"10.1.0.0/16": {"192.168.0.2"},
+		"10.2.0.0/16": {"192.168.0.2", "192.168.0.6"},
+		"10.3.0.0/16": {"192.168.0.6"},
+	} {
+		if err := sliceRouterInjectRoute(remoteSubnet, nextHops); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedVpp := []*pb.InterfaceRoutes{
+		{InterfaceName: "gw-a", PodName: "gw-a", RemoteSubnets: []string{"10.1.0.0/16", "10.2.0.0/16"}},
+		{InterfaceName: "gw-b", PodName: "gw-b", RemoteSubnets: []string{"10.2.0.0/16", "10.3.0.0/16"}},
+	}
+	expectedKernel := []*pb.InterfaceRoutes{
+		{InterfaceName: "vl3-1", PodName: "gw-a", RemoteSubnets: []string{"10.1.0.0/16", "10.2.0.0/16"}},
+		{InterfaceName: "vl3-2", PodName: "gw-b", RemoteSubnets: []string{"10.2.0.0/16", "10.3.0.0/16"}},
+	}
+
+	tests := []struct {
+		testName  string
+		dataplane string
+		expected  []*pb.InterfaceRoutes
+	}{
+		{"kernel routes grouped by link", SliceRouterDataplaneKernel, expectedKernel},
+		{"vpp routes grouped by interface subnet", SliceRouterDataplaneVpp, expectedVpp},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			response, err := client.GetRoutesByInterface(ctx, &emptypb.Empty{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(response.GetInterfaces()) != len(tt.expected) {
+				t.Fatal("expected", tt.expected, "received", response.GetInterfaces())
+			}
+			for i, intf := range response.GetInterfaces() {
+				if intf.GetInterfaceName() != tt.expected[i].InterfaceName || intf.GetPodName() != tt.expected[i].PodName ||
+					!reflect.DeepEqual(intf.GetRemoteSubnets(), tt.expected[i].RemoteSubnets) {
+					t.Error("expected", tt.expected[i], "received", intf)
+				}
+			}
+		})
+	}
+}
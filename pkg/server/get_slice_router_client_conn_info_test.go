@@ -37,7 +37,7 @@ import (
 func dialer() func(context.Context, string) (net.Conn, error) {
 
 	listner := bufconn.Listen(1024 * 1024)
-	server := grpc.NewServer()
+	server := grpc.NewServer(ServerOptions()...)
 
 	pb.RegisterSliceRouterSidecarServiceServer(server, &SliceRouterSidecar{})
 
@@ -0,0 +1,110 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetSupportBundle(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+	fake.links[0].Attrs().Alias = "iperf-client"
+	fake.addrs[1] = []netlink.Addr{{IPNet: mustParseCIDR("192.168.0.1/32")}}
+
+	vppAgent := newFakeVppAgent()
+	vppAgent.config.Routes = []*vpp.Route{{DstNetwork: "10.1.0.0/16", NextHopAddr: "192.168.0.2"}}
+	useFakeVppAgent(t, vppAgent)
+
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	logger.GlobalLogger.Errorf("support bundle test error")
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	t.Run("kernel", func(t *testing.T) {
+		bundle, err := client.GetSupportBundle(ctx, &emptypb.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bundle.GetDataplaneMode() != SliceRouterDataplaneKernel {
+			t.Error("dataplane mode: expected", SliceRouterDataplaneKernel, "received", bundle.GetDataplaneMode())
+		}
+		routes := bundle.GetRouteTable().GetRoutes()
+		if len(routes) != 1 || routes[0].GetRemoteSubnet() != "10.1.0.0/16" {
+			t.Error("route table: expected 10.1.0.0/16, received", routes)
+		}
+		found := false
+		for _, route := range bundle.GetKernelRoutes() {
+			found = found || strings.Contains(route, "10.1.0.0/16")
+		}
+		if !found {
+			t.Error("kernel routes: expected route to 10.1.0.0/16, received", bundle.GetKernelRoutes())
+		}
+		if len(bundle.GetConnections()) != 1 || bundle.GetConnections()[0].GetPodName() != "iperf-client" {
+			t.Error("connections: expected iperf-client, received", bundle.GetConnections())
+		}
+		found = false
+		for _, entry := range bundle.GetRecentErrors() {
+			found = found || entry.GetMessage() == "support bundle test error"
+		}
+		if !found {
+			t.Error("recent errors: expected test error, received", bundle.GetRecentErrors())
+		}
+		if len(bundle.GetCollectionErrors()) != 0 {
+			t.Error("unexpected collection errors", bundle.GetCollectionErrors())
+		}
+	})
+
+	t.Run("vpp", func(t *testing.T) {
+		t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+		bundle, err := client.GetSupportBundle(ctx, &emptypb.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(bundle.GetVppConfig(), "10.1.0.0/16") {
+			t.Error("vpp config: expected route to 10.1.0.0/16, received", bundle.GetVppConfig())
+		}
+		if len(bundle.GetKernelRoutes()) != 0 {
+			t.Error("kernel routes should not be collected in vpp mode", bundle.GetKernelRoutes())
+		}
+	})
+}
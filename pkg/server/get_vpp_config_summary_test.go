@@ -0,0 +1,102 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetVppConfigSummary(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+
+	fake := newFakeVppAgent()
+	fake.config.Interfaces = []*vpp.Interface{
+		{Name: "iperf-client", IpAddresses: []string{"10.1.1.2/30"}},
+		{Name: "iperf-server", IpAddresses: []string{"10.1.1.6/30"}},
+	}
+	fake.config.Routes = []*vpp.Route{
+		{DstNetwork: "10.2.0.0/16", NextHopAddr: "10.1.1.1"},
+	}
+	useFakeVppAgent(t, fake)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	first, err := client.GetVppConfigSummary(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHash, err := hashVppConfig(fake.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.GetConfigHash() != expectedHash {
+		t.Error("hash: expected", expectedHash, "received", first.GetConfigHash())
+	}
+	if first.GetInterfaceCount() != 2 || first.GetRouteCount() != 1 {
+		t.Error("counts: expected 2 interfaces and 1 route, received", first)
+	}
+
+	// Reading the same config again does not report a change.
+	second, err := client.GetVppConfigSummary(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.GetConfigHash() != first.GetConfigHash() || !second.GetChangedAt().AsTime().Equal(first.GetChangedAt().AsTime()) {
+		t.Error("unchanged config: expected", first, "received", second)
+	}
+
+	// A route added behind the sidecar's back changes the hash.
+	fake.config.Routes = append(fake.config.Routes, &vpp.Route{DstNetwork: "10.3.0.0/16", NextHopAddr: "10.1.1.5"})
+	third, err := client.GetVppConfigSummary(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.GetConfigHash() == second.GetConfigHash() {
+		t.Error("hash did not change after the vpp config changed")
+	}
+	if third.GetRouteCount() != 2 {
+		t.Error("route count: expected 2, received", third.GetRouteCount())
+	}
+	if third.GetChangedAt().AsTime().Before(second.GetCapturedAt().AsTime()) {
+		t.Error("changedAt was not updated", third)
+	}
+
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	_, err = client.GetVppConfigSummary(ctx, &emptypb.Empty{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("error code: expected", codes.FailedPrecondition, "received", err)
+	}
+}
@@ -0,0 +1,484 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOrderRouteRequests(t *testing.T) {
+	routes := []routeRequest{
+		{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.5.2"}},
+		{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+		{remoteSubnet: "192.168.5.2/32", nextHopIPList: []string{"192.168.0.2"}},
+	}
+	order := orderRouteRequests(routes)
+	if !reflect.DeepEqual(order, []int{1, 2, 0}) {
+		t.Error("order: expected", []int{1, 2, 0}, "received", order)
+	}
+}
+
+func TestInjectRoutes(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName  string
+		routes    []*pb.RouteInfo
+		installed []bool
+		errCode   codes.Code
+	}{
+		{
+			"dependent route listed before the route it depends on",
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.5.2"}},
+				{RemoteSubnet: "192.168.5.2/32", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			[]bool{true, true},
+			codes.OK,
+		},
+		{
+			"unresolvable route fails without failing the others",
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.9.9"}},
+				{RemoteSubnet: "10.2.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			[]bool{false, true},
+			codes.OK,
+		},
+		{
+			"empty batch",
+			[]*pb.RouteInfo{},
+			nil,
+			codes.InvalidArgument,
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+
+			response, err := client.InjectRoutes(ctx, &pb.RouteBatch{Routes: tt.routes})
+			if status.Code(err) != tt.errCode {
+				t.Fatal("error code: expected", tt.errCode, "received", err)
+			}
+			if err != nil {
+				return
+			}
+			for i, result := range response.GetResults() {
+				if result.GetRemoteSubnet() != tt.routes[i].RemoteSubnet {
+					t.Error("result order: expected", tt.routes[i].RemoteSubnet, "received", result.GetRemoteSubnet())
+				}
+				if result.GetIsInstalled() != tt.installed[i] {
+					t.Error("route", result.GetRemoteSubnet(), "installed: expected", tt.installed[i], "received", result)
+				}
+			}
+		})
+	}
+}
+
+func TestInjectRoutesAtomic(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName string
+		routes   []*pb.RouteInfo
+		errCode  codes.Code
+		// Next hops of the route to 10.1.0.0/16 after the batch, which was installed via 192.168.0.2
+		// before the batch.
+		nextHops []string
+	}{
+		{
+			"all routes installed",
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.2.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			codes.OK,
+			[]string{"192.168.0.6"},
+		},
+		{
+			"mid-batch failure rolls back the batch",
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.2.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+				{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.9.9"}},
+				{RemoteSubnet: "10.4.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			},
+			codes.FailedPrecondition,
+			[]string{"192.168.0.2"},
+		},
+		{
+			"route deletion is rolled back",
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16"},
+				{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.9.9"}},
+			},
+			codes.FailedPrecondition,
+			[]string{"192.168.0.2"},
+		},
+		{
+			"non canonical subnets are rolled back",
+			[]*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.5/16", NextHopIPList: []string{"192.168.0.6"}},
+				{RemoteSubnet: "10.2.0.5/16", NextHopIPList: []string{"192.168.0.2"}},
+				{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.9.9"}},
+			},
+			codes.FailedPrecondition,
+			[]string{"192.168.0.2"},
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.0.6", 2)
+			useFakeNetlink(t, fake)
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+			before, _ := fake.RouteList(nil, 0)
+
+			_, err := client.InjectRoutes(ctx, &pb.RouteBatch{Routes: tt.routes, Atomic: true})
+			if status.Code(err) != tt.errCode {
+				t.Fatal("error code: expected", tt.errCode, "received", err)
+			}
+
+			after, _ := fake.RouteList(nil, 0)
+			installed := getRouteNextHops(after, "10.1.0.0/16")
+			if !sameNextHops(installed, tt.nextHops) {
+				t.Error("installed next hops: expected", tt.nextHops, "received", installed)
+			}
+			cached, _ := remoteSubnetRouteMap.Load("10.1.0.0/16")
+			if cached == nil || !sameNextHops(cached.([]string), tt.nextHops) {
+				t.Error("cached next hops: expected", tt.nextHops, "received", cached)
+			}
+			if err == nil {
+				return
+			}
+			if len(after) != len(before) {
+				t.Fatal("routing table not restored: expected", before, "received", after)
+			}
+			for _, route := range before {
+				found := false
+				for _, restored := range after {
+					found = found || reflect.DeepEqual(route, restored)
+				}
+				if !found {
+					t.Error("route not restored:", route)
+				}
+			}
+			if _, ok := remoteSubnetRouteMap.Load("10.2.0.0/16"); ok {
+				t.Error("rolled back route is still cached")
+			}
+		})
+	}
+}
+
+func TestInjectRoutesAtomicRollbackLeavesOtherRoutes(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("DEFAULT_NEXTHOPS", "192.168.0.2")
+	skipReconcile(t)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	fake.metricKeyed = true
+	// The default route of the pod network is listed before the default next hop route.
+	podDefault := netlink.Route{Dst: mustParseCIDR("0.0.0.0/0"), Gw: net.ParseIP("10.0.0.1"), LinkIndex: 3}
+	fake.routes = append(fake.routes, podDefault)
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+	if _, err := sliceRouterInjectDefaultNextHopRoute(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := sliceRouterInjectRoutesAtomic([]routeRequest{
+		{remoteSubnet: "0.0.0.0/0", nextHopIPList: []string{"192.168.0.6"}},
+		{remoteSubnet: "10.3.0.0/16", nextHopIPList: []string{"192.168.9.9"}},
+	})
+	if err == nil {
+		t.Fatal("batch with an unresolved next hop succeeded")
+	}
+
+	routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+	defaults := map[int]string{}
+	for _, route := range routes {
+		if route.Dst != nil && route.Dst.String() == "0.0.0.0/0" {
+			defaults[route.Priority] = route.Gw.String()
+		}
+	}
+	expected := map[int]string{0: "10.0.0.1", defaultNextHopRouteMetric: "192.168.0.2"}
+	if !reflect.DeepEqual(defaults, expected) {
+		t.Error("default routes by metric: expected", expected, "received", defaults)
+	}
+}
+
+func TestInjectRoutesAtomicInVpp(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName string
+		routes   []routeRequest
+		expectOK bool
+		// Next hops of the route to 10.1.0.0/16 after the batch, which was configured via 192.168.0.2
+		// before the batch.
+		nextHops []string
+	}{
+		{
+			"all routes configured",
+			[]routeRequest{
+				{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.0.6"}},
+				{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+			},
+			true,
+			[]string{"192.168.0.6"},
+		},
+		{
+			"mid-batch failure rolls back the batch",
+			[]routeRequest{
+				{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.0.6"}},
+				{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+				{remoteSubnet: "10.3.0.0/16", nextHopIPList: []string{"192.168.0.2", "192.168.0.6"}},
+				{remoteSubnet: "10.4.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+			},
+			false,
+			[]string{"192.168.0.2"},
+		},
+		{
+			"route deletion is rolled back",
+			[]routeRequest{
+				{remoteSubnet: "10.1.0.0/16"},
+				{remoteSubnet: "10.3.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+			},
+			false,
+			[]string{"192.168.0.2"},
+		},
+		{
+			"non canonical subnets are rolled back",
+			[]routeRequest{
+				{remoteSubnet: "10.1.0.5/16", nextHopIPList: []string{"192.168.0.6"}},
+				{remoteSubnet: "10.2.0.5/16", nextHopIPList: []string{"192.168.0.2"}},
+				{remoteSubnet: "10.3.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+			},
+			false,
+			[]string{"192.168.0.2"},
+		},
+		{
+			"weight change is rolled back",
+			[]routeRequest{
+				{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.0.2"}, vppAttrs: vppRouteAttrs{weight: 7}},
+				{remoteSubnet: "10.3.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+			},
+			false,
+			[]string{"192.168.0.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeVppAgent()
+			useFakeVppAgent(t, fake)
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+			routeMap := map[string][]string{}
+			remoteSubnetRouteMap.Range(func(key, value any) bool {
+				routeMap[key.(string)] = value.([]string)
+				return true
+			})
+			fake.mu.Lock()
+			fake.rejectedRoutes = map[string]bool{"10.3.0.0/16": true}
+			before := proto.Clone(fake.config).(*vpp.ConfigData)
+			fake.mu.Unlock()
+
+			err := sliceRouterInjectRoutesAtomic(tt.routes)
+			if tt.expectOK != (err == nil) {
+				t.Fatal("unexpected error:", err)
+			}
+			if state := vppRouteState(fake, "10.1.0.0/16"); !sameNextHops(state, tt.nextHops) {
+				t.Error("configured next hops: expected", tt.nextHops, "received", state)
+			}
+			cached, _ := remoteSubnetRouteMap.Load("10.1.0.0/16")
+			if cached == nil || !sameNextHops(cached.([]string), tt.nextHops) {
+				t.Error("cached next hops: expected", tt.nextHops, "received", cached)
+			}
+			if err == nil {
+				return
+			}
+			after := map[string][]string{}
+			remoteSubnetRouteMap.Range(func(key, value any) bool {
+				after[key.(string)] = value.([]string)
+				return true
+			})
+			if !reflect.DeepEqual(after, routeMap) {
+				t.Error("route map not restored: expected", routeMap, "received", after)
+			}
+			if attrs := vppRouteAttrsTo(fake, "10.1.0.0/16"); attrs["192.168.0.2"] != (vppRouteAttrs{}) ||
+				loadVppRouteAttrs("10.1.0.0/16") != (vppRouteAttrs{}) {
+				t.Error("route attributes not restored:", attrs, loadVppRouteAttrs("10.1.0.0/16"))
+			}
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			if len(diffVppRoutes(before.GetRoutes(), fake.config.GetRoutes())) != 0 ||
+				len(diffVppRoutes(fake.config.GetRoutes(), before.GetRoutes())) != 0 {
+				t.Error("vpp config not restored: expected", before.GetRoutes(), "received", fake.config.GetRoutes())
+			}
+		})
+	}
+}
+
+// skipReconcile keeps the injections from reconciling the routing table for the duration of the test.
+func skipReconcile(t *testing.T) {
+	t.Helper()
+	reconcileMu.Lock()
+	saved := lastRoutingTableReconcileTime
+	lastRoutingTableReconcileTime = time.Now()
+	reconcileMu.Unlock()
+	t.Cleanup(func() {
+		reconcileMu.Lock()
+		lastRoutingTableReconcileTime = saved
+		reconcileMu.Unlock()
+	})
+}
+
+func TestInjectRoutesResolvesLinksOnce(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName       string
+		routes         []routeRequest
+		routeListCalls int
+	}{
+		{
+			"routes sharing next hops",
+			[]routeRequest{
+				{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+				{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"192.168.0.2", "192.168.0.6"}},
+				{remoteSubnet: "10.3.0.0/16", nextHopIPList: []string{"192.168.0.6"}},
+				{remoteSubnet: "10.4.0.0/16", nextHopIPList: []string{"192.168.0.6", "192.168.0.2"}},
+			},
+			1,
+		},
+		{
+			"next hop reached through a route of the batch",
+			[]routeRequest{
+				{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.5.2"}},
+				{remoteSubnet: "192.168.5.2/32", nextHopIPList: []string{"192.168.0.2"}},
+				{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+			},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.0.6", 2)
+			useFakeNetlink(t, fake)
+
+			for i, err := range sliceRouterInjectRoutes(tt.routes) {
+				if err != nil {
+					t.Error(tt.routes[i].remoteSubnet, err)
+				}
+			}
+			if fake.routeListCalls != tt.routeListCalls {
+				t.Error("RouteList calls: expected", tt.routeListCalls, "received", fake.routeListCalls)
+			}
+		})
+	}
+}
+
+func BenchmarkInjectRoutes(b *testing.B) {
+	logger.GlobalLogger = logger.NewLogger("ERROR")
+	b.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	fake := newFakeNetlink()
+	nextHops := []string{"192.168.0.2", "192.168.0.6", "192.168.0.10", "192.168.0.14"}
+	for i, nextHopIP := range nextHops {
+		fake.addConnectedRoute(nextHopIP, i+1)
+	}
+	orig := nlHandle
+	nlHandle = fake
+	defer func() {
+		nlHandle = orig
+	}()
+
+	routes := []routeRequest{}
+	for i := 0; i < 200; i++ {
+		routes = append(routes, routeRequest{
+			remoteSubnet:  fmt.Sprintf("10.%d.%d.0/24", i/256, i%256),
+			nextHopIPList: []string{nextHops[i%len(nextHops)]},
+		})
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		remoteSubnetRouteMap.Range(func(key, value any) bool {
+			remoteSubnetRouteMap.Delete(key)
+			return true
+		})
+		sliceRouterInjectRoutes(routes)
+	}
+}
@@ -0,0 +1,82 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestLogLevel(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Cleanup(func() { logger.GlobalLogger = logger.NewLogger("INFO") })
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	level, err := client.GetLogLevel(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level.GetLevel() != "INFO" {
+		t.Error("expected INFO, received", level.GetLevel())
+	}
+
+	level, err = client.SetLogLevel(ctx, &pb.LogLevel{Level: "debug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level.GetLevel() != "DEBUG" {
+		t.Error("set: expected DEBUG, received", level.GetLevel())
+	}
+	if level, err = client.GetLogLevel(ctx, &emptypb.Empty{}); err != nil || level.GetLevel() != "DEBUG" {
+		t.Error("get: expected DEBUG, received", level.GetLevel(), err)
+	}
+
+	for _, invalid := range []string{"", "verbose"} {
+		_, err = client.SetLogLevel(ctx, &pb.LogLevel{Level: invalid})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%q: expected InvalidArgument, received %v", invalid, err)
+		}
+	}
+	if logger.GlobalLogger.Level() != "DEBUG" {
+		t.Error("expected the level to be left at DEBUG, received", logger.GlobalLogger.Level())
+	}
+
+	bundle, err := client.GetSupportBundle(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.GetLogLevel() != "DEBUG" {
+		t.Error("support bundle: expected DEBUG, received", bundle.GetLogLevel())
+	}
+}
@@ -0,0 +1,152 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestProbeNextHop(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	fake := newFakeNetlink()
+	fake.setNeighState(1, "10.1.1.1", netlink.NUD_REACHABLE)
+	useFakeNetlink(t, fake)
+
+	// The fake probe records the probes and fails those to 10.1.1.5.
+	var probedMode string
+	var probedTimeout time.Duration
+	saved := probeNextHop
+	probeNextHop = func(mode string, nextHopIP string, timeout time.Duration) error {
+		probedMode, probedTimeout = mode, timeout
+		if nextHopIP == "10.1.1.5" {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+	t.Cleanup(func() { probeNextHop = saved })
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	tests := []struct {
+		testName        string
+		healthCheck     string
+		req             *pb.ProbeNextHopRequest
+		errCode         codes.Code
+		expectMode      string
+		expectTimeout   time.Duration
+		expectReachable bool
+		expectNeighbor  pb.NeighborState
+	}{
+		{
+			testName:        "reachable next hop with icmp by default",
+			req:             &pb.ProbeNextHopRequest{NextHop: "10.1.1.1"},
+			expectMode:      nextHopHealthCheckICMP,
+			expectTimeout:   defaultNextHopHealthCheckTimeout,
+			expectReachable: true,
+			expectNeighbor:  pb.NeighborState_NEIGHBOR_REACHABLE,
+		},
+		{
+			testName:       "unreachable next hop without neighbor entry",
+			req:            &pb.ProbeNextHopRequest{NextHop: "10.1.1.5"},
+			expectMode:     nextHopHealthCheckICMP,
+			expectTimeout:  defaultNextHopHealthCheckTimeout,
+			expectNeighbor: pb.NeighborState_NEIGHBOR_NONE,
+		},
+		{
+			testName:        "health check probe used by default",
+			healthCheck:     nextHopHealthCheckTCP,
+			req:             &pb.ProbeNextHopRequest{NextHop: "10.1.1.1"},
+			expectMode:      nextHopHealthCheckTCP,
+			expectTimeout:   defaultNextHopHealthCheckTimeout,
+			expectReachable: true,
+			expectNeighbor:  pb.NeighborState_NEIGHBOR_REACHABLE,
+		},
+		{
+			testName:        "requested method and timeout",
+			healthCheck:     nextHopHealthCheckTCP,
+			req:             &pb.ProbeNextHopRequest{NextHop: "10.1.1.1", Method: "icmp", Timeout: durationpb.New(300 * time.Millisecond)},
+			expectMode:      nextHopHealthCheckICMP,
+			expectTimeout:   300 * time.Millisecond,
+			expectReachable: true,
+			expectNeighbor:  pb.NeighborState_NEIGHBOR_REACHABLE,
+		},
+		{
+			testName: "invalid next hop",
+			req:      &pb.ProbeNextHopRequest{NextHop: "10.1.1"},
+			errCode:  codes.InvalidArgument,
+		},
+		{
+			testName: "invalid method",
+			req:      &pb.ProbeNextHopRequest{NextHop: "10.1.1.1", Method: "udp"},
+			errCode:  codes.InvalidArgument,
+		},
+		{
+			testName: "invalid timeout",
+			req:      &pb.ProbeNextHopRequest{NextHop: "10.1.1.1", Timeout: durationpb.New(-time.Second)},
+			errCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("NEXTHOP_HEALTH_CHECK", tt.healthCheck)
+			result, err := client.ProbeNextHop(ctx, tt.req)
+			if tt.errCode != codes.OK {
+				if status.Code(err) != tt.errCode {
+					t.Fatal("error: expected", tt.errCode, "received", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if probedMode != tt.expectMode || probedTimeout != tt.expectTimeout {
+				t.Error("probe: expected", tt.expectMode, tt.expectTimeout, "received", probedMode, probedTimeout)
+			}
+			if result.GetReachable() != tt.expectReachable || (result.GetError() == "") != tt.expectReachable {
+				t.Error("reachable: expected", tt.expectReachable, "received", result.GetReachable(), result.GetError())
+			}
+			if result.GetLatency() == nil {
+				t.Error("latency not reported")
+			}
+			if result.GetNeighbor().GetState() != tt.expectNeighbor {
+				t.Error("neighbor state: expected", tt.expectNeighbor, "received", result.GetNeighbor().GetState())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// peerIPs returns the nsm peer IPs of the connections.
+func peerIPs(connList []*pb.ConnectionInfo) []string {
+	ips := []string{}
+	for _, conn := range connList {
+		ips = append(ips, conn.GetNsmPeerIP())
+	}
+	return ips
+}
+
+func TestRescanClientConnections(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}}}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	useFakeNetlink(t, fake)
+	if err := connCache.fill(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(connCache.invalidate)
+
+	// A link shows up without the cache seeing its events.
+	fake.mu.Lock()
+	fake.links = append(fake.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2", Alias: "iperf-server"}})
+	fake.addrs[2] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.6"), Mask: net.CIDRMask(32, 32)}}}
+	fake.mu.Unlock()
+	fake.addConnectedRoute("10.1.1.5", 2)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	cached, err := client.GetSliceRouterClientConnectionInfo(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ips := peerIPs(cached.GetConnection()); len(ips) != 1 {
+		t.Fatal("expected the stale cached connections, received", ips)
+	}
+
+	rescanned, err := client.RescanClientConnections(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ips := peerIPs(rescanned.GetConnection()); len(ips) != 2 || ips[0] != "10.1.1.2" || ips[1] != "10.1.1.6" {
+		t.Error("rescanned connections: expected [10.1.1.2 10.1.1.6], received", ips)
+	}
+	// The cache was refreshed along.
+	connList, ok := connCache.list()
+	if ips := peerIPs(connList); !ok || len(ips) != 2 {
+		t.Error("cached connections after the rescan: expected 2, received", ips, ok)
+	}
+}
@@ -0,0 +1,282 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+// aggregateRoute is a route installed by an aggregate route request in place of the routes to the
+// subnets it covers.
+type aggregateRoute struct {
+	// members are the requested subnets covered by the aggregate.
+	members       []string
+	nextHopIPList []string
+}
+
+var (
+	// aggregateOpMu serializes the aggregate route requests.
+	aggregateOpMu sync.Mutex
+	// aggregateRoutes holds the installed aggregate routes by prefix, guarded by aggregateRoutesMu.
+	aggregateRoutesMu sync.Mutex
+	aggregateRoutes   = map[string]*aggregateRoute{}
+)
+
+// loadAggregatedSubnets returns the subnets aggregated by the route to the remote subnet, nil if it is
+// not an aggregate route.
+func loadAggregatedSubnets(remoteSubnet string) []string {
+	aggregateRoutesMu.Lock()
+	defer aggregateRoutesMu.Unlock()
+	if route, ok := aggregateRoutes[remoteSubnet]; ok {
+		return append([]string{}, route.members...)
+	}
+	return nil
+}
+
+// forgetAggregateRoute stops tracking the aggregate route to the remote subnet once the route is
+// deleted, whatever the path that deleted it.
+func forgetAggregateRoute(remoteSubnet string) {
+	aggregateRoutesMu.Lock()
+	defer aggregateRoutesMu.Unlock()
+	delete(aggregateRoutes, remoteSubnet)
+}
+
+// isAggregatedSubnet returns true if the subnet is covered by an aggregate route.
+func isAggregatedSubnet(subnet string) bool {
+	aggregateRoutesMu.Lock()
+	defer aggregateRoutesMu.Unlock()
+	for _, route := range aggregateRoutes {
+		if contains(route.members, subnet) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPrefix returns true if the inner prefix is within the outer prefix.
+func containsPrefix(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
+
+// siblingPrefix returns the prefix sharing the parent of the prefix along with that parent. The prefix
+// must not be a default route.
+func siblingPrefix(prefix *net.IPNet) (*net.IPNet, *net.IPNet) {
+	ones, bits := prefix.Mask.Size()
+	sibling := &net.IPNet{IP: append(net.IP{}, prefix.IP...), Mask: prefix.Mask}
+	sibling.IP[(ones-1)/8] ^= 1 << (7 - uint((ones-1)%8))
+	parentMask := net.CIDRMask(ones-1, bits)
+	return sibling, &net.IPNet{IP: prefix.IP.Mask(parentMask), Mask: parentMask}
+}
+
+// aggregateSubnets returns the fewest prefixes covering exactly the subnets, sorted. Subnets within
+// other subnets are dropped and sibling prefixes are merged into their parent, unless conflicts reports
+// that the parent is not safe to install.
+func aggregateSubnets(subnets []*net.IPNet, conflicts func(*net.IPNet) bool) []*net.IPNet {
+	prefixes := map[string]*net.IPNet{}
+	for _, subnet := range subnets {
+		prefixes[subnet.String()] = subnet
+	}
+	for key, prefix := range prefixes {
+		for otherKey, other := range prefixes {
+			if key != otherKey && containsPrefix(other, prefix) {
+				delete(prefixes, key)
+				break
+			}
+		}
+	}
+
+	for merged := true; merged; {
+		merged = false
+		for key, prefix := range prefixes {
+			if ones, _ := prefix.Mask.Size(); ones == 0 {
+				continue
+			}
+			sibling, parent := siblingPrefix(prefix)
+			if _, ok := prefixes[sibling.String()]; !ok || conflicts(parent) {
+				continue
+			}
+			delete(prefixes, key)
+			delete(prefixes, sibling.String())
+			prefixes[parent.String()] = parent
+			merged = true
+			break
+		}
+	}
+
+	result := []*net.IPNet{}
+	for _, prefix := range prefixes {
+		result = append(result, prefix)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
+
+// aggregateConflicts returns a function reporting whether an aggregate would cover a route injected
+// with other next hops. The more specific route would still win, but deleting it would silently move
+// its traffic to the aggregate. The routes in skip are about to be replaced and are ignored.
+func aggregateConflicts(nextHopIPList []string, skip map[string]bool) func(*net.IPNet) bool {
+	return func(aggregate *net.IPNet) bool {
+		conflict := false
+		remoteSubnetRouteMap.Range(func(key, value any) bool {
+			if skip[key.(string)] {
+				return true
+			}
+			_, dst, err := net.ParseCIDR(key.(string))
+			if err == nil && containsPrefix(aggregate, dst) && !sameNextHops(value.([]string), nextHopIPList) {
+				conflict = true
+				return false
+			}
+			return true
+		})
+		return conflict
+	}
+}
+
+// membersOf returns the subnets within the aggregate.
+func membersOf(aggregate *net.IPNet, subnets []*net.IPNet) []string {
+	members := []string{}
+	for _, subnet := range subnets {
+		if containsPrefix(aggregate, subnet) {
+			members = append(members, subnet.String())
+		}
+	}
+	return members
+}
+
+// sliceRouterInjectAggregateRoute routes the subnets via the next hops with the fewest aggregate routes
+// and returns the aggregates. An empty next hop list deletes the routes to the subnets.
+// Aggregates already covering some of the subnets are withdrawn and their other subnets are aggregated
+// again with their own next hops. Routes to the subnets injected on their own are replaced by the
+// aggregates. The new routes are installed before the old ones are deleted so that traffic to the
+// subnets that keep their next hops is not interrupted.
+func sliceRouterInjectAggregateRoute(subnets []string, nextHopIPList []string) ([]string, error) {
+	requested := map[string]bool{}
+	requestedPrefixes := []*net.IPNet{}
+	for _, subnet := range subnets {
+		_, prefix, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, newRouteError(routeErrorInvalidArgument, subnet, err)
+		}
+		if !requested[prefix.String()] {
+			requested[prefix.String()] = true
+			requestedPrefixes = append(requestedPrefixes, prefix)
+		}
+	}
+	if len(requestedPrefixes) == 0 {
+		return nil, newRouteError(routeErrorInvalidArgument, "", errors.New("no subnets to aggregate"))
+	}
+	isRequested := func(member string) bool {
+		_, prefix, err := net.ParseCIDR(member)
+		if err != nil {
+			return false
+		}
+		for _, requestedPrefix := range requestedPrefixes {
+			if containsPrefix(requestedPrefix, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	aggregateOpMu.Lock()
+	defer aggregateOpMu.Unlock()
+
+	// The aggregates covering requested subnets are withdrawn.
+	replaced := map[string]*aggregateRoute{}
+	skip := map[string]bool{}
+	aggregateRoutesMu.Lock()
+	for aggregate, route := range aggregateRoutes {
+		for _, member := range route.members {
+			if isRequested(member) {
+				replaced[aggregate] = route
+				skip[aggregate] = true
+				break
+			}
+		}
+	}
+	aggregateRoutesMu.Unlock()
+
+	plan := map[string]*aggregateRoute{}
+	for _, route := range replaced {
+		remaining := []*net.IPNet{}
+		for _, member := range route.members {
+			if _, prefix, err := net.ParseCIDR(member); err == nil && !isRequested(member) {
+				remaining = append(remaining, prefix)
+			}
+		}
+		for _, aggregate := range aggregateSubnets(remaining, aggregateConflicts(route.nextHopIPList, skip)) {
+			plan[aggregate.String()] = &aggregateRoute{members: membersOf(aggregate, remaining), nextHopIPList: route.nextHopIPList}
+		}
+	}
+	result := []string{}
+	if len(nextHopIPList) > 0 {
+		for _, aggregate := range aggregateSubnets(requestedPrefixes, aggregateConflicts(nextHopIPList, skip)) {
+			plan[aggregate.String()] = &aggregateRoute{members: membersOf(aggregate, requestedPrefixes), nextHopIPList: nextHopIPList}
+			result = append(result, aggregate.String())
+		}
+	}
+
+	installs := []string{}
+	for aggregate := range plan {
+		installs = append(installs, aggregate)
+	}
+	sort.Strings(installs)
+	resolver := newNextHopResolver()
+	for _, aggregate := range installs {
+		route := plan[aggregate]
+		if err := sliceRouterInjectRouteWithResolver(routeRequest{remoteSubnet: aggregate, nextHopIPList: route.nextHopIPList}, resolver); err != nil {
+			return nil, err
+		}
+		aggregateRoutesMu.Lock()
+		aggregateRoutes[aggregate] = route
+		aggregateRoutesMu.Unlock()
+	}
+
+	// The withdrawn aggregates and the routes to the requested subnets that were not installed as
+	// aggregates are deleted.
+	deletes := []string{}
+	for aggregate := range replaced {
+		deletes = append(deletes, aggregate)
+	}
+	for _, prefix := range requestedPrefixes {
+		deletes = append(deletes, prefix.String())
+	}
+	sort.Strings(deletes)
+	for _, remoteSubnet := range deletes {
+		if plan[remoteSubnet] != nil {
+			continue
+		}
+		if _, ok := remoteSubnetRouteMap.Load(remoteSubnet); !ok {
+			continue
+		}
+		if err := sliceRouterInjectRouteWithResolver(routeRequest{remoteSubnet: remoteSubnet}, resolver); err != nil {
+			return nil, err
+		}
+	}
+	logger.GlobalLogger.Infof("Aggregated routes. Subnets: %v, NextHops: %v, Aggregates: %v", subnets, nextHopIPList, result)
+	return result, nil
+}
@@ -0,0 +1,201 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestAggregateSubnets(t *testing.T) {
+	tests := []struct {
+		testName  string
+		subnets   []string
+		conflicts []string
+		expected  []string
+	}{
+		{"siblings merged", []string{"10.1.0.0/24", "10.1.1.0/24"}, nil, []string{"10.1.0.0/23"}},
+		{"merged repeatedly", []string{"10.1.3.0/24", "10.1.1.0/24", "10.1.2.0/24", "10.1.0.0/24"}, nil, []string{"10.1.0.0/22"}},
+		{"gap not covered", []string{"10.1.0.0/24", "10.1.2.0/24"}, nil, []string{"10.1.0.0/24", "10.1.2.0/24"}},
+		{"contiguous but not siblings", []string{"10.1.1.0/24", "10.1.2.0/24"}, nil, []string{"10.1.1.0/24", "10.1.2.0/24"}},
+		{"contained subnet dropped", []string{"10.1.5.0/24", "10.1.0.0/16"}, nil, []string{"10.1.0.0/16"}},
+		{"conflicting aggregate not installed", []string{"10.1.0.0/24", "10.1.1.0/24", "10.1.2.0/24", "10.1.3.0/24"},
+			[]string{"10.1.0.0/22"}, []string{"10.1.0.0/23", "10.1.2.0/23"}},
+		{"ipv6 siblings merged", []string{"fd00:1::/64", "fd00:1:0:1::/64"}, nil, []string{"fd00:1::/63"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			subnets := []*net.IPNet{}
+			for _, subnet := range tt.subnets {
+				subnets = append(subnets, mustParseCIDR(subnet))
+			}
+			conflicts := func(aggregate *net.IPNet) bool {
+				return contains(tt.conflicts, aggregate.String())
+			}
+			aggregates := []string{}
+			for _, aggregate := range aggregateSubnets(subnets, conflicts) {
+				aggregates = append(aggregates, aggregate.String())
+			}
+			if !reflect.DeepEqual(aggregates, tt.expected) {
+				t.Error("aggregates: expected", tt.expected, "received", aggregates)
+			}
+		})
+	}
+}
+
+// installedRoutes returns the routes in the slice route map with their next hops.
+func installedRoutes() map[string][]string {
+	routes := map[string][]string{}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		routes[key.(string)] = value.([]string)
+		return true
+	})
+	return routes
+}
+
+func TestInjectAggregateRoute(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	a, b := []string{"192.168.0.2"}, []string{"192.168.0.6"}
+	fourSubnets := []string{"10.1.0.0/24", "10.1.1.0/24", "10.1.2.0/24", "10.1.3.0/24"}
+	type step struct {
+		// Injects a route on its own when subnets is empty.
+		subnets  []string
+		subnet   string
+		nextHops []string
+	}
+	tests := []struct {
+		testName   string
+		steps      []step
+		expected   map[string][]string
+		aggregated map[string][]string
+	}{
+		{
+			testName:   "subnets installed as one aggregate",
+			steps:      []step{{subnets: fourSubnets, nextHops: a}},
+			expected:   map[string][]string{"10.1.0.0/22": a},
+			aggregated: map[string][]string{"10.1.0.0/22": fourSubnets},
+		},
+		{
+			testName: "aggregate not covering a route with other next hops",
+			steps: []step{
+				{subnet: "10.1.2.128/25", nextHops: b},
+				{subnets: fourSubnets, nextHops: a},
+			},
+			expected: map[string][]string{"10.1.0.0/23": a, "10.1.2.0/24": a, "10.1.3.0/24": a, "10.1.2.128/25": b},
+		},
+		{
+			testName: "routes to the subnets replaced by the aggregate",
+			steps: []step{
+				{subnet: "10.1.0.0/24", nextHops: a},
+				{subnets: []string{"10.1.0.0/24", "10.1.1.0/24"}, nextHops: a},
+			},
+			expected:   map[string][]string{"10.1.0.0/23": a},
+			aggregated: map[string][]string{"10.1.0.0/23": {"10.1.0.0/24", "10.1.1.0/24"}},
+		},
+		{
+			testName: "delete of an aggregated subnet splits the aggregate",
+			steps: []step{
+				{subnets: fourSubnets, nextHops: a},
+				{subnet: "10.1.1.0/24"},
+			},
+			expected: map[string][]string{"10.1.0.0/24": a, "10.1.2.0/23": a},
+			aggregated: map[string][]string{
+				"10.1.0.0/24": {"10.1.0.0/24"},
+				"10.1.2.0/23": {"10.1.2.0/24", "10.1.3.0/24"},
+			},
+		},
+		{
+			testName: "subnet moved to other next hops",
+			steps: []step{
+				{subnets: fourSubnets, nextHops: a},
+				{subnets: []string{"10.1.3.0/24"}, nextHops: b},
+			},
+			expected: map[string][]string{"10.1.0.0/23": a, "10.1.2.0/24": a, "10.1.3.0/24": b},
+		},
+		{
+			testName: "aggregate deleted",
+			steps: []step{
+				{subnets: fourSubnets, nextHops: a},
+				{subnets: fourSubnets},
+			},
+			expected: map[string][]string{},
+		},
+		{
+			testName: "aggregate deleted as a route",
+			steps: []step{
+				{subnets: fourSubnets, nextHops: a},
+				{subnet: "10.1.0.0/22"},
+				{subnet: "10.1.1.0/24"},
+			},
+			expected: map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.0.6", 2)
+			useFakeNetlink(t, fake)
+
+			for _, s := range tt.steps {
+				var err error
+				if len(s.subnets) > 0 {
+					_, err = sliceRouterInjectAggregateRoute(s.subnets, s.nextHops)
+				} else {
+					err = sliceRouterInjectRoute(s.subnet, s.nextHops)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if routes := installedRoutes(); !reflect.DeepEqual(routes, tt.expected) {
+				t.Error("routes: expected", tt.expected, "received", routes)
+			}
+			for remoteSubnet, nextHops := range tt.expected {
+				if installed, _ := vl3GetInstalledNextHopsInKernel(remoteSubnet); !sameNextHops(installed, nextHops) {
+					t.Error("route to", remoteSubnet, ": expected", nextHops, "installed", installed)
+				}
+			}
+			fake.mu.Lock()
+			for _, route := range fake.routes {
+				if _, ok := tt.expected[route.Dst.String()]; !ok && route.Gw != nil {
+					t.Error("unexpected route in the kernel", route.Dst)
+				}
+			}
+			fake.mu.Unlock()
+			for _, route := range sliceRouterGetRouteTable().GetRoutes() {
+				aggregated := route.GetAggregatedSubnets()
+				sort.Strings(aggregated)
+				if tt.aggregated != nil && !reflect.DeepEqual(aggregated, tt.aggregated[route.GetRemoteSubnet()]) {
+					t.Error("aggregated subnets of", route.GetRemoteSubnet(), ": expected", tt.aggregated[route.GetRemoteSubnet()], "received", aggregated)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// isAsymmetricRouteCheckEnabled returns true if the injected routes are checked for next hops sent out
+// of another interface than the one of their connection, read from the CHECK_ASYMMETRIC_ROUTES env
+// variable. Traffic from the remote subnet comes back on the interface of the connection, so such a
+// route makes the traffic asymmetric.
+func isAsymmetricRouteCheckEnabled() bool {
+	return os.Getenv("CHECK_ASYMMETRIC_ROUTES") == "true"
+}
+
+// asymmetricNextHop is a next hop of an installed route whose egress link is not the link of its
+// connection.
+type asymmetricNextHop struct {
+	nextHopIP string
+	// egressLink is the link the route sends the traffic out of, connLink the link holding the slice
+	// router end of the connection of the next hop.
+	egressLink string
+	connLink   string
+}
+
+// linkName returns the name of the link with the index, the index itself if the link is unknown.
+func linkName(linkMap map[int]netlink.Link, index int) string {
+	if link, ok := linkMap[index]; ok {
+		return link.Attrs().Name
+	}
+	return strconv.Itoa(index)
+}
+
+// vl3FindAsymmetricNextHops returns the next hops of the route to the remote subnet installed in the
+// kernel that go out of another link than the one holding the nsm peer IP of their connection. Next hops
+// without a connection, or whose nsm peer IP is not a local address, cannot be checked and are skipped.
+func vl3FindAsymmetricNextHops(remoteSubnet string) ([]asymmetricNextHop, error) {
+	_, dst, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := nlHandle.RouteList(nil, ipFamily(dst.IP))
+	if err != nil {
+		return nil, err
+	}
+	connList, err := sliceRouterGetClientConnections()
+	if err != nil {
+		return nil, err
+	}
+	peerIPs := map[string]string{}
+	for _, conn := range connList {
+		peerIPs[conn.GetNsmIP()] = conn.GetNsmPeerIP()
+	}
+	linkMap, err := getLinkIndexMap()
+	if err != nil {
+		return nil, err
+	}
+
+	asymmetric := []asymmetricNextHop{}
+	check := func(gw net.IP, egress int) error {
+		if gw == nil {
+			return nil
+		}
+		peerIP := net.ParseIP(peerIPs[gw.String()])
+		if peerIP == nil {
+			return nil
+		}
+		connLink, err := findAddressLink(peerIP)
+		if err != nil || connLink == nil {
+			return err
+		}
+		if connLink.Attrs().Index != egress {
+			asymmetric = append(asymmetric, asymmetricNextHop{
+				nextHopIP:  gw.String(),
+				egressLink: linkName(linkMap, egress),
+				connLink:   connLink.Attrs().Name,
+			})
+		}
+		return nil
+	}
+	for _, route := range routes {
+		if route.Dst == nil || route.Dst.String() != dst.String() {
+			continue
+		}
+		if len(route.MultiPath) == 0 {
+			if err := check(route.Gw, route.LinkIndex); err != nil {
+				return nil, err
+			}
+		}
+		for _, nextHop := range route.MultiPath {
+			if err := check(nextHop.Gw, nextHop.LinkIndex); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return asymmetric, nil
+}
+
+// checkAsymmetricNextHops logs and counts the next hops of the route to the remote subnet that make the
+// traffic asymmetric, if the check is enabled. The route is left as installed.
+func checkAsymmetricNextHops(remoteSubnet string) {
+	if !isAsymmetricRouteCheckEnabled() {
+		return
+	}
+	asymmetric, err := vl3FindAsymmetricNextHops(remoteSubnet)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to check the route to %v for asymmetric next hops: %v", remoteSubnet, err)
+		return
+	}
+	for _, nextHop := range asymmetric {
+		logger.GlobalLogger.Warnf("Asymmetric route to %v: next hop %v goes out of %v, its connection is on %v",
+			describeRoute(remoteSubnet), nextHop.nextHopIP, nextHop.egressLink, nextHop.connLink)
+		asymmetricNextHopsCounter.Inc()
+	}
+}
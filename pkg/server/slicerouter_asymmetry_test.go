@@ -0,0 +1,102 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+func TestAsymmetricNextHops(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("CHECK_ASYMMETRIC_ROUTES", "true")
+	skipReconcile(t)
+
+	gw1, gw2 := net.ParseIP("10.1.1.1"), net.ParseIP("10.1.1.5")
+	tests := []struct {
+		testName string
+		route    netlink.Route
+		// expected next hops reported as asymmetric
+		expected []string
+	}{
+		{"next hop on its connection link", netlink.Route{Gw: gw1, LinkIndex: 1}, []string{}},
+		{"next hop out of another link", netlink.Route{Gw: gw1, LinkIndex: 2}, []string{"10.1.1.1"}},
+		{"multipath with one asymmetric next hop", netlink.Route{MultiPath: []*netlink.NexthopInfo{
+			{Gw: gw1, LinkIndex: 1}, {Gw: gw2, LinkIndex: 1}}}, []string{"10.1.1.5"}},
+		{"next hop without connection", netlink.Route{Gw: net.ParseIP("10.1.1.9"), LinkIndex: 2}, []string{}},
+		{"interface next hop", netlink.Route{LinkIndex: 2}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.links = []netlink.Link{
+				&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1"}},
+				&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2"}},
+			}
+			fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+			fake.addrs[2] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.6"), Mask: net.CIDRMask(32, 32)}}}
+			fake.addConnectedRoute("10.1.1.1", 1)
+			fake.addConnectedRoute("10.1.1.5", 2)
+			route := tt.route
+			route.Dst = mustParseCIDR("10.9.0.0/16")
+			fake.routes = append(fake.routes, route)
+			useFakeNetlink(t, fake)
+
+			asymmetric, err := vl3FindAsymmetricNextHops("10.9.0.0/16")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(asymmetric) != len(tt.expected) {
+				t.Fatal("asymmetric next hops: expected", tt.expected, "received", asymmetric)
+			}
+			for i, nextHop := range asymmetric {
+				if nextHop.nextHopIP != tt.expected[i] {
+					t.Error("asymmetric next hop: expected", tt.expected[i], "received", nextHop.nextHopIP)
+				}
+			}
+
+			asymmetricNextHopsCounter.Reset()
+			checkAsymmetricNextHops("10.9.0.0/16")
+			if v := asymmetricNextHopsCounter.Value(); v != float64(len(tt.expected)) {
+				t.Error("asymmetric next hops counted: expected", len(tt.expected), "received", v)
+			}
+		})
+	}
+
+	t.Run("symmetric injection", func(t *testing.T) {
+		resetRouteMap(t)
+		fake := newFakeNetlink()
+		fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+		fake.addConnectedRoute("10.1.1.1", 1)
+		useFakeNetlink(t, fake)
+		asymmetricNextHopsCounter.Reset()
+
+		if err := sliceRouterInjectRoute("10.9.0.0/16", []string{"10.1.1.1"}); err != nil {
+			t.Fatal(err)
+		}
+		if v := asymmetricNextHopsCounter.Value(); v != 0 {
+			t.Error("asymmetric next hops counted for a symmetric route:", v)
+		}
+	})
+}
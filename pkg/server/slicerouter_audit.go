@@ -0,0 +1,176 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultRouteAuditSize is the number of route operations kept in the audit log.
+const defaultRouteAuditSize = 256
+
+const (
+	routeAuditInject    = "inject"
+	routeAuditDelete    = "delete"
+	routeAuditReconcile = "reconcile"
+)
+
+const (
+	routeAuditSuccess = "success"
+	// The route is left to the reconcile loop until the neighbors of its next hops are resolved.
+	routeAuditPending = "pending"
+	routeAuditFailed  = "failed"
+)
+
+// routeAuditEntry is a route operation performed on the dataplane.
+type routeAuditEntry struct {
+	time         time.Time
+	operation    string
+	remoteSubnet string
+	nextHopIPs   []string
+	outcome      string
+	err          string
+	// deletionReason tells why the route was removed, for delete operations.
+	deletionReason sidecar.DeletionReason
+	// correction tells what was wrong with the installed route, for reconcile operations.
+	correction string
+	sequence   uint64
+}
+
+// routeAuditLog keeps the last route operations so that the recent route history is available without
+// captured logs.
+type routeAuditLog struct {
+	mu sync.Mutex
+	// size is the number of operations kept, read from ROUTE_AUDIT_SIZE on first use if zero.
+	size    int
+	entries []routeAuditEntry
+	next    int
+	// sequence is the sequence number of the last operation recorded.
+	sequence uint64
+}
+
+var routeAudit = &routeAuditLog{}
+
+// getRouteAuditSize returns the number of route operations kept in the audit log, read from the
+// ROUTE_AUDIT_SIZE env variable.
+func getRouteAuditSize() int {
+	size, err := strconv.Atoi(os.Getenv("ROUTE_AUDIT_SIZE"))
+	if err != nil || size <= 0 {
+		return defaultRouteAuditSize
+	}
+	return size
+}
+
+// record adds the outcome of a route operation to the audit log, dropping the oldest operation if the
+// log is full. Operations other than the pending ones are also exported as route events. Deletions
+// recorded this way are explicit deletions, recordDeletion records deletions made for another reason.
+func (r *routeAuditLog) record(operation, remoteSubnet string, nextHopIPs []string, err error) {
+	reason := sidecar.DeletionReason_DELETION_NONE
+	if operation == routeAuditDelete {
+		reason = sidecar.DeletionReason_DELETION_EXPLICIT
+	}
+	r.add(routeAuditEntry{operation: operation, remoteSubnet: remoteSubnet, nextHopIPs: nextHopIPs, deletionReason: reason}, err)
+}
+
+// recordDeletion adds the outcome of the deletion of a route to the audit log along with the reason of
+// the deletion.
+func (r *routeAuditLog) recordDeletion(remoteSubnet string, nextHopIPs []string, reason sidecar.DeletionReason, err error) {
+	r.add(routeAuditEntry{operation: routeAuditDelete, remoteSubnet: remoteSubnet, nextHopIPs: nextHopIPs, deletionReason: reason}, err)
+}
+
+// recordCorrection adds the outcome of a reconcile correction of a route to the audit log along with
+// what the reconcile found wrong with the installed route, so that drift can be told apart from the
+// routes changed by the controller.
+func (r *routeAuditLog) recordCorrection(remoteSubnet string, nextHopIPs []string, correction string, err error) {
+	r.add(routeAuditEntry{operation: routeAuditReconcile, remoteSubnet: remoteSubnet, nextHopIPs: nextHopIPs, correction: correction}, err)
+}
+
+// add records the operation of the entry with the outcome of err.
+func (r *routeAuditLog) add(entry routeAuditEntry, err error) {
+	event := routeEvent{Type: routeEventRouteAdded, RemoteSubnet: entry.remoteSubnet, NextHopIPList: entry.nextHopIPs}
+	switch {
+	case err == errNeighborPending:
+	case err != nil:
+		event.Type = routeEventRouteFailed
+		event.Operation = entry.operation
+		event.Error = err.Error()
+		publishRouteEvent(event)
+	case entry.operation == routeAuditDelete:
+		event.Type = routeEventRouteDeleted
+		event.DeletionReason = entry.deletionReason.String()
+		publishRouteEvent(event)
+	case entry.operation == routeAuditReconcile:
+		event.Type = routeEventRouteCorrected
+		event.Correction = entry.correction
+		publishRouteEvent(event)
+	default:
+		publishRouteEvent(event)
+	}
+
+	entry.time = time.Now()
+	entry.nextHopIPs = append([]string{}, entry.nextHopIPs...)
+	entry.outcome = routeAuditSuccess
+	switch {
+	case err == errNeighborPending:
+		entry.outcome = routeAuditPending
+	case err != nil:
+		entry.outcome = routeAuditFailed
+		entry.err = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size == 0 {
+		r.size = getRouteAuditSize()
+	}
+	r.sequence++
+	entry.sequence = r.sequence
+	if len(r.entries) < r.size {
+		r.entries = append(r.entries, entry)
+		return
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % r.size
+}
+
+// list returns the operations in the audit log, oldest first.
+func (r *routeAuditLog) list() []*sidecar.RouteAuditEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := []*sidecar.RouteAuditEntry{}
+	for _, entry := range append(append([]routeAuditEntry{}, r.entries[r.next:]...), r.entries[:r.next]...) {
+		entries = append(entries, &sidecar.RouteAuditEntry{
+			Time:           timestamppb.New(entry.time),
+			Operation:      entry.operation,
+			RemoteSubnet:   entry.remoteSubnet,
+			NextHopIPList:  entry.nextHopIPs,
+			Outcome:        entry.outcome,
+			Error:          entry.err,
+			DeletionReason: entry.deletionReason,
+			Sequence:       entry.sequence,
+			Correction:     entry.correction,
+		})
+	}
+	return entries
+}
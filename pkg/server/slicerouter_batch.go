@@ -0,0 +1,115 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+// routeRequest is a single route of a batch injection.
+type routeRequest struct {
+	// ctx carries the trace of the request the route is injected by, nil for the routes the sidecar
+	// injects on its own.
+	ctx           context.Context
+	remoteSubnet  string
+	nextHopIPList []string
+	// preferredSrc is the source address of the route, empty to let the kernel pick it.
+	preferredSrc string
+	// description is the human-readable description of the route, empty if none.
+	description string
+	// reconcilePriority is the reconcile tier of the route, normal by default.
+	reconcilePriority sidecar.ReconcilePriority
+	// vppAttrs are the path attributes of the vpp entries of the route, unset by default.
+	vppAttrs vppRouteAttrs
+	// immediate applies the route without waiting for the inject debounce window, for the atomic
+	// batches and for the changes held back by the window itself.
+	immediate bool
+	// deletionReason tells why the route is deleted when it has no next hops, DELETION_NONE standing
+	// for an explicit deletion.
+	deletionReason sidecar.DeletionReason
+	// group is the route group the route is injected by, empty for the routes injected on their own.
+	group string
+}
+
+// sliceRouterInjectRoutes injects a batch of routes into the slice router and returns one error per
+// route, in request order.
+// Routes whose next hops are reached through other routes of the same batch are installed after the
+// routes they depend on. Routes that fail because their next hop could not be resolved are retried as
+// long as the previous pass over the batch made progress.
+func sliceRouterInjectRoutes(routes []routeRequest) []error {
+	errs := make([]error, len(routes))
+	// The links of the next hops are resolved once for the whole batch.
+	resolver := newNextHopResolver()
+	pending := orderRouteRequests(routes)
+	for len(pending) > 0 {
+		retry := []int{}
+		for _, i := range pending {
+			errs[i] = sliceRouterInjectRouteWithResolver(routes[i], resolver)
+			if errs[i] == nil {
+				resolver.invalidate()
+			}
+			if isNextHopUnresolved(errs[i]) {
+				retry = append(retry, i)
+			}
+		}
+		if len(retry) == len(pending) {
+			break
+		}
+		if len(retry) > 0 {
+			logger.GlobalLogger.Infof("Retrying %v routes with unresolved next hops", len(retry))
+		}
+		pending = retry
+	}
+	return errs
+}
+
+// orderRouteRequests returns the indices of the routes in the order they should be installed. Routes
+// with a next hop that is the destination of another route in the batch are moved after the others.
+func orderRouteRequests(routes []routeRequest) []int {
+	batchDsts := map[string]bool{}
+	for _, route := range routes {
+		batchDsts[route.remoteSubnet] = true
+	}
+	dependsOnBatch := func(route routeRequest) bool {
+		for _, nextHopIP := range route.nextHopIPList {
+			if batchDsts[nextHopIP+"/32"] {
+				return true
+			}
+		}
+		return false
+	}
+
+	order := make([]int, len(routes))
+	for i := range routes {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return !dependsOnBatch(routes[order[a]]) && dependsOnBatch(routes[order[b]])
+	})
+	return order
+}
+
+func isNextHopUnresolved(err error) bool {
+	var rErr *routeError
+	return errors.As(err, &rErr) && rErr.reason == routeErrorNextHopUnresolved
+}
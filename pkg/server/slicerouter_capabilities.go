@@ -0,0 +1,47 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+// sliceRouterGetCapabilities returns the features supported by the slice router with its dataplane mode
+// and feature flags. The features missing from the vpp dataplane are the ones rejected or skipped by the
+// route paths in vpp mode, and the other modes only report what does not depend on the dataplane.
+func sliceRouterGetCapabilities() *sidecar.Capabilities {
+	mode := getSliceRouterDataplaneMode()
+	kernel := mode == SliceRouterDataplaneKernel
+	return &sidecar.Capabilities{
+		DataplaneMode:        mode,
+		Ecmp:                 kernel || mode == SliceRouterDataplaneVpp,
+		Ipv6:                 isIPv6Enabled(),
+		PolicyRouting:        kernel,
+		InterfaceNextHops:    kernel,
+		PodNextHops:          kernel,
+		PreferredSource:      kernel,
+		AtomicBatches:        kernel || mode == SliceRouterDataplaneVpp,
+		RouteReconcile:       kernel,
+		NextHopWeightDecay:   kernel && isNextHopWeightDecayEnabled(),
+		RouteStatistics:      kernel,
+		KernelRoutes:         kernel,
+		VppConfigSummary:     mode == SliceRouterDataplaneVpp,
+		NextHopHealthCheck:   getNextHopHealthCheckMode(),
+		DefaultNextHopPrefix: getDefaultNextHopPrefix(),
+	}
+}
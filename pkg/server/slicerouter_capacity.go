@@ -0,0 +1,92 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+// Default utilization of the slice route table, in percent, above which a warning is logged.
+const defaultRouteTableWarnThreshold = 80
+
+// routeTableAboveThreshold is set while the slice route table utilization is above the warning
+// threshold, so that the warning is logged once per crossing. Guarded by routeCountMu.
+var routeTableAboveThreshold bool
+
+// getRouteTableCapacity returns the number of slice routes the dataplane is expected to hold, read
+// from the ROUTE_TABLE_CAPACITY env variable. Zero means the capacity is unknown. The vpp-agent does
+// not report the FIB limits of vpp, so the configured capacity is used in both dataplane modes.
+func getRouteTableCapacity() int {
+	capacityStr := os.Getenv("ROUTE_TABLE_CAPACITY")
+	if capacityStr == "" {
+		return 0
+	}
+	capacity, err := strconv.Atoi(capacityStr)
+	if err != nil || capacity < 0 {
+		logger.GlobalLogger.Errorf("Invalid ROUTE_TABLE_CAPACITY %q, capacity unknown", capacityStr)
+		return 0
+	}
+	return capacity
+}
+
+// getRouteTableWarnThreshold returns the utilization of the slice route table, in percent, above which
+// a warning is logged. It is read from the ROUTE_TABLE_WARN_THRESHOLD env variable.
+func getRouteTableWarnThreshold() int {
+	thresholdStr := os.Getenv("ROUTE_TABLE_WARN_THRESHOLD")
+	if thresholdStr == "" {
+		return defaultRouteTableWarnThreshold
+	}
+	threshold, err := strconv.Atoi(thresholdStr)
+	if err != nil || threshold <= 0 || threshold > 100 {
+		logger.GlobalLogger.Errorf("Invalid ROUTE_TABLE_WARN_THRESHOLD %q, using %v", thresholdStr, defaultRouteTableWarnThreshold)
+		return defaultRouteTableWarnThreshold
+	}
+	return threshold
+}
+
+// routeTableUtilization returns the fraction of the slice route table capacity used by the routes,
+// zero if the capacity is unknown.
+func routeTableUtilization(routeCount int, capacity int) float64 {
+	if capacity == 0 {
+		return 0
+	}
+	return float64(routeCount) / float64(capacity)
+}
+
+// checkRouteTableUtilization updates the route table gauges and warns when the utilization crosses the
+// warning threshold. Must be called with routeCountMu held.
+func checkRouteTableUtilization(routeCount int) {
+	capacity := getRouteTableCapacity()
+	utilization := routeTableUtilization(routeCount, capacity)
+	routeCountGauge.Set(float64(routeCount))
+	routeTableCapacityGauge.Set(float64(capacity))
+	routeTableUtilizationGauge.Set(utilization)
+
+	threshold := getRouteTableWarnThreshold()
+	above := capacity > 0 && utilization*100 >= float64(threshold)
+	if above && !routeTableAboveThreshold {
+		logger.GlobalLogger.Warnf("Slice route table utilization above %v%%: %v of %v routes", threshold, routeCount, capacity)
+	}
+	if !above && routeTableAboveThreshold {
+		logger.GlobalLogger.Infof("Slice route table utilization back below %v%%: %v of %v routes", threshold, routeCount, capacity)
+	}
+	routeTableAboveThreshold = above
+}
@@ -0,0 +1,110 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestRouteTableUtilization(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("ROUTE_TABLE_CAPACITY", "4")
+	t.Setenv("ROUTE_TABLE_WARN_THRESHOLD", "50")
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	steps := []struct {
+		testName            string
+		remoteSubnet        string
+		nextHops            []string
+		expectedCount       uint32
+		expectedUtilization float64
+		expectedAbove       bool
+	}{
+		{"below threshold", "10.1.0.0/16", []string{"192.168.0.2"}, 1, 0.25, false},
+		{"at threshold", "10.2.0.0/16", []string{"192.168.0.2"}, 2, 0.5, true},
+		{"above threshold", "10.3.0.0/16", []string{"192.168.0.2"}, 3, 0.75, true},
+		{"still above threshold", "10.3.0.0/16", []string{}, 2, 0.5, true},
+		{"back below threshold", "10.2.0.0/16", []string{}, 1, 0.25, false},
+	}
+
+	for _, step := range steps {
+		t.Run(step.testName, func(t *testing.T) {
+			if err := sliceRouterInjectRoute(step.remoteSubnet, step.nextHops); err != nil {
+				t.Fatal(err)
+			}
+			table := sliceRouterGetRouteTable()
+			if table.GetRouteCount() != step.expectedCount || table.GetCapacity() != 4 ||
+				table.GetUtilization() != step.expectedUtilization {
+				t.Error("route table: expected", step.expectedCount, 4, step.expectedUtilization, "received",
+					table.GetRouteCount(), table.GetCapacity(), table.GetUtilization())
+			}
+			if v := routeTableUtilizationGauge.Value(); v != step.expectedUtilization {
+				t.Error("utilization gauge: expected", step.expectedUtilization, "received", v)
+			}
+			if v := routeCountGauge.Value(); v != float64(step.expectedCount) {
+				t.Error("route count gauge: expected", step.expectedCount, "received", v)
+			}
+			routeCountMu.Lock()
+			above := routeTableAboveThreshold
+			routeCountMu.Unlock()
+			if above != step.expectedAbove {
+				t.Error("above threshold: expected", step.expectedAbove, "received", above)
+			}
+		})
+	}
+}
+
+func TestRouteTableCapacityConfig(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		capacity          string
+		threshold         string
+		expectedCapacity  int
+		expectedThreshold int
+	}{
+		{"", "", 0, defaultRouteTableWarnThreshold},
+		{"1000", "90", 1000, 90},
+		{"-1", "0", 0, defaultRouteTableWarnThreshold},
+		{"many", "101", 0, defaultRouteTableWarnThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("capacity %q threshold %q", tt.capacity, tt.threshold), func(t *testing.T) {
+			t.Setenv("ROUTE_TABLE_CAPACITY", tt.capacity)
+			t.Setenv("ROUTE_TABLE_WARN_THRESHOLD", tt.threshold)
+			if capacity := getRouteTableCapacity(); capacity != tt.expectedCapacity {
+				t.Error("capacity: expected", tt.expectedCapacity, "received", capacity)
+			}
+			if threshold := getRouteTableWarnThreshold(); threshold != tt.expectedThreshold {
+				t.Error("threshold: expected", tt.expectedThreshold, "received", threshold)
+			}
+			if utilization := routeTableUtilization(10, tt.expectedCapacity); tt.expectedCapacity == 0 && utilization != 0 {
+				t.Error("utilization with unknown capacity:", utilization)
+			}
+		})
+	}
+}
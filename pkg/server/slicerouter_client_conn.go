@@ -0,0 +1,252 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// The connected route of the nsm link points to the client, the link address is the slice router end.
+	nsmIPSourceRoute = "route"
+	// The link address is the client end, the connected route points to the slice router end.
+	nsmIPSourceAddress = "address"
+)
+
+// getNsmIPSource returns which side of a kernel nsm link holds the client IP, read from the
+// NSM_IP_SOURCE env variable. The connected route holds it by default.
+func getNsmIPSource() string {
+	source := os.Getenv("NSM_IP_SOURCE")
+	switch source {
+	case nsmIPSourceRoute, nsmIPSourceAddress:
+		return source
+	case "":
+		return nsmIPSourceRoute
+	}
+	logger.GlobalLogger.Errorf("Invalid NSM_IP_SOURCE %q, using %v", source, nsmIPSourceRoute)
+	return nsmIPSourceRoute
+}
+
+// newKernelConnectionInfo builds the connection information of a kernel nsm link from the destination
+// of its connected route and its address, assigning them to the client and slice router ends as
+// configured by NSM_IP_SOURCE.
+func newKernelConnectionInfo(nsmLink netlink.Link, routeIP, addrIP string) *sidecar.ConnectionInfo {
+	nsmIP, nsmPeerIP := orientNsmIPs(routeIP, addrIP)
+	return &sidecar.ConnectionInfo{
+		PodName:      nsmLink.Attrs().Alias,
+		NsmInterface: "nsm0",
+		NsmIP:        nsmIP,
+		NsmPeerIP:    nsmPeerIP,
+	}
+}
+
+// orientNsmIPs returns the client and slice router IPs of an nsm link from the destination of its
+// connected route and its address.
+func orientNsmIPs(routeIP, addrIP string) (string, string) {
+	// nsmIP is the IP address on the app pod, whereas nsmPeerIP is the IP address on the
+	// corresponding link on the vl3 slice router
+	if getNsmIPSource() == nsmIPSourceAddress {
+		return addrIP, routeIP
+	}
+	return routeIP, addrIP
+}
+
+// vl3SetIPv6ConnectionInfo adds the IPv6 end of the connection to the connection information of the
+// kernel nsm link, along with the reachability of the client. ipv6Routes are the IPv6 routes of the
+// link, or of all the links. Link local addresses are not part of the connection.
+func vl3SetIPv6ConnectionInfo(conn *sidecar.ConnectionInfo, nsmLink netlink.Link, ipv6Routes []netlink.Route) error {
+	addrList, err := nlHandle.AddrList(nsmLink, unix.AF_INET6)
+	if err != nil {
+		return err
+	}
+	addrIP := ""
+	for _, addr := range addrList {
+		if addr.IP.IsGlobalUnicast() {
+			addrIP = addr.IP.String()
+			break
+		}
+	}
+	routeIP := ""
+	for _, route := range ipv6Routes {
+		if route.LinkIndex != nsmLink.Attrs().Index || route.Dst == nil || !route.Dst.IP.IsGlobalUnicast() {
+			continue
+		}
+		if ones, bits := route.Dst.Mask.Size(); ones == bits {
+			routeIP = route.Dst.IP.String()
+		}
+	}
+
+	conn.NsmIPv6, conn.NsmPeerIPv6 = orientNsmIPs(routeIP, addrIP)
+	if conn.NsmIPv6 == "" {
+		return nil
+	}
+	conn.NsmIPv6Reachable, err = isNeighborResolved(nsmLink.Attrs().Index, net.ParseIP(conn.NsmIPv6))
+	return err
+}
+
+// vl3GetNsmInterfaceInKernel returns the connection information of the nsm interface whose alias is
+// the pod name. Only the addresses and routes of the matching link are read.
+func vl3GetNsmInterfaceInKernel(podName string) (*sidecar.ConnectionInfo, error) {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Could not get link list, Err: %v", err)
+		return nil, err
+	}
+
+	var nsmLink netlink.Link
+	for _, link := range links {
+		if isNsmInterface(link.Attrs().Name) && link.Attrs().Alias == podName {
+			nsmLink = link
+			break
+		}
+	}
+	if nsmLink == nil {
+		return nil, errConnectionNotFound
+	}
+
+	return vl3GetLinkConnectionInfo(nsmLink)
+}
+
+// isClientConnectedRoute returns true if the route is the connected host route to the client end of an
+// nsm link, as opposed to the slice routes going through the client.
+func isClientConnectedRoute(route netlink.Route) bool {
+	if route.Dst == nil || route.Gw != nil || len(route.MultiPath) > 0 {
+		return false
+	}
+	ones, bits := route.Dst.Mask.Size()
+	return ones == bits
+}
+
+// vl3GetLinkConnectionInfo returns the connection information of a client from its nsm link. The link
+// carries an address and the connected route to the other end of the connection.
+// errConnectionNotFound is returned if the link does not have a single address yet.
+func vl3GetLinkConnectionInfo(nsmLink netlink.Link) (*sidecar.ConnectionInfo, error) {
+	addrList, err := nlHandle.AddrList(nsmLink, unix.AF_INET)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get address list for intf: %v, err: %v", nsmLink.Attrs().Name, err)
+		return nil, err
+	}
+	if len(addrList) != 1 {
+		logger.GlobalLogger.Infof("No address or more than one address on nsm intf: %v", addrList)
+		return nil, errConnectionNotFound
+	}
+
+	routes, err := nlHandle.RouteList(nsmLink, netlink.FAMILY_V4)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Could not get route list, Err: %v", err)
+		return nil, err
+	}
+	routeIP := ""
+	for _, route := range routes {
+		if isClientConnectedRoute(route) {
+			routeIP = strings.Split(route.Dst.String(), "/")[0]
+		}
+	}
+
+	conn := newKernelConnectionInfo(nsmLink, routeIP, addrList[0].IP.String())
+	if isIPv6Enabled() {
+		ipv6Routes, err := nlHandle.RouteList(nsmLink, netlink.FAMILY_V6)
+		if err == nil {
+			err = vl3SetIPv6ConnectionInfo(conn, nsmLink, ipv6Routes)
+		}
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to get IPv6 connection info for intf: %v, err: %v", nsmLink.Attrs().Name, err)
+		}
+	}
+	return conn, nil
+}
+
+// vl3GetNsmInterfaceInVpp returns the connection information of the nsm interface named after the pod.
+// The vpp-agent configurator has no per interface lookup, so the interface is picked from the vpp config.
+func vl3GetNsmInterfaceInVpp(podName string) (*sidecar.ConnectionInfo, error) {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		logger.GlobalLogger.Errorf("can't dial grpc server: %v", err)
+		return nil, err
+	}
+	defer closeConn()
+
+	// Only the interfaces are read, the rest of the config is skipped.
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{}, vppGetInterfacesOnly())
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get vpp config: %v", err)
+		return nil, err
+	}
+
+	for _, intf := range vppConfig.GetConfig().GetVppConfig().GetInterfaces() {
+		if intf.Name != podName || len(intf.IpAddresses) == 0 || !isVppNsmInterface(intf.Name) {
+			continue
+		}
+		return vppInterfaceToConnectionInfo(intf)
+	}
+
+	return nil, errConnectionNotFound
+}
+
+// sliceRouterGetClientConnection returns the connection information of the client pod, or
+// errConnectionNotFound if the pod is not connected to the slice router.
+func sliceRouterGetClientConnection(podName string) (*sidecar.ConnectionInfo, error) {
+	var conn *sidecar.ConnectionInfo
+	var err error
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel {
+		conn, err = vl3GetNsmInterfaceInKernel(podName)
+	} else if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		conn, err = vl3GetNsmInterfaceInVpp(podName)
+	} else {
+		return nil, errConnectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return connAge.observeOne(conn), nil
+}
+
+// sliceRouterGetDataplaneConnections lists the client connections of both the kernel and vpp dataplanes,
+// ignoring the dataplane mode, so that the two views can be compared when migrating a slice router. The
+// error of one dataplane is recorded in the result and the list of the other is still returned.
+func sliceRouterGetDataplaneConnections() *sidecar.DataplaneConnections {
+	result := &sidecar.DataplaneConnections{}
+
+	kernelConns, err := vl3GetNsmInterfacesInKernel()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get kernel connections: %v", err)
+		result.Errors = append(result.Errors, fmt.Sprintf("kernel: %v", err))
+	}
+	result.KernelConnections = kernelConns
+
+	vppConns, err := vl3GetNsmInterfacesInVpp()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get vpp connections: %v", err)
+		result.Errors = append(result.Errors, fmt.Sprintf("vpp: %v", err))
+	}
+	result.VppConnections = vppConns
+
+	return result
+}
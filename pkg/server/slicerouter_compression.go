@@ -0,0 +1,94 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// Calls to the vpp-agent are not compressed.
+	vppAgentCompressionNone = "none"
+	// Calls to the vpp-agent are compressed with gzip, the vpp-agent answers with the same compressor.
+	vppAgentCompressionGzip = "gzip"
+)
+
+// getVppAgentCompression returns the compression of the calls to the vpp-agent, read from the
+// VPP_AGENT_COMPRESSION env variable. Calls are not compressed by default.
+func getVppAgentCompression() string {
+	compression := os.Getenv("VPP_AGENT_COMPRESSION")
+	switch compression {
+	case vppAgentCompressionNone, vppAgentCompressionGzip:
+		return compression
+	case "":
+		return vppAgentCompressionNone
+	}
+	logger.GlobalLogger.Errorf("Invalid VPP_AGENT_COMPRESSION %q, using %v", compression, vppAgentCompressionNone)
+	return vppAgentCompressionNone
+}
+
+// vppAgentCallOptions returns the call options applied to every call to the vpp-agent.
+func vppAgentCallOptions() []grpc.CallOption {
+	if getVppAgentCompression() == vppAgentCompressionGzip {
+		return []grpc.CallOption{grpc.UseCompressor(vppAgentCompressionGzip)}
+	}
+	return nil
+}
+
+// gzipCompressor is the gzip grpc compressor. It is registered by the sidecar as the grpc gzip encoding
+// package is not vendored. Registering it also lets the sidecar GRPC server accept gzip requests.
+type gzipCompressor struct {
+	writers sync.Pool
+}
+
+func init() {
+	encoding.RegisterCompressor(&gzipCompressor{})
+}
+
+type gzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+func (w *gzipWriter) Close() error {
+	defer w.pool.Put(w)
+	return w.Writer.Close()
+}
+
+func (c *gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if writer, ok := c.writers.Get().(*gzipWriter); ok {
+		writer.Reset(w)
+		return writer, nil
+	}
+	return &gzipWriter{Writer: gzip.NewWriter(w), pool: &c.writers}, nil
+}
+
+func (c *gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+func (c *gzipCompressor) Name() string {
+	return vppAgentCompressionGzip
+}
@@ -0,0 +1,123 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// vppAgentServer is a vpp-agent configurator server recording the compression of the requests it receives.
+type vppAgentServer struct {
+	configurator.UnimplementedConfiguratorServiceServer
+
+	mu          sync.Mutex
+	compression []string
+}
+
+func (s *vppAgentServer) Get(ctx context.Context, in *configurator.GetRequest) (*configurator.GetResponse, error) {
+	return &configurator.GetResponse{Config: &configurator.Config{VppConfig: &vpp.ConfigData{}}}, nil
+}
+
+func (s *vppAgentServer) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (s *vppAgentServer) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	if header, ok := rs.(*stats.InHeader); ok {
+		s.mu.Lock()
+		s.compression = append(s.compression, header.Compression)
+		s.mu.Unlock()
+	}
+}
+
+func (s *vppAgentServer) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (s *vppAgentServer) HandleConn(ctx context.Context, cs stats.ConnStats) {}
+
+func TestVppAgentCompression(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName            string
+		compression         string
+		expectedCompression string
+	}{
+		{"default off", "", ""},
+		{"none", vppAgentCompressionNone, ""},
+		{"gzip", vppAgentCompressionGzip, "gzip"},
+		{"invalid falls back to none", "zstd", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_AGENT_COMPRESSION", tt.compression)
+
+			agent := &vppAgentServer{}
+			listener := bufconn.Listen(1024 * 1024)
+			srv := grpc.NewServer(grpc.StatsHandler(agent))
+			configurator.RegisterConfiguratorServiceServer(srv, agent)
+			go srv.Serve(listener)
+			defer srv.Stop()
+
+			conn, err := newVppAgentConn("bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return listener.Dial()
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			// The response is decoded with the compressor the vpp-agent picked, the call must succeed
+			// either way.
+			if _, err := configurator.NewConfiguratorServiceClient(conn).Get(context.Background(), &configurator.GetRequest{}); err != nil {
+				t.Fatal(err)
+			}
+			agent.mu.Lock()
+			defer agent.mu.Unlock()
+			if len(agent.compression) != 1 || agent.compression[0] != tt.expectedCompression {
+				t.Error("request compression: expected", tt.expectedCompression, "received", agent.compression)
+			}
+
+			callOptions := vppAgentCallOptions()
+			if tt.expectedCompression == "" {
+				if len(callOptions) != 0 {
+					t.Error("unexpected call options:", callOptions)
+				}
+				return
+			}
+			if len(callOptions) != 1 {
+				t.Fatal("expected a compressor call option, received", callOptions)
+			}
+			if option, ok := callOptions[0].(grpc.CompressorCallOption); !ok || option.CompressorType != tt.expectedCompression {
+				t.Error("call option: expected compressor", tt.expectedCompression, "received", callOptions[0])
+			}
+		})
+	}
+}
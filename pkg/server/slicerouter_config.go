@@ -23,57 +23,116 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kubeslice/router-sidecar/pkg/logger"
 	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
 
-	"github.com/lorenzosaino/go-sysctl"
 	"github.com/vishvananda/netlink"
 	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
 	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
 	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"sync"
+	"sync/atomic"
 
 	"golang.org/x/sys/unix"
-	"google.golang.org/grpc"
 )
 
 const (
-	vppAgentEndpoint                  = "localhost:9113"
 	SliceRouterDataplaneVpp    string = "vpp"
 	SliceRouterDataplaneKernel string = "kernel"
 	/* Routing table reconcilation interval in seconds */
 	routingTableReconcileInterval float64 = 60.0
+	/* Default prefix of the NSM interfaces that connect app pods to the slice router */
+	defaultNsmInterfacePrefix = "vl3-"
 )
 
 // remoteSubnetRouteMap holds all the routes that were injected by the vL3 sidecar into the
 // vL3 routing table.
 var remoteSubnetRouteMap sync.Map
 
-// Records the last time the routing table in the slice router was reconciled.
-var lastRoutingTableReconcileTime time.Time
+// Records the last time the routing table in the slice router was reconciled. Injections run
+// concurrently, so it is guarded by reconcileMu.
+var (
+	reconcileMu                   sync.Mutex
+	lastRoutingTableReconcileTime time.Time
+	// reconcileLoopStartTime is the time the reconcile loop started ticking, zero if it is not running.
+	reconcileLoopStartTime time.Time
+)
+
+// reconcileRunning is set while a routing table reconcile is running, so that at most one reconcile
+// runs against the dataplane at a time.
+var reconcileRunning atomic.Bool
+
+// dialVppAgent connects to the active vpp-agent endpoint and returns a configurator client along with
+// a func to close the connection. The client reports the outcome of its calls so that persistent
+// failures move to the next endpoint. It is a variable so that tests can substitute a fake vpp-agent.
+var dialVppAgent = func() (configurator.ConfiguratorServiceClient, func(), error) {
+	index, endpoint := vppAgentEndpoints.active()
+	client, closeConn, err := dialVppAgentEndpoint(endpoint)
+	if err != nil {
+		vppAgentEndpoints.recordFailure(index)
+		return nil, nil, err
+	}
+	return &failoverVppAgentClient{ConfiguratorServiceClient: client, index: index}, closeConn, nil
+}
+
+// dialVppAgentEndpoint connects to the vpp-agent at the endpoint. It is a variable so that tests can
+// substitute fake vpp-agent endpoints.
+var dialVppAgentEndpoint = func(endpoint string) (configurator.ConfiguratorServiceClient, func(), error) {
+	conn, err := newVppAgentConn(endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return configurator.NewConfiguratorServiceClient(conn), func() { conn.Close() }, nil
+}
 
-func sendConfigToVppAgent(vppconfig *vpp.ConfigData, cfgDelete bool) error {
+// sendConfigToVppAgent updates or deletes the config in vpp through the vpp-agent. The ctx only carries
+// the trace of the operation, the vpp-agent calls are bounded by their own timeout. The outcome is
+// reported to the push breaker.
+func sendConfigToVppAgent(ctx context.Context, vppconfig *vpp.ConfigData, cfgDelete bool) (err error) {
+	_, span := startSpan(ctx, spanSendConfigToVppAgent, trace.SpanKindClient,
+		attribute.Bool(spanAttrVppDelete, cfgDelete), attribute.Int(spanAttrVppRoutes, len(vppconfig.GetRoutes())))
+	defer func() {
+		endSpan(span, err)
+	}()
 
 	dataChange := &configurator.Config{
 		VppConfig: vppconfig,
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	op := vppAgentOpUpdate
+	if cfgDelete {
+		op = vppAgentOpDelete
+	}
+	vppCtx, cancel := newVppAgentContext(op)
 	defer cancel()
 
-	conn, err := grpc.Dial(vppAgentEndpoint, grpc.WithInsecure())
+	// Every attempt dials the active endpoint, so that a retry goes to the next endpoint once the
+	// endpoint in use failed over.
+	defer func() {
+		vppAgentPushBreaker.record(err)
+	}()
+	err = withVppAgentRetries(vppCtx, func() error {
+		return sendDataChangeToVppAgent(vppCtx, dataChange, cfgDelete)
+	})
+	if err != nil || cfgDelete || !isVppUpdateVerifyEnabled() {
+		return err
+	}
+	return verifyVppRoutesApplied(vppconfig.GetRoutes())
+}
+
+func sendDataChangeToVppAgent(ctx context.Context, dataChange *configurator.Config, cfgDelete bool) error {
+	client, closeConn, err := dialVppAgent()
 	if err != nil {
 		logger.GlobalLogger.Errorf("can't dial grpc server: %v", err)
 		return err
 	}
-	defer conn.Close()
-
-	client := configurator.NewConfiguratorServiceClient(conn)
+	defer closeConn()
 
 	logger.GlobalLogger.Infof("Sending DataChange to vppagent: %v", dataChange)
 
@@ -96,35 +155,82 @@ func sendConfigToVppAgent(vppconfig *vpp.ConfigData, cfgDelete bool) error {
 	return err
 }
 
-func vl3InjectRouteInVpp(dstIP string, nextHopIP string) error {
-	vppconfig := getVppConfig(dstIP, nextHopIP)
-	return sendConfigToVppAgent(vppconfig, false)
+// vl3InjectRouteInVpp configures the route entry to the destination via the next hop in vpp, with the
+// path attributes given.
+func vl3InjectRouteInVpp(ctx context.Context, dstIP string, nextHopIP string, attrs vppRouteAttrs) error {
+	vppconfig := getVppConfig(dstIP, nextHopIP, attrs)
+	return sendConfigToVppAgent(ctx, vppconfig, false)
 }
 
-func getVppConfig(dstIP string, nextHopIP string) *vpp.ConfigData {
+// getVppConfig returns the vpp config of the route entry to the destination via the next hop. The
+// vpp-agent keys the entries by destination and next hop, the path attributes do not matter to delete
+// an entry.
+func getVppConfig(dstIP string, nextHopIP string, attrs vppRouteAttrs) *vpp.ConfigData {
 	vppconfig := &vpp.ConfigData{}
+	vrf := getVppSliceRouteVrf()
 	route := &vpp.Route{
 		Type:        vpp_l3.Route_INTER_VRF,
+		VrfId:       vrf,
+		ViaVrfId:    vrf,
 		DstNetwork:  dstIP,
 		NextHopAddr: nextHopIP,
+		Weight:      attrs.weight,
+		Preference:  attrs.preference,
 	}
 	vppconfig.Routes = append(vppconfig.Routes, route)
 	return vppconfig
 }
 
-func vl3DeleteRouteInVpp(dstIP string, nextHopIP string) error {
-	vppconfig := getVppConfig(dstIP, nextHopIP)
-	return sendConfigToVppAgent(vppconfig, true)
+// vl3DeleteRouteInVpp deletes the route entry to the destination via the next hop in vpp. The reason
+// tells apart the deletions requested by the controller from those the sidecar makes on its own, in
+// the logs and in the route deletion metric.
+func vl3DeleteRouteInVpp(ctx context.Context, dstIP string, nextHopIP string, reason string) error {
+	logger.GlobalLogger.Infof("Deleting vpp route. RemoteSubnet: %v, NextHop: %v, Reason: %v", dstIP, nextHopIP, reason)
+	vppconfig := getVppConfig(dstIP, nextHopIP, vppRouteAttrs{})
+	if err := sendConfigToVppAgent(ctx, vppconfig, true); err != nil {
+		return err
+	}
+	vppRouteDeletesCounter.Inc(reason)
+	return nil
 }
 
+// vl3InjectRouteInKernel installs the route to the remote subnet in the main table, with the preferred
+// source recorded for it.
 func vl3InjectRouteInKernel(dstIP string, nextHopIPSlice []*netlink.NexthopInfo) error {
+	return vl3InjectTableRouteInKernel(dstIP, 0, loadPreferredSrc(dstIP), nextHopIPSlice)
+}
+
+// vl3InjectTableRouteInKernel installs the route in the given routing table, zero being the main table.
+// A nil src lets the kernel pick the source address.
+func vl3InjectTableRouteInKernel(dstIP string, table int, src net.IP, nextHopIPSlice []*netlink.NexthopInfo) error {
 	_, dstIPNet, err := net.ParseCIDR(dstIP)
 	if err != nil {
 		return err
 	}
 
-	route := netlink.Route{Dst: dstIPNet, MultiPath: nextHopIPSlice}
-	if err := netlink.RouteReplace(&route); err != nil {
+	// The link indices may have been resolved a while ago, make sure they were not reused by
+	// another interface since.
+	if err := verifyNextHopLinks(nextHopIPSlice); err != nil {
+		logger.GlobalLogger.Errorf("Failed to verify next hop links. Dst: %v, Err: %v", dstIPNet, err)
+		return err
+	}
+
+	// Without ONLINK the kernel only accepts next hops it can reach through neighbor resolution, and
+	// traffic is dropped until the neighbors are resolved. The route is installed once they are.
+	if !isRouteOnlinkEnabled() {
+		for _, nextHop := range nextHopIPSlice {
+			nextHop.Flags &^= int(netlink.FLAG_ONLINK)
+		}
+		if err := waitForNeighbors(nextHopIPSlice); err != nil {
+			return err
+		}
+	}
+
+	route := netlink.Route{Dst: dstIPNet, MultiPath: nextHopIPSlice, Table: table, Src: src}
+	if table == 0 {
+		route.Priority = kernelRouteMetric(dstIP)
+	}
+	if err := vl3WriteRouteInKernel(dstIP, &route); err != nil {
 		logger.GlobalLogger.Errorf("Route add failed in kernel. Dst: %v, NextHop: %v, Err: %v", dstIPNet, nextHopIPSlice, err)
 		return err
 	}
@@ -134,19 +240,18 @@ func vl3InjectRouteInKernel(dstIP string, nextHopIPSlice []*netlink.NexthopInfo)
 }
 
 func vl3GetNsmInterfacesInVpp() ([]*sidecar.ConnectionInfo, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
 	defer cancel()
 
-	conn, err := grpc.Dial(vppAgentEndpoint, grpc.WithInsecure())
+	client, closeConn, err := dialVppAgent()
 	if err != nil {
 		logger.GlobalLogger.Errorf("can't dial grpc server: %v", err)
 		return nil, err
 	}
-	defer conn.Close()
+	defer closeConn()
 
-	client := configurator.NewConfiguratorServiceClient(conn)
-
-	vppConfig, err := client.Get(ctx, &configurator.GetRequest{})
+	// Only the interfaces are read, the rest of the config is skipped.
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{}, vppGetInterfacesOnly())
 	if err != nil {
 		logger.GlobalLogger.Errorf("Failed to get vpp config: %v", err)
 		return nil, err
@@ -164,35 +269,51 @@ func vl3GetNsmInterfacesInVpp() ([]*sidecar.ConnectionInfo, error) {
 		if len(intf.IpAddresses) == 0 {
 			continue
 		}
-		nsmPeerIP := strings.TrimSuffix(intf.IpAddresses[0], "/30")
-		nsmIpOctetList := strings.Split(nsmPeerIP, ".")
-		nsmIpLastOctet, _ := strconv.Atoi(nsmIpOctetList[3])
-		nsmIpOctetList[3] = strconv.Itoa(nsmIpLastOctet - 1)
-		nsmIP := strings.Join(nsmIpOctetList, ".")
-		conn := sidecar.ConnectionInfo{
-			PodName:      intf.Name,
-			NsmInterface: "nsm0",
-			NsmIP:        nsmIP,
-			NsmPeerIP:    nsmPeerIP,
+		if !isVppNsmInterface(intf.Name) {
+			logger.GlobalLogger.Debugf("Skipping non-nsm vpp intf: %v", intf.Name)
+			continue
+		}
+		connInfo, err := vppInterfaceToConnectionInfo(intf)
+		if err != nil {
+			// Skip the interface rather than failing the whole list for one bad interface.
+			logger.GlobalLogger.Errorf("Skipping nsm vpp intf: %v", err)
+			continue
 		}
-		connList = append(connList, &conn)
+		connList = append(connList, connInfo)
 	}
 	logger.GlobalLogger.Infof("Conn list: %v", connList)
 
 	return connList, nil
 }
 
+// vppInterfaceToConnectionInfo builds the client connection information of an nsm interface in vpp.
+// The interface is named after the client pod and carries the IP address of the slice router end of the
+// /30 nsm link, the client end is derived from it according to VPP_NSM_ADDRESS_LAYOUT. An error is
+// returned if the client IP cannot be derived from the address.
+func vppInterfaceToConnectionInfo(intf *vpp.Interface) (*sidecar.ConnectionInfo, error) {
+	nsmIP, nsmPeerIP, err := vppNsmLinkAddresses(intf.IpAddresses[0])
+	if err != nil {
+		return nil, fmt.Errorf("%v on vpp intf %v", err, intf.Name)
+	}
+	return &sidecar.ConnectionInfo{
+		PodName:      intf.Name,
+		NsmInterface: "nsm0",
+		NsmIP:        nsmIP,
+		NsmPeerIP:    nsmPeerIP,
+	}, nil
+}
+
 // vl3GetNsmInterfacesInKernel()
 // Returns a list of nsm interfaces created to connect clients to the
 // slice router.
 func vl3GetNsmInterfacesInKernel() ([]*sidecar.ConnectionInfo, error) {
-	links, err := netlink.LinkList()
+	links, err := nlHandle.LinkList()
 	if err != nil {
 		logger.GlobalLogger.Errorf("Could not get link list, Err: %v", err)
 		return nil, err
 	}
 
-	installedRoutes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	installedRoutes, err := nlHandle.RouteList(nil, netlink.FAMILY_V4)
 	if err != nil {
 		logger.GlobalLogger.Errorf("Could not get route list, Err: %v", err)
 		return nil, err
@@ -201,7 +322,7 @@ func vl3GetNsmInterfacesInKernel() ([]*sidecar.ConnectionInfo, error) {
 	intfMap := make(map[int]string)
 
 	for _, route := range installedRoutes {
-		if route.Dst == nil {
+		if !isClientConnectedRoute(route) {
 			continue
 		}
 		intfMap[route.LinkIndex] = route.Dst.String()
@@ -209,11 +330,20 @@ func vl3GetNsmInterfacesInKernel() ([]*sidecar.ConnectionInfo, error) {
 
 	logger.GlobalLogger.Debugf("intf map: %v", intfMap)
 
+	ipv6Routes := []netlink.Route{}
+	if isIPv6Enabled() {
+		ipv6Routes, err = nlHandle.RouteList(nil, netlink.FAMILY_V6)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Could not get IPv6 route list, Err: %v", err)
+			return nil, err
+		}
+	}
+
 	connList := []*sidecar.ConnectionInfo{}
 
 	for _, link := range links {
-		if strings.HasPrefix(link.Attrs().Name, "vl3-") {
-			addrList, err := netlink.AddrList(link, unix.AF_INET)
+		if isNsmInterface(link.Attrs().Name) {
+			addrList, err := nlHandle.AddrList(link, unix.AF_INET)
 			if err != nil {
 				logger.GlobalLogger.Errorf("Failed to get address list for intf: %v, err: %v",
 					link.Attrs().Name, err)
@@ -224,18 +354,15 @@ func vl3GetNsmInterfacesInKernel() ([]*sidecar.ConnectionInfo, error) {
 				continue
 			}
 
-			// nsmIP is the IP address on the app pod, whereas nsmPeerIP is the IP address on the
-			// corresponding link on the vl3 slice router
-			nsmIP := strings.Split(intfMap[link.Attrs().Index], "/")[0]
-			nsmPeerIP := addrList[0].IP.String()
-
-			conn := sidecar.ConnectionInfo{
-				PodName:      link.Attrs().Alias,
-				NsmInterface: "nsm0",
-				NsmIP:        nsmIP,
-				NsmPeerIP:    nsmPeerIP,
+			routeIP := strings.Split(intfMap[link.Attrs().Index], "/")[0]
+			conn := newKernelConnectionInfo(link, routeIP, addrList[0].IP.String())
+			if isIPv6Enabled() {
+				if err := vl3SetIPv6ConnectionInfo(conn, link, ipv6Routes); err != nil {
+					logger.GlobalLogger.Errorf("Failed to get IPv6 connection info for intf: %v, err: %v",
+						link.Attrs().Name, err)
+				}
 			}
-			connList = append(connList, &conn)
+			connList = append(connList, conn)
 		}
 	}
 
@@ -252,7 +379,7 @@ func vl3GetRouteInKernel(dstIP string, nsmIP string) (bool, error) {
 	}
 	gwIP := net.ParseIP(nsmIP)
 
-	routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	routes, err := nlHandle.RouteList(nil, netlink.FAMILY_V4)
 	if err != nil {
 		return false, err
 	}
@@ -264,17 +391,17 @@ func vl3GetRouteInKernel(dstIP string, nsmIP string) (bool, error) {
 		}
 	}
 	if len(ecmpRoutes) == 0 {
-		links, err := netlink.LinkList()
+		links, err := nlHandle.LinkList()
 		if err != nil {
 			logger.GlobalLogger.Errorf("Could not get link list, Err: %v", err)
 			return false, err
 		}
 
 		for _, link := range links {
-			if strings.HasPrefix(link.Attrs().Name, "vl3") {
+			if isNsmInterface(link.Attrs().Name) {
 				// Get the routes
 				logger.GlobalLogger.Info("link name", "link", link.Attrs().Name, "link index", link.Attrs().Index)
-				routes, err := netlink.RouteList(link, netlink.FAMILY_V4)
+				routes, err := nlHandle.RouteList(link, netlink.FAMILY_V4)
 				if err != nil {
 					return false, err
 				}
@@ -311,48 +438,90 @@ func printSliceRouteMap() {
 	})
 }
 
-func vl3ReconcileRoutesInKernel() error {
+// vl3ReconcileRoutesInKernel reconciles the kernel routes to the remote subnets matching the filter,
+// correcting at most the routes the budget allows.
+func vl3ReconcileRoutesInKernel(filter prefixFilter, budget *reconcileBudget) error {
 	// Build a map of existing routes in the vl3
-	installedRoutes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	installedRoutes, err := nlHandle.RouteList(nil, netlink.FAMILY_ALL)
 	if err != nil {
 		return err
 	}
 
 	routeMap := make(map[string][]netlink.Route, 0)
 	for _, route := range installedRoutes {
-		// Default route will have a Dst of nil so it is
-		// important to have a null check here. Else we will
-		// crash trying to deref a null pointer.
-		if route.Dst == nil {
+		// Default routes have a Dst of nil, they are keyed by the default route of their family.
+		// Default routes other than the default next hop route, like the one of the pod network, are
+		// told apart by their metric.
+		dst := kernelRouteDst(route)
+		if dst == "" || !isSliceRouteMetric(route) {
 			continue
 		}
-		routeMap[route.Dst.String()] = append(routeMap[route.Dst.String()], route)
+		routeMap[dst] = append(routeMap[dst], route)
 	}
 
 	logger.GlobalLogger.Debugf("Installed routes map: %v", routeMap)
 	printSliceRouteMap()
 
-	nextHopInfoSlice := []*netlink.NexthopInfo{}
+	linkMap, err := getLinkIndexMap()
+	if err != nil {
+		return err
+	}
+
 	remoteSubnetRouteMap.Range(func(key, value any) bool {
-		nextHopList := value.([]string)
 		remoteSubnet := key.(string)
-		for _, ip := range nextHopList {
+		if !filter.matches(remoteSubnet) {
+			return true
+		}
+		// Routes whose next hops or egress links are all down are left to the next hop down and link
+		// down policies.
+		if isRouteSuspended(remoteSubnet) {
+			return true
+		}
+		// Next hops that are down are kept out of the installed route by the health checker.
+		nextHopList := healthyNextHops(value.([]string))
+		// Pod next hops are resolved again so that the route follows the pods.
+		resolvedNextHops, err := resolvePodNextHops(remoteSubnet, nextHopList)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to resolve next hop pods: dst: %v, gw: %v, err: %v", remoteSubnet, nextHopList, err)
+			return true
+		}
+		nextHopInfoSlice := []*netlink.NexthopInfo{}
+		correction := ""
+		for _, ip := range resolvedNextHops {
 			_, ok := routeMap[remoteSubnet]
-			if !ok || !containsRoute(routeMap[remoteSubnet], ip) {
-				nextHopInfoSlice, err = getNetlinkNextHopInfo(nextHopList)
+			installed := ok && (containsNextHop(routeMap[remoteSubnet], ip, linkMap) ||
+				isConnectedOnNextHopLink(routeMap[remoteSubnet], ip, installedRoutes, linkMap))
+			correction = routeCorrection(remoteSubnet, routeMap[remoteSubnet], installed, linkMap)
+			if correction != "" {
+				nextHopInfoSlice, err = getNetlinkNextHopInfo(resolvedNextHops)
 				if err != nil {
-					return false
+					// Failed injections may be kept for retry before their next hops are connected,
+					// they must not hold back the other routes.
+					logger.GlobalLogger.Errorf("Failed to resolve next hops: dst: %v, gw: %v, err: %v", remoteSubnet, nextHopList, err)
+					markUnresolvedRoute(remoteSubnet)
+					budget.recordCorrection(remoteSubnet, correction, err)
+					return true
 				}
+				clearUnresolvedRoute(remoteSubnet)
+				break
 			}
 		}
 		if len(nextHopInfoSlice) > 0 {
-			logger.GlobalLogger.Infof("Installed route does not reflect slice state. Reconciling dst: %v, gw: %v", remoteSubnet, nextHopInfoSlice)
+			if !budget.take() {
+				budget.deferCorrection(remoteSubnet)
+				return false
+			}
+			logger.GlobalLogger.Infof("Installed route does not reflect slice state. Reconciling dst: %v, gw: %v, correction: %v", describeRoute(remoteSubnet), nextHopInfoSlice, correction)
 			err := vl3InjectRouteInKernel(remoteSubnet, nextHopInfoSlice)
+			routeAudit.recordCorrection(remoteSubnet, nextHopList, correction, err)
+			budget.recordCorrection(remoteSubnet, correction, err)
+			if err == errNeighborPending {
+				return true
+			}
 			if err != nil {
 				logger.GlobalLogger.Errorf("Failed to install route: dst: %v, gw: %v", remoteSubnet, nextHopInfoSlice)
-				return false
+				return true
 			}
-			remoteSubnetRouteMap.Store(remoteSubnet, contructArrayFromNextHop(nextHopInfoSlice))
 		} else {
 			logger.GlobalLogger.Debugf("Skipping installing routes since they are already present!")
 		}
@@ -362,32 +531,7 @@ func vl3ReconcileRoutesInKernel() error {
 }
 
 func getNetlinkNextHopInfo(nextHopIPList []string) ([]*netlink.NexthopInfo, error) {
-	nextHopIpSlice := []*netlink.NexthopInfo{}
-	for _, nextHopIP := range nextHopIPList {
-		installedRoutes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
-		if err != nil {
-			return nil, err
-		}
-		var linkIdx int = -1
-		for _, route := range installedRoutes {
-			if route.Dst == nil {
-				continue
-			}
-			// Default route will have a Dst of nil so it is
-			// important to have a null check here. Else we will
-			// crash trying to deref a null pointer.
-			if route.Dst.String() == nextHopIP+"/32" {
-				linkIdx = route.LinkIndex
-				gwObj := &netlink.NexthopInfo{LinkIndex: linkIdx, Gw: net.ParseIP(nextHopIP), Flags: int(netlink.FLAG_ONLINK)}
-				nextHopIpSlice = append(nextHopIpSlice, gwObj)
-				break
-			}
-		}
-		if linkIdx == -1 {
-			return nil, errors.New(fmt.Sprintf("link idx of nexthop not found for %v", nextHopIP))
-		}
-	}
-	return nextHopIpSlice, nil
+	return newNextHopResolver().resolve(nextHopIPList)
 }
 
 // contructArrayFromNextHop takes  []*netlink.NexthopInfo and flattens nextHop IPs to []string
@@ -399,70 +543,315 @@ func contructArrayFromNextHop(nextHopIP []*netlink.NexthopInfo) []string {
 	return nextHopIPList
 }
 
-func sliceRouterReconcileRoutingTable() error {
+// sliceRouterReconcileRoutingTable reconciles the routing table with the injected routes to the remote
+// subnets matching the filter, an empty filter matching every route. errReconcileInProgress is returned
+// without reconciling if a reconcile is already running, the running reconcile covers the request. The
+// ctx carries the trace of the request triggering the reconcile, if any.
+func sliceRouterReconcileRoutingTable(ctx context.Context, filter prefixFilter) error {
+	return reconcileRoutingTableWithBudget(ctx, filter, newReconcileBudget())
+}
+
+// reconcileRoutingTableWithBudget reconciles the routing table like sliceRouterReconcileRoutingTable,
+// the corrections being bounded and recorded by the budget.
+func reconcileRoutingTableWithBudget(ctx context.Context, filter prefixFilter, budget *reconcileBudget) (err error) {
+	if !reconcileRunning.CompareAndSwap(false, true) {
+		return errReconcileInProgress
+	}
+	defer reconcileRunning.Store(false)
+
+	_, span := startSpan(ctx, spanReconcile, trace.SpanKindInternal,
+		attribute.String(spanAttrDataplane, getSliceRouterDataplaneMode()),
+		attribute.StringSlice(spanAttrReconcilePrefixes, filter.prefixes()))
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	// The route groups are converged first, the routes of a group being reconciled together.
+	filter = routeGroupFilter(filter)
+	reconcileRouteGroups(ctx, filter, budget)
+
 	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
-		return nil
+		return vl3ReconcileRoutesInVpp(ctx, filter, budget)
 	} else {
-		return vl3ReconcileRoutesInKernel()
+		// The policy routes share the corrections of the cycle with the main table routes.
+		if err := vl3ReconcileRoutesInKernel(filter, budget); err != nil {
+			return err
+		}
+		return vl3ReconcilePolicyRoutesInKernel(filter, budget)
 	}
 }
 
+// sliceRouterReconcileIfDue reconciles the routing table if it was not reconciled within the
+// reconcile interval. The request is skipped if a reconcile is already running.
+func sliceRouterReconcileIfDue() {
+	reconcileMu.Lock()
+	due := time.Since(lastRoutingTableReconcileTime) > getReconcileInterval()
+	reconcileMu.Unlock()
+	if !due {
+		return
+	}
+	err := sliceRouterReconcileRoutingTable(context.Background(), nil)
+	if err == errReconcileInProgress {
+		logger.GlobalLogger.Debugf("Routing table reconcile already running, skipping")
+		return
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to reconcile routing table: %v", err)
+		return
+	}
+	reconciledAt := time.Now()
+	reconcileMu.Lock()
+	lastRoutingTableReconcileTime = reconciledAt
+	reconcileMu.Unlock()
+	logger.GlobalLogger.Debugf("RT reconciled at: %v", reconciledAt)
+}
+
 func sliceRouterDeleteRouteToDst(dstIP string) error {
 	_, dstIPNet, err := net.ParseCIDR(dstIP)
 	if err != nil {
 		return err
 	}
 
-	routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	routes, err := nlHandle.RouteList(nil, ipFamily(dstIPNet.IP))
 	if err != nil {
 		return err
 	}
 
 	for _, route := range routes {
-		if route.Dst.String() == dstIPNet.String() {
-			err := netlink.RouteDel(&route)
-			return err
+		if kernelRouteDst(route) == dstIPNet.String() && isSliceRouteMetric(route) {
+			if err := nlHandle.RouteDel(&route); err != nil {
+				return err
+			}
+			logIproute2Command("del", &route)
+			return nil
 		}
 	}
 
-	return errors.New("Route to delete not found")
+	return errRouteNotFound
 }
 
 // Function to inject remote cluster subnet routes into the local slice router.
 // The next hop IP would be the IP address of the slice-gw that connects to the remote cluster.
+// An empty nextHopIPList is a request to delete the route to the remote subnet.
+//
+// The returned error reflects whether the route is now in the requested state: nil is returned only
+// if the dataplane write succeeded or the route was already consistent with the request (including
+// deleting a route that is not installed). Otherwise a *routeError describing the failure is returned.
+// A failure to reconcile the rest of the routing table does not fail the injection.
 func sliceRouterInjectRoute(remoteSubnet string, nextHopIPList []string) error {
-	logger.GlobalLogger.Infof("Received NSM IPS from operator: %v", nextHopIPList)
-	if time.Since(lastRoutingTableReconcileTime).Seconds() > routingTableReconcileInterval {
-		err := sliceRouterReconcileRoutingTable()
-		if err != nil {
-			logger.GlobalLogger.Errorf("Failed to reconcile routing table: %v", err)
-			return err
+	return sliceRouterInjectRouteWithResolver(routeRequest{remoteSubnet: remoteSubnet, nextHopIPList: nextHopIPList}, newNextHopResolver())
+}
+
+// sliceRouterInjectRouteWithResolver injects the route resolving the links of its next hops with the
+// given resolver, so that batches can share the resolution across routes. A non empty preferredSrc is
+// installed as the source address of the route, it must be a local address. The description is recorded
+// along with the route once it is in the requested state. The deletion reason of a route with no next
+// hops is recorded in the audit log.
+func sliceRouterInjectRouteWithResolver(route routeRequest, resolver *nextHopResolver) (err error) {
+	remoteSubnet, nextHopIPList, preferredSrc, description := route.remoteSubnet, route.nextHopIPList, route.preferredSrc, route.description
+	ctx, span := startSpan(route.ctx, spanInjectRoute, trace.SpanKindInternal,
+		attribute.String(spanAttrDataplane, getSliceRouterDataplaneMode()),
+		attribute.String(spanAttrRemoteSubnet, remoteSubnet),
+		attribute.StringSlice(spanAttrNextHops, nextHopIPList))
+	defer func() {
+		endSpan(span, err)
+	}()
+	deletionReason := route.deletionReason
+	if deletionReason == sidecar.DeletionReason_DELETION_NONE {
+		deletionReason = sidecar.DeletionReason_DELETION_EXPLICIT
+	}
+	if description != "" {
+		logger.GlobalLogger.Infof("Received NSM IPS from operator: %v, route: %v (%v)", nextHopIPList, remoteSubnet, description)
+	} else {
+		logger.GlobalLogger.Infof("Received NSM IPS from operator: %v", nextHopIPList)
+	}
+	_, remoteNet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	// Routes are recorded under the canonical form of their remote subnet, whatever the form requested.
+	remoteSubnet = remoteNet.String()
+	route.remoteSubnet = remoteSubnet
+	// The routes of a route group are changed by the requests of their group only.
+	if group := loadRouteGroup(remoteSubnet); group != "" && group != route.group {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, fmt.Errorf("route is in route group %q, inject the group instead", group))
+	}
+	if err := checkRouteDescription(description); err != nil {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	if err := checkReconcilePriority(route.reconcilePriority); err != nil {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	if err := checkVppRouteAttrs(route.vppAttrs); err != nil {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	for _, nextHopIP := range nextHopIPList {
+		if name, ok := parseDevNextHop(nextHopIP); ok {
+			if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+				return newRouteError(routeErrorInvalidArgument, remoteSubnet,
+					fmt.Errorf("interface next hop %q is not supported in the vpp dataplane", nextHopIP))
+			}
+			if err := validateDevNextHop(name); err != nil {
+				return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+			}
+			continue
+		}
+		if name, ok := parsePodNextHop(nextHopIP); ok {
+			if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+				return newRouteError(routeErrorInvalidArgument, remoteSubnet,
+					fmt.Errorf("pod next hop %q is not supported in the vpp dataplane", nextHopIP))
+			}
+			if err := validatePodNextHop(name); err != nil {
+				return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+			}
+			continue
+		}
+		ip := net.ParseIP(nextHopIP)
+		if ip == nil {
+			return newRouteError(routeErrorInvalidArgument, remoteSubnet, fmt.Errorf("invalid next hop %q", nextHopIP))
+		}
+		// The route of each family of a dual-stack slice is injected with the next hops of that family.
+		if ipFamily(ip) != ipFamily(remoteNet.IP) {
+			return newRouteError(routeErrorInvalidArgument, remoteSubnet,
+				fmt.Errorf("next hop %v is not in the family of the remote subnet", nextHopIP))
 		}
+		if err := checkUnicastNextHop(ip); err != nil {
+			return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+		}
+	}
+	// Injections are rejected before the checks that read the dataplane.
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		if err := vppAgentPushBreaker.allow(); err != nil {
+			return newRouteError(routeErrorDataplaneUnhealthy, remoteSubnet, err)
+		}
+	}
 
-		lastRoutingTableReconcileTime = time.Now()
+	// The default next hop route covers the local subnets by design, their connected routes are more
+	// specific.
+	if len(nextHopIPList) > 0 && !isDefaultNextHopRoute(remoteSubnet) {
+		if err := checkLocalSubnetOverlap(remoteSubnet); err != nil {
+			return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+		}
+		if isStrictNextHopValidationEnabled() {
+			if err := checkNsmPeerNextHops(nextHopIPList); err != nil {
+				return newRouteError(routeErrorNextHopUnresolved, remoteSubnet, err)
+			}
+		}
+	}
+	src, err := parsePreferredSrc(remoteNet, preferredSrc)
+	if err != nil {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	if src != nil && len(nextHopIPList) > 0 {
+		if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+			return newRouteError(routeErrorInvalidArgument, remoteSubnet,
+				fmt.Errorf("preferred source is not supported in the vpp dataplane"))
+		}
+		if err := checkLocalAddress(src); err != nil {
+			return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+		}
+	}
 
-		logger.GlobalLogger.Debugf("RT reconciled at: %v", lastRoutingTableReconcileTime)
+	if reconcileOnInject() {
+		sliceRouterReconcileIfDue()
+		sliceRouterReconcileFastTierIfDue()
 	}
 
 	printSliceRouteMap()
+	defer updateRouteCountGauge()
+	defer func() {
+		if err == nil && len(nextHopIPList) > 0 {
+			storeRouteDescription(remoteSubnet, description)
+			storeReconcilePriority(remoteSubnet, route.reconcilePriority)
+			storeVppRouteAttrs(remoteSubnet, route.vppAttrs)
+		}
+	}()
+
+	// Operations that reach the dataplane are recorded in the route audit log.
+	auditOperation := ""
+	pending := false
+	// Next hops the route moves from, nil if the next hops of the route do not change.
+	var previousNextHops []string
+	defer func() {
+		if auditOperation == "" {
+			return
+		}
+		if err == nil && !pending && previousNextHops != nil {
+			publishRouteEvent(routeEvent{Type: routeEventNextHopChanged, RemoteSubnet: remoteSubnet,
+				NextHopIPList: nextHopIPList, PreviousNextHopIPList: previousNextHops})
+		}
+		if auditOperation == routeAuditDelete {
+			routeAudit.recordDeletion(remoteSubnet, nextHopIPList, deletionReason, err)
+			return
+		}
+		if pending {
+			routeAudit.record(auditOperation, remoteSubnet, nextHopIPList, errNeighborPending)
+			return
+		}
+		routeAudit.record(auditOperation, remoteSubnet, nextHopIPList, err)
+	}()
+	defer func() {
+		if err != nil {
+			return
+		}
+		switch auditOperation {
+		case routeAuditInject:
+			routeDebounce.written(remoteSubnet)
+		case routeAuditDelete:
+			routeDebounce.forget(remoteSubnet)
+		}
+	}()
+
+	// A later injection supersedes the change held back by the debounce window.
+	collapsed := routeDebounce.cancel(remoteSubnet)
 
 	if len(nextHopIPList) == 0 {
+		// A subnet covered by an aggregate route has no route of its own, the aggregate is split.
+		if _, ok := remoteSubnetRouteMap.Load(remoteSubnet); !ok && isAggregatedSubnet(remoteNet.String()) {
+			_, err := sliceRouterInjectAggregateRoute([]string{remoteSubnet}, nil)
+			return err
+		}
 		// Treat this as a signal to delete the route to the remoteSubnet
+		auditOperation = routeAuditDelete
+		logger.GlobalLogger.Infof("Deleting route. RemoteSubnet: %v, Reason: %v", remoteSubnet, deletionReason)
+		if err := sliceRouterResumeRoute(remoteSubnet); err != nil {
+			return newRouteError(routeErrorDataplane, remoteSubnet, err)
+		}
+		if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+			if err := sliceRouterDeleteRouteToDstInVpp(ctx, remoteSubnet); err != nil {
+				return newRouteError(routeErrorDataplane, remoteSubnet, err)
+			}
+			storePreferredSrc(remoteSubnet, nil)
+			storeRouteDescription(remoteSubnet, "")
+			storeReconcilePriority(remoteSubnet, sidecar.ReconcilePriority_RECONCILE_PRIORITY_NORMAL)
+			storeVppRouteAttrs(remoteSubnet, vppRouteAttrs{})
+			forgetAggregateRoute(remoteSubnet)
+			return nil
+		}
 		err := sliceRouterDeleteRouteToDst(remoteSubnet)
-		if err != nil {
-			return err
+		if err != nil && err != errRouteNotFound {
+			return newRouteError(routeErrorDataplane, remoteSubnet, err)
+		}
+		if err == errRouteNotFound {
+			logger.GlobalLogger.Infof("Route to delete is not installed. RemoteSubnet: %v", remoteSubnet)
 		}
 		remoteSubnetRouteMap.Delete(remoteSubnet)
+		storePreferredSrc(remoteSubnet, nil)
+		storeRouteDescription(remoteSubnet, "")
+		storeReconcilePriority(remoteSubnet, sidecar.ReconcilePriority_RECONCILE_PRIORITY_NORMAL)
+		storeVppRouteAttrs(remoteSubnet, vppRouteAttrs{})
+		forgetAggregateRoute(remoteSubnet)
 		return nil
 	}
 
 	installRoute := false
 
+	cachedNextHopList := []string{}
 	cachedNextHopValue, routePresent := remoteSubnetRouteMap.Load(remoteSubnet)
 	if !routePresent {
 		installRoute = true
 	} else {
-		cachedNextHopList := cachedNextHopValue.([]string)
+		cachedNextHopList = cachedNextHopValue.([]string)
 		// Route is present in the cache. Check if the stored nexthop matches with the nexthop received
 		// in the input param.
 		// We reinstall the route if the two lists do not match.
@@ -476,20 +865,52 @@ func sliceRouterInjectRoute(remoteSubnet string, nextHopIPList []string) error {
 				}
 			}
 		}
+		if !loadPreferredSrc(remoteSubnet).Equal(src) || loadVppRouteAttrs(remoteSubnet) != route.vppAttrs {
+			installRoute = true
+		}
 	}
 
 	if !installRoute {
+		if collapsed {
+			logger.GlobalLogger.Infof("Next hop change reverted within the debounce window, leaving the route as is. RemoteSubnet: %v, NextHops: %v",
+				remoteSubnet, nextHopIPList)
+		}
+		return nil
+	}
+	if routePresent && !route.immediate && routeDebounce.hold(route) {
+		logger.GlobalLogger.Infof("Holding back next hop change until the debounce window ends. RemoteSubnet: %v, NextHops: %v",
+			remoteSubnet, nextHopIPList)
 		return nil
 	}
+	if len(cachedNextHopList) > 0 {
+		// The subnet is moving to a different next hop, frequent changes point at flapping slice gws.
+		nextHopChangesCounter.Inc()
+		if !sameNextHops(cachedNextHopList, nextHopIPList) {
+			previousNextHops = cachedNextHopList
+		}
+	}
 
-	// Convert nexthop IPs in string to netlink nexthop info struct
-	netlinkNextHopList, err := getNetlinkNextHopInfo(nextHopIPList)
-	if err != nil {
-		return err
+	auditOperation = routeAuditInject
+
+	// The remote subnet route map records the requested next hops. Next hops that the health checker
+	// reports as down are left out of the route programmed in the dataplane.
+	programmedNextHops := healthyNextHops(nextHopIPList)
+
+	// Routes whose next hops are all down are handled by the next hop down policy until one recovers.
+	if policy := getNextHopDownPolicy(); policy != nextHopDownPolicyKeep && allNextHopsDown(nextHopIPList) {
+		if err := sliceRouterSuspendRoute(remoteSubnet, nextHopIPList, policy); err != nil {
+			return newRouteError(routeErrorDataplane, remoteSubnet, err)
+		}
+		remoteSubnetRouteMap.Store(remoteSubnet, nextHopIPList)
+		storePreferredSrc(remoteSubnet, src)
+		return nil
+	}
+	if err := sliceRouterResumeRoute(remoteSubnet); err != nil {
+		return newRouteError(routeErrorDataplane, remoteSubnet, err)
 	}
 
 	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
-		for i := 0; i < len(nextHopIPList); i++ {
+		for i := 0; i < len(programmedNextHops); i++ {
 			// If a route was previously installed for the remote subnet then we should
 			// delete it before adding a route with a new nexthop IP.
 			// VPP treats a route modify as a route add operation, creating multiple
@@ -497,40 +918,65 @@ func sliceRouterInjectRoute(remoteSubnet string, nextHopIPList []string) error {
 			// routes.
 			// In our case, we should have only one route with the nexthop as the nsm IP on
 			// the slice gw pod connecting the remote subnet.
-			nextHopList, _ := remoteSubnetRouteMap.Load(remoteSubnet)
-			nextHopListConverted := nextHopList.([]string)
-			if len(nextHopListConverted) != 0 {
-				err := vl3DeleteRouteInVpp(remoteSubnet, nextHopListConverted[i])
+			if i < len(cachedNextHopList) {
+				err := vl3DeleteRouteInVpp(ctx, remoteSubnet, cachedNextHopList[i], vppRouteDeleteReplace)
 				if err != nil {
 					logger.GlobalLogger.Errorf("Failed to delete route with old gw IP. RemoteSubent: %v, NextHop: %v",
-						remoteSubnet, nextHopListConverted[i])
+						remoteSubnet, cachedNextHopList[i])
 				}
 			}
-			err := vl3InjectRouteInVpp(remoteSubnet, nextHopIPList[i])
+			err := vl3InjectRouteInVpp(ctx, remoteSubnet, programmedNextHops[i], route.vppAttrs)
 			if err != nil {
 				logger.GlobalLogger.Errorf("Failed to inject route in vpp: %v", err)
+				// Record the next hops that did make it into vpp so that the next injection retries
+				// the rest.
+				remoteSubnetRouteMap.Store(remoteSubnet, programmedNextHops[:i])
+				return newRouteError(routeErrorDataplane, remoteSubnet, err)
 			}
 		}
-	} else {
-		err := vl3InjectRouteInKernel(remoteSubnet, netlinkNextHopList)
-		if err != nil {
-			logger.GlobalLogger.Errorf("Failed to inject route in kernel: %v", err)
-			return err
-		}
+		remoteSubnetRouteMap.Store(remoteSubnet, nextHopIPList)
+		return nil
 	}
 
-	// at the end of for loop , the global map should contain the exact routes that are installed
-	routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	// Convert nexthop IPs in string to netlink nexthop info struct
+	resolvedNextHops, err := resolvePodNextHops(remoteSubnet, programmedNextHops)
 	if err != nil {
-		return err
+		recordFailedInject(remoteSubnet, nextHopIPList, src)
+		return newRouteError(routeErrorNextHopUnresolved, remoteSubnet, err)
 	}
-	ecmpRoutes := make([]*netlink.NexthopInfo, 0)
-	for _, route := range routes {
-		if route.Dst.String() == remoteSubnet {
-			ecmpRoutes = route.MultiPath
+	netlinkNextHopList, err := resolver.resolve(resolvedNextHops)
+	if err != nil {
+		// The route is retried as soon as a new nsm link shows up.
+		recordFailedInject(remoteSubnet, nextHopIPList, src)
+		if isRetryFailedInjectsEnabled() {
+			markUnresolvedRoute(remoteSubnet)
 		}
+		return newRouteError(routeErrorNextHopUnresolved, remoteSubnet, err)
+	}
+	clearUnresolvedRoute(remoteSubnet)
+
+	err = vl3InjectTableRouteInKernel(remoteSubnet, 0, src, netlinkNextHopList)
+	if err == errNeighborPending {
+		// The reconcile loop installs the route once the neighbors are resolved.
+		logger.GlobalLogger.Infof("Route pending neighbor resolution. RemoteSubnet: %v, NextHops: %v", remoteSubnet, nextHopIPList)
+		pending = true
+		remoteSubnetRouteMap.Store(remoteSubnet, nextHopIPList)
+		storePreferredSrc(remoteSubnet, src)
+		return nil
 	}
-	remoteSubnetRouteMap.Store(remoteSubnet, contructArrayFromNextHop(ecmpRoutes))
+	if errors.Is(err, errRouteConflict) {
+		logger.GlobalLogger.Errorf("Failed to inject route in kernel: %v", err)
+		return newRouteError(routeErrorConflict, remoteSubnet, err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to inject route in kernel: %v", err)
+		recordFailedInject(remoteSubnet, nextHopIPList, src)
+		return newRouteError(routeErrorDataplane, remoteSubnet, err)
+	}
+
+	remoteSubnetRouteMap.Store(remoteSubnet, nextHopIPList)
+	storePreferredSrc(remoteSubnet, src)
+	checkAsymmetricNextHops(remoteSubnet)
 	return nil
 }
 
@@ -547,12 +993,13 @@ func containsRoute(routeList []netlink.Route, s string) bool {
 	for _, route := range routeList {
 		if len(route.MultiPath) > 0 {
 			for _, path := range route.MultiPath {
-				if path.Gw.String() == s {
+				if path.Gw != nil && path.Gw.String() == s {
 					return true
 				}
 			}
 		} else {
-			if route.Gw.String() == s {
+			// Routes without gateway are directly connected, they never match a next hop IP.
+			if route.Gw != nil && route.Gw.String() == s {
 				return true
 			}
 		}
@@ -560,8 +1007,23 @@ func containsRoute(routeList []netlink.Route, s string) bool {
 	return false
 }
 
+// sliceRouterGetClientConnections lists the client connections of the slice router along with how long
+// they have existed.
 func sliceRouterGetClientConnections() ([]*sidecar.ConnectionInfo, error) {
+	connList, err := sliceRouterListClientConnections()
+	if err != nil || connList == nil {
+		return connList, err
+	}
+	return connAge.observe(connList), nil
+}
+
+// sliceRouterListClientConnections lists the client connections from the connection cache or the
+// dataplane.
+func sliceRouterListClientConnections() ([]*sidecar.ConnectionInfo, error) {
 	if getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel {
+		if connList, ok := connCache.list(); ok {
+			return connList, nil
+		}
 		return vl3GetNsmInterfacesInKernel()
 	} else if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
 		return vl3GetNsmInterfacesInVpp()
@@ -570,41 +1032,258 @@ func sliceRouterGetClientConnections() ([]*sidecar.ConnectionInfo, error) {
 	return nil, nil
 }
 
+// sliceRouterRescanClientConnections derives the client connections from the dataplane without the
+// connection cache, for when the cache is suspected to be out of sync. The cache is refreshed from the
+// kernel along so that the next connection lists are current too.
+func sliceRouterRescanClientConnections() ([]*sidecar.ConnectionInfo, error) {
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel {
+		connList, err := vl3GetNsmInterfacesInKernel()
+		if err != nil {
+			return nil, err
+		}
+		if err := connCache.resync(); err != nil {
+			logger.GlobalLogger.Errorf("Failed to refresh the connection cache: %v", err)
+		}
+		return connAge.observe(connList), nil
+	} else if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		connList, err := vl3GetNsmInterfacesInVpp()
+		if err != nil {
+			return nil, err
+		}
+		return connAge.observe(connList), nil
+	}
+
+	return nil, nil
+}
+
 func getSliceRouterDataplaneMode() string {
 	return os.Getenv("DATAPLANE")
 }
 
-func BootstrapSliceRouterPod() error {
+// getNsmInterfacePrefixes returns the list of interface name prefixes used to identify
+// the NSM interfaces on the slice router. The list is read from the comma-separated
+// NSM_INTERFACE_PREFIXES env variable and defaults to "vl3-".
+func getNsmInterfacePrefixes() []string {
+	prefixes := getEnvList("NSM_INTERFACE_PREFIXES")
+	if len(prefixes) == 0 {
+		return []string{defaultNsmInterfacePrefix}
+	}
+	return prefixes
+}
+
+// getVppNsmInterfacePrefixes returns the list of name prefixes used to identify the NSM interfaces
+// in vpp. The list is read from the comma-separated VPP_NSM_INTERFACE_PREFIXES env variable. If it
+// is not set, every vpp interface with an IP address is treated as an NSM interface.
+func getVppNsmInterfacePrefixes() []string {
+	return getEnvList("VPP_NSM_INTERFACE_PREFIXES")
+}
+
+// isVppNsmInterface checks if the vpp interface name matches any of the configured VPP NSM
+// interface prefixes.
+func isVppNsmInterface(intfName string) bool {
+	prefixes := getVppNsmInterfacePrefixes()
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(intfName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// getEnvList returns the entries of a comma-separated env variable, skipping empty entries.
+func getEnvList(key string) []string {
+	list := []string{}
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// isNsmInterface checks if the interface name matches any of the configured NSM interface prefixes.
+func isNsmInterface(intfName string) bool {
+	for _, prefix := range getNsmInterfacePrefixes() {
+		if strings.HasPrefix(intfName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// BootstrapResult summarizes the config applied by BootstrapSliceRouterPod.
+type BootstrapResult struct {
+	// Dataplane mode of the slice router.
+	DataplaneMode string
+	// Slice the metrics are labeled with, empty if SLICE_NAME is not set.
+	SliceName string
+	// IP forwarding was enabled in the kernel, for IPv6 too if IPv6ForwardingEnabled is set. If
+	// ForwardingExternallyManaged is set, the sidecar left forwarding alone and only found it enabled.
+	ForwardingEnabled           bool
+	IPv6ForwardingEnabled       bool
+	ForwardingExternallyManaged bool
+	// The multipath hash policy considers L4. It is not set on platforms that lack the option.
+	MultipathHashPolicyL4 bool
+	// The ip rule steering traffic into the slice route table is present.
+	SliceRouteRuleEnsured bool
+	// The connection cache is kept from netlink events.
+	ConnectionCacheStarted bool
+	// Policy applied to the routes whose egress links go down, empty if the links are not monitored.
+	LinkDownRoutePolicy string
+	// The routing table is reconciled every ReconcileInterval.
+	ReconcileLoopStarted bool
+	ReconcileInterval    time.Duration
+	// Next hop health check probe, empty if disabled, and its interval.
+	HealthCheckMode     string
+	HealthCheckInterval time.Duration
+	// Vpp-agent endpoints in order of preference, in vpp mode.
+	VppAgentEndpoints []string
+	// Sinks the route events are exported to.
+	RouteEventSinks []string
+	// Unix socket the route state is served on, empty if not served.
+	RouteStateSocket string
+	// Dataplane mode of the previous run of the sidecar, empty if unknown, and the number of its slice
+	// routes flushed because the mode changed.
+	PreviousDataplaneMode  string
+	FlushedDataplaneRoutes int
+	// Prefix routed to the default next hops, empty if none are set.
+	DefaultNextHopPrefix string
+	// OTLP endpoint the traces are exported to, empty if tracing is off.
+	TracingEndpoint string
+	// Custom resource whose status the route state is exported to, empty if not exported.
+	RouteStatusResource string
+}
+
+// BootstrapSliceRouterPod waits for the dataplane to be ready, then configures it and starts the
+// background loops of the sidecar. The returned result describes the config applied, up to the failed
+// step if an error is returned.
+func BootstrapSliceRouterPod() (*BootstrapResult, error) {
+	// The routes of the previous dataplane and the default next hop route are handled through the
+	// dataplane, which must be ready first. The sidecar is configured anyway past the timeout, the
+	// reconcile applies the routes that fail until then.
+	if err := WaitForDataplaneReady(); err != nil {
+		logger.GlobalLogger.Errorf("Dataplane not ready, bootstrapping anyway: %v", err)
+	}
+	result := &BootstrapResult{DataplaneMode: getSliceRouterDataplaneMode(), SliceName: setSliceMetricsLabel()}
+	// Start the sinks first so that the connections found by the connection cache are exported.
+	result.RouteEventSinks = startRouteExporters()
+	result.TracingEndpoint = startTracing()
+	// The routes of the previous dataplane are handled before the new one is programmed.
+	previous, flushed, err := sliceRouterHandleDataplaneChange()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to handle the dataplane mode change: %v", err)
+	}
+	result.PreviousDataplaneMode, result.FlushedDataplaneRoutes = previous, flushed
 	if getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel {
 		// Turn on the forwarding in the kernel. It is an absolute must since the router
 		// needs to forward traffic to app and gw pods.
-		err := sysctl.Set("net.ipv4.ip_forward", "1")
-		if err != nil {
-			logger.GlobalLogger.Fatalf("Failed to enable IP forwarding in the kernel", err)
-			return err
+		if isIPForwardManaged() {
+			err := sliceRouterEnableForwarding()
+			if err != nil {
+				logger.GlobalLogger.Fatalf("Failed to enable IP forwarding in the kernel", err)
+				return result, err
+			}
+			result.ForwardingEnabled = true
+			result.IPv6ForwardingEnabled = isIPv6Enabled()
+		} else {
+			// Forwarding is set up outside the sidecar, which may not be allowed to change it. The
+			// router is left to start so that forwarding enabled later takes effect.
+			result.ForwardingExternallyManaged = true
+			if err := sliceRouterCheckForwarding(); err != nil {
+				logger.GlobalLogger.Warnf("IP forwarding is managed externally but does not look enabled: %v", err)
+			} else {
+				result.ForwardingEnabled = true
+				result.IPv6ForwardingEnabled = isIPv6Enabled()
+			}
 		}
 		// Set the ecmp hash policy to consider L3 and L4 (IP + Port) if possible. This will help with
 		// improving the load balancing between the multi paths.
 		// This configuration might not be available on some operating systems. First check if the config
 		// option is available before attempting to update it.
-		val, err := sysctl.Get("net.ipv4.fib_multipath_hash_policy")
+		val, err := sysctlHandle.Get("net.ipv4.fib_multipath_hash_policy")
 		if err == nil {
 			// Config option is available. Set the config if it does not have the needed value.
 			if val != "1" {
-				err = sysctl.Set("net.ipv4.fib_multipath_hash_policy", "1")
+				err = sysctlHandle.Set("net.ipv4.fib_multipath_hash_policy", "1")
 				if err != nil {
 					logger.GlobalLogger.Fatalf("failed to set hash policy to L4 for mutipath routes", err)
-					return err
+					return result, err
 				}
 			} else {
 				logger.GlobalLogger.Debugf("Hash policy already set")
 			}
+			result.MultipathHashPolicyL4 = true
 		} else {
 			// If the config option is not available, log an error message and let the platform use the default method
 			// to load balance.
 			logger.GlobalLogger.Errorf("Hash policy cannot be set on this platform..", err)
 		}
+		// Create the ip rule that steers traffic into the custom slice route table so that the
+		// table is usable from the first route injection.
+		if isSliceRouteRuleEnabled() {
+			err := sliceRouterEnsureRouteRule()
+			if err != nil {
+				return result, err
+			}
+			result.SliceRouteRuleEnsured = true
+		}
+		// Keep the client connections cached from netlink events.
+		startBackgroundTask("connection-cache", func(ctx context.Context) {
+			connectionCacheMonitor(ctx.Done())
+		})
+		result.ConnectionCacheStarted = true
+		if policy := getLinkDownRoutePolicy(); policy != linkDownRoutePolicyNone {
+			startBackgroundTask("link-down-monitor", linkDownMonitorLoop)
+			result.LinkDownRoutePolicy = policy
+		}
+	} else {
+		result.VppAgentEndpoints = getVppAgentEndpoints()
+		if getVppAgentBreakerThreshold() > 0 {
+			startBackgroundTask("vpp-agent-breaker-reconcile", vppAgentBreakerReconcileLoop)
+		}
+	}
+	// The routes are reconciled periodically in timer mode, whatever the dataplane. In kernel mode, routes
+	// without ONLINK wait for neighbor resolution, the loop installs the routes whose neighbors were not
+	// resolved at injection time.
+	if reconcileOnTimer() || (getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel && !isRouteOnlinkEnabled()) {
+		startBackgroundTask("reconcile-loop", routingTableReconcileLoop)
+		result.ReconcileLoopStarted = true
+		result.ReconcileInterval = getReconcileInterval()
 	}
+	// A misconfigured health check would report every next hop down, the checker is not started.
+	if err := checkNextHopHealthCheckConfig(); err != nil {
+		logger.GlobalLogger.Errorf("Not starting the next hop health checker: %v", err)
+	} else if getNextHopHealthCheckMode() != nextHopHealthCheckNone {
+		startBackgroundTask("health-check", nextHopHealthCheckLoop)
+		result.HealthCheckMode = getNextHopHealthCheckMode()
+		result.HealthCheckInterval = getNextHopHealthCheckInterval()
+	}
+	if path := getRouteStateSocket(); path != "" {
+		// The route state is a debugging aid, the sidecar runs without it.
+		if _, err := startRouteStateServer(path); err != nil {
+			logger.GlobalLogger.Errorf("Failed to serve the route state on unix socket %v: %v", path, err)
+		} else {
+			result.RouteStateSocket = path
+		}
+	}
+	// The route status is a visibility aid too.
+	if resource, err := startRouteStatusExport(); err != nil {
+		logger.GlobalLogger.Errorf("Failed to export the route state to the custom resource status: %v", err)
+	} else {
+		result.RouteStatusResource = resource
+	}
+	// Destinations without a slice route are sent to the default next hops, if any.
+	prefix, err := sliceRouterInjectDefaultNextHopRoute()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to inject the default next hop route: %v", err)
+	}
+	result.DefaultNextHopPrefix = prefix
+	reconcileMu.Lock()
 	lastRoutingTableReconcileTime = time.Now()
-	return nil
+	reconcileMu.Unlock()
+	return result, nil
 }
@@ -0,0 +1,480 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/kubeslice/router-sidecar/pkg/metrics"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsNsmInterface(t *testing.T) {
+	links := []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "eth0"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "vl3-abcd"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "nsm-1234"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "custom0"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "vl3"}},
+	}
+
+	tests := []struct {
+		testName string
+		prefixes string
+		expected []string
+	}{
+		{
+			"default prefix matches only vl3- links",
+			"",
+			[]string{"vl3-abcd"},
+		},
+		{
+			"single custom prefix",
+			"nsm-",
+			[]string{"nsm-1234"},
+		},
+		{
+			"multiple prefixes with spaces and empty entries",
+			"vl3-, nsm-,,custom",
+			[]string{"vl3-abcd", "nsm-1234", "custom0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("NSM_INTERFACE_PREFIXES", tt.prefixes)
+			matched := []string{}
+			for _, link := range links {
+				if isNsmInterface(link.Attrs().Name) {
+					matched = append(matched, link.Attrs().Name)
+				}
+			}
+			if !reflect.DeepEqual(matched, tt.expected) {
+				t.Error("matched links: expected", tt.expected, "received", matched)
+			}
+		})
+	}
+}
+
+func TestVl3GetNsmInterfacesInVpp(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	fake := newFakeVppAgent()
+	fake.config.Interfaces = []*vpp.Interface{
+		{Name: "memif-nsm-app1", IpAddresses: []string{"10.1.1.2/30"}},
+		{Name: "memif-nsm-app2", IpAddresses: []string{"10.1.1.6/30"}},
+		{Name: "loop0", IpAddresses: []string{"172.16.0.1/30"}},
+		{Name: "memif-nsm-noip"},
+	}
+	useFakeVppAgent(t, fake)
+
+	tests := []struct {
+		testName string
+		prefixes string
+		expected []string
+	}{
+		{
+			"no prefix configured reports every interface with an IP",
+			"",
+			[]string{"memif-nsm-app1", "memif-nsm-app2", "loop0"},
+		},
+		{
+			"prefix filters out non-nsm interfaces",
+			"memif-nsm-",
+			[]string{"memif-nsm-app1", "memif-nsm-app2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_NSM_INTERFACE_PREFIXES", tt.prefixes)
+			connList, err := vl3GetNsmInterfacesInVpp()
+			if err != nil {
+				t.Fatal(err)
+			}
+			podNames := []string{}
+			for _, conn := range connList {
+				podNames = append(podNames, conn.PodName)
+			}
+			if !reflect.DeepEqual(podNames, tt.expected) {
+				t.Error("connections: expected", tt.expected, "received", podNames)
+			}
+		})
+	}
+}
+
+func TestVl3GetNsmInterfacesInVppSkipsInvalidAddress(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("VPP_NSM_INTERFACE_PREFIXES", "")
+
+	fake := newFakeVppAgent()
+	fake.config.Interfaces = []*vpp.Interface{
+		{Name: "memif-nsm-app1", IpAddresses: []string{"10.1.2.0/30"}},
+		{Name: "memif-nsm-app2", IpAddresses: []string{"10.1.1.6/30"}},
+		{Name: "memif-nsm-app3", IpAddresses: []string{"10.1.1/30"}},
+	}
+	useFakeVppAgent(t, fake)
+
+	connList, err := vl3GetNsmInterfacesInVpp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connList) != 1 {
+		t.Fatalf("connections: expected only memif-nsm-app2, received %v", connList)
+	}
+	if connList[0].PodName != "memif-nsm-app2" || connList[0].NsmIP != "10.1.1.5" {
+		t.Error("connection: expected memif-nsm-app2 with IP 10.1.1.5, received", connList[0])
+	}
+}
+
+func TestSliceRouterInjectRoute(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName      string
+		dataplane     string
+		cached        []string
+		installed     []netlink.Route
+		remoteSubnet  string
+		nextHops      []string
+		replaceErr    error
+		vppUpdateErr  error
+		expectReason  routeErrorReason
+		expectCached  []string
+		expectPresent bool
+	}{
+		{
+			testName:      "new route installed in kernel",
+			dataplane:     SliceRouterDataplaneKernel,
+			remoteSubnet:  "10.1.0.0/16",
+			nextHops:      []string{"192.168.0.2"},
+			expectCached:  []string{"192.168.0.2"},
+			expectPresent: true,
+		},
+		{
+			testName:      "route already consistent",
+			dataplane:     SliceRouterDataplaneKernel,
+			cached:        []string{"192.168.0.2"},
+			installed:     []netlink.Route{{Dst: mustParseCIDR("10.1.0.0/16"), Gw: net.ParseIP("192.168.0.2")}},
+			remoteSubnet:  "10.1.0.0/16",
+			nextHops:      []string{"192.168.0.2"},
+			expectCached:  []string{"192.168.0.2"},
+			expectPresent: true,
+		},
+		{
+			testName:     "invalid remote subnet",
+			dataplane:    SliceRouterDataplaneKernel,
+			remoteSubnet: "10.1.0.0",
+			nextHops:     []string{"192.168.0.2"},
+			expectReason: routeErrorInvalidArgument,
+		},
+		{
+			testName:     "invalid next hop",
+			dataplane:    SliceRouterDataplaneKernel,
+			remoteSubnet: "10.1.0.0/16",
+			nextHops:     []string{"192.168.0"},
+			expectReason: routeErrorInvalidArgument,
+		},
+		{
+			testName:     "next hop not resolved",
+			dataplane:    SliceRouterDataplaneKernel,
+			remoteSubnet: "10.1.0.0/16",
+			nextHops:     []string{"192.168.9.9"},
+			expectReason: routeErrorNextHopUnresolved,
+		},
+		{
+			testName:     "kernel write fails",
+			dataplane:    SliceRouterDataplaneKernel,
+			remoteSubnet: "10.1.0.0/16",
+			nextHops:     []string{"192.168.0.2"},
+			replaceErr:   errors.New("file exists"),
+			expectReason: routeErrorDataplane,
+		},
+		{
+			testName:     "delete of a route that is not installed",
+			dataplane:    SliceRouterDataplaneKernel,
+			cached:       []string{"192.168.0.2"},
+			remoteSubnet: "10.1.0.0/16",
+		},
+		{
+			testName:      "new route installed in vpp",
+			dataplane:     SliceRouterDataplaneVpp,
+			remoteSubnet:  "10.1.0.0/16",
+			nextHops:      []string{"192.168.0.2"},
+			expectCached:  []string{"192.168.0.2"},
+			expectPresent: true,
+		},
+		{
+			testName:     "vpp update fails",
+			dataplane:    SliceRouterDataplaneVpp,
+			remoteSubnet: "10.1.0.0/16",
+			nextHops:     []string{"192.168.0.2"},
+			vppUpdateErr: errors.New("vpp-agent unavailable"),
+			expectReason: routeErrorDataplane,
+			expectCached: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			resetRouteMap(t)
+			fakeNl := newFakeNetlink()
+			fakeNl.addConnectedRoute("192.168.0.2", 1)
+			fakeNl.routes = append(fakeNl.routes, tt.installed...)
+			fakeNl.routeReplaceErr = tt.replaceErr
+			useFakeNetlink(t, fakeNl)
+			fakeVpp := newFakeVppAgent()
+			fakeVpp.updateErr = tt.vppUpdateErr
+			useFakeVppAgent(t, fakeVpp)
+			if tt.cached != nil {
+				remoteSubnetRouteMap.Store(tt.remoteSubnet, tt.cached)
+			}
+
+			err := sliceRouterInjectRoute(tt.remoteSubnet, tt.nextHops)
+			if tt.expectReason == "" && err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if tt.expectReason != "" {
+				var rErr *routeError
+				if !errors.As(err, &rErr) || rErr.reason != tt.expectReason {
+					t.Fatal("error: expected", tt.expectReason, "received", err)
+				}
+			}
+
+			cached, ok := remoteSubnetRouteMap.Load(tt.remoteSubnet)
+			if tt.expectCached == nil && ok {
+				t.Error("route map: expected no entry, received", cached)
+			}
+			if tt.expectCached != nil && (!ok || !sameNextHops(cached.([]string), tt.expectCached)) {
+				t.Error("route map: expected", tt.expectCached, "received", cached)
+			}
+
+			var installed []string
+			if tt.dataplane == SliceRouterDataplaneVpp {
+				installed, _ = vl3GetInstalledNextHopsInVpp(tt.remoteSubnet)
+			} else {
+				installed, _ = vl3GetInstalledNextHopsInKernel(tt.remoteSubnet)
+			}
+			if (len(installed) > 0) != tt.expectPresent {
+				t.Error("route present: expected", tt.expectPresent, "received", installed)
+			}
+		})
+	}
+}
+
+func TestRouteErrorToStatus(t *testing.T) {
+	tests := []struct {
+		err     error
+		errCode codes.Code
+	}{
+		{newRouteError(routeErrorInvalidArgument, "10.1.0.0/16", errors.New("bad")), codes.InvalidArgument},
+		{newRouteError(routeErrorNextHopUnresolved, "10.1.0.0/16", errors.New("bad")), codes.FailedPrecondition},
+		{newRouteError(routeErrorDataplane, "10.1.0.0/16", errors.New("bad")), codes.Unavailable},
+		{newRouteError(routeErrorConflict, "10.1.0.0/16", errors.New("bad")), codes.AlreadyExists},
+		{errors.New("bad"), codes.Internal},
+	}
+	for _, tt := range tests {
+		if code := status.Code(routeErrorToStatus(tt.err)); code != tt.errCode {
+			t.Error("error code: expected", tt.errCode, "received", code)
+		}
+	}
+}
+
+func TestNextHopChangesCounter(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+
+	steps := []struct {
+		testName string
+		nextHops []string
+		changes  float64
+	}{
+		{"new subnet", []string{"192.168.0.2"}, 0},
+		{"same next hop", []string{"192.168.0.2"}, 0},
+		{"different next hop", []string{"192.168.0.6"}, 1},
+		{"next hop added", []string{"192.168.0.6", "192.168.0.2"}, 1},
+		{"route deleted", []string{}, 0},
+		{"subnet added again", []string{"192.168.0.2"}, 0},
+	}
+
+	for _, step := range steps {
+		t.Run(step.testName, func(t *testing.T) {
+			before := nextHopChangesCounter.Value()
+			if err := sliceRouterInjectRoute("10.1.0.0/16", step.nextHops); err != nil {
+				t.Fatal(err)
+			}
+			if changes := nextHopChangesCounter.Value() - before; changes != step.changes {
+				t.Error("next hop changes: expected", step.changes, "received", changes)
+			}
+		})
+	}
+}
+
+func TestRouteCountByNextHopGauge(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+
+	steps := []struct {
+		testName     string
+		remoteSubnet string
+		nextHops     []string
+		// expected route count of 192.168.0.2 and 192.168.0.6
+		expected [2]float64
+	}{
+		{"first route", "10.1.0.0/16", []string{"192.168.0.2"}, [2]float64{1, 0}},
+		{"second route on the same next hop", "10.2.0.0/16", []string{"192.168.0.2"}, [2]float64{2, 0}},
+		{"ecmp route", "10.3.0.0/16", []string{"192.168.0.2", "192.168.0.6"}, [2]float64{3, 1}},
+		{"failover to the other next hop", "10.1.0.0/16", []string{"192.168.0.6"}, [2]float64{2, 2}},
+		{"route deleted", "10.2.0.0/16", []string{}, [2]float64{1, 2}},
+		{"last route of a next hop deleted", "10.3.0.0/16", []string{}, [2]float64{0, 1}},
+	}
+
+	for _, step := range steps {
+		t.Run(step.testName, func(t *testing.T) {
+			if err := sliceRouterInjectRoute(step.remoteSubnet, step.nextHops); err != nil {
+				t.Fatal(err)
+			}
+			for i, nextHopIP := range []string{"192.168.0.2", "192.168.0.6"} {
+				if count := routeCountByNextHopGauge.Value(nextHopIP); count != step.expected[i] {
+					t.Error("routes via", nextHopIP, "expected", step.expected[i], "received", count)
+				}
+			}
+		})
+	}
+
+	routeCountMu.Lock()
+	defer routeCountMu.Unlock()
+	if routeCountNextHops["192.168.0.2"] {
+		t.Error("next hop without routes still reported")
+	}
+}
+
+func TestBootstrapResult(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("NEXTHOP_HEALTH_CHECK", "")
+
+	tests := []struct {
+		testName      string
+		endpoints     string
+		reconcileMode string
+		expected      *BootstrapResult
+	}{
+		{
+			"default vpp agent endpoint",
+			"",
+			"",
+			&BootstrapResult{DataplaneMode: SliceRouterDataplaneVpp, VppAgentEndpoints: []string{defaultVppAgentEndpoint}},
+		},
+		{
+			"configured vpp agent endpoints",
+			"primary:9113,secondary:9113",
+			"",
+			&BootstrapResult{DataplaneMode: SliceRouterDataplaneVpp, VppAgentEndpoints: []string{"primary:9113", "secondary:9113"}},
+		},
+		{
+			"reconcile loop in timer mode",
+			"",
+			reconcileModeTimer,
+			&BootstrapResult{DataplaneMode: SliceRouterDataplaneVpp, VppAgentEndpoints: []string{defaultVppAgentEndpoint},
+				ReconcileLoopStarted: true, ReconcileInterval: getReconcileInterval()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_AGENT_ENDPOINTS", tt.endpoints)
+			t.Setenv("RECONCILE_MODE", tt.reconcileMode)
+			useFakeVppAgent(t, newFakeVppAgent())
+			t.Cleanup(func() {
+				stopBackgroundTasks(time.Second)
+			})
+			result, err := BootstrapSliceRouterPod()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %+v, received %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBootstrapSliceMetricsLabel(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("NEXTHOP_HEALTH_CHECK", "")
+	t.Setenv("SLICE_NAME", "red")
+	useFakeVppAgent(t, newFakeVppAgent())
+	t.Cleanup(func() {
+		metrics.DefaultRegistry.SetConstLabels(nil)
+	})
+
+	result, err := BootstrapSliceRouterPod()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SliceName != "red" {
+		t.Error("slice name: expected red, received", result.SliceName)
+	}
+
+	// Labeled and unlabeled metrics both carry the slice.
+	nextHopChangesCounter.Inc()
+	nextHopHealthGauge.Set(1, "10.1.1.1")
+	defer nextHopHealthGauge.Delete("10.1.1.1")
+	var buf bytes.Buffer
+	if err := metrics.DefaultRegistry.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	samples := 0
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		samples++
+		if !strings.Contains(line, `slice="red"`) {
+			t.Error("sample without the slice label:", line)
+		}
+	}
+	if samples == 0 {
+		t.Error("no metric samples written")
+	}
+}
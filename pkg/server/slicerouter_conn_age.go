@@ -0,0 +1,101 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// connectionAgeTracker remembers when each client connection was first listed, so that the age of the
+// connections can be reported and flapping nsm links spotted. A connection is keyed by its pod and nsm
+// interface, and forgotten as soon as a listing does not have it anymore.
+type connectionAgeTracker struct {
+	mu        sync.Mutex
+	firstSeen map[string]time.Time
+	// now is a variable so that tests can control the clock.
+	now func() time.Time
+}
+
+var connAge = newConnectionAgeTracker()
+
+func newConnectionAgeTracker() *connectionAgeTracker {
+	return &connectionAgeTracker{firstSeen: map[string]time.Time{}, now: time.Now}
+}
+
+// connectionKey identifies a connection across listings.
+func connectionKey(conn *sidecar.ConnectionInfo) string {
+	return conn.GetPodName() + "/" + conn.GetNsmInterface()
+}
+
+// withAge returns a copy of the connection carrying when it was first seen and its age. The copy
+// leaves the connections shared with the connection cache untouched.
+func withAge(conn *sidecar.ConnectionInfo, firstSeen, now time.Time) *sidecar.ConnectionInfo {
+	aged := proto.Clone(conn).(*sidecar.ConnectionInfo)
+	aged.FirstSeen = timestamppb.New(firstSeen)
+	aged.Age = durationpb.New(now.Sub(firstSeen))
+	return aged
+}
+
+// observe records the connections of a full listing, forgetting the connections that are not listed
+// anymore, and returns them along with their age.
+func (t *connectionAgeTracker) observe(connList []*sidecar.ConnectionInfo) []*sidecar.ConnectionInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	listed := map[string]time.Time{}
+	aged := make([]*sidecar.ConnectionInfo, 0, len(connList))
+	for _, conn := range connList {
+		key := connectionKey(conn)
+		firstSeen, ok := t.firstSeen[key]
+		if !ok {
+			firstSeen = now
+		}
+		listed[key] = firstSeen
+		aged = append(aged, withAge(conn, firstSeen, now))
+	}
+	t.firstSeen = listed
+	return aged
+}
+
+// observeOne records a connection looked up on its own and returns it along with its age. Other
+// connections are left alone since the lookup does not tell whether they still exist.
+func (t *connectionAgeTracker) observeOne(conn *sidecar.ConnectionInfo) *sidecar.ConnectionInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	key := connectionKey(conn)
+	firstSeen, ok := t.firstSeen[key]
+	if !ok {
+		firstSeen = now
+		t.firstSeen[key] = firstSeen
+	}
+	return withAge(conn, firstSeen, now)
+}
+
+// reset forgets all the connections.
+func (t *connectionAgeTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.firstSeen = map[string]time.Time{}
+}
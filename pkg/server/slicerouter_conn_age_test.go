@@ -0,0 +1,150 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// useConnectionAge makes the sidecar track the connection ages with the clock for the duration of the
+// test.
+func useConnectionAge(t *testing.T, now *time.Time) {
+	t.Helper()
+	orig := connAge
+	connAge = newConnectionAgeTracker()
+	connAge.now = func() time.Time { return *now }
+	t.Cleanup(func() {
+		connAge = orig
+	})
+}
+
+func TestConnectionAgeTracker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newConnectionAgeTracker()
+	tracker.now = func() time.Time { return now }
+	client := &pb.ConnectionInfo{PodName: "iperf-client", NsmInterface: "vl3-1"}
+	server := &pb.ConnectionInfo{PodName: "iperf-server", NsmInterface: "vl3-2"}
+
+	ages := func(connList []*pb.ConnectionInfo) map[string]time.Duration {
+		ages := map[string]time.Duration{}
+		for _, conn := range connList {
+			ages[conn.GetPodName()] = conn.GetAge().AsDuration()
+		}
+		return ages
+	}
+	steps := []struct {
+		step     string
+		advance  time.Duration
+		listed   []*pb.ConnectionInfo
+		expected map[string]time.Duration
+	}{
+		{"first listing", 0, []*pb.ConnectionInfo{client}, map[string]time.Duration{"iperf-client": 0}},
+		{"new connection", 30 * time.Second, []*pb.ConnectionInfo{client, server},
+			map[string]time.Duration{"iperf-client": 30 * time.Second, "iperf-server": 0}},
+		{"connection gone", 10 * time.Second, []*pb.ConnectionInfo{server}, map[string]time.Duration{"iperf-server": 10 * time.Second}},
+		{"connection back", 5 * time.Second, []*pb.ConnectionInfo{client, server},
+			map[string]time.Duration{"iperf-client": 0, "iperf-server": 15 * time.Second}},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		connList := tracker.observe(step.listed)
+		if got := ages(connList); len(got) != len(step.expected) {
+			t.Fatal(step.step, ": expected", step.expected, "received", got)
+		}
+		for pod, age := range ages(connList) {
+			if age != step.expected[pod] {
+				t.Error(step.step, ": age of", pod, "expected", step.expected[pod], "received", age)
+			}
+		}
+		for _, conn := range connList {
+			if !conn.GetFirstSeen().AsTime().Equal(now.Add(-conn.GetAge().AsDuration())) {
+				t.Error(step.step, ": first seen of", conn.GetPodName(), "does not match its age", conn)
+			}
+		}
+	}
+	// The listed connections are left untouched.
+	if client.GetFirstSeen() != nil || client.GetAge() != nil {
+		t.Error("expected the listed connection to be copied, received", client)
+	}
+
+	// A single lookup does not forget the other connections.
+	now = now.Add(time.Minute)
+	if age := tracker.observeOne(server).GetAge().AsDuration(); age != 75*time.Second {
+		t.Error("lookup: expected 1m15s, received", age)
+	}
+	if age := ages(tracker.observe([]*pb.ConnectionInfo{client, server}))["iperf-client"]; age != time.Minute {
+		t.Error("after the lookup: expected 1m0s, received", age)
+	}
+}
+
+func TestClientConnectionAge(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	connCache.invalidate()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	useConnectionAge(t, &now)
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}}}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	useFakeNetlink(t, fake)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	if _, err := client.GetSliceRouterClientConnectionInfo(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Minute)
+	response, err := client.GetSliceRouterClientConnectionInfo(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.GetConnection()) != 1 {
+		t.Fatal("expected 1 connection, received", response.GetConnection())
+	}
+	listed := response.GetConnection()[0]
+	if listed.GetAge().AsDuration() != time.Minute || !listed.GetFirstSeen().AsTime().Equal(now.Add(-time.Minute)) {
+		t.Error("listed connection: expected a minute old, received", listed)
+	}
+
+	now = now.Add(time.Minute)
+	lookedUp, err := client.GetClientConnection(ctx, &pb.ClientConnectionRequest{PodName: "iperf-client"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lookedUp.GetAge().AsDuration() != 2*time.Minute {
+		t.Error("looked up connection: expected 2m0s old, received", lookedUp.GetAge().AsDuration())
+	}
+}
@@ -0,0 +1,290 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// connectionCacheRetryInterval is the delay before subscribing again to netlink events after the
+// subscription failed or was closed.
+const connectionCacheRetryInterval = 5 * time.Second
+
+// Netlink event subscriptions used by the connection cache. They are variables so that tests can feed
+// synthetic events.
+var (
+	linkSubscribe  = netlink.LinkSubscribe
+	addrSubscribe  = netlink.AddrSubscribe
+	routeSubscribe = netlink.RouteSubscribe
+	neighSubscribe = netlink.NeighSubscribe
+)
+
+// connectionCache holds the client connections of the nsm links in the kernel, keyed by link index.
+// It is kept up to date from netlink link, address and route events so that the connection list can
+// be served without scanning the kernel.
+type connectionCache struct {
+	mu sync.Mutex
+	// ready is false until the cache is filled, and while the netlink subscription is down.
+	ready bool
+	conns map[int]*sidecar.ConnectionInfo
+}
+
+var connCache = &connectionCache{conns: map[int]*sidecar.ConnectionInfo{}}
+
+// refreshLink updates the connection of the link from its current addresses and routes.
+func (c *connectionCache) refreshLink(link netlink.Link) {
+	index := link.Attrs().Index
+	if !isNsmInterface(link.Attrs().Name) {
+		c.delete(index)
+		return
+	}
+	conn, err := vl3GetLinkConnectionInfo(link)
+	if err != nil {
+		// The link is not fully set up yet, the address or route event that completes it refreshes
+		// the link again.
+		c.delete(index)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.conns[index]; !ok {
+		publishConnectionEvent(routeEventConnectionAdded, conn)
+		reconcileOnNsmLinkAdded(link.Attrs().Name)
+	}
+	c.conns[index] = conn
+}
+
+// refreshLinkIndex updates the connection of the link with the given index.
+func (c *connectionCache) refreshLinkIndex(index int) {
+	link, err := nlHandle.LinkByIndex(index)
+	if err != nil {
+		// The link is gone, its delete event may not have been processed yet.
+		c.delete(index)
+		return
+	}
+	c.refreshLink(link)
+}
+
+func (c *connectionCache) delete(index int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn, ok := c.conns[index]; ok {
+		publishConnectionEvent(routeEventConnectionRemoved, conn)
+	}
+	delete(c.conns, index)
+}
+
+func (c *connectionCache) handleLinkUpdate(update netlink.LinkUpdate) {
+	if update.Header.Type == unix.RTM_DELLINK {
+		c.delete(update.Attrs().Index)
+		return
+	}
+	c.refreshLink(update.Link)
+}
+
+func (c *connectionCache) handleAddrUpdate(update netlink.AddrUpdate) {
+	c.refreshLinkIndex(update.LinkIndex)
+}
+
+func (c *connectionCache) handleRouteUpdate(update netlink.RouteUpdate) {
+	// Only the connected route to the client is part of the connection information.
+	if update.Dst == nil || update.LinkIndex == 0 {
+		return
+	}
+	if ones, bits := update.Dst.Mask.Size(); ones != bits {
+		return
+	}
+	c.refreshLinkIndex(update.LinkIndex)
+}
+
+func (c *connectionCache) handleNeighUpdate(update netlink.NeighUpdate) {
+	// Only the IPv6 neighbors are reported as the reachability of the client.
+	if update.IP.To4() != nil || !update.IP.IsGlobalUnicast() {
+		return
+	}
+	c.refreshLinkIndex(update.LinkIndex)
+}
+
+// fill loads the connections of all nsm links and marks the cache ready. The connections are exported
+// as added again, the events missed while the cache was not ready are unknown.
+func (c *connectionCache) fill() error {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.conns = map[int]*sidecar.ConnectionInfo{}
+	c.mu.Unlock()
+	for _, link := range links {
+		c.refreshLink(link)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ready = true
+	return nil
+}
+
+// resync refreshes the connections of all nsm links from the kernel and drops the links that are gone,
+// in case a change was missed. Unlike fill, only the connections that changed are exported. A cache that
+// is not ready is left alone, the connections are read from the kernel until it is.
+func (c *connectionCache) resync() error {
+	c.mu.Lock()
+	ready := c.ready
+	c.mu.Unlock()
+	if !ready {
+		return nil
+	}
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		return err
+	}
+	present := map[int]bool{}
+	for _, link := range links {
+		present[link.Attrs().Index] = true
+		c.refreshLink(link)
+	}
+	c.mu.Lock()
+	gone := []int{}
+	for index := range c.conns {
+		if !present[index] {
+			gone = append(gone, index)
+		}
+	}
+	c.mu.Unlock()
+	for _, index := range gone {
+		c.delete(index)
+	}
+	return nil
+}
+
+// invalidate marks the cache as not usable, connections are then read from the kernel.
+func (c *connectionCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ready = false
+	c.conns = map[int]*sidecar.ConnectionInfo{}
+}
+
+// list returns the cached connections ordered by link index, and false if the cache is not ready.
+func (c *connectionCache) list() ([]*sidecar.ConnectionInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.ready {
+		return nil, false
+	}
+	indices := []int{}
+	for index := range c.conns {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	connList := []*sidecar.ConnectionInfo{}
+	for _, index := range indices {
+		connList = append(connList, c.conns[index])
+	}
+	return connList, true
+}
+
+// connectionCacheMonitor keeps the connection cache up to date from netlink events until stop is
+// closed. The subscription is retried if it fails, the cache is not used while it is down.
+func connectionCacheMonitor(stop <-chan struct{}) {
+	for {
+		connCache.monitor(stop)
+		connCache.invalidate()
+		select {
+		case <-stop:
+			return
+		case <-time.After(connectionCacheRetryInterval):
+		}
+	}
+}
+
+// monitor subscribes to netlink events, fills the cache and applies the events until stop is closed or
+// a subscription ends.
+func (c *connectionCache) monitor(stop <-chan struct{}) {
+	done := make(chan struct{})
+	defer close(done)
+
+	linkCh := make(chan netlink.LinkUpdate, 64)
+	addrCh := make(chan netlink.AddrUpdate, 64)
+	routeCh := make(chan netlink.RouteUpdate, 64)
+	if err := linkSubscribe(linkCh, done); err != nil {
+		logger.GlobalLogger.Errorf("Failed to subscribe to link events: %v", err)
+		return
+	}
+	if err := addrSubscribe(addrCh, done); err != nil {
+		logger.GlobalLogger.Errorf("Failed to subscribe to address events: %v", err)
+		return
+	}
+	if err := routeSubscribe(routeCh, done); err != nil {
+		logger.GlobalLogger.Errorf("Failed to subscribe to route events: %v", err)
+		return
+	}
+	// Neighbor events keep the IPv6 reachability of the clients current.
+	neighCh := make(chan netlink.NeighUpdate, 64)
+	if isIPv6Enabled() {
+		if err := neighSubscribe(neighCh, done); err != nil {
+			logger.GlobalLogger.Errorf("Failed to subscribe to neighbor events: %v", err)
+			return
+		}
+	}
+	// The cache is filled after subscribing so that no change is missed in between.
+	if err := c.fill(); err != nil {
+		logger.GlobalLogger.Errorf("Failed to fill the connection cache: %v", err)
+		return
+	}
+	logger.GlobalLogger.Infof("Connection cache ready")
+
+	for {
+		select {
+		case <-stop:
+			return
+		case update, ok := <-linkCh:
+			if !ok {
+				logger.GlobalLogger.Errorf("Link event subscription closed")
+				return
+			}
+			c.handleLinkUpdate(update)
+		case update, ok := <-addrCh:
+			if !ok {
+				logger.GlobalLogger.Errorf("Address event subscription closed")
+				return
+			}
+			c.handleAddrUpdate(update)
+		case update, ok := <-routeCh:
+			if !ok {
+				logger.GlobalLogger.Errorf("Route event subscription closed")
+				return
+			}
+			c.handleRouteUpdate(update)
+		case update, ok := <-neighCh:
+			if !ok {
+				logger.GlobalLogger.Errorf("Neighbor event subscription closed")
+				return
+			}
+			c.handleNeighUpdate(update)
+		}
+	}
+}
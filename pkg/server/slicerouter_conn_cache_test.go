@@ -0,0 +1,218 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// fakeSubscriptions captures the netlink event channels handed to the subscribe functions.
+type fakeSubscriptions struct {
+	linkCh  chan chan<- netlink.LinkUpdate
+	addrCh  chan chan<- netlink.AddrUpdate
+	routeCh chan chan<- netlink.RouteUpdate
+	neighCh chan chan<- netlink.NeighUpdate
+}
+
+func useFakeSubscriptions(t *testing.T) *fakeSubscriptions {
+	t.Helper()
+	f := &fakeSubscriptions{
+		linkCh:  make(chan chan<- netlink.LinkUpdate, 1),
+		addrCh:  make(chan chan<- netlink.AddrUpdate, 1),
+		routeCh: make(chan chan<- netlink.RouteUpdate, 1),
+		neighCh: make(chan chan<- netlink.NeighUpdate, 1),
+	}
+	origLink, origAddr, origRoute, origNeigh := linkSubscribe, addrSubscribe, routeSubscribe, neighSubscribe
+	linkSubscribe = func(ch chan<- netlink.LinkUpdate, done <-chan struct{}) error {
+		f.linkCh <- ch
+		return nil
+	}
+	addrSubscribe = func(ch chan<- netlink.AddrUpdate, done <-chan struct{}) error {
+		f.addrCh <- ch
+		return nil
+	}
+	routeSubscribe = func(ch chan<- netlink.RouteUpdate, done <-chan struct{}) error {
+		f.routeCh <- ch
+		return nil
+	}
+	neighSubscribe = func(ch chan<- netlink.NeighUpdate, done <-chan struct{}) error {
+		f.neighCh <- ch
+		return nil
+	}
+	t.Cleanup(func() {
+		linkSubscribe, addrSubscribe, routeSubscribe, neighSubscribe = origLink, origAddr, origRoute, origNeigh
+		connCache.invalidate()
+	})
+	return f
+}
+
+// waitForConnections polls the connection cache until it holds the wanted peer IPs.
+func waitForConnections(t *testing.T, want []string) {
+	t.Helper()
+	var got []*sidecar.ConnectionInfo
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		var ok bool
+		got, ok = connCache.list()
+		if ok && len(got) == len(want) {
+			match := true
+			for i := range want {
+				if got[i].NsmPeerIP != want[i] {
+					match = false
+				}
+			}
+			if match {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Connection cache = %v, want peer IPs %v", got, want)
+}
+
+func TestConnectionCacheMonitor(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 3, Name: "eth0"}},
+	}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addrs[3] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("192.168.0.2"), Mask: net.CIDRMask(24, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	useFakeNetlink(t, fake)
+	subs := useFakeSubscriptions(t)
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		connCache.monitor(stop)
+		close(stopped)
+	}()
+	linkCh, addrCh := <-subs.linkCh, <-subs.addrCh
+	<-subs.routeCh
+
+	// The cache is filled from the links present at subscription time.
+	waitForConnections(t, []string{"10.1.1.2"})
+
+	// A new link is cached once its address shows up.
+	link := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2", Alias: "iperf-server"}}
+	fake.mu.Lock()
+	fake.links = append(fake.links, link)
+	fake.mu.Unlock()
+	linkCh <- netlink.LinkUpdate{Header: unix.NlMsghdr{Type: unix.RTM_NEWLINK}, Link: link}
+	waitForConnections(t, []string{"10.1.1.2"})
+
+	fake.mu.Lock()
+	fake.addrs[2] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.6"), Mask: net.CIDRMask(32, 32)}}}
+	fake.mu.Unlock()
+	fake.addConnectedRoute("10.1.1.5", 2)
+	addrCh <- netlink.AddrUpdate{LinkIndex: 2, NewAddr: true}
+	waitForConnections(t, []string{"10.1.1.2", "10.1.1.6"})
+
+	connList, err := sliceRouterGetClientConnections()
+	if err != nil {
+		t.Fatalf("sliceRouterGetClientConnections() error = %v", err)
+	}
+	if len(connList) != 2 || connList[1].PodName != "iperf-server" || connList[1].NsmIP != "10.1.1.5" {
+		t.Errorf("sliceRouterGetClientConnections() = %v, want the cached connections", connList)
+	}
+
+	// A deleted link is dropped from the cache.
+	linkCh <- netlink.LinkUpdate{
+		Header: unix.NlMsghdr{Type: unix.RTM_DELLINK},
+		Link:   &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1"}},
+	}
+	waitForConnections(t, []string{"10.1.1.6"})
+
+	// A closed subscription stops the monitor, the connections are then read from the kernel.
+	close(linkCh)
+	<-stopped
+	connCache.invalidate()
+	if _, ok := connCache.list(); ok {
+		t.Errorf("Connection cache is ready after the subscription was closed")
+	}
+	connList, err = sliceRouterGetClientConnections()
+	if err != nil {
+		t.Fatalf("sliceRouterGetClientConnections() error = %v", err)
+	}
+	if len(connList) != 2 {
+		t.Errorf("sliceRouterGetClientConnections() = %v, want both kernel connections", connList)
+	}
+	close(stop)
+}
+
+func TestConnectionCacheIPv6Reachability(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("ENABLE_IPV6", "true")
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}},
+	}
+	fake.addrs[1] = []netlink.Addr{
+		{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}},
+		{IPNet: &net.IPNet{IP: net.ParseIP("fd00:1::2"), Mask: net.CIDRMask(128, 128)}},
+	}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	fake.routes = append(fake.routes, netlink.Route{Dst: mustParseCIDR("fd00:1::1/128"), LinkIndex: 1})
+	useFakeNetlink(t, fake)
+	subs := useFakeSubscriptions(t)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go connCache.monitor(stop)
+	<-subs.linkCh
+	<-subs.addrCh
+	<-subs.routeCh
+	neighCh := <-subs.neighCh
+
+	waitForReachability := func(want bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			connList, ok := connCache.list()
+			if ok && len(connList) == 1 && connList[0].NsmIPv6 == "fd00:1::1" && connList[0].NsmIPv6Reachable == want {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		connList, _ := connCache.list()
+		t.Fatalf("Connection cache = %v, want IPv6 reachable %v", connList, want)
+	}
+
+	waitForReachability(false)
+
+	fake.setNeighState(1, "fd00:1::1", netlink.NUD_REACHABLE)
+	neighCh <- netlink.NeighUpdate{Type: unix.RTM_NEWNEIGH, Neigh: netlink.Neigh{LinkIndex: 1, IP: net.ParseIP("fd00:1::1")}}
+	waitForReachability(true)
+
+	fake.setNeighState(1, "fd00:1::1", netlink.NUD_FAILED)
+	neighCh <- netlink.NeighUpdate{Type: unix.RTM_NEWNEIGH, Neigh: netlink.Neigh{LinkIndex: 1, IP: net.ParseIP("fd00:1::1")}}
+	waitForReachability(false)
+}
@@ -0,0 +1,198 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+)
+
+// getDataplaneStateFile returns the file the dataplane mode is persisted to across restarts, read from
+// the DATAPLANE_STATE_FILE env variable. Dataplane mode changes are not detected if it is not set, the
+// file must be on a volume that outlives the sidecar container.
+func getDataplaneStateFile() string {
+	return os.Getenv("DATAPLANE_STATE_FILE")
+}
+
+// isFlushOnDataplaneChangeEnabled returns true if the slice routes of the previous dataplane are removed
+// when the sidecar starts with another dataplane mode, read from the FLUSH_ON_DATAPLANE_CHANGE env
+// variable. The routes of the previous dataplane are left in place by default.
+func isFlushOnDataplaneChangeEnabled() bool {
+	return os.Getenv("FLUSH_ON_DATAPLANE_CHANGE") == "true"
+}
+
+// readDataplaneState returns the dataplane mode persisted in the state file, empty if there is none.
+func readDataplaneState(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeDataplaneState persists the dataplane mode in the state file. The file is replaced atomically so
+// that a crash does not leave a truncated mode behind.
+func writeDataplaneState(path string, mode string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(mode + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// isSliceManagedKernelRoute returns true if the route looks like one injected by the sidecar: a route
+// to a remote subnet through gateways reached over nsm interfaces only. The connected routes installed
+// by nsm have no gateway and are left alone.
+func isSliceManagedKernelRoute(route netlink.Route, linkMap map[int]netlink.Link) bool {
+	if route.Dst == nil {
+		return false
+	}
+	if len(route.MultiPath) == 0 {
+		return route.Gw != nil && isNsmLinkIndex(linkMap, route.LinkIndex)
+	}
+	for _, nextHop := range route.MultiPath {
+		if nextHop.Gw == nil || !isNsmLinkIndex(linkMap, nextHop.LinkIndex) {
+			return false
+		}
+	}
+	return true
+}
+
+// vl3FlushSliceRoutesInKernel removes the slice routes left in the main table by a sidecar running in
+// kernel mode and returns the number of routes removed.
+func vl3FlushSliceRoutesInKernel() (int, error) {
+	routes, err := nlHandle.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return 0, err
+	}
+	linkMap, err := getLinkIndexMap()
+	if err != nil {
+		return 0, err
+	}
+	flushed := 0
+	for i := range routes {
+		if !isSliceManagedKernelRoute(routes[i], linkMap) {
+			continue
+		}
+		if err := nlHandle.RouteDel(&routes[i]); err != nil {
+			return flushed, err
+		}
+		logIproute2Command("del", &routes[i])
+		flushed++
+	}
+	return flushed, nil
+}
+
+// vl3FlushSliceRoutesInVpp removes the slice routes left in vpp by a sidecar running in vpp mode and
+// returns the number of routes removed. The routes of other agents are left alone, nothing is flushed
+// unless the slice routes are in a dedicated vrf.
+func vl3FlushSliceRoutesInVpp() (int, error) {
+	if !hasDedicatedVppSliceRouteVrf() {
+		logger.GlobalLogger.Infof("Not flushing the slice routes in vpp, no dedicated VPP_SLICE_ROUTE_VRF is set")
+		return 0, nil
+	}
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		logger.GlobalLogger.Errorf("can't dial grpc server: %v", err)
+		return 0, err
+	}
+	defer closeConn()
+
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{})
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get vpp config: %v", err)
+		return 0, err
+	}
+	routes := []*vpp.Route{}
+	for _, route := range vppConfig.GetConfig().GetVppConfig().GetRoutes() {
+		if isSliceVppRoute(route) {
+			routes = append(routes, route)
+		}
+	}
+	if len(routes) == 0 {
+		return 0, nil
+	}
+	if err := sendConfigToVppAgent(context.Background(), &vpp.ConfigData{Routes: routes}, true); err != nil {
+		return 0, err
+	}
+	return len(routes), nil
+}
+
+// sliceRouterHandleDataplaneChange compares the dataplane mode with the one persisted by the previous
+// run of the sidecar and, if it changed and flushing is enabled, removes the slice routes of the
+// previous dataplane. The current mode is then persisted. It returns the previous mode, empty if
+// unknown, and the number of routes flushed.
+func sliceRouterHandleDataplaneChange() (string, int, error) {
+	path := getDataplaneStateFile()
+	if path == "" {
+		return "", 0, nil
+	}
+	previous, err := readDataplaneState(path)
+	if err != nil {
+		return "", 0, err
+	}
+	mode := getSliceRouterDataplaneMode()
+	flushed := 0
+	if previous != "" && previous != mode {
+		logger.GlobalLogger.Infof("Dataplane mode changed from %v to %v", previous, mode)
+		if isFlushOnDataplaneChangeEnabled() {
+			switch previous {
+			case SliceRouterDataplaneKernel:
+				flushed, err = vl3FlushSliceRoutesInKernel()
+			case SliceRouterDataplaneVpp:
+				flushed, err = vl3FlushSliceRoutesInVpp()
+			}
+			logger.GlobalLogger.Infof("Flushed %v slice routes of the %v dataplane", flushed, previous)
+			if err != nil {
+				// The state is not updated so that the flush is attempted again on the next start.
+				return previous, flushed, err
+			}
+		} else {
+			logger.GlobalLogger.Infof("Leaving the slice routes of the %v dataplane in place", previous)
+		}
+	}
+	if previous != mode {
+		if err := writeDataplaneState(path, mode); err != nil {
+			return previous, flushed, err
+		}
+	}
+	return previous, flushed, nil
+}
@@ -0,0 +1,124 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+)
+
+func TestDataplaneChange(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName string
+		// previous is the mode in the state file, none if empty.
+		previous string
+		mode     string
+		flush    bool
+		vrf      string
+		// expected routes left in each dataplane
+		expectedFlushed      int
+		expectedKernelRoutes int
+		expectedVppRoutes    int
+	}{
+		{"first start", "", SliceRouterDataplaneKernel, true, "5", 0, 4, 3},
+		{"same mode", SliceRouterDataplaneKernel, SliceRouterDataplaneKernel, true, "5", 0, 4, 3},
+		{"kernel to vpp keeps the routes by default", SliceRouterDataplaneKernel, SliceRouterDataplaneVpp, false, "5", 0, 4, 3},
+		{"kernel to vpp flushes the kernel routes", SliceRouterDataplaneKernel, SliceRouterDataplaneVpp, true, "5", 1, 3, 3},
+		{"vpp to kernel flushes the vpp routes", SliceRouterDataplaneVpp, SliceRouterDataplaneKernel, true, "5", 1, 4, 2},
+		{"vpp to kernel keeps the vpp routes of vrf 0", SliceRouterDataplaneVpp, SliceRouterDataplaneKernel, true, "", 0, 4, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "dataplane")
+			if tt.previous != "" {
+				if err := os.WriteFile(path, []byte(tt.previous+"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("DATAPLANE_STATE_FILE", path)
+			t.Setenv("DATAPLANE", tt.mode)
+			t.Setenv("VPP_SLICE_ROUTE_VRF", tt.vrf)
+			if tt.flush {
+				t.Setenv("FLUSH_ON_DATAPLANE_CHANGE", "true")
+			}
+
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.links = append(fake.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "eth0"}})
+			fake.routes = append(fake.routes,
+				// Only the route through the nsm interface is a slice route.
+				netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), Gw: mustParseCIDR("192.168.0.2/32").IP, LinkIndex: 1},
+				netlink.Route{Dst: mustParseCIDR("10.2.0.0/16"), Gw: mustParseCIDR("172.16.0.1/32").IP, LinkIndex: 2},
+				netlink.Route{Dst: mustParseCIDR("10.3.0.0/16"), LinkIndex: 1})
+			useFakeNetlink(t, fake)
+			vppAgent := newFakeVppAgent()
+			vppAgent.config.Routes = []*vpp.Route{
+				// Only the inter vrf route of the slice route vrf is a slice route.
+				getVppConfig("10.1.0.0/16", "192.168.0.2", vppRouteAttrs{}).GetRoutes()[0],
+				{Type: vpp_l3.Route_INTRA_VRF, DstNetwork: "10.2.0.0/16", NextHopAddr: "172.16.0.1"},
+				{Type: vpp_l3.Route_INTER_VRF, DstNetwork: "10.4.0.0/16", NextHopAddr: "172.16.0.1", ViaVrfId: 2},
+			}
+			useFakeVppAgent(t, vppAgent)
+
+			previous, flushed, err := sliceRouterHandleDataplaneChange()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if previous != tt.previous {
+				t.Error("previous mode: expected", tt.previous, "received", previous)
+			}
+			if flushed != tt.expectedFlushed {
+				t.Error("flushed routes: expected", tt.expectedFlushed, "received", flushed)
+			}
+			if routes, _ := fake.RouteList(nil, netlink.FAMILY_ALL); len(routes) != tt.expectedKernelRoutes {
+				t.Error("kernel routes: expected", tt.expectedKernelRoutes, "received", routes)
+			}
+			if routes := vppAgent.config.GetRoutes(); len(routes) != tt.expectedVppRoutes {
+				t.Error("vpp routes: expected", tt.expectedVppRoutes, "received", routes)
+			}
+			if mode, err := readDataplaneState(path); err != nil || mode != tt.mode {
+				t.Error("persisted mode: expected", tt.mode, "received", mode, err)
+			}
+		})
+	}
+}
+
+func TestDataplaneChangeWithoutStateFile(t *testing.T) {
+	t.Setenv("DATAPLANE_STATE_FILE", "")
+	t.Setenv("FLUSH_ON_DATAPLANE_CHANGE", "true")
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.routes = append(fake.routes, netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), Gw: mustParseCIDR("192.168.0.2/32").IP, LinkIndex: 1})
+	useFakeNetlink(t, fake)
+
+	if previous, flushed, err := sliceRouterHandleDataplaneChange(); previous != "" || flushed != 0 || err != nil {
+		t.Error("expected no dataplane change handling, received", previous, flushed, err)
+	}
+	if routes, _ := fake.RouteList(nil, netlink.FAMILY_ALL); len(routes) != 2 {
+		t.Error("expected the routes to be kept, received", routes)
+	}
+}
@@ -0,0 +1,155 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+const (
+	// The next hop change was held back until the debounce window ends.
+	debounceOutcomeDeferred = "deferred"
+	// A held back change was reverted or superseded before it reached the dataplane.
+	debounceOutcomeCollapsed = "collapsed"
+)
+
+// getInjectDebounceWindow returns the window after a route write during which further next hop changes
+// of the route are held back, read from the INJECT_DEBOUNCE_WINDOW env variable. Only the last change
+// requested within the window is applied when it ends, so that a next hop flapping back and forth does
+// not churn the dataplane. Changes are applied right away if it is not set.
+func getInjectDebounceWindow() time.Duration {
+	return getEnvDuration("INJECT_DEBOUNCE_WINDOW", 0)
+}
+
+// debounceEntry is the debounce state of the route to a remote subnet.
+type debounceEntry struct {
+	// lastWrite is the time the route was last written to the dataplane.
+	lastWrite time.Time
+	// pending is the change held back until the window ends, nil if none.
+	pending *routeRequest
+	timer   *time.Timer
+}
+
+// routeDebouncer holds back the next hop changes of routes written within the debounce window.
+type routeDebouncer struct {
+	mu      sync.Mutex
+	entries map[string]*debounceEntry
+}
+
+var routeDebounce = &routeDebouncer{entries: map[string]*debounceEntry{}}
+
+// written records that the route to the remote subnet was written to the dataplane.
+func (d *routeDebouncer) written(remoteSubnet string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[remoteSubnet]
+	if !ok {
+		entry = &debounceEntry{}
+		d.entries[remoteSubnet] = entry
+	}
+	entry.lastWrite = time.Now()
+}
+
+// forget drops the debounce state of the route to the remote subnet, once it is deleted.
+func (d *routeDebouncer) forget(remoteSubnet string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if entry, ok := d.entries[remoteSubnet]; ok && entry.timer != nil {
+		entry.timer.Stop()
+	}
+	delete(d.entries, remoteSubnet)
+}
+
+// cancel drops the change held back for the route to the remote subnet, returning true if there was
+// one. The request being injected supersedes it.
+func (d *routeDebouncer) cancel(remoteSubnet string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[remoteSubnet]
+	if !ok || entry.pending == nil {
+		return false
+	}
+	entry.timer.Stop()
+	entry.pending, entry.timer = nil, nil
+	routeInjectsDebouncedCounter.Inc(debounceOutcomeCollapsed)
+	return true
+}
+
+// hold holds back the change of the route if the route was written within the debounce window, and
+// returns true if it did. The change is applied when the window ends, unless a later injection cancels
+// it first.
+func (d *routeDebouncer) hold(route routeRequest) bool {
+	window := getInjectDebounceWindow()
+	if window == 0 {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[route.remoteSubnet]
+	if !ok {
+		return false
+	}
+	wait := window - time.Since(entry.lastWrite)
+	if wait <= 0 {
+		return false
+	}
+	pending := route
+	entry.pending = &pending
+	entry.timer = time.AfterFunc(wait, func() {
+		d.apply(route.remoteSubnet, &pending)
+	})
+	routeInjectsDebouncedCounter.Inc(debounceOutcomeDeferred)
+	return true
+}
+
+// apply injects the change held back for the route once the debounce window ended, unless it was
+// superseded meanwhile.
+func (d *routeDebouncer) apply(remoteSubnet string, pending *routeRequest) {
+	d.mu.Lock()
+	entry, ok := d.entries[remoteSubnet]
+	if !ok || entry.pending != pending {
+		d.mu.Unlock()
+		return
+	}
+	entry.pending, entry.timer = nil, nil
+	d.mu.Unlock()
+
+	route := *pending
+	route.immediate = true
+	logger.GlobalLogger.Infof("Applying the next hop change held back by the debounce window. RemoteSubnet: %v, NextHops: %v",
+		remoteSubnet, route.nextHopIPList)
+	if err := sliceRouterInjectRouteWithResolver(route, newNextHopResolver()); err != nil {
+		logger.GlobalLogger.Errorf("Failed to apply debounced route to %v: %v", remoteSubnet, err)
+	}
+}
+
+// reset drops the changes held back for all routes, so that they are not written after the routes are
+// flushed.
+func (d *routeDebouncer) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, entry := range d.entries {
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+	}
+	d.entries = map[string]*debounceEntry{}
+}
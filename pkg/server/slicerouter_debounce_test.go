@@ -0,0 +1,172 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+// countAuditOperations returns the number of operations of the kind in the route audit log.
+func countAuditOperations(log *routeAuditLog, operation string) int {
+	count := 0
+	for _, entry := range log.list() {
+		if entry.GetOperation() == operation {
+			count++
+		}
+	}
+	return count
+}
+
+func TestInjectDebounce(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	const window = 100 * time.Millisecond
+	a, b, c := []string{"192.168.0.2"}, []string{"192.168.1.2"}, []string{"192.168.2.2"}
+
+	tests := []struct {
+		testName string
+		window   string
+		// Next hops injected back to back, an empty list deleting the route.
+		sequence        [][]string
+		expectInstalled []string
+		expectInjects   int
+		expectDeletes   int
+		expectDeferred  float64
+		expectCollapsed float64
+	}{
+		{
+			testName:        "change reverted within the window",
+			window:          window.String(),
+			sequence:        [][]string{a, b, a},
+			expectInstalled: a,
+			expectInjects:   1,
+			expectDeferred:  1,
+			expectCollapsed: 1,
+		},
+		{
+			testName:        "flaps settle on the last change",
+			window:          window.String(),
+			sequence:        [][]string{a, b, c, b},
+			expectInstalled: b,
+			expectInjects:   2,
+			expectDeferred:  3,
+			expectCollapsed: 2,
+		},
+		{
+			testName:        "superseded change reverted",
+			window:          window.String(),
+			sequence:        [][]string{a, b, c, a},
+			expectInstalled: a,
+			expectInjects:   1,
+			expectDeferred:  2,
+			expectCollapsed: 2,
+		},
+		{
+			testName:        "deletion drops the held back change",
+			window:          window.String(),
+			sequence:        [][]string{a, b, nil},
+			expectInstalled: nil,
+			expectInjects:   1,
+			expectDeletes:   1,
+			expectDeferred:  1,
+			expectCollapsed: 1,
+		},
+		{
+			testName:        "debounce disabled",
+			sequence:        [][]string{a, b, a},
+			expectInstalled: a,
+			expectInjects:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("INJECT_DEBOUNCE_WINDOW", tt.window)
+			resetRouteMap(t)
+			skipReconcile(t)
+			log := &routeAuditLog{size: 20}
+			useRouteAuditLog(t, log)
+			routeInjectsDebouncedCounter.Reset()
+
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.1.2", 2)
+			fake.addConnectedRoute("192.168.2.2", 3)
+			useFakeNetlink(t, fake)
+
+			for _, nextHops := range tt.sequence {
+				if err := sliceRouterInjectRoute("10.1.0.0/16", nextHops); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// Changes held back are applied once the window ends.
+			time.Sleep(3 * window)
+
+			if got := installedNextHops(t, fake, "10.1.0.0/16"); !sameNextHops(got, tt.expectInstalled) {
+				t.Error("installed next hops: expected", tt.expectInstalled, "received", got)
+			}
+			if got := countAuditOperations(log, routeAuditInject); got != tt.expectInjects {
+				t.Error("route writes: expected", tt.expectInjects, "received", got)
+			}
+			if got := countAuditOperations(log, routeAuditDelete); got != tt.expectDeletes {
+				t.Error("route deletions: expected", tt.expectDeletes, "received", got)
+			}
+			if got := routeInjectsDebouncedCounter.Value(debounceOutcomeDeferred); got != tt.expectDeferred {
+				t.Error("deferred changes: expected", tt.expectDeferred, "received", got)
+			}
+			if got := routeInjectsDebouncedCounter.Value(debounceOutcomeCollapsed); got != tt.expectCollapsed {
+				t.Error("collapsed changes: expected", tt.expectCollapsed, "received", got)
+			}
+		})
+	}
+}
+
+func TestInjectDebounceAtomicBatch(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("INJECT_DEBOUNCE_WINDOW", "1h")
+	resetRouteMap(t)
+	skipReconcile(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.1.2", 2)
+	useFakeNetlink(t, fake)
+
+	if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	// A regular injection is held back, the atomic batch is applied right away and supersedes it.
+	if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.1.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := installedNextHops(t, fake, "10.1.0.0/16"); !sameNextHops(got, []string{"192.168.0.2"}) {
+		t.Error("held back change: expected 192.168.0.2 installed, received", got)
+	}
+	err := sliceRouterInjectRoutesAtomic([]routeRequest{{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.1.2"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := installedNextHops(t, fake, "10.1.0.0/16"); !sameNextHops(got, []string{"192.168.1.2"}) {
+		t.Error("atomic batch: expected 192.168.1.2 installed, received", got)
+	}
+}
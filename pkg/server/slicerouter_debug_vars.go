@@ -0,0 +1,66 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+// debugVars is the internal state of the sidecar published as the "slicerouter" expvar, for quick
+// debugging with a curl of /debug/vars.
+type debugVars struct {
+	RouteCount        int       `json:"routeCount"`
+	LastReconcileTime time.Time `json:"lastReconcileTime"`
+	DataplaneMode     string    `json:"dataplaneMode"`
+	// The vpp-agent fields are only set in vpp mode. The vpp-agent is reachable unless the last call to
+	// it found it unreachable.
+	VppAgentEndpoint  string `json:"vppAgentEndpoint,omitempty"`
+	VppAgentReachable *bool  `json:"vppAgentReachable,omitempty"`
+}
+
+var publishDebugVarsOnce sync.Once
+
+// PublishDebugVars publishes the internal state of the sidecar as the "slicerouter" expvar. The state is
+// read when the vars are served.
+func PublishDebugVars() {
+	publishDebugVarsOnce.Do(func() {
+		expvar.Publish("slicerouter", expvar.Func(func() any {
+			return getDebugVars()
+		}))
+	})
+}
+
+// getDebugVars returns a snapshot of the internal state of the sidecar.
+func getDebugVars() *debugVars {
+	vars := &debugVars{DataplaneMode: getSliceRouterDataplaneMode()}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		vars.RouteCount++
+		return true
+	})
+	reconcileMu.Lock()
+	vars.LastReconcileTime = lastRoutingTableReconcileTime
+	reconcileMu.Unlock()
+	if vars.DataplaneMode == SliceRouterDataplaneVpp {
+		reachable := vppAgentEndpoints.reachable()
+		vars.VppAgentEndpoint = vppAgentEndpoints.activeEndpoint()
+		vars.VppAgentReachable = &reachable
+	}
+	return vars
+}
@@ -0,0 +1,112 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDebugVars(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("VPP_AGENT_ENDPOINTS", "")
+	resetRouteMap(t)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2"})
+	remoteSubnetRouteMap.Store("10.2.0.0/16", []string{"192.168.0.2"})
+	reconciled := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reconcileMu.Lock()
+	saved := lastRoutingTableReconcileTime
+	lastRoutingTableReconcileTime = reconciled
+	reconcileMu.Unlock()
+	t.Cleanup(func() {
+		reconcileMu.Lock()
+		lastRoutingTableReconcileTime = saved
+		reconcileMu.Unlock()
+	})
+
+	tests := []struct {
+		testName  string
+		dataplane string
+		// getErr is returned by the vpp-agent to the last call.
+		getErr            error
+		expectedReachable *bool
+	}{
+		{"kernel mode", SliceRouterDataplaneKernel, nil, nil},
+		{"vpp agent reachable", SliceRouterDataplaneVpp, nil, newBool(true)},
+		{"vpp agent unreachable", SliceRouterDataplaneVpp, status.Error(codes.Unavailable, "connection refused"), newBool(false)},
+		{"vpp agent rejecting calls is reachable", SliceRouterDataplaneVpp, status.Error(codes.InvalidArgument, "bad config"), newBool(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			agent := newFakeVppAgent()
+			agent.getErr = tt.getErr
+			useFakeVppAgentEndpoints(t, map[string]*fakeVppAgent{defaultVppAgentEndpoint: agent})
+			if tt.dataplane == SliceRouterDataplaneVpp {
+				vppAgentReady()
+			}
+
+			vars := getDebugVars()
+			if vars.RouteCount != 2 {
+				t.Error("route count: expected 2, received", vars.RouteCount)
+			}
+			if !vars.LastReconcileTime.Equal(reconciled) {
+				t.Error("last reconcile time: expected", reconciled, "received", vars.LastReconcileTime)
+			}
+			if vars.DataplaneMode != tt.dataplane {
+				t.Error("dataplane mode: expected", tt.dataplane, "received", vars.DataplaneMode)
+			}
+			if (vars.VppAgentReachable == nil) != (tt.expectedReachable == nil) ||
+				(vars.VppAgentReachable != nil && *vars.VppAgentReachable != *tt.expectedReachable) {
+				t.Error("vpp agent reachable: expected", tt.expectedReachable, "received", vars.VppAgentReachable)
+			}
+		})
+	}
+}
+
+func TestPublishDebugVars(t *testing.T) {
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2"})
+
+	// Publishing twice must not panic on the duplicate expvar.
+	PublishDebugVars()
+	PublishDebugVars()
+	v := expvar.Get("slicerouter")
+	if v == nil {
+		t.Fatal("slicerouter expvar not published")
+	}
+	vars := debugVars{}
+	if err := json.Unmarshal([]byte(v.String()), &vars); err != nil {
+		t.Fatal(err)
+	}
+	if vars.RouteCount != 1 || vars.DataplaneMode != SliceRouterDataplaneKernel {
+		t.Errorf("unexpected vars %+v", vars)
+	}
+}
+
+func newBool(b bool) *bool {
+	return &b
+}
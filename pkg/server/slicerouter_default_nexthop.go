@@ -0,0 +1,148 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// defaultNextHopRouteMetric is the kernel metric of the default next hop route unless configured. It is
+// above the metric of the slice routes and of the default route of the pod network so that the route
+// only catches the traffic no other route takes.
+const defaultNextHopRouteMetric = 4096
+
+// getDefaultNextHops returns the next hops the destinations without a slice route are sent to, read from
+// the comma-separated DEFAULT_NEXTHOPS env variable. No default next hop route is installed by default.
+func getDefaultNextHops() []string {
+	return getEnvList("DEFAULT_NEXTHOPS")
+}
+
+// getDefaultNextHopPrefix returns the prefix routed to the default next hops, read from the
+// DEFAULT_NEXTHOP_PREFIX env variable. A summary prefix limits the catch-all to the slice address space,
+// the default route of the family of the first default next hop is used if it is not set. Empty is
+// returned if there are no default next hops.
+func getDefaultNextHopPrefix() string {
+	nextHops := getDefaultNextHops()
+	if len(nextHops) == 0 {
+		return ""
+	}
+	catchAll := "0.0.0.0/0"
+	if ip := net.ParseIP(nextHops[0]); ip != nil && ip.To4() == nil {
+		catchAll = "::/0"
+	}
+	prefix := os.Getenv("DEFAULT_NEXTHOP_PREFIX")
+	if prefix == "" {
+		return catchAll
+	}
+	_, ipNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Invalid DEFAULT_NEXTHOP_PREFIX %q, using %v", prefix, catchAll)
+		return catchAll
+	}
+	return ipNet.String()
+}
+
+// getDefaultNextHopMetric returns the kernel metric of the default next hop route, read from the
+// DEFAULT_NEXTHOP_METRIC env variable.
+func getDefaultNextHopMetric() int {
+	value := os.Getenv("DEFAULT_NEXTHOP_METRIC")
+	if value == "" {
+		return defaultNextHopRouteMetric
+	}
+	metric, err := strconv.Atoi(value)
+	if err != nil || metric <= 0 {
+		logger.GlobalLogger.Errorf("Invalid DEFAULT_NEXTHOP_METRIC %q, using %v", value, defaultNextHopRouteMetric)
+		return defaultNextHopRouteMetric
+	}
+	return metric
+}
+
+// isDefaultNextHopRoute returns true if the remote subnet is the prefix routed to the default next hops.
+func isDefaultNextHopRoute(remoteSubnet string) bool {
+	prefix := getDefaultNextHopPrefix()
+	if prefix == "" {
+		return false
+	}
+	_, ipNet, err := net.ParseCIDR(remoteSubnet)
+	return err == nil && ipNet.String() == prefix
+}
+
+// kernelRouteMetric returns the metric the route to the remote subnet is installed with in the kernel.
+// The slice routes leave it to the kernel, the default next hop route has a low priority one.
+func kernelRouteMetric(remoteSubnet string) int {
+	if isDefaultNextHopRoute(remoteSubnet) {
+		return getDefaultNextHopMetric()
+	}
+	return 0
+}
+
+// kernelRouteDst returns the destination of a kernel route. The kernel reports default routes without a
+// destination, they are returned as the default route of the family of their gateway. Empty is returned
+// for a route without destination nor gateway.
+func kernelRouteDst(route netlink.Route) string {
+	if route.Dst != nil {
+		return route.Dst.String()
+	}
+	gw := route.Gw
+	if gw == nil && len(route.MultiPath) > 0 {
+		gw = route.MultiPath[0].Gw
+	}
+	if gw == nil {
+		return ""
+	}
+	if gw.To4() != nil {
+		return "0.0.0.0/0"
+	}
+	return "::/0"
+}
+
+// isSliceRouteMetric returns true if the kernel route has the metric the slice router installs routes to
+// its destination with. Only the default next hop route is told apart by its metric, the pod network
+// has a default route of its own.
+func isSliceRouteMetric(route netlink.Route) bool {
+	metric := kernelRouteMetric(kernelRouteDst(route))
+	return metric == 0 || route.Priority == metric
+}
+
+// sliceRouterInjectDefaultNextHopRoute installs the route to the default next hops, managed like the
+// routes injected by the controller, and returns its prefix, empty if no default next hop is set. In
+// kernel mode a route whose next hops are not reachable yet is left to the reconcile.
+func sliceRouterInjectDefaultNextHopRoute() (string, error) {
+	prefix := getDefaultNextHopPrefix()
+	if prefix == "" {
+		return "", nil
+	}
+	nextHops := getDefaultNextHops()
+	logger.GlobalLogger.Infof("Injecting default next hop route. Prefix: %v, NextHops: %v", prefix, nextHops)
+	err := sliceRouterInjectRoute(prefix, nextHops)
+	var rErr *routeError
+	if errors.As(err, &rErr) && rErr.reason == routeErrorNextHopUnresolved &&
+		getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel {
+		logger.GlobalLogger.Infof("Default next hops not reachable yet, leaving the route to the reconcile: %v", err)
+		remoteSubnetRouteMap.Store(prefix, nextHops)
+		updateRouteCountGauge()
+		return prefix, nil
+	}
+	return prefix, err
+}
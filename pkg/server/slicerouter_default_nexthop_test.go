@@ -0,0 +1,129 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+func TestGetDefaultNextHopPrefix(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	tests := []struct {
+		testName string
+		nextHops string
+		prefix   string
+		expected string
+	}{
+		{"no default next hop", "", "10.0.0.0/8", ""},
+		{"ipv4 catch-all", "192.168.0.2", "", "0.0.0.0/0"},
+		{"ipv6 catch-all", "fd00:1::1", "", "::/0"},
+		{"summary prefix", "192.168.0.2", "10.1.2.3/8", "10.0.0.0/8"},
+		{"invalid prefix", "192.168.0.2", "10.0.0.0", "0.0.0.0/0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DEFAULT_NEXTHOPS", tt.nextHops)
+			t.Setenv("DEFAULT_NEXTHOP_PREFIX", tt.prefix)
+			if prefix := getDefaultNextHopPrefix(); prefix != tt.expected {
+				t.Errorf("expected %q, received %q", tt.expected, prefix)
+			}
+		})
+	}
+}
+
+// defaultNextHopRoute returns the kernel route to the default next hop prefix, nil if there is none.
+func defaultNextHopRoute(fake *fakeNetlink, prefix string) *netlink.Route {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	for _, route := range fake.routes {
+		if route.Dst != nil && route.Dst.String() == prefix {
+			found := route
+			return &found
+		}
+	}
+	return nil
+}
+
+func TestInjectDefaultNextHopRoute(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("DEFAULT_NEXTHOPS", "192.168.0.2")
+	t.Setenv("DEFAULT_NEXTHOP_METRIC", "5000")
+	skipReconcile(t)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	useFakeNetlink(t, fake)
+
+	// The next hop is not connected yet, the route is left to the reconcile.
+	prefix, err := sliceRouterInjectDefaultNextHopRoute()
+	if err != nil || prefix != "0.0.0.0/0" {
+		t.Fatalf("expected 0.0.0.0/0 and no error, received %q and %v", prefix, err)
+	}
+	if route := defaultNextHopRoute(fake, prefix); route != nil {
+		t.Fatal("route installed before its next hop is connected:", route)
+	}
+	if nextHops, ok := remoteSubnetRouteMap.Load(prefix); !ok || !sameNextHops(nextHops.([]string), []string{"192.168.0.2"}) {
+		t.Fatal("default next hop route not recorded in the slice route map")
+	}
+
+	// The catch-all covers the local nsm subnet, the connected route of the subnet being more specific.
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("192.168.0.1"), Mask: net.CIDRMask(30, 32)}}}
+	if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	route := defaultNextHopRoute(fake, prefix)
+	if route == nil || route.Priority != 5000 || !route.Gw.Equal(net.ParseIP("192.168.0.2")) {
+		t.Fatal("expected the default next hop route via 192.168.0.2 with metric 5000, received", route)
+	}
+	if err := sliceRouterInjectRoute(prefix, []string{"192.168.0.2"}); err != nil {
+		t.Fatal("injecting the default next hop route:", err)
+	}
+
+	// A route to the prefix with another metric is not the default next hop route.
+	fake.mu.Lock()
+	for i := range fake.routes {
+		if fake.routes[i].Dst != nil && fake.routes[i].Dst.String() == prefix {
+			fake.routes[i].Priority = 0
+		}
+	}
+	fake.mu.Unlock()
+	if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if route := defaultNextHopRoute(fake, prefix); route == nil || route.Priority != 5000 {
+		t.Fatal("expected the reconcile to install the route with metric 5000, received", route)
+	}
+
+	// Other remote subnets are still checked against the local subnets.
+	if err := sliceRouterInjectRoute("192.168.0.0/16", []string{"192.168.0.2"}); err == nil {
+		t.Error("expected a remote subnet overlapping the local subnet to be refused")
+	}
+
+	if err := sliceRouterInjectRoute(prefix, nil); err != nil {
+		t.Fatal(err)
+	}
+	if route := defaultNextHopRoute(fake, prefix); route != nil {
+		t.Error("default next hop route not deleted:", route)
+	}
+}
@@ -0,0 +1,94 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// Point-to-point NSM links can route traffic out of the interface without a gateway. Such a next hop is
+// given as "dev:<interface name>" in place of a next hop IP and is installed as a route through the link
+// without a gateway. Interface next hops are only supported in the kernel dataplane and are not health
+// checked.
+
+// devNextHopPrefix marks a next hop given as an interface name.
+const devNextHopPrefix = "dev:"
+
+// parseDevNextHop returns the interface name of an interface next hop, and false if the next hop is not
+// an interface next hop.
+func parseDevNextHop(nextHop string) (string, bool) {
+	if !strings.HasPrefix(nextHop, devNextHopPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(nextHop, devNextHopPrefix), true
+}
+
+// validateDevNextHop checks that the interface name of an interface next hop is a valid kernel
+// interface name.
+func validateDevNextHop(name string) error {
+	if name == "" || len(name) >= unix.IFNAMSIZ || strings.ContainsAny(name, "/: \t\n") {
+		return fmt.Errorf("invalid next hop interface %q", name)
+	}
+	return nil
+}
+
+// resolveDevNextHop returns the next hop info routing out of the NSM interface with the given name.
+func resolveDevNextHop(name string, linkMap map[int]netlink.Link) (*netlink.NexthopInfo, error) {
+	for index, link := range linkMap {
+		if link.Attrs().Name != name {
+			continue
+		}
+		if !isNsmInterface(name) {
+			return nil, fmt.Errorf("next hop interface %v is not an nsm interface", name)
+		}
+		return &netlink.NexthopInfo{LinkIndex: index}, nil
+	}
+	return nil, fmt.Errorf("next hop interface %v not found", name)
+}
+
+// routeHasDevNextHop returns true if one of the routes goes out of the named interface without a gateway.
+func routeHasDevNextHop(routeList []netlink.Route, name string, linkMap map[int]netlink.Link) bool {
+	isDev := func(linkIndex int) bool {
+		link, ok := linkMap[linkIndex]
+		return ok && link.Attrs().Name == name
+	}
+	for _, route := range routeList {
+		if len(route.MultiPath) > 0 {
+			for _, path := range route.MultiPath {
+				if path.Gw == nil && isDev(path.LinkIndex) {
+					return true
+				}
+			}
+		} else if route.Gw == nil && isDev(route.LinkIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsNextHop checks if one of the routes has the next hop, given as an IP or an interface next hop.
+func containsNextHop(routeList []netlink.Route, nextHop string, linkMap map[int]netlink.Link) bool {
+	if name, ok := parseDevNextHop(nextHop); ok {
+		return routeHasDevNextHop(routeList, name, linkMap)
+	}
+	return containsRoute(routeList, nextHop)
+}
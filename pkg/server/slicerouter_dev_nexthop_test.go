@@ -0,0 +1,201 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// routeTo returns the route to the destination in the fake kernel.
+func routeTo(t *testing.T, fake *fakeNetlink, dst string) *netlink.Route {
+	t.Helper()
+	routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+	for _, route := range routes {
+		if route.Dst != nil && route.Dst.String() == dst {
+			return &route
+		}
+	}
+	return nil
+}
+
+func TestInjectDevNextHop(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	skipReconcile(t)
+
+	tests := []struct {
+		testName     string
+		dataplane    string
+		nextHops     []string
+		expectReason routeErrorReason
+		// expectedLinks are the links of the installed next hops, with a gateway if non empty.
+		expectedLinks map[int]string
+	}{
+		{
+			testName:      "interface next hop",
+			dataplane:     SliceRouterDataplaneKernel,
+			nextHops:      []string{"dev:vl3-1"},
+			expectedLinks: map[int]string{1: ""},
+		},
+		{
+			testName:      "interface and gateway next hops",
+			dataplane:     SliceRouterDataplaneKernel,
+			nextHops:      []string{"dev:vl3-1", "192.168.1.2"},
+			expectedLinks: map[int]string{1: "", 2: "192.168.1.2"},
+		},
+		{
+			testName:     "empty interface name",
+			dataplane:    SliceRouterDataplaneKernel,
+			nextHops:     []string{"dev:"},
+			expectReason: routeErrorInvalidArgument,
+		},
+		{
+			testName:     "invalid interface name",
+			dataplane:    SliceRouterDataplaneKernel,
+			nextHops:     []string{"dev:vl3-a/b"},
+			expectReason: routeErrorInvalidArgument,
+		},
+		{
+			testName:     "interface name too long",
+			dataplane:    SliceRouterDataplaneKernel,
+			nextHops:     []string{"dev:vl3-0123456789abc"},
+			expectReason: routeErrorInvalidArgument,
+		},
+		{
+			testName:     "vpp dataplane",
+			dataplane:    SliceRouterDataplaneVpp,
+			nextHops:     []string{"dev:vl3-1"},
+			expectReason: routeErrorInvalidArgument,
+		},
+		{
+			testName:     "unknown interface",
+			dataplane:    SliceRouterDataplaneKernel,
+			nextHops:     []string{"dev:vl3-9"},
+			expectReason: routeErrorNextHopUnresolved,
+		},
+		{
+			testName:     "non-nsm interface",
+			dataplane:    SliceRouterDataplaneKernel,
+			nextHops:     []string{"dev:eth0"},
+			expectReason: routeErrorNextHopUnresolved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.1.2", 2)
+			fake.links = append(fake.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 3, Name: "eth0"}})
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectRoute("10.1.0.0/16", tt.nextHops)
+			if tt.expectReason != "" {
+				var rErr *routeError
+				if !errors.As(err, &rErr) || rErr.reason != tt.expectReason {
+					t.Fatal("expected reason", tt.expectReason, "received", err)
+				}
+				if routeTo(t, fake, "10.1.0.0/16") != nil {
+					t.Error("route installed for a rejected injection")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			route := routeTo(t, fake, "10.1.0.0/16")
+			if route == nil {
+				t.Fatal("route not installed")
+			}
+			paths := route.MultiPath
+			if len(paths) == 0 {
+				paths = []*netlink.NexthopInfo{{LinkIndex: route.LinkIndex, Gw: route.Gw, Flags: route.Flags}}
+			}
+			if len(paths) != len(tt.expectedLinks) {
+				t.Fatal("next hops: expected", tt.expectedLinks, "received", paths)
+			}
+			for _, path := range paths {
+				gw, ok := tt.expectedLinks[path.LinkIndex]
+				if !ok || (gw == "") != (path.Gw == nil) || (path.Gw != nil && path.Gw.String() != gw) {
+					t.Error("next hop: expected one of", tt.expectedLinks, "received", path)
+				}
+				if path.Gw == nil && path.Flags&int(netlink.FLAG_ONLINK) != 0 {
+					t.Error("interface next hop installed with ONLINK")
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileDevNextHop(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	audit := &routeAuditLog{size: 10}
+	useRouteAuditLog(t, audit)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"dev:vl3-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An installed interface route is left alone.
+	if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range audit.list() {
+		if entry.GetOperation() == routeAuditReconcile {
+			t.Fatal("installed interface route reconciled", entry)
+		}
+	}
+
+	// A missing interface route is reinstalled.
+	route := routeTo(t, fake, "10.1.0.0/16")
+	if err := fake.RouteDel(route); err != nil {
+		t.Fatal(err)
+	}
+	if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	route = routeTo(t, fake, "10.1.0.0/16")
+	if route == nil || route.LinkIndex != 1 || route.Gw != nil {
+		t.Fatal("interface route not reinstalled, received", route)
+	}
+
+	// The route moves to the new link when the interface is recreated with another index.
+	fake.mu.Lock()
+	fake.links[0] = &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 5, Name: "vl3-1"}}
+	fake.mu.Unlock()
+	if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	route = routeTo(t, fake, "10.1.0.0/16")
+	if route == nil || route.LinkIndex != 5 || route.Gw != nil {
+		t.Fatal("interface route not moved to the new link, received", route)
+	}
+}
@@ -0,0 +1,122 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+// ensureMu serializes the EnsureRoutes and SyncRoutes requests so that generations are applied in order
+// and desired states are not interleaved.
+var (
+	ensureMu              sync.Mutex
+	lastAppliedGeneration uint64
+)
+
+// ensureRoutesResult is the outcome of a sliceRouterEnsureRoutes call.
+type ensureRoutesResult struct {
+	// Errors of the desired routes, in request order.
+	errs []error
+	// Routes removed because they are not in the desired set, with their errors.
+	removed    []string
+	removedErr []error
+	// Last generation applied.
+	generation uint64
+	// True if the request was ignored because its generation is stale.
+	stale bool
+}
+
+// sliceRouterEnsureRoutes brings the routes injected by the sidecar to the desired set. Missing or
+// changed routes are injected and the routes that are not in the desired set are deleted. Requests
+// with a generation older than the last applied one are ignored, while the same generation can be
+// re-applied to retry failed routes.
+func sliceRouterEnsureRoutes(ctx context.Context, desired []routeRequest, generation uint64) (*ensureRoutesResult, error) {
+	desiredSet, err := desiredRouteSet(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	ensureMu.Lock()
+	defer ensureMu.Unlock()
+	if generation < lastAppliedGeneration {
+		logger.GlobalLogger.Infof("Ignoring stale routes. Generation: %v, Applied: %v", generation, lastAppliedGeneration)
+		return &ensureRoutesResult{generation: lastAppliedGeneration, stale: true}, nil
+	}
+
+	removed := undesiredRoutes(desiredSet)
+
+	// Deletions are routes with no next hops.
+	batch := append([]routeRequest{}, desired...)
+	for _, remoteSubnet := range removed {
+		batch = append(batch, routeRequest{ctx: ctx, remoteSubnet: remoteSubnet, deletionReason: sidecar.DeletionReason_DELETION_NOT_DESIRED})
+	}
+	errs := sliceRouterInjectRoutes(batch)
+
+	lastAppliedGeneration = generation
+	logger.GlobalLogger.Infof("Applied routes generation %v. Routes: %v, Removed: %v", generation, len(desired), removed)
+	return &ensureRoutesResult{
+		errs:       errs[:len(desired)],
+		removed:    removed,
+		removedErr: errs[len(desired):],
+		generation: generation,
+	}, nil
+}
+
+// desiredRouteSet validates a desired set of routes and returns their remote subnets. Every route must
+// have next hops and appear once. The remote subnets of the routes are rewritten in their canonical form,
+// the one routes are recorded under in the slice route map, so that the same subnet written in different
+// forms is one route.
+func desiredRouteSet(desired []routeRequest) (map[string]bool, error) {
+	desiredSet := map[string]bool{}
+	for i := range desired {
+		// Invalid subnets are kept as requested, their injection reports the error.
+		if _, remoteNet, err := net.ParseCIDR(desired[i].remoteSubnet); err == nil {
+			desired[i].remoteSubnet = remoteNet.String()
+		}
+		route := desired[i]
+		if desiredSet[route.remoteSubnet] {
+			return nil, newRouteError(routeErrorInvalidArgument, route.remoteSubnet, fmt.Errorf("duplicate route"))
+		}
+		if len(route.nextHopIPList) == 0 {
+			return nil, newRouteError(routeErrorInvalidArgument, route.remoteSubnet, fmt.Errorf("no next hops"))
+		}
+		desiredSet[route.remoteSubnet] = true
+	}
+	return desiredSet, nil
+}
+
+// undesiredRoutes returns the remote subnets of the injected routes that are not in the desired set,
+// sorted. The routes of the route groups are left to their group requests.
+func undesiredRoutes(desiredSet map[string]bool) []string {
+	removed := []string{}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		if !desiredSet[key.(string)] && loadRouteGroup(key.(string)) == "" {
+			removed = append(removed, key.(string))
+		}
+		return true
+	})
+	sort.Strings(removed)
+	return removed
+}
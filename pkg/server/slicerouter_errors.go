@@ -0,0 +1,91 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// routeErrorReason classifies why a route could not be programmed in the slice router.
+type routeErrorReason string
+
+const (
+	// The route request itself is invalid, retrying it will not help.
+	routeErrorInvalidArgument routeErrorReason = "InvalidArgument"
+	// The next hop of the route could not be resolved to a connected NSM link.
+	routeErrorNextHopUnresolved routeErrorReason = "NextHopUnresolved"
+	// The dataplane rejected the route or could not be reached.
+	routeErrorDataplane routeErrorReason = "DataplaneError"
+	// The dataplane has a route to the remote subnet that was not injected by the sidecar.
+	routeErrorConflict routeErrorReason = "RouteConflict"
+	// The dataplane keeps failing, injections are rejected until it recovers.
+	routeErrorDataplaneUnhealthy routeErrorReason = "DataplaneUnhealthy"
+)
+
+// errRouteNotFound is returned when the route to delete is not installed in the dataplane.
+var errRouteNotFound = errors.New("Route to delete not found")
+
+// errConnectionNotFound is returned when no client connection matches the requested pod.
+var errConnectionNotFound = errors.New("Client connection not found")
+
+// errReconcileInProgress is returned when a routing table reconcile is requested while another one is
+// running.
+var errReconcileInProgress = errors.New("Routing table reconcile already in progress")
+
+// routeError is returned by the route programming functions when the route could not be
+// brought to the requested state.
+type routeError struct {
+	reason       routeErrorReason
+	remoteSubnet string
+	err          error
+}
+
+func newRouteError(reason routeErrorReason, remoteSubnet string, err error) *routeError {
+	return &routeError{reason: reason, remoteSubnet: remoteSubnet, err: err}
+}
+
+func (e *routeError) Error() string {
+	return fmt.Sprintf("%s: route to %s: %v", e.reason, e.remoteSubnet, e.err)
+}
+
+func (e *routeError) Unwrap() error {
+	return e.err
+}
+
+// routeErrorToStatus converts an error returned by the route programming functions into a
+// GRPC status error that can be returned to the client.
+func routeErrorToStatus(err error) error {
+	var rErr *routeError
+	if !errors.As(err, &rErr) {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	switch rErr.reason {
+	case routeErrorInvalidArgument:
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case routeErrorNextHopUnresolved:
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case routeErrorConflict:
+		return status.Errorf(codes.AlreadyExists, "%v", err)
+	default:
+		return status.Errorf(codes.Unavailable, "%v", err)
+	}
+}
@@ -0,0 +1,271 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+const (
+	routeEventRouteAdded        = "route_added"
+	routeEventRouteDeleted      = "route_deleted"
+	routeEventRouteFailed       = "route_failed"
+	routeEventRouteCorrected    = "route_corrected"
+	routeEventNextHopChanged    = "nexthop_changed"
+	routeEventConnectionAdded   = "connection_added"
+	routeEventConnectionRemoved = "connection_removed"
+)
+
+const (
+	defaultRouteEventQueueSize      = 256
+	defaultRouteEventWebhookRetry   = 3
+	defaultRouteEventWebhookTimeout = 5 * time.Second
+	routeEventWebhookBackoff        = 500 * time.Millisecond
+)
+
+// routeEvent is a change of the slice router routes or client connections, exported to the
+// configured sinks.
+type routeEvent struct {
+	Type          string    `json:"type"`
+	Time          time.Time `json:"time"`
+	RemoteSubnet  string    `json:"remoteSubnet,omitempty"`
+	NextHopIPList []string  `json:"nextHopIPList,omitempty"`
+	PodName       string    `json:"podName,omitempty"`
+	NsmInterface  string    `json:"nsmInterface,omitempty"`
+	NsmIP         string    `json:"nsmIP,omitempty"`
+	// DeletionReason tells why a route was deleted, for route deleted events.
+	DeletionReason string `json:"deletionReason,omitempty"`
+	// PreviousNextHopIPList holds the next hops the route moved from, for next hop changed events.
+	PreviousNextHopIPList []string `json:"previousNextHopIPList,omitempty"`
+	// Correction tells what the reconcile found wrong with the route, for route corrected events.
+	Correction string `json:"correction,omitempty"`
+	// Operation and Error tell which route operation failed and why, for route failed events.
+	Operation string `json:"operation,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// routeExporter is a sink the route events are exported to. Sinks are called from a single
+// goroutine, one event at a time.
+type routeExporter interface {
+	// name identifies the sink in logs and metrics.
+	name() string
+	export(event routeEvent) error
+}
+
+// routeEventDispatcher queues the route events and exports them to the sinks in the background so
+// that a slow sink never holds back the dataplane programming.
+type routeEventDispatcher struct {
+	exporters []routeExporter
+	events    chan routeEvent
+	done      chan struct{}
+}
+
+var (
+	routeEventsMu sync.Mutex
+	// routeEvents is nil if no sink is configured.
+	routeEvents *routeEventDispatcher
+)
+
+// getRouteEventWebhookURL returns the URL the route events are posted to, read from the
+// ROUTE_EVENT_WEBHOOK_URL env variable. The webhook is disabled if it is not set.
+func getRouteEventWebhookURL() string {
+	return os.Getenv("ROUTE_EVENT_WEBHOOK_URL")
+}
+
+// getRouteEventWebhookRetries returns the number of times the delivery of an event to the webhook is
+// retried, read from the ROUTE_EVENT_WEBHOOK_RETRIES env variable.
+func getRouteEventWebhookRetries() int {
+	retries, err := strconv.Atoi(os.Getenv("ROUTE_EVENT_WEBHOOK_RETRIES"))
+	if err != nil || retries < 0 {
+		return defaultRouteEventWebhookRetry
+	}
+	return retries
+}
+
+// getRouteEventWebhookTimeout returns the timeout of a single webhook delivery attempt.
+func getRouteEventWebhookTimeout() time.Duration {
+	return getEnvDuration("ROUTE_EVENT_WEBHOOK_TIMEOUT", defaultRouteEventWebhookTimeout)
+}
+
+// newRouteEventDispatcher starts exporting the published events to the sinks.
+func newRouteEventDispatcher(exporters []routeExporter) *routeEventDispatcher {
+	d := &routeEventDispatcher{
+		exporters: exporters,
+		events:    make(chan routeEvent, defaultRouteEventQueueSize),
+		done:      make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+func (d *routeEventDispatcher) run() {
+	defer close(d.done)
+	for event := range d.events {
+		for _, exporter := range d.exporters {
+			if err := exporter.export(event); err != nil {
+				logger.GlobalLogger.Errorf("Failed to export route event. Sink: %v, Event: %v, Err: %v",
+					exporter.name(), event.Type, err)
+				routeEventFailuresCounter.Inc(exporter.name())
+			}
+		}
+	}
+}
+
+// publish queues the event for export. The event is dropped if the queue is full.
+func (d *routeEventDispatcher) publish(event routeEvent) {
+	select {
+	case d.events <- event:
+	default:
+		logger.GlobalLogger.Errorf("Route event queue full, dropping event: %v", event.Type)
+		routeEventsDroppedCounter.Inc()
+	}
+}
+
+// stop exports the queued events and stops the dispatcher.
+func (d *routeEventDispatcher) stop() {
+	close(d.events)
+	<-d.done
+}
+
+// startRouteExporters starts exporting the route events to the sinks configured in the env. Nothing is
+// started if no sink is configured.
+func startRouteExporters() []string {
+	exporters := []routeExporter{}
+	if url := getRouteEventWebhookURL(); url != "" {
+		exporters = append(exporters, newWebhookExporter(url, getRouteEventWebhookRetries(), getRouteEventWebhookTimeout()))
+	}
+	if isKubeEventsEnabled() {
+		if exporter := newKubeEventExporter(); exporter != nil {
+			exporters = append(exporters, exporter)
+		}
+	}
+	if len(exporters) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for _, exporter := range exporters {
+		names = append(names, exporter.name())
+	}
+	logger.GlobalLogger.Infof("Exporting route events to: %v", names)
+	routeEventsMu.Lock()
+	defer routeEventsMu.Unlock()
+	routeEvents = newRouteEventDispatcher(exporters)
+	// The queued events are exported before the sidecar stops.
+	startBackgroundTask("route-events", func(ctx context.Context) {
+		<-ctx.Done()
+		stopRouteExporters()
+	})
+	return names
+}
+
+// stopRouteExporters exports the queued route events and stops exporting the events published later.
+func stopRouteExporters() {
+	routeEventsMu.Lock()
+	d := routeEvents
+	routeEvents = nil
+	routeEventsMu.Unlock()
+	if d != nil {
+		d.stop()
+	}
+}
+
+// publishRouteEvent exports the event to the configured sinks, if any.
+func publishRouteEvent(event routeEvent) {
+	routeEventsMu.Lock()
+	defer routeEventsMu.Unlock()
+	if routeEvents == nil {
+		return
+	}
+	event.Time = time.Now()
+	routeEvents.publish(event)
+}
+
+// publishConnectionEvent exports the addition or removal of a client connection.
+func publishConnectionEvent(eventType string, conn *sidecar.ConnectionInfo) {
+	publishRouteEvent(routeEvent{
+		Type:         eventType,
+		PodName:      conn.GetPodName(),
+		NsmInterface: conn.GetNsmInterface(),
+		NsmIP:        conn.GetNsmIP(),
+	})
+}
+
+// webhookExporter posts every route event as JSON to a URL, retrying failed deliveries.
+type webhookExporter struct {
+	url     string
+	retries int
+	client  *http.Client
+	backoff time.Duration
+}
+
+func newWebhookExporter(url string, retries int, timeout time.Duration) *webhookExporter {
+	return &webhookExporter{
+		url:     url,
+		retries: retries,
+		client:  &http.Client{Timeout: timeout},
+		backoff: routeEventWebhookBackoff,
+	}
+}
+
+func (w *webhookExporter) name() string {
+	return "webhook"
+}
+
+func (w *webhookExporter) export(event routeEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	for attempt := 0; ; attempt++ {
+		err = w.post(body)
+		if err == nil || attempt >= w.retries {
+			return err
+		}
+		logger.GlobalLogger.Debugf("Route event delivery failed, retrying. Attempt: %v, Err: %v", attempt+1, err)
+		time.Sleep(w.backoff * time.Duration(attempt+1))
+	}
+}
+
+func (w *webhookExporter) post(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook returned status %v", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+)
+
+// fakeExporter records the events exported to it.
+type fakeExporter struct {
+	mu     sync.Mutex
+	events []routeEvent
+	err    error
+}
+
+func (f *fakeExporter) name() string {
+	return "fake"
+}
+
+func (f *fakeExporter) export(event routeEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return f.err
+}
+
+func (f *fakeExporter) eventTypes() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	types := []string{}
+	for _, event := range f.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// useFakeExporter exports the route events to a fake sink for the duration of the test. The events
+// are delivered once flush is called.
+func useFakeExporter(t *testing.T, exporter *fakeExporter) (flush func()) {
+	t.Helper()
+	routeEventsMu.Lock()
+	routeEvents = newRouteEventDispatcher([]routeExporter{exporter})
+	routeEventsMu.Unlock()
+	flush = func() {
+		routeEventsMu.Lock()
+		defer routeEventsMu.Unlock()
+		if routeEvents != nil {
+			routeEvents.stop()
+			routeEvents = nil
+		}
+	}
+	t.Cleanup(flush)
+	return flush
+}
+
+func TestRouteEventsExported(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+	skipReconcile(t)
+	useRouteAuditLog(t, &routeAuditLog{})
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1", Alias: "iperf-client"}},
+	}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	useFakeNetlink(t, fake)
+	exporter := &fakeExporter{}
+	flush := useFakeExporter(t, exporter)
+
+	cache := &connectionCache{conns: map[int]*sidecar.ConnectionInfo{}}
+	if err := cache.fill(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"10.1.1.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{}); err != nil {
+		t.Fatal(err)
+	}
+	// A failed injection is exported as a failure.
+	if err := sliceRouterInjectRoute("10.3.0.0/16", []string{"10.9.9.9"}); err == nil {
+		t.Fatal("injection with an unconnected next hop succeeded")
+	}
+	cache.delete(1)
+	flush()
+
+	expected := []string{routeEventConnectionAdded, routeEventRouteAdded, routeEventRouteDeleted, routeEventRouteFailed,
+		routeEventConnectionRemoved}
+	if types := exporter.eventTypes(); !reflect.DeepEqual(types, expected) {
+		t.Fatal("exported events: expected", expected, "received", types)
+	}
+	if event := exporter.events[0]; event.PodName != "iperf-client" || event.NsmIP != "10.1.1.1" {
+		t.Error("connection event: received", event)
+	}
+	if event := exporter.events[1]; event.RemoteSubnet != "10.2.0.0/16" || !reflect.DeepEqual(event.NextHopIPList, []string{"10.1.1.1"}) {
+		t.Error("route event: received", event)
+	}
+}
+
+func TestRouteEventFailuresCounted(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	routeEventFailuresCounter.Reset()
+
+	exporter := &fakeExporter{err: errors.New("unreachable")}
+	flush := useFakeExporter(t, exporter)
+	publishRouteEvent(routeEvent{Type: routeEventRouteAdded, RemoteSubnet: "10.2.0.0/16"})
+	publishRouteEvent(routeEvent{Type: routeEventRouteDeleted, RemoteSubnet: "10.2.0.0/16"})
+	flush()
+
+	if got := routeEventFailuresCounter.Value("fake"); got != 2 {
+		t.Error("delivery failures: expected 2, received", got)
+	}
+}
+
+func TestWebhookExporter(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName string
+		failures int32
+		retries  int
+		attempts int32
+		wantErr  bool
+	}{
+		{"delivered at the first attempt", 0, 2, 1, false},
+		{"delivered after retries", 2, 2, 3, false},
+		{"retries exhausted", 3, 2, 3, true},
+		{"retries disabled", 1, 0, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			var attempts int32
+			var received routeEvent
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			exporter := newWebhookExporter(server.URL, tt.retries, time.Second)
+			exporter.backoff = 0
+			event := routeEvent{Type: routeEventRouteAdded, RemoteSubnet: "10.2.0.0/16", NextHopIPList: []string{"10.1.1.1"}}
+			err := exporter.export(event)
+			if (err != nil) != tt.wantErr {
+				t.Fatal("expected error:", tt.wantErr, "received", err)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.attempts {
+				t.Error("attempts: expected", tt.attempts, "received", got)
+			}
+			if !tt.wantErr && (received.Type != event.Type || received.RemoteSubnet != event.RemoteSubnet) {
+				t.Error("posted event: expected", event, "received", received)
+			}
+		})
+	}
+}
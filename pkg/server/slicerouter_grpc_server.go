@@ -19,6 +19,9 @@ package server
 
 import (
 	"context"
+	"errors"
+	"net"
+	"time"
 
 	"github.com/kubeslice/router-sidecar/pkg/logger"
 	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
@@ -49,9 +52,14 @@ func (s *SliceRouterSidecar) UpdateSliceGwConnectionContext(ctx context.Context,
 	// Note: Do not check for the validity of the conContext.GetLocalNsmGwPeerIPList() here. It is being
 	// done in the sliceRouterInjectRoute func.
 
-	err := sliceRouterInjectRoute(conContext.GetRemoteSliceGwNsmSubnet(), conContext.GetLocalNsmGwPeerIPList())
+	err := sliceRouterInjectRouteWithResolver(routeRequest{
+		ctx:           ctx,
+		remoteSubnet:  conContext.GetRemoteSliceGwNsmSubnet(),
+		nextHopIPList: conContext.GetLocalNsmGwPeerIPList(),
+	}, newNextHopResolver())
 	if err != nil {
 		logger.GlobalLogger.Errorf("Failed to add route in slice router: %v", err)
+		return nil, routeErrorToStatus(err)
 	}
 
 	return &sidecar.SidecarResponse{StatusMsg: "Slice Gw Connection Context Updated Successfully"}, nil
@@ -80,6 +88,28 @@ func (s *SliceRouterSidecar) GetSliceRouterClientConnectionInfo(ctx context.Cont
 	return &clientConnInfo, nil
 }
 
+// GetClientConnection requests the slice router sidecar to send the connection information of a single
+// client pod connected to the slice router.
+func (s *SliceRouterSidecar) GetClientConnection(ctx context.Context, in *sidecar.ClientConnectionRequest) (*sidecar.ConnectionInfo, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if in.GetPodName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Pod Name")
+	}
+
+	connInfo, err := sliceRouterGetClientConnection(in.GetPodName())
+	if errors.Is(err, errConnectionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "No connection found for pod %v", in.GetPodName())
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get client connection: %v", err)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return connInfo, nil
+}
+
 func (s *SliceRouterSidecar) GetRouteInKernel(ctx context.Context, v *sidecar.VerifyRouteAddRequest) (*sidecar.VerifyRouteAddResponse, error) {
 	if ctx.Err() == context.Canceled {
 		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
@@ -97,3 +127,518 @@ func (s *SliceRouterSidecar) GetRouteInKernel(ctx context.Context, v *sidecar.Ve
 	}
 	return &sidecar.VerifyRouteAddResponse{IsRoutePresent: isPresent}, nil
 }
+
+// GetRouteStatus checks if the route to a remote subnet is installed in the dataplane with the expected
+// next hop. Clients use it to verify convergence after updating the slice gw connection context.
+func (s *SliceRouterSidecar) GetRouteStatus(ctx context.Context, req *sidecar.RouteStatusRequest) (*sidecar.RouteStatusResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if req.GetRemoteSubnet() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Remote Subnet")
+	}
+	if _, _, err := net.ParseCIDR(req.GetRemoteSubnet()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Remote Subnet")
+	}
+
+	routeStatus, err := sliceRouterGetRouteStatus(req.GetRemoteSubnet(), req.GetNextHopIP(), req.GetIncludeNeighborState())
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get route status: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get route status: %v", err)
+	}
+
+	return routeStatus, nil
+}
+
+// InjectRoutes injects a batch of remote subnet routes in the slice router. The result of each route is
+// reported individually so that a failing route does not hide the routes that were installed.
+func (s *SliceRouterSidecar) InjectRoutes(ctx context.Context, batch *sidecar.RouteBatch) (*sidecar.RouteBatchResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if len(batch.GetRoutes()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Empty Route Batch")
+	}
+
+	routes := []routeRequest{}
+	for _, route := range batch.GetRoutes() {
+		routes = append(routes, routeRequest{
+			ctx:               ctx,
+			remoteSubnet:      route.GetRemoteSubnet(),
+			nextHopIPList:     route.GetNextHopIPList(),
+			preferredSrc:      route.GetPreferredSrc(),
+			description:       route.GetDescription(),
+			reconcilePriority: route.GetReconcilePriority(),
+			vppAttrs:          vppRouteAttrs{weight: route.GetVppWeight(), preference: route.GetVppPreference()},
+		})
+	}
+
+	if batch.GetAtomic() {
+		err := sliceRouterInjectRoutesAtomic(routes)
+		if err == errAtomicBatchUnsupported {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to add route batch in slice router: %v", err)
+			return nil, routeErrorToStatus(err)
+		}
+		results := []*sidecar.RouteResult{}
+		for _, route := range routes {
+			results = append(results, &sidecar.RouteResult{RemoteSubnet: route.remoteSubnet, IsInstalled: true})
+		}
+		return &sidecar.RouteBatchResponse{Results: results}, nil
+	}
+
+	errs := sliceRouterInjectRoutes(routes)
+
+	results := []*sidecar.RouteResult{}
+	for i, route := range routes {
+		result := &sidecar.RouteResult{RemoteSubnet: route.remoteSubnet, IsInstalled: errs[i] == nil}
+		if errs[i] != nil {
+			logger.GlobalLogger.Errorf("Failed to add route in slice router: %v", errs[i])
+			result.ErrorMsg = errs[i].Error()
+		}
+		results = append(results, result)
+	}
+
+	return &sidecar.RouteBatchResponse{Results: results}, nil
+}
+
+// InjectPolicyRoute installs a route in a custom routing table along with the ip rule steering the
+// traffic from a source prefix into the table. An empty next hop list deletes the route.
+func (s *SliceRouterSidecar) InjectPolicyRoute(ctx context.Context, route *sidecar.PolicyRoute) (*sidecar.SidecarResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if route.GetRemoteSubnet() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Remote Subnet")
+	}
+
+	err := sliceRouterInjectPolicyRoute(route.GetRemoteSubnet(), route.GetNextHopIPList(), route.GetTable(),
+		route.GetSrcPrefix(), route.GetRulePriority())
+	if err == errPolicyRouteUnsupported {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to add policy route in slice router: %v", err)
+		return nil, routeErrorToStatus(err)
+	}
+
+	return &sidecar.SidecarResponse{StatusMsg: "Policy Route Updated Successfully"}, nil
+}
+
+// ReconcileNow reconciles the routing table with the injected routes without waiting for the reconcile
+// interval. Only the routes to remote subnets within the requested prefixes are reconciled, if any.
+func (s *SliceRouterSidecar) ReconcileNow(ctx context.Context, req *sidecar.ReconcileRequest) (*sidecar.SidecarResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	filter, err := newPrefixFilter(req.GetPrefixes())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	err = sliceRouterReconcileRoutingTable(ctx, filter)
+	if err == errReconcileInProgress {
+		return nil, status.Errorf(codes.Aborted, "%v", err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to reconcile routing table: %v", err)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &sidecar.SidecarResponse{StatusMsg: "Routing Table Reconciled Successfully"}, nil
+}
+
+// GetReconcileStatus provides when the routing table was last reconciled and when it will next be.
+func (s *SliceRouterSidecar) GetReconcileStatus(ctx context.Context, in *emptypb.Empty) (*sidecar.ReconcileStatus, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	return sliceRouterGetReconcileStatus(), nil
+}
+
+// RefreshNextHops enumerates the client connections again and reprograms the routes with pod next hops
+// whose pods are no longer at the installed next hop IPs, so that a controller knowing a slice gw
+// re-connected does not have to wait for the reconcile.
+func (s *SliceRouterSidecar) RefreshNextHops(ctx context.Context, in *emptypb.Empty) (*sidecar.RefreshNextHopsResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	refreshed, failed, err := sliceRouterRefreshPodNextHops()
+	if err == errReconcileInProgress {
+		return nil, status.Errorf(codes.Aborted, "%v", err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to refresh next hops: %v", err)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &sidecar.RefreshNextHopsResponse{RefreshedSubnets: refreshed, FailedSubnets: failed}, nil
+}
+
+// RescanClientConnections sends the client connections derived from the dataplane again, bypassing the
+// connection cache, and refreshes the cache.
+func (s *SliceRouterSidecar) RescanClientConnections(ctx context.Context, in *emptypb.Empty) (*sidecar.ClientConnectionInfo, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	connInfo, err := sliceRouterRescanClientConnections()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to rescan client connections: %v", err)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &sidecar.ClientConnectionInfo{Connection: connInfo}, nil
+}
+
+// InjectAggregateRoute injects the routes to many remote subnets with the same next hops as the fewest
+// aggregate routes covering them.
+func (s *SliceRouterSidecar) InjectAggregateRoute(ctx context.Context, req *sidecar.AggregateRouteRequest) (*sidecar.AggregateRouteResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	aggregates, err := sliceRouterInjectAggregateRoute(req.GetSubnets(), req.GetNextHopIPList())
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to inject aggregate route: %v", err)
+		return nil, routeErrorToStatus(err)
+	}
+
+	return &sidecar.AggregateRouteResponse{Aggregates: aggregates}, nil
+}
+
+// InjectRouteGroup injects the routes of a named group of remote subnets sharing their next hops as one
+// unit, so that a reconcile never finds the group half configured.
+func (s *SliceRouterSidecar) InjectRouteGroup(ctx context.Context, req *sidecar.RouteGroupRequest) (*sidecar.RouteGroupResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	members, deleted, err := sliceRouterInjectRouteGroup(ctx, req.GetName(), req.GetRemoteSubnets(), req.GetNextHopIPList())
+	if err == errAtomicBatchUnsupported {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to inject route group %v: %v", req.GetName(), err)
+		return nil, routeErrorToStatus(err)
+	}
+
+	return &sidecar.RouteGroupResponse{Name: req.GetName(), RemoteSubnets: members, DeletedSubnets: deleted}, nil
+}
+
+// ProbeNextHop probes the reachability of a next hop from the slice router, so that operators can tell
+// whether a route that does not forward is let down by its next hop.
+func (s *SliceRouterSidecar) ProbeNextHop(ctx context.Context, req *sidecar.ProbeNextHopRequest) (*sidecar.ProbeNextHopResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	nextHopIP := net.ParseIP(req.GetNextHop())
+	if nextHopIP == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Next Hop")
+	}
+	method, ok := getProbeMethod(req.GetMethod())
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Probe Method %q", req.GetMethod())
+	}
+	timeout := getNextHopHealthCheckTimeout()
+	if req.GetTimeout() != nil {
+		timeout = req.GetTimeout().AsDuration()
+		if timeout <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid Probe Timeout")
+		}
+	}
+	// The probe does not outlive the request.
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+
+	result, err := sliceRouterProbeNextHop(nextHopIP, method, timeout)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to probe next hop: %v", err)
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return result, nil
+}
+
+// EnsureRoutes brings the slice router routes to the desired set. Routes injected by the sidecar that
+// are not in the set are removed, and requests older than the last applied generation are ignored.
+func (s *SliceRouterSidecar) EnsureRoutes(ctx context.Context, req *sidecar.EnsureRoutesRequest) (*sidecar.EnsureRoutesResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	routes := []routeRequest{}
+	for _, route := range req.GetRoutes() {
+		routes = append(routes, routeRequest{
+			ctx:               ctx,
+			remoteSubnet:      route.GetRemoteSubnet(),
+			nextHopIPList:     route.GetNextHopIPList(),
+			preferredSrc:      route.GetPreferredSrc(),
+			description:       route.GetDescription(),
+			reconcilePriority: route.GetReconcilePriority(),
+			vppAttrs:          vppRouteAttrs{weight: route.GetVppWeight(), preference: route.GetVppPreference()},
+		})
+	}
+
+	result, err := sliceRouterEnsureRoutes(ctx, routes, req.GetGeneration())
+	if err != nil {
+		return nil, routeErrorToStatus(err)
+	}
+
+	newRouteResult := func(remoteSubnet string, err error) *sidecar.RouteResult {
+		routeResult := &sidecar.RouteResult{RemoteSubnet: remoteSubnet, IsInstalled: err == nil}
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to ensure route in slice router: %v", err)
+			routeResult.ErrorMsg = err.Error()
+		}
+		return routeResult
+	}
+	response := &sidecar.EnsureRoutesResponse{AppliedGeneration: result.generation, Stale: result.stale}
+	if result.stale {
+		return response, nil
+	}
+	for i, route := range routes {
+		response.Results = append(response.Results, newRouteResult(route.remoteSubnet, result.errs[i]))
+	}
+	for i, remoteSubnet := range result.removed {
+		response.Results = append(response.Results, newRouteResult(remoteSubnet, result.removedErr[i]))
+	}
+
+	return response, nil
+}
+
+// SyncRoutes converges the slice router routes to the desired set and returns the change made to each
+// route. Unlike EnsureRoutes, the routes are applied right away and the unchanged routes are checked for
+// drift in the dataplane.
+func (s *SliceRouterSidecar) SyncRoutes(ctx context.Context, req *sidecar.SyncRoutesRequest) (*sidecar.SyncRoutesResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	routes := []routeRequest{}
+	for _, route := range req.GetRoutes() {
+		routes = append(routes, routeRequest{
+			ctx:               ctx,
+			remoteSubnet:      route.GetRemoteSubnet(),
+			nextHopIPList:     route.GetNextHopIPList(),
+			preferredSrc:      route.GetPreferredSrc(),
+			description:       route.GetDescription(),
+			reconcilePriority: route.GetReconcilePriority(),
+			vppAttrs:          vppRouteAttrs{weight: route.GetVppWeight(), preference: route.GetVppPreference()},
+		})
+	}
+
+	results, err := sliceRouterSyncRoutes(ctx, routes)
+	if err != nil {
+		return nil, routeErrorToStatus(err)
+	}
+
+	response := &sidecar.SyncRoutesResponse{}
+	for _, result := range results {
+		routeResult := &sidecar.RouteSyncResult{
+			RemoteSubnet:          result.remoteSubnet,
+			Action:                result.action,
+			IsInstalled:           result.err == nil,
+			PreviousNextHopIPList: result.previous,
+			NextHopIPList:         result.nextHops,
+			Correction:            result.correction,
+		}
+		if result.err != nil {
+			logger.GlobalLogger.Errorf("Failed to sync route in slice router: %v", result.err)
+			routeResult.ErrorMsg = result.err.Error()
+		}
+		response.Results = append(response.Results, routeResult)
+	}
+
+	return response, nil
+}
+
+// CleanupVppRoute converges the vpp route entries of a prefix to the next hops of its route, deleting
+// the stale equal cost members left behind by next hop changes. The reconcile does the same for all the
+// routes, the RPC is for manual cleanup.
+func (s *SliceRouterSidecar) CleanupVppRoute(ctx context.Context, req *sidecar.VppRouteCleanupRequest) (*sidecar.VppRouteCleanupResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if getSliceRouterDataplaneMode() != SliceRouterDataplaneVpp {
+		return nil, status.Errorf(codes.FailedPrecondition, "Slice router dataplane is not vpp")
+	}
+	_, remoteNet, err := net.ParseCIDR(req.GetRemoteSubnet())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Remote Subnet %q: %v", req.GetRemoteSubnet(), err)
+	}
+	// The route is recorded under the canonical form of its subnet.
+	remoteSubnet := remoteNet.String()
+	if isRouteSuspended(remoteSubnet) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Route to %v is suspended", remoteSubnet)
+	}
+
+	config, err := getVppRoutesConfig()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get vpp config: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+	cleanup, err := sliceRouterConvergeVppRoute(ctx, remoteSubnet, config)
+	if len(cleanup.removed) > 0 {
+		routeAudit.recordCorrection(remoteSubnet, desiredVppNextHops(remoteSubnet), routeCorrectionStale, err)
+	} else if len(cleanup.added) > 0 {
+		routeAudit.recordCorrection(remoteSubnet, desiredVppNextHops(remoteSubnet), routeCorrectionMissing, err)
+	} else if len(cleanup.updated) > 0 {
+		routeAudit.recordCorrection(remoteSubnet, desiredVppNextHops(remoteSubnet), routeCorrectionWeight, err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to converge vpp route entries: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	return &sidecar.VppRouteCleanupResponse{
+		RemoteSubnet:         remoteSubnet,
+		KeptNextHopIPList:    cleanup.kept,
+		RemovedNextHopIPList: cleanup.removed,
+		AddedNextHopIPList:   cleanup.added,
+		UpdatedNextHopIPList: cleanup.updated,
+	}, nil
+}
+
+// GetVppConfigSummary provides a summary of the config reported by the vpp agent so that operators can
+// detect unexpected changes in the vpp state.
+func (s *SliceRouterSidecar) GetVppConfigSummary(ctx context.Context, in *emptypb.Empty) (*sidecar.VppConfigSummary, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if getSliceRouterDataplaneMode() != SliceRouterDataplaneVpp {
+		return nil, status.Errorf(codes.FailedPrecondition, "Slice router dataplane is not vpp")
+	}
+
+	summary, err := sliceRouterGetVppConfigSummary()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	return summary, nil
+}
+
+// GetRoutesByInterface provides the remote subnets routed via each nsm interface of the slice router.
+func (s *SliceRouterSidecar) GetRoutesByInterface(ctx context.Context, in *emptypb.Empty) (*sidecar.InterfaceRouteList, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	intfRoutes, err := sliceRouterGetRoutesByInterface()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get routes by interface: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	return &sidecar.InterfaceRouteList{Interfaces: intfRoutes}, nil
+}
+
+// GetRouteStatistics provides the traffic counters of the egress interfaces of each slice route, an
+// approximation of the traffic of the routes as the kernel does not count traffic per route.
+func (s *SliceRouterSidecar) GetRouteStatistics(ctx context.Context, in *emptypb.Empty) (*sidecar.RouteStatisticsList, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if getSliceRouterDataplaneMode() != SliceRouterDataplaneKernel {
+		return nil, status.Errorf(codes.FailedPrecondition, "Slice router dataplane is not kernel")
+	}
+
+	routeStats, err := sliceRouterGetRouteStatistics()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get route statistics: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	return &sidecar.RouteStatisticsList{Routes: routeStats}, nil
+}
+
+// GetCapabilities provides the features supported by the slice router with its dataplane mode and
+// feature flags, for clients talking to sidecars running different dataplanes.
+func (s *SliceRouterSidecar) GetCapabilities(ctx context.Context, in *emptypb.Empty) (*sidecar.Capabilities, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	return sliceRouterGetCapabilities(), nil
+}
+
+// GetKernelRoutes provides the routes of a kernel routing table exactly as listed by netlink, including
+// the fields the sidecar does not interpret, to diagnose why the reconcile sees a route as drifted.
+func (s *SliceRouterSidecar) GetKernelRoutes(ctx context.Context, req *sidecar.KernelRoutesRequest) (*sidecar.KernelRouteList, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+	if getSliceRouterDataplaneMode() != SliceRouterDataplaneKernel {
+		return nil, status.Errorf(codes.FailedPrecondition, "Slice router dataplane is not kernel")
+	}
+
+	routes, err := sliceRouterGetKernelRoutes(int(req.GetTable()))
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to list kernel routes: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	return &sidecar.KernelRouteList{Routes: routes}, nil
+}
+
+// GetLogLevel provides the level the sidecar logs messages from.
+func (s *SliceRouterSidecar) GetLogLevel(ctx context.Context, in *emptypb.Empty) (*sidecar.LogLevel, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	return &sidecar.LogLevel{Level: logger.GlobalLogger.Level()}, nil
+}
+
+// SetLogLevel changes the level the sidecar logs messages from, so that a debug trace can be captured
+// without restarting the sidecar. The LOG_LEVEL env variable applies again after a restart.
+func (s *SliceRouterSidecar) SetLogLevel(ctx context.Context, req *sidecar.LogLevel) (*sidecar.LogLevel, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	previous := logger.GlobalLogger.Level()
+	if err := logger.GlobalLogger.SetLevel(req.GetLevel()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Log Level %q", req.GetLevel())
+	}
+	level := logger.GlobalLogger.Level()
+	// Logged as a warning so that the change shows at all the usual levels.
+	logger.GlobalLogger.Warnf("Log level changed from %v to %v", previous, level)
+	return &sidecar.LogLevel{Level: level}, nil
+}
+
+// GetSupportBundle collects the state of the sidecar for support bundles.
+func (s *SliceRouterSidecar) GetSupportBundle(ctx context.Context, in *emptypb.Empty) (*sidecar.SupportBundle, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	return sliceRouterGetSupportBundle(), nil
+}
+
+// GetDataplaneConnections provides the client connections seen by both the kernel and vpp dataplanes,
+// whatever the dataplane mode. It is meant for validating migrations between dataplanes.
+func (s *SliceRouterSidecar) GetDataplaneConnections(ctx context.Context, in *emptypb.Empty) (*sidecar.DataplaneConnections, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	return sliceRouterGetDataplaneConnections(), nil
+}
+
+// GetRouteAuditLog provides the recent route operations performed on the slice router dataplane.
+func (s *SliceRouterSidecar) GetRouteAuditLog(ctx context.Context, in *emptypb.Empty) (*sidecar.RouteAuditLog, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	return &sidecar.RouteAuditLog{Entries: routeAudit.list()}, nil
+}
+
+// GetRouteTable provides the routes injected in the slice router along with the health of their next hops.
+func (s *SliceRouterSidecar) GetRouteTable(ctx context.Context, in *emptypb.Empty) (*sidecar.RouteTable, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Errorf(codes.Canceled, "Client cancelled, abandoning.")
+	}
+
+	return sliceRouterGetRouteTable(), nil
+}
@@ -0,0 +1,403 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+const (
+	nextHopHealthCheckNone = ""
+	nextHopHealthCheckTCP  = "tcp"
+	nextHopHealthCheckICMP = "icmp"
+
+	defaultNextHopHealthCheckInterval  = 5 * time.Second
+	defaultNextHopHealthCheckTimeout   = time.Second
+	defaultNextHopHealthCheckThreshold = 3
+)
+
+// nextHopHealthState is the health of a single next hop.
+type nextHopHealthState struct {
+	healthy  bool
+	failures int
+}
+
+// nextHopHealthTracker records the health of the next hops probed by the health checker. Next hops
+// that were never probed are considered healthy.
+type nextHopHealthTracker struct {
+	mu    sync.Mutex
+	state map[string]*nextHopHealthState
+}
+
+var nextHopHealth = &nextHopHealthTracker{state: map[string]*nextHopHealthState{}}
+
+// getNextHopHealthCheckMode returns the probe used to check the health of next hops, read from the
+// NEXTHOP_HEALTH_CHECK env variable. Health checking is disabled if it is not set.
+func getNextHopHealthCheckMode() string {
+	return os.Getenv("NEXTHOP_HEALTH_CHECK")
+}
+
+// getNextHopHealthCheckPort returns the port probed by the tcp health check, read from the
+// NEXTHOP_HEALTH_CHECK_PORT env variable.
+func getNextHopHealthCheckPort() string {
+	return os.Getenv("NEXTHOP_HEALTH_CHECK_PORT")
+}
+
+// checkNextHopHealthCheckConfig returns an error if the health check mode is unknown or if the tcp
+// health check has no valid port to probe. Every probe would fail and mark every next hop down.
+func checkNextHopHealthCheckConfig() error {
+	switch mode := getNextHopHealthCheckMode(); mode {
+	case nextHopHealthCheckNone, nextHopHealthCheckICMP:
+		return nil
+	case nextHopHealthCheckTCP:
+		port, err := strconv.Atoi(getNextHopHealthCheckPort())
+		if err != nil || port <= 0 || port > 65535 {
+			return fmt.Errorf("invalid NEXTHOP_HEALTH_CHECK_PORT %q for the tcp health check", getNextHopHealthCheckPort())
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown NEXTHOP_HEALTH_CHECK %q, expected %q or %q", mode, nextHopHealthCheckTCP, nextHopHealthCheckICMP)
+	}
+}
+
+func getNextHopHealthCheckInterval() time.Duration {
+	return getEnvDuration("NEXTHOP_HEALTH_CHECK_INTERVAL", defaultNextHopHealthCheckInterval)
+}
+
+func getNextHopHealthCheckTimeout() time.Duration {
+	return getEnvDuration("NEXTHOP_HEALTH_CHECK_TIMEOUT", defaultNextHopHealthCheckTimeout)
+}
+
+// getNextHopHealthCheckThreshold returns the number of consecutive failed probes after which a next
+// hop is considered down.
+func getNextHopHealthCheckThreshold() int {
+	threshold, err := strconv.Atoi(os.Getenv("NEXTHOP_HEALTH_CHECK_FAILURES"))
+	if err != nil || threshold <= 0 {
+		return defaultNextHopHealthCheckThreshold
+	}
+	return threshold
+}
+
+// getEnvDuration returns the duration in an env variable, or def if it is not set or invalid.
+func getEnvDuration(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		logger.GlobalLogger.Errorf("Invalid duration %v=%q, using default %v", key, value, def)
+		return def
+	}
+	return duration
+}
+
+// probeNextHop checks if the next hop is reachable. It is a variable so that tests can fake probe results.
+var probeNextHop = func(mode string, nextHopIP string, timeout time.Duration) error {
+	switch mode {
+	case nextHopHealthCheckTCP:
+		return probeTCP(nextHopIP, getNextHopHealthCheckPort(), timeout)
+	case nextHopHealthCheckICMP:
+		return probeICMP(nextHopIP, timeout)
+	}
+	return fmt.Errorf("unknown health check %q", mode)
+}
+
+// probeTCP checks if a TCP connection can be opened to the next hop.
+func probeTCP(nextHopIP string, port string, timeout time.Duration) error {
+	if port == "" {
+		return errors.New("tcp health check port is not configured")
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(nextHopIP, port), timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// icmpEcho returns the network of the ICMP probe of the next hop along with the types of its echo
+// request and reply, the ICMPv6 ones for IPv6 next hops.
+func icmpEcho(nextHop net.IP) (string, byte, byte) {
+	if nextHop.To4() == nil {
+		return "ip6:ipv6-icmp", 128, 129
+	}
+	return "ip4:icmp", 8, 0
+}
+
+// icmpEchoSeq numbers the ICMP echo requests of the process. The identifier of the requests is the pid,
+// the sequence number tells apart the replies of concurrent probes.
+var icmpEchoSeq atomic.Uint32
+
+// isICMPEchoReply returns true if the ICMP message is the echo reply with the identifier and sequence
+// number of the request.
+func isICMPEchoReply(message []byte, replyType byte, id int, seq uint16) bool {
+	return len(message) >= 8 && message[0] == replyType && int(message[4])<<8|int(message[5]) == id &&
+		uint16(message[6])<<8|uint16(message[7]) == seq
+}
+
+// probeICMP sends an ICMP echo request to the next hop and waits for the reply.
+func probeICMP(nextHopIP string, timeout time.Duration) error {
+	ip := net.ParseIP(nextHopIP)
+	if ip == nil {
+		return fmt.Errorf("invalid next hop %q", nextHopIP)
+	}
+	network, requestType, replyType := icmpEcho(ip)
+	conn, err := net.DialTimeout(network, nextHopIP, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	id := os.Getpid() & 0xffff
+	seq := uint16(icmpEchoSeq.Add(1))
+	echo := []byte{requestType, 0, 0, 0, byte(id >> 8), byte(id), byte(seq >> 8), byte(seq)}
+	// The kernel computes the checksum of ICMPv6 messages, which covers the IPv6 pseudo header.
+	if requestType == 8 {
+		checksum := icmpChecksum(echo)
+		echo[2], echo[3] = byte(checksum>>8), byte(checksum)
+	}
+	if _, err := conn.Write(echo); err != nil {
+		return err
+	}
+
+	reply := make([]byte, 1500)
+	for {
+		n, _, err := conn.(*net.IPConn).ReadFrom(reply)
+		if err != nil {
+			return err
+		}
+		if isICMPEchoReply(reply[:n], replyType, id, seq) {
+			return nil
+		}
+	}
+}
+
+func icmpChecksum(b []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+// update records the result of a probe and returns true if the health of the next hop changed.
+func (t *nextHopHealthTracker) update(nextHopIP string, probeErr error, threshold int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.state[nextHopIP]
+	if !ok {
+		state = &nextHopHealthState{healthy: true}
+		t.state[nextHopIP] = state
+	}
+	changed := false
+	if probeErr == nil {
+		state.failures = 0
+		changed = !state.healthy
+		state.healthy = true
+	} else {
+		state.failures++
+		if state.healthy && state.failures >= threshold {
+			state.healthy = false
+			changed = true
+		}
+	}
+	if state.healthy {
+		nextHopHealthGauge.Set(1, nextHopIP)
+	} else {
+		nextHopHealthGauge.Set(0, nextHopIP)
+	}
+	return changed
+}
+
+// health returns the health of the next hop as reported to clients.
+func (t *nextHopHealthTracker) health(nextHopIP string) sidecar.NextHopHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.state[nextHopIP]
+	if !ok {
+		return sidecar.NextHopHealth_NEXTHOP_HEALTH_UNKNOWN
+	}
+	if state.healthy {
+		return sidecar.NextHopHealth_NEXTHOP_HEALTHY
+	}
+	return sidecar.NextHopHealth_NEXTHOP_UNHEALTHY
+}
+
+func (t *nextHopHealthTracker) isHealthy(nextHopIP string) bool {
+	return t.health(nextHopIP) != sidecar.NextHopHealth_NEXTHOP_UNHEALTHY
+}
+
+// prune forgets the next hops that are no longer used by any route.
+func (t *nextHopHealthTracker) prune(active map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for nextHopIP := range t.state {
+		if !active[nextHopIP] {
+			delete(t.state, nextHopIP)
+			nextHopHealthGauge.Delete(nextHopIP)
+		}
+	}
+}
+
+// healthyNextHops returns the next hops that are not known to be down. If all of them are down the
+// full list is returned, since removing every next hop would delete the route altogether.
+func healthyNextHops(nextHopIPList []string) []string {
+	healthy := []string{}
+	for _, nextHopIP := range nextHopIPList {
+		if nextHopHealth.isHealthy(nextHopIP) {
+			healthy = append(healthy, nextHopIP)
+		}
+	}
+	if len(healthy) == 0 {
+		return nextHopIPList
+	}
+	return healthy
+}
+
+// sliceRouterCheckNextHopHealth probes every next hop used by the slice routes and re-programs the
+// routes of the next hops whose health changed, so that only healthy next hops are ECMP members.
+func sliceRouterCheckNextHopHealth() {
+	mode := getNextHopHealthCheckMode()
+	timeout := getNextHopHealthCheckTimeout()
+	threshold := getNextHopHealthCheckThreshold()
+
+	routes := map[string][]string{}
+	active := map[string]bool{}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		routes[key.(string)] = value.([]string)
+		for _, nextHopIP := range value.([]string) {
+			active[nextHopIP] = true
+		}
+		return true
+	})
+	nextHopHealth.prune(active)
+
+	changed := map[string]bool{}
+	for nextHopIP := range active {
+		// Interface next hops have no address to probe, pod next hops are resolved when programmed.
+		if _, ok := parseDevNextHop(nextHopIP); ok {
+			continue
+		}
+		if _, ok := parsePodNextHop(nextHopIP); ok {
+			continue
+		}
+		err := probeNextHop(mode, nextHopIP, timeout)
+		if nextHopHealth.update(nextHopIP, err, threshold) {
+			logger.GlobalLogger.Infof("Next hop health changed. NextHop: %v, Healthy: %v, Err: %v",
+				nextHopIP, err == nil, err)
+			changed[nextHopIP] = true
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	remoteSubnets := []string{}
+	for remoteSubnet := range routes {
+		remoteSubnets = append(remoteSubnets, remoteSubnet)
+	}
+	sort.Strings(remoteSubnets)
+	for _, remoteSubnet := range remoteSubnets {
+		for _, nextHopIP := range routes[remoteSubnet] {
+			if changed[nextHopIP] {
+				err := sliceRouterProgramHealthyNextHops(remoteSubnet, routes[remoteSubnet])
+				if err != nil {
+					logger.GlobalLogger.Errorf("Failed to update ECMP members. RemoteSubnet: %v, Err: %v", remoteSubnet, err)
+				}
+				break
+			}
+		}
+	}
+}
+
+// sliceRouterProgramHealthyNextHops programs the route to the remote subnet in the dataplane with the
+// healthy subset of its next hops. If they are all down, the next hop down policy decides whether the
+// route is kept, blackholed or deleted, the route is restored once a next hop recovers.
+func sliceRouterProgramHealthyNextHops(remoteSubnet string, nextHopIPList []string) error {
+	if policy := getNextHopDownPolicy(); policy != nextHopDownPolicyKeep && allNextHopsDown(nextHopIPList) {
+		return sliceRouterSuspendRoute(remoteSubnet, nextHopIPList, policy)
+	}
+	if err := sliceRouterResumeRoute(remoteSubnet); err != nil {
+		return err
+	}
+	programmedNextHops := healthyNextHops(nextHopIPList)
+	logger.GlobalLogger.Infof("Updating ECMP members. RemoteSubnet: %v, NextHops: %v", remoteSubnet, programmedNextHops)
+
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		// Every next hop is a separate route entry in vpp.
+		for _, nextHopIP := range nextHopIPList {
+			var err error
+			if contains(programmedNextHops, nextHopIP) {
+				err = vl3InjectRouteInVpp(context.Background(), remoteSubnet, nextHopIP, loadVppRouteAttrs(remoteSubnet))
+			} else {
+				err = vl3DeleteRouteInVpp(context.Background(), remoteSubnet, nextHopIP, vppRouteDeleteUnhealthy)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	resolvedNextHops, err := resolvePodNextHops(remoteSubnet, programmedNextHops)
+	if err != nil {
+		return err
+	}
+	netlinkNextHopList, err := getNetlinkNextHopInfo(resolvedNextHops)
+	if err != nil {
+		return err
+	}
+	return vl3InjectRouteInKernel(remoteSubnet, netlinkNextHopList)
+}
+
+// nextHopHealthCheckLoop runs the next hop health checker periodically until ctx is done.
+func nextHopHealthCheckLoop(ctx context.Context) {
+	interval := getNextHopHealthCheckInterval()
+	logger.GlobalLogger.Infof("Starting next hop health checker. Probe: %v, Interval: %v", getNextHopHealthCheckMode(), interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sliceRouterCheckNextHopHealth()
+		}
+	}
+}
@@ -0,0 +1,272 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// useFakeProbe makes the health checker fail the probes of the next hops in down.
+func useFakeProbe(t *testing.T, down map[string]bool) {
+	t.Helper()
+	saved := probeNextHop
+	probeNextHop = func(mode string, nextHopIP string, timeout time.Duration) error {
+		if down[nextHopIP] {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+	t.Cleanup(func() {
+		probeNextHop = saved
+		nextHopHealth.prune(map[string]bool{})
+	})
+}
+
+func installedNextHops(t *testing.T, fake *fakeNetlink, remoteSubnet string) []string {
+	t.Helper()
+	routes, err := fake.RouteList(nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return getRouteNextHops(routes, remoteSubnet)
+}
+
+func TestSliceRouterCheckNextHopHealth(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("NEXTHOP_HEALTH_CHECK", nextHopHealthCheckTCP)
+	t.Setenv("NEXTHOP_HEALTH_CHECK_FAILURES", "2")
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+	down := map[string]bool{}
+	useFakeProbe(t, down)
+
+	remoteSubnet := "10.1.0.0/16"
+	if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2", "192.168.0.6"}); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		testName  string
+		down      []string
+		installed []string
+		healthy   float64
+	}{
+		{"all next hops healthy", nil, []string{"192.168.0.2", "192.168.0.6"}, 1},
+		{"single failure is below the threshold", []string{"192.168.0.6"}, []string{"192.168.0.2", "192.168.0.6"}, 1},
+		{"unhealthy next hop is removed", []string{"192.168.0.6"}, []string{"192.168.0.2"}, 0},
+		{"recovered next hop is added back", nil, []string{"192.168.0.2", "192.168.0.6"}, 1},
+	}
+
+	for _, step := range steps {
+		t.Run(step.testName, func(t *testing.T) {
+			for k := range down {
+				delete(down, k)
+			}
+			for _, nextHopIP := range step.down {
+				down[nextHopIP] = true
+			}
+			sliceRouterCheckNextHopHealth()
+
+			installed := installedNextHops(t, fake, remoteSubnet)
+			if !sameNextHops(installed, step.installed) {
+				t.Error("installed next hops: expected", step.installed, "received", installed)
+			}
+			if got := nextHopHealthGauge.Value("192.168.0.6"); got != step.healthy {
+				t.Error("health gauge: expected", step.healthy, "received", got)
+			}
+			cached, _ := remoteSubnetRouteMap.Load(remoteSubnet)
+			if !sameNextHops(cached.([]string), []string{"192.168.0.2", "192.168.0.6"}) {
+				t.Error("requested next hops should not change, received", cached)
+			}
+		})
+	}
+}
+
+func TestHealthyNextHops(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	useFakeProbe(t, nil)
+	nextHopHealth.update("192.168.0.2", errors.New("unreachable"), 1)
+	nextHopHealth.update("192.168.0.6", errors.New("unreachable"), 1)
+	nextHopHealth.update("192.168.0.10", nil, 1)
+
+	tests := []struct {
+		testName string
+		input    []string
+		expected []string
+	}{
+		{"unhealthy next hops are dropped", []string{"192.168.0.2", "192.168.0.10"}, []string{"192.168.0.10"}},
+		{"unknown next hops are kept", []string{"192.168.0.2", "192.168.0.14"}, []string{"192.168.0.14"}},
+		{"all next hops unhealthy", []string{"192.168.0.2", "192.168.0.6"}, []string{"192.168.0.2", "192.168.0.6"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := healthyNextHops(tt.input); !sameNextHops(got, tt.expected) {
+				t.Error("expected", tt.expected, "received", got)
+			}
+		})
+	}
+}
+
+func TestGetRouteTable(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	resetRouteMap(t)
+	useFakeProbe(t, nil)
+	remoteSubnetRouteMap.Store("10.2.0.0/16", []string{"192.168.0.6"})
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2", "192.168.0.10"})
+	nextHopHealth.update("192.168.0.2", nil, 1)
+	nextHopHealth.update("192.168.0.10", errors.New("unreachable"), 1)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+	response, err := client.GetRouteTable(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		remoteSubnet string
+		health       map[string]pb.NextHopHealth
+	}{
+		{"10.1.0.0/16", map[string]pb.NextHopHealth{
+			"192.168.0.2":  pb.NextHopHealth_NEXTHOP_HEALTHY,
+			"192.168.0.10": pb.NextHopHealth_NEXTHOP_UNHEALTHY,
+		}},
+		{"10.2.0.0/16", map[string]pb.NextHopHealth{
+			"192.168.0.6": pb.NextHopHealth_NEXTHOP_HEALTH_UNKNOWN,
+		}},
+	}
+	if len(response.GetRoutes()) != len(expected) {
+		t.Fatal("routes: expected", len(expected), "received", response.GetRoutes())
+	}
+	for i, route := range response.GetRoutes() {
+		if route.GetRemoteSubnet() != expected[i].remoteSubnet {
+			t.Error("remote subnet: expected", expected[i].remoteSubnet, "received", route.GetRemoteSubnet())
+		}
+		if len(route.GetNextHopStatus()) != len(expected[i].health) {
+			t.Error("next hop status: expected", expected[i].health, "received", route.GetNextHopStatus())
+		}
+		for _, nextHop := range route.GetNextHopStatus() {
+			if nextHop.GetHealth() != expected[i].health[nextHop.GetNextHopIP()] {
+				t.Error(nextHop.GetNextHopIP(), "health: expected", expected[i].health[nextHop.GetNextHopIP()], "received", nextHop.GetHealth())
+			}
+		}
+	}
+}
+
+func TestICMPEcho(t *testing.T) {
+	tests := []struct {
+		nextHop string
+		network string
+		request byte
+		reply   byte
+	}{
+		{"192.168.0.2", "ip4:icmp", 8, 0},
+		{"fd00::2", "ip6:ipv6-icmp", 128, 129},
+		{"::ffff:192.168.0.2", "ip4:icmp", 8, 0},
+	}
+	for _, tt := range tests {
+		network, request, reply := icmpEcho(net.ParseIP(tt.nextHop))
+		if network != tt.network || request != tt.request || reply != tt.reply {
+			t.Error("echo of", tt.nextHop, ": expected", tt.network, tt.request, tt.reply, "received", network, request, reply)
+		}
+	}
+}
+
+func TestCheckNextHopHealthCheckConfig(t *testing.T) {
+	tests := []struct {
+		mode  string
+		port  string
+		valid bool
+	}{
+		{"", "", true},
+		{nextHopHealthCheckICMP, "", true},
+		{nextHopHealthCheckTCP, "8080", true},
+		{nextHopHealthCheckTCP, "", false},
+		{nextHopHealthCheckTCP, "http", false},
+		{nextHopHealthCheckTCP, "70000", false},
+		{"ICMP", "", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("NEXTHOP_HEALTH_CHECK", tt.mode)
+		t.Setenv("NEXTHOP_HEALTH_CHECK_PORT", tt.port)
+		if err := checkNextHopHealthCheckConfig(); (err == nil) != tt.valid {
+			t.Errorf("mode %q port %q: expected valid %v, received %v", tt.mode, tt.port, tt.valid, err)
+		}
+	}
+}
+
+func TestIsICMPEchoReply(t *testing.T) {
+	reply := []byte{0, 0, 0, 0, 0x12, 0x34, 0x00, 0x07}
+	tests := []struct {
+		testName  string
+		replyType byte
+		id        int
+		seq       uint16
+		expected  bool
+	}{
+		{"reply to the probe", 0, 0x1234, 7, true},
+		{"reply to another probe of the process", 0, 0x1234, 8, false},
+		{"reply to another process", 0, 0x4321, 7, false},
+		{"not a reply", 129, 0x1234, 7, false},
+	}
+	for _, tt := range tests {
+		if isICMPEchoReply(reply, tt.replyType, tt.id, tt.seq) != tt.expected {
+			t.Error(tt.testName, ": expected", tt.expected)
+		}
+	}
+	if isICMPEchoReply(reply[:6], 0, 0x1234, 7) {
+		t.Error("truncated message accepted as a reply")
+	}
+}
+
+func TestProbeICMPLoopback(t *testing.T) {
+	for _, nextHop := range []string{"127.0.0.1", "::1"} {
+		t.Run(nextHop, func(t *testing.T) {
+			err := probeICMP(nextHop, time.Second)
+			if errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EACCES) || errors.Is(err, syscall.EAFNOSUPPORT) {
+				t.Skip("raw sockets not available:", err)
+			}
+			if err != nil {
+				t.Error("probe of", nextHop, "failed:", err)
+			}
+		})
+	}
+}
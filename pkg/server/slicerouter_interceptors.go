@@ -0,0 +1,85 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerOptions returns the options the sidecar GRPC server is created with. Requests are logged and
+// counted with their duration and status code, traced if tracing is on, and a panicking handler fails
+// the request instead of the sidecar.
+func ServerOptions() []grpc.ServerOption {
+	// The logging interceptor wraps the recovery interceptor so that it logs and counts the status of
+	// the recovered requests, and the tracing interceptor spans both.
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(tracingUnaryInterceptor, loggingUnaryInterceptor, recoveryUnaryInterceptor),
+		grpc.ChainStreamInterceptor(loggingStreamInterceptor, recoveryStreamInterceptor),
+	}
+}
+
+// recoverHandlerPanic converts a handler panic into an Internal error. It must be deferred.
+func recoverHandlerPanic(method string, err *error) {
+	if r := recover(); r != nil {
+		logger.GlobalLogger.Errorf("Panic in GRPC handler %v: %v\n%s", method, r, debug.Stack())
+		*err = status.Errorf(codes.Internal, "Internal error in %v", method)
+	}
+}
+
+func recoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer recoverHandlerPanic(info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+func recoveryStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverHandlerPanic(info.FullMethod, &err)
+	return handler(srv, ss)
+}
+
+// observeRequest logs the completed request and records it in the request metrics.
+func observeRequest(method string, start time.Time, err error) {
+	duration := time.Since(start)
+	code := status.Code(err)
+	grpcRequestsCounter.Inc(method, code.String())
+	if code != codes.OK {
+		grpcRequestErrorsCounter.Inc(method)
+	}
+	grpcRequestDurationHistogram.Observe(duration.Seconds(), method)
+	logger.GlobalLogger.Infof("GRPC request %v completed in %v with code %v", method, duration, code)
+}
+
+func loggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+func loggingStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeRequest(info.FullMethod, start, err)
+	return err
+}
@@ -0,0 +1,143 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/kubeslice/router-sidecar/pkg/metrics"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestRecoveryInterceptors(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName     string
+		handlerErr   error
+		handlerPanic bool
+		expectedCode codes.Code
+	}{
+		{"successful handler", nil, false, codes.OK},
+		{"handler error is returned as is", status.Error(codes.NotFound, "not found"), false, codes.NotFound},
+		{"handler panic returns Internal", nil, true, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			call := func() error {
+				if tt.handlerPanic {
+					var conn *SliceRouterSidecar
+					_ = *conn
+				}
+				return tt.handlerErr
+			}
+
+			unaryInfo := &grpc.UnaryServerInfo{FullMethod: "/sidecar.SliceRouterSidecarService/Test"}
+			_, err := loggingUnaryInterceptor(context.Background(), nil, unaryInfo,
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return recoveryUnaryInterceptor(ctx, req, unaryInfo, func(context.Context, interface{}) (interface{}, error) {
+						return nil, call()
+					})
+				})
+			if status.Code(err) != tt.expectedCode {
+				t.Errorf("unary: expected code %v, received %v", tt.expectedCode, err)
+			}
+
+			streamInfo := &grpc.StreamServerInfo{FullMethod: "/sidecar.SliceRouterSidecarService/TestStream"}
+			err = loggingStreamInterceptor(nil, nil, streamInfo, func(srv interface{}, ss grpc.ServerStream) error {
+				return recoveryStreamInterceptor(srv, ss, streamInfo, func(interface{}, grpc.ServerStream) error {
+					return call()
+				})
+			})
+			if status.Code(err) != tt.expectedCode {
+				t.Errorf("stream: expected code %v, received %v", tt.expectedCode, err)
+			}
+		})
+	}
+}
+
+func TestRequestMetrics(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	resetRouteMap(t)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	const (
+		routeTableMethod = "/router.SliceRouterSidecarService/GetRouteTable"
+		connectionMethod = "/router.SliceRouterSidecarService/GetClientConnection"
+	)
+	routeTableOK := grpcRequestsCounter.Value(routeTableMethod, codes.OK.String())
+	connectionInvalid := grpcRequestsCounter.Value(connectionMethod, codes.InvalidArgument.String())
+	routeTableDurations := grpcRequestDurationHistogram.Count(routeTableMethod)
+	routeTableErrors := grpcRequestErrorsCounter.Value(routeTableMethod)
+	connectionErrors := grpcRequestErrorsCounter.Value(connectionMethod)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetRouteTable(ctx, &emptypb.Empty{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.GetClientConnection(ctx, &pb.ClientConnectionRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("expected InvalidArgument, received", err)
+	}
+
+	if v := grpcRequestsCounter.Value(routeTableMethod, codes.OK.String()) - routeTableOK; v != 2 {
+		t.Error("GetRouteTable OK requests: expected 2, received", v)
+	}
+	if v := grpcRequestsCounter.Value(connectionMethod, codes.InvalidArgument.String()) - connectionInvalid; v != 1 {
+		t.Error("GetClientConnection InvalidArgument requests: expected 1, received", v)
+	}
+	if v := grpcRequestErrorsCounter.Value(routeTableMethod) - routeTableErrors; v != 0 {
+		t.Error("GetRouteTable errors: expected 0, received", v)
+	}
+	if v := grpcRequestErrorsCounter.Value(connectionMethod) - connectionErrors; v != 1 {
+		t.Error("GetClientConnection errors: expected 1, received", v)
+	}
+	if v := grpcRequestDurationHistogram.Count(routeTableMethod) - routeTableDurations; v != 2 {
+		t.Error("GetRouteTable durations: expected 2, received", v)
+	}
+
+	var out strings.Builder
+	if err := metrics.DefaultRegistry.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`slicerouter_grpc_requests_total{method="` + connectionMethod + `",code="InvalidArgument"}`,
+		`slicerouter_grpc_request_errors_total{method="` + connectionMethod + `"}`,
+		`slicerouter_grpc_request_duration_seconds_count{method="` + routeTableMethod + `"}`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Error("metrics: expected", want)
+		}
+	}
+}
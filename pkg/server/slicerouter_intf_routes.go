@@ -0,0 +1,179 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"sort"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+)
+
+// intfRoutes accumulates the remote subnets routed via an interface.
+type intfRoutes struct {
+	podName       string
+	remoteSubnets map[string]bool
+	// Counters of the interface, only known in kernel mode.
+	stats *netlink.LinkStatistics
+}
+
+// isSliceRoute returns true if the route to dst was injected by the sidecar.
+func isSliceRoute(dst string) bool {
+	_, ok := remoteSubnetRouteMap.Load(dst)
+	return ok
+}
+
+// vl3GetRoutesByInterfaceInKernel groups the slice routes installed in the kernel by the NSM link
+// of their next hops. A route with multiple next hops is listed under each of their links.
+func vl3GetRoutesByInterfaceInKernel() (map[string]*intfRoutes, error) {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Could not get link list, Err: %v", err)
+		return nil, err
+	}
+	linkMap := map[int]netlink.Link{}
+	for _, link := range links {
+		linkMap[link.Attrs().Index] = link
+	}
+
+	routes, err := nlHandle.RouteList(nil, netlink.FAMILY_V4)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Could not get route list, Err: %v", err)
+		return nil, err
+	}
+
+	intfMap := map[string]*intfRoutes{}
+	add := func(linkIndex int, dst string) {
+		link, ok := linkMap[linkIndex]
+		if !ok {
+			return
+		}
+		name := link.Attrs().Name
+		if intfMap[name] == nil {
+			intfMap[name] = &intfRoutes{podName: link.Attrs().Alias, remoteSubnets: map[string]bool{}, stats: link.Attrs().Statistics}
+		}
+		intfMap[name].remoteSubnets[dst] = true
+	}
+	for _, route := range routes {
+		if route.Dst == nil || !isSliceRoute(route.Dst.String()) {
+			continue
+		}
+		if len(route.MultiPath) > 0 {
+			for _, nextHop := range route.MultiPath {
+				add(nextHop.LinkIndex, route.Dst.String())
+			}
+		} else {
+			add(route.LinkIndex, route.Dst.String())
+		}
+	}
+	return intfMap, nil
+}
+
+// vl3GetRoutesByInterfaceInVpp groups the slice routes configured in vpp by the NSM interface whose
+// subnet holds their next hop.
+func vl3GetRoutesByInterfaceInVpp() (map[string]*intfRoutes, error) {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		logger.GlobalLogger.Errorf("can't dial grpc server: %v", err)
+		return nil, err
+	}
+	defer closeConn()
+
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{})
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get vpp config: %v", err)
+		return nil, err
+	}
+	recordVppConfig(vppConfig.GetConfig().GetVppConfig())
+
+	type intfSubnet struct {
+		name   string
+		subnet *net.IPNet
+	}
+	intfSubnets := []intfSubnet{}
+	for _, intf := range vppConfig.GetConfig().GetVppConfig().GetInterfaces() {
+		if !isVppNsmInterface(intf.Name) {
+			continue
+		}
+		for _, addr := range intf.IpAddresses {
+			if _, subnet, err := net.ParseCIDR(addr); err == nil {
+				intfSubnets = append(intfSubnets, intfSubnet{name: intf.Name, subnet: subnet})
+			}
+		}
+	}
+
+	intfMap := map[string]*intfRoutes{}
+	for _, route := range vppConfig.GetConfig().GetVppConfig().GetRoutes() {
+		if !isSliceRoute(route.GetDstNetwork()) || !isSliceVppRoute(route) {
+			continue
+		}
+		nextHopIP := net.ParseIP(route.GetNextHopAddr())
+		for _, intf := range intfSubnets {
+			if nextHopIP == nil || !intf.subnet.Contains(nextHopIP) {
+				continue
+			}
+			// vpp nsm interfaces are named after the client pod.
+			if intfMap[intf.name] == nil {
+				intfMap[intf.name] = &intfRoutes{podName: intf.name, remoteSubnets: map[string]bool{}}
+			}
+			intfMap[intf.name].remoteSubnets[route.GetDstNetwork()] = true
+			break
+		}
+	}
+	return intfMap, nil
+}
+
+// sliceRouterGetRoutesByInterface returns the slice routes grouped by outgoing interface, sorted by
+// interface name and remote subnet.
+func sliceRouterGetRoutesByInterface() ([]*sidecar.InterfaceRoutes, error) {
+	var intfMap map[string]*intfRoutes
+	var err error
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel {
+		intfMap, err = vl3GetRoutesByInterfaceInKernel()
+	} else if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		intfMap, err = vl3GetRoutesByInterfaceInVpp()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*sidecar.InterfaceRoutes{}
+	for name, routes := range intfMap {
+		remoteSubnets := []string{}
+		for remoteSubnet := range routes.remoteSubnets {
+			remoteSubnets = append(remoteSubnets, remoteSubnet)
+		}
+		sort.Strings(remoteSubnets)
+		result = append(result, &sidecar.InterfaceRoutes{
+			InterfaceName: name,
+			PodName:       routes.podName,
+			RemoteSubnets: remoteSubnets,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].InterfaceName < result[j].InterfaceName
+	})
+	return result, nil
+}
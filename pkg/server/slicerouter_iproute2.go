@@ -0,0 +1,117 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// isIproute2LogEnabled returns true if the routes written to and deleted from the kernel should be logged
+// as the equivalent ip route command, read from the ROUTE_LOG_IPROUTE2 env variable.
+func isIproute2LogEnabled() bool {
+	return os.Getenv("ROUTE_LOG_IPROUTE2") == "true"
+}
+
+// logIproute2Command logs the route operation as the ip route command reproducing it, if enabled.
+func logIproute2Command(verb string, route *netlink.Route) {
+	if !isIproute2LogEnabled() {
+		return
+	}
+	logger.GlobalLogger.Infof("Kernel route operation: %v", formatIproute2Command(verb, route, linkNameByIndex))
+}
+
+// linkNameByIndex returns the name of the link, or a placeholder if the link is gone.
+func linkNameByIndex(linkIndex int) string {
+	link, err := nlHandle.LinkByIndex(linkIndex)
+	if err != nil {
+		return fmt.Sprintf("if%d", linkIndex)
+	}
+	return link.Attrs().Name
+}
+
+// formatIproute2Command returns the ip route command performing the operation on the route, such as
+// "ip route replace 10.1.0.0/16 via 192.168.0.2 dev vl3-1 onlink". The verb is the ip route command:
+// add, replace or del.
+func formatIproute2Command(verb string, route *netlink.Route, linkName func(int) string) string {
+	args := []string{"ip"}
+	if route.Dst != nil && route.Dst.IP.To4() == nil {
+		args = append(args, "-6")
+	}
+	args = append(args, "route", verb)
+	if route.Type == unix.RTN_BLACKHOLE {
+		args = append(args, "blackhole")
+	}
+	// The kernel reports default routes without a Dst.
+	if route.Dst != nil {
+		args = append(args, route.Dst.String())
+	} else {
+		args = append(args, "default")
+	}
+	if route.Table != 0 {
+		args = append(args, "table", fmt.Sprint(route.Table))
+	}
+	if route.Priority != 0 {
+		args = append(args, "metric", fmt.Sprint(route.Priority))
+	}
+	if verb == "del" || route.Type == unix.RTN_BLACKHOLE {
+		return strings.Join(args, " ")
+	}
+
+	// A single path is written as a regular route, the kernel stores it that way.
+	if len(route.MultiPath) == 1 {
+		path := route.MultiPath[0]
+		return strings.Join(append(args, formatIproute2NextHop(path.Gw, path.LinkIndex, path.Flags, linkName)...), " ")
+	}
+	if len(route.MultiPath) == 0 {
+		return strings.Join(append(args, formatIproute2NextHop(route.Gw, route.LinkIndex, route.Flags, linkName)...), " ")
+	}
+	for _, path := range route.MultiPath {
+		args = append(args, "nexthop")
+		args = append(args, formatIproute2NextHop(path.Gw, path.LinkIndex, 0, linkName)...)
+		// The kernel stores the weight of a next hop as its hop count plus one.
+		if path.Hops > 0 {
+			args = append(args, "weight", fmt.Sprint(path.Hops+1))
+		}
+		if path.Flags&int(netlink.FLAG_ONLINK) != 0 {
+			args = append(args, "onlink")
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// formatIproute2NextHop returns the ip route arguments of a next hop.
+func formatIproute2NextHop(gw net.IP, linkIndex int, flags int, linkName func(int) string) []string {
+	args := []string{}
+	if gw != nil {
+		args = append(args, "via", gw.String())
+	}
+	if linkIndex != 0 {
+		args = append(args, "dev", linkName(linkIndex))
+	}
+	if flags&int(netlink.FLAG_ONLINK) != 0 {
+		args = append(args, "onlink")
+	}
+	return args
+}
@@ -0,0 +1,120 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+)
+
+func TestFormatIproute2Command(t *testing.T) {
+	linkName := func(linkIndex int) string {
+		return fmt.Sprintf("vl3-%d", linkIndex)
+	}
+	onlink := int(netlink.FLAG_ONLINK)
+
+	tests := []struct {
+		testName string
+		verb     string
+		route    netlink.Route
+		expected string
+	}{
+		{
+			"single next hop",
+			"replace",
+			netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), MultiPath: []*netlink.NexthopInfo{
+				{LinkIndex: 1, Gw: net.ParseIP("192.168.0.2"), Flags: onlink},
+			}},
+			"ip route replace 10.1.0.0/16 via 192.168.0.2 dev vl3-1 onlink",
+		},
+		{
+			"regular route",
+			"add",
+			netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), LinkIndex: 1, Gw: net.ParseIP("192.168.0.2")},
+			"ip route add 10.1.0.0/16 via 192.168.0.2 dev vl3-1",
+		},
+		{
+			"multipath route",
+			"replace",
+			netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), MultiPath: []*netlink.NexthopInfo{
+				{LinkIndex: 1, Gw: net.ParseIP("192.168.0.2"), Flags: onlink},
+				{LinkIndex: 2, Gw: net.ParseIP("192.168.0.6"), Flags: onlink, Hops: 3},
+			}},
+			"ip route replace 10.1.0.0/16 nexthop via 192.168.0.2 dev vl3-1 onlink nexthop via 192.168.0.6 dev vl3-2 weight 4 onlink",
+		},
+		{
+			"interface next hop",
+			"replace",
+			netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), MultiPath: []*netlink.NexthopInfo{{LinkIndex: 1}}},
+			"ip route replace 10.1.0.0/16 dev vl3-1",
+		},
+		{
+			"policy route table",
+			"replace",
+			netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), Table: 100, MultiPath: []*netlink.NexthopInfo{
+				{LinkIndex: 1, Gw: net.ParseIP("192.168.0.2"), Flags: onlink},
+			}},
+			"ip route replace 10.1.0.0/16 table 100 via 192.168.0.2 dev vl3-1 onlink",
+		},
+		{
+			"ipv6 route",
+			"replace",
+			netlink.Route{Dst: mustParseCIDR("fd00:10::/64"), MultiPath: []*netlink.NexthopInfo{
+				{LinkIndex: 1, Gw: net.ParseIP("fd00:1::1"), Flags: onlink},
+			}},
+			"ip -6 route replace fd00:10::/64 via fd00:1::1 dev vl3-1 onlink",
+		},
+		{
+			"delete",
+			"del",
+			netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), LinkIndex: 1, Gw: net.ParseIP("192.168.0.2")},
+			"ip route del 10.1.0.0/16",
+		},
+		{
+			"delete from a table",
+			"del",
+			netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), Table: 100},
+			"ip route del 10.1.0.0/16 table 100",
+		},
+		{
+			"default next hop route",
+			"replace",
+			netlink.Route{Dst: mustParseCIDR("0.0.0.0/0"), Priority: 4096, MultiPath: []*netlink.NexthopInfo{
+				{LinkIndex: 1, Gw: net.ParseIP("192.168.0.2"), Flags: onlink},
+			}},
+			"ip route replace 0.0.0.0/0 metric 4096 via 192.168.0.2 dev vl3-1 onlink",
+		},
+		{
+			"delete a listed default route",
+			"del",
+			netlink.Route{LinkIndex: 1, Gw: net.ParseIP("192.168.0.2"), Priority: 4096},
+			"ip route del default metric 4096",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := formatIproute2Command(tt.verb, &tt.route, linkName); got != tt.expected {
+				t.Errorf("expected %q, received %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// Names of the route protocols, scopes and types as ip route prints them.
+var (
+	kernelRouteProtocolNames = map[int]string{
+		unix.RTPROT_UNSPEC:   "unspec",
+		unix.RTPROT_REDIRECT: "redirect",
+		unix.RTPROT_KERNEL:   "kernel",
+		unix.RTPROT_BOOT:     "boot",
+		unix.RTPROT_STATIC:   "static",
+		unix.RTPROT_RA:       "ra",
+		unix.RTPROT_ZEBRA:    "zebra",
+		unix.RTPROT_BIRD:     "bird",
+		unix.RTPROT_DHCP:     "dhcp",
+		unix.RTPROT_BGP:      "bgp",
+		unix.RTPROT_OSPF:     "ospf",
+	}
+	kernelRouteScopeNames = map[int]string{
+		unix.RT_SCOPE_UNIVERSE: "global",
+		unix.RT_SCOPE_SITE:     "site",
+		unix.RT_SCOPE_LINK:     "link",
+		unix.RT_SCOPE_HOST:     "host",
+		unix.RT_SCOPE_NOWHERE:  "nowhere",
+	}
+	kernelRouteTypeNames = map[int]string{
+		unix.RTN_UNSPEC:      "unspec",
+		unix.RTN_UNICAST:     "unicast",
+		unix.RTN_LOCAL:       "local",
+		unix.RTN_BROADCAST:   "broadcast",
+		unix.RTN_ANYCAST:     "anycast",
+		unix.RTN_MULTICAST:   "multicast",
+		unix.RTN_BLACKHOLE:   "blackhole",
+		unix.RTN_UNREACHABLE: "unreachable",
+		unix.RTN_PROHIBIT:    "prohibit",
+		unix.RTN_THROW:       "throw",
+		unix.RTN_NAT:         "nat",
+	}
+)
+
+// kernelRouteName returns the name of the value, or the value itself if it has no name.
+func kernelRouteName(names map[int]string, value int) string {
+	if name, ok := names[value]; ok {
+		return name
+	}
+	return fmt.Sprint(value)
+}
+
+// kernelRouteIP returns the IP as a string, empty if it is not set.
+func kernelRouteIP(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// kernelLinkName returns the name of the link, empty if the link is gone.
+func kernelLinkName(linkMap map[int]netlink.Link, linkIndex int) string {
+	link, ok := linkMap[linkIndex]
+	if !ok {
+		return ""
+	}
+	return link.Attrs().Name
+}
+
+// newKernelRoute serializes the fields of a netlink route.
+func newKernelRoute(route netlink.Route, linkMap map[int]netlink.Link) *sidecar.KernelRoute {
+	kernelRoute := &sidecar.KernelRoute{
+		Gw:        kernelRouteIP(route.Gw),
+		Src:       kernelRouteIP(route.Src),
+		LinkIndex: int32(route.LinkIndex),
+		LinkName:  kernelLinkName(linkMap, route.LinkIndex),
+		Table:     int32(route.Table),
+		Priority:  int32(route.Priority),
+		Protocol:  kernelRouteName(kernelRouteProtocolNames, route.Protocol),
+		Scope:     kernelRouteName(kernelRouteScopeNames, int(route.Scope)),
+		Type:      kernelRouteName(kernelRouteTypeNames, route.Type),
+		Tos:       int32(route.Tos),
+		Flags:     route.ListFlags(),
+	}
+	if route.Dst != nil {
+		kernelRoute.Dst = route.Dst.String()
+	}
+	for _, path := range route.MultiPath {
+		kernelRoute.MultiPath = append(kernelRoute.MultiPath, &sidecar.KernelNextHop{
+			Gw:        kernelRouteIP(path.Gw),
+			LinkIndex: int32(path.LinkIndex),
+			LinkName:  kernelLinkName(linkMap, path.LinkIndex),
+			Hops:      int32(path.Hops),
+			Flags:     path.ListFlags(),
+		})
+	}
+	return kernelRoute
+}
+
+// sliceRouterGetKernelRoutes returns the routes of the kernel routing table as listed by netlink, in
+// the order netlink lists them. The main table is listed like the reconcile lists it, the other tables
+// like the policy route reconcile does.
+func sliceRouterGetKernelRoutes(table int) ([]*sidecar.KernelRoute, error) {
+	var routes []netlink.Route
+	var err error
+	if table == 0 {
+		routes, err = nlHandle.RouteList(nil, netlink.FAMILY_ALL)
+	} else {
+		routes, err = nlHandle.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	}
+	if err != nil {
+		return nil, err
+	}
+	linkMap, err := getLinkIndexMap()
+	if err != nil {
+		return nil, err
+	}
+
+	kernelRoutes := []*sidecar.KernelRoute{}
+	for _, route := range routes {
+		kernelRoutes = append(kernelRoutes, newKernelRoute(route, linkMap))
+	}
+	return kernelRoutes, nil
+}
@@ -0,0 +1,205 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/kube"
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+const (
+	kubeEventComponent     = "router-sidecar"
+	kubeEventCreateTimeout = 5 * time.Second
+	// An event occurring again within the window is counted on the event recorded before instead of
+	// creating a new one, like the client-go event recorder does.
+	kubeEventAggregationWindow = 10 * time.Minute
+)
+
+// kubeEventRecorder creates Kubernetes events and counts their repetitions.
+type kubeEventRecorder interface {
+	CreateEvent(ctx context.Context, event *kube.Event) error
+	PatchEvent(ctx context.Context, event *kube.Event) error
+}
+
+// newKubeEventClient returns the client of the API server the events are created through, along with
+// the namespace of the sidecar. The service account of the pod is used. It is a variable so that tests
+// can substitute a fake.
+var newKubeEventClient = func() (kubeEventRecorder, string, error) {
+	config, err := kube.InClusterConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	return kube.NewClient(config, kubeEventCreateTimeout), config.Namespace, nil
+}
+
+// isKubeEventsEnabled returns true if the notable route events are recorded as Kubernetes events on the
+// pod of the sidecar, read from the ROUTE_EVENT_KUBERNETES env variable.
+func isKubeEventsEnabled() bool {
+	return os.Getenv("ROUTE_EVENT_KUBERNETES") == "true"
+}
+
+// getKubeEventPod returns the pod the events are recorded on, read from the POD_NAME, POD_NAMESPACE and
+// POD_UID env variables, which are expected to be set through the downward API. The name defaults to
+// the hostname and the namespace to the one of the service account.
+func getKubeEventPod(namespace string) kube.ObjectReference {
+	pod := kube.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Name:       os.Getenv("POD_NAME"),
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+		UID:        os.Getenv("POD_UID"),
+	}
+	if pod.Name == "" {
+		pod.Name, _ = os.Hostname()
+	}
+	if pod.Namespace == "" {
+		pod.Namespace = namespace
+	}
+	return pod
+}
+
+// kubeEventExporter records the notable route events as events on the pod of the sidecar so that they
+// show up in kubectl describe. Routes added are left out as they are too frequent to be notable. The
+// events with the same reason about the same route or connection are aggregated, so that a route failing
+// every reconcile cycle is one event with a growing count.
+type kubeEventExporter struct {
+	recorder kubeEventRecorder
+	pod      kube.ObjectReference
+	host     string
+
+	mu sync.Mutex
+	// recorded holds the last event recorded for each aggregation key.
+	recorded map[string]*kube.Event
+}
+
+// newKubeEventExporter returns the Kubernetes event sink, or nil if the sidecar does not run in a
+// cluster or the API server cannot be reached. The sidecar runs without the sink in that case.
+func newKubeEventExporter() *kubeEventExporter {
+	recorder, namespace, err := newKubeEventClient()
+	if errors.Is(err, kube.ErrNotInCluster) {
+		logger.GlobalLogger.Warnf("Not running in a Kubernetes cluster, route events are not recorded as Kubernetes events")
+		return nil
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to create the Kubernetes client, route events are not recorded as Kubernetes events: %v", err)
+		return nil
+	}
+	pod := getKubeEventPod(namespace)
+	if pod.Name == "" || pod.Namespace == "" {
+		logger.GlobalLogger.Errorf("Pod of the sidecar unknown, route events are not recorded as Kubernetes events. Name: %q, Namespace: %q",
+			pod.Name, pod.Namespace)
+		return nil
+	}
+	host, _ := os.Hostname()
+	return &kubeEventExporter{recorder: recorder, pod: pod, host: host, recorded: map[string]*kube.Event{}}
+}
+
+func (k *kubeEventExporter) name() string {
+	return "kubernetes"
+}
+
+func (k *kubeEventExporter) export(event routeEvent) error {
+	eventType, reason, message := kubeEventOf(event)
+	if reason == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), kubeEventCreateTimeout)
+	defer cancel()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for key, recorded := range k.recorded {
+		if event.Time.Sub(recorded.LastTimestamp) > kubeEventAggregationWindow {
+			delete(k.recorded, key)
+		}
+	}
+	key := strings.Join([]string{eventType, reason, event.RemoteSubnet, event.PodName}, "/")
+	if recorded, ok := k.recorded[key]; ok {
+		repeated := *recorded
+		repeated.Count++
+		repeated.Message = message
+		repeated.LastTimestamp = event.Time
+		err := k.recorder.PatchEvent(ctx, &repeated)
+		if err == nil {
+			k.recorded[key] = &repeated
+			return nil
+		}
+		// The API server deletes the events after a while, a new one is created then.
+		if !kube.IsNotFound(err) {
+			return err
+		}
+		delete(k.recorded, key)
+	}
+	// The name is unique for the pod, like the names the client-go event recorder gives.
+	created := &kube.Event{
+		Metadata:       kube.EventMetadata{Name: fmt.Sprintf("%v.%x", k.pod.Name, event.Time.UnixNano()), Namespace: k.pod.Namespace},
+		InvolvedObject: k.pod,
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         kube.EventSource{Component: kubeEventComponent, Host: k.host},
+		FirstTimestamp: event.Time,
+		LastTimestamp:  event.Time,
+		Count:          1,
+	}
+	if err := k.recorder.CreateEvent(ctx, created); err != nil {
+		return err
+	}
+	k.recorded[key] = created
+	return nil
+}
+
+// kubeEventOf returns the type, reason and message of the Kubernetes event a route event is recorded
+// as. The reason is empty for the route events that are not recorded.
+func kubeEventOf(event routeEvent) (eventType, reason, message string) {
+	nextHops := strings.Join(event.NextHopIPList, ", ")
+	switch event.Type {
+	case routeEventRouteFailed:
+		reason = map[string]string{
+			routeAuditInject:    "RouteInstallFailed",
+			routeAuditDelete:    "RouteDeleteFailed",
+			routeAuditReconcile: "RouteCorrectionFailed",
+		}[event.Operation]
+		return kube.EventTypeWarning, reason, fmt.Sprintf("Failed to %v route to %v via [%v]: %v",
+			event.Operation, event.RemoteSubnet, nextHops, event.Error)
+	case routeEventRouteCorrected:
+		return kube.EventTypeWarning, "RouteCorrected", fmt.Sprintf("Corrected %v route to %v via [%v]",
+			event.Correction, event.RemoteSubnet, nextHops)
+	case routeEventNextHopChanged:
+		return kube.EventTypeNormal, "NextHopChanged", fmt.Sprintf("Route to %v moved from [%v] to [%v]",
+			event.RemoteSubnet, strings.Join(event.PreviousNextHopIPList, ", "), nextHops)
+	case routeEventRouteDeleted:
+		return kube.EventTypeNormal, "RouteDeleted", fmt.Sprintf("Deleted route to %v, reason: %v",
+			event.RemoteSubnet, event.DeletionReason)
+	case routeEventConnectionAdded:
+		return kube.EventTypeNormal, "ConnectionAdded", fmt.Sprintf("Client %v connected over %v with IP %v",
+			event.PodName, event.NsmInterface, event.NsmIP)
+	case routeEventConnectionRemoved:
+		return kube.EventTypeNormal, "ConnectionRemoved", fmt.Sprintf("Client %v disconnected from %v with IP %v",
+			event.PodName, event.NsmInterface, event.NsmIP)
+	}
+	return "", "", ""
+}
@@ -0,0 +1,209 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/kube"
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// fakeKubeEventRecorder records the Kubernetes events created and patched.
+type fakeKubeEventRecorder struct {
+	mu      sync.Mutex
+	events  []*kube.Event
+	patches []*kube.Event
+	// patchErr fails the patches if set.
+	patchErr error
+}
+
+func (f *fakeKubeEventRecorder) CreateEvent(ctx context.Context, event *kube.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeKubeEventRecorder) PatchEvent(ctx context.Context, event *kube.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.patchErr != nil {
+		return f.patchErr
+	}
+	f.patches = append(f.patches, event)
+	return nil
+}
+
+// useFakeKubeEventClient makes the Kubernetes event sink use the recorder for the duration of the test,
+// or fail with err if set.
+func useFakeKubeEventClient(t *testing.T, recorder kubeEventRecorder, err error) {
+	t.Helper()
+	orig := newKubeEventClient
+	newKubeEventClient = func() (kubeEventRecorder, string, error) {
+		if err != nil {
+			return nil, "", err
+		}
+		return recorder, "kubeslice-system", nil
+	}
+	t.Cleanup(func() {
+		newKubeEventClient = orig
+	})
+}
+
+func TestKubeEventsRecorded(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("POD_NAME", "vl3-slice-router-red-0")
+	t.Setenv("POD_UID", "4b7d0c1e")
+	resetRouteMap(t)
+	skipReconcile(t)
+	useRouteAuditLog(t, &routeAuditLog{})
+
+	fake := newFakeNetlink()
+	fake.links = []netlink.Link{&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "vl3-1"}}}
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(32, 32)}}}
+	fake.addConnectedRoute("10.1.1.1", 1)
+	fake.addConnectedRoute("10.1.1.3", 1)
+	useFakeNetlink(t, fake)
+
+	recorder := &fakeKubeEventRecorder{}
+	useFakeKubeEventClient(t, recorder, nil)
+	exporter := newKubeEventExporter()
+	if exporter == nil {
+		t.Fatal("Kubernetes event sink not created")
+	}
+	routeEventsMu.Lock()
+	routeEvents = newRouteEventDispatcher([]routeExporter{exporter})
+	routeEventsMu.Unlock()
+	t.Cleanup(stopRouteExporters)
+
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"10.1.1.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"10.1.1.3"}); err != nil {
+		t.Fatal(err)
+	}
+	fake.routeReplaceErr = errors.New("no buffer space available")
+	if err := sliceRouterInjectRoute("10.3.0.0/16", []string{"10.1.1.1"}); err == nil {
+		t.Fatal("injection succeeded despite the dataplane failure")
+	}
+	routeAudit.recordCorrection("10.2.0.0/16", []string{"10.1.1.3"}, routeCorrectionMissing, nil)
+	stopRouteExporters()
+
+	// The route added is not notable, its next hop change is.
+	expected := []string{"NextHopChanged", "RouteInstallFailed", "RouteCorrected"}
+	reasons := []string{}
+	for _, event := range recorder.events {
+		reasons = append(reasons, event.Reason)
+	}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Fatal("event reasons: expected", expected, "received", reasons)
+	}
+	pod := kube.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "kubeslice-system", Name: "vl3-slice-router-red-0", UID: "4b7d0c1e"}
+	for _, event := range recorder.events {
+		if event.InvolvedObject != pod || event.Metadata.Namespace != "kubeslice-system" || event.Source.Component != kubeEventComponent {
+			t.Error("event not recorded on the sidecar pod:", event)
+		}
+	}
+	if event := recorder.events[0]; event.Type != kube.EventTypeNormal ||
+		event.Message != "Route to 10.2.0.0/16 moved from [10.1.1.1] to [10.1.1.3]" {
+		t.Error("next hop change event: received", event)
+	}
+	if event := recorder.events[1]; event.Type != kube.EventTypeWarning ||
+		event.Message != "Failed to inject route to 10.3.0.0/16 via [10.1.1.1]: DataplaneError: route to 10.3.0.0/16: no buffer space available" {
+		t.Error("install failure event: received", event)
+	}
+	if event := recorder.events[2]; event.Type != kube.EventTypeWarning ||
+		event.Message != "Corrected missing route to 10.2.0.0/16 via [10.1.1.3]" {
+		t.Error("correction event: received", event)
+	}
+}
+
+func TestKubeEventsAggregated(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("POD_NAME", "vl3-slice-router-red-0")
+	recorder := &fakeKubeEventRecorder{}
+	useFakeKubeEventClient(t, recorder, nil)
+	exporter := newKubeEventExporter()
+	if exporter == nil {
+		t.Fatal("Kubernetes event sink not created")
+	}
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	failed := func(remoteSubnet string, at time.Duration) routeEvent {
+		return routeEvent{Type: routeEventRouteFailed, Operation: routeAuditReconcile, RemoteSubnet: remoteSubnet,
+			NextHopIPList: []string{"10.1.1.1"}, Error: "no buffer space available", Time: start.Add(at)}
+	}
+	for i := 0; i < 3; i++ {
+		if err := exporter.export(failed("10.2.0.0/16", time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := exporter.export(failed("10.3.0.0/16", 3*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.events) != 2 || len(recorder.patches) != 2 {
+		t.Fatalf("expected 2 events created and 2 patches, received %v and %v", recorder.events, recorder.patches)
+	}
+	if patch := recorder.patches[1]; patch.Metadata != recorder.events[0].Metadata || patch.Count != 3 ||
+		!patch.LastTimestamp.Equal(start.Add(2*time.Minute)) || !patch.FirstTimestamp.Equal(start) {
+		t.Error("repeated event: expected the count of the first event at 3, received", patch)
+	}
+	if recorder.events[0].Metadata.Name == recorder.events[1].Metadata.Name {
+		t.Error("events created with the same name", recorder.events[0].Metadata.Name)
+	}
+
+	// Past the aggregation window, and once the API server deleted the event, a new event is created.
+	if err := exporter.export(failed("10.2.0.0/16", 2*time.Minute+kubeEventAggregationWindow+time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	recorder.patchErr = &kube.StatusError{Code: http.StatusNotFound, Message: "not found"}
+	if err := exporter.export(failed("10.3.0.0/16", 4*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.events) != 4 || len(recorder.patches) != 2 {
+		t.Fatalf("expected 4 events created and 2 patches, received %v and %v", recorder.events, recorder.patches)
+	}
+	for _, event := range recorder.events {
+		if event.Count != 1 {
+			t.Error("created event: expected count 1, received", event)
+		}
+	}
+}
+
+func TestKubeEventsNotInCluster(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("ROUTE_EVENT_KUBERNETES", "true")
+	t.Setenv("ROUTE_EVENT_WEBHOOK_URL", "")
+	useFakeKubeEventClient(t, nil, kube.ErrNotInCluster)
+
+	// The sidecar runs without the sink.
+	if sinks := startRouteExporters(); sinks != nil {
+		stopRouteExporters()
+		t.Fatal("expected no sink, received", sinks)
+	}
+}
@@ -0,0 +1,117 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+// Default time the background tasks are given to return on shutdown.
+const defaultBackgroundTaskStopTimeout = 10 * time.Second
+
+// taskGroup tracks the background tasks of the sidecar. The tasks share a context that is canceled to
+// stop them, and must return once it is done.
+type taskGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// running counts the running tasks by name.
+	running map[string]int
+}
+
+func newTaskGroup() *taskGroup {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &taskGroup{ctx: ctx, cancel: cancel, running: map[string]int{}}
+}
+
+// runningTasks returns the names of the tasks that have not returned yet, sorted.
+func (g *taskGroup) runningTasks() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	names := []string{}
+	for name := range g.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g *taskGroup) done(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.running[name]--; g.running[name] == 0 {
+		delete(g.running, name)
+	}
+}
+
+// backgroundTasks is the group of the running background tasks. It is replaced by a new group once
+// stopped, so that tasks started afterwards are not tied to the canceled context.
+var (
+	backgroundTasksMu sync.Mutex
+	backgroundTasks   = newTaskGroup()
+)
+
+// startBackgroundTask runs the task in a goroutine tracked by the background task group. The task is
+// passed the context of the group and must return once it is done.
+func startBackgroundTask(name string, task func(ctx context.Context)) {
+	backgroundTasksMu.Lock()
+	g := backgroundTasks
+	// The task is added while holding the lock so that a concurrent stop waits for it.
+	g.wg.Add(1)
+	g.mu.Lock()
+	g.running[name]++
+	g.mu.Unlock()
+	backgroundTasksMu.Unlock()
+
+	go func() {
+		defer g.wg.Done()
+		defer g.done(name)
+		task(g.ctx)
+	}()
+}
+
+// stopBackgroundTasks cancels the background tasks and waits up to the timeout for them to return. An
+// error naming the tasks still running is returned if the timeout expires.
+func stopBackgroundTasks(timeout time.Duration) error {
+	backgroundTasksMu.Lock()
+	g := backgroundTasks
+	backgroundTasks = newTaskGroup()
+	backgroundTasksMu.Unlock()
+
+	g.cancel()
+	stopped := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		logger.GlobalLogger.Infof("Background tasks stopped")
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("background tasks still running after %v: %v", timeout, g.runningTasks())
+	}
+}
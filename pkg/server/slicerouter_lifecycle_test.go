@@ -0,0 +1,137 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+// waitForBackgroundTask waits for the running background tasks of the given name to return on their own.
+func waitForBackgroundTask(t *testing.T, name string) {
+	t.Helper()
+	backgroundTasksMu.Lock()
+	g := backgroundTasks
+	backgroundTasksMu.Unlock()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		running := g.running[name]
+		g.mu.Unlock()
+		if running == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("background task", name, "still running")
+}
+
+func TestStopBackgroundTasks(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("NEXTHOP_HEALTH_CHECK", nextHopHealthCheckICMP)
+	useFakeNetlink(t, newFakeNetlink())
+	useFakeSubscriptions(t)
+	backgroundTasksMu.Lock()
+	g := backgroundTasks
+	backgroundTasksMu.Unlock()
+
+	startBackgroundTask("connection-cache", func(ctx context.Context) {
+		connectionCacheMonitor(ctx.Done())
+	})
+	startBackgroundTask("reconcile-loop", routingTableReconcileLoop)
+	startBackgroundTask("health-check", nextHopHealthCheckLoop)
+	if _, err := startRouteStateServer(filepath.Join(t.TempDir(), "routes.sock")); err != nil {
+		t.Fatal(err)
+	}
+	running := strings.Join(g.runningTasks(), ",")
+	for _, name := range []string{"connection-cache", "reconcile-loop", "health-check", "route-state-server", "route-state-server-close"} {
+		if !strings.Contains(running, name) {
+			t.Fatal("task", name, "not running:", running)
+		}
+	}
+
+	if err := stopBackgroundTasks(2 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if running := g.runningTasks(); len(running) != 0 {
+		t.Error("tasks running after the stop:", running)
+	}
+	if status := sliceRouterGetReconcileStatus(); status.GetScheduled() {
+		t.Error("reconcile still scheduled after the reconcile loop stopped")
+	}
+
+	// Tasks started after the stop run in a new group.
+	ran := make(chan struct{})
+	startBackgroundTask("after-stop", func(ctx context.Context) {
+		if ctx.Err() == nil {
+			close(ran)
+		}
+	})
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Error("task started after the stop did not run with a live context")
+	}
+	waitForBackgroundTask(t, "after-stop")
+}
+
+func TestStopBackgroundTasksTimeout(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	release := make(chan struct{})
+	startBackgroundTask("stuck", func(ctx context.Context) {
+		<-release
+	})
+	defer close(release)
+
+	err := stopBackgroundTasks(10 * time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "stuck") {
+		t.Error("expected an error naming the stuck task, received", err)
+	}
+}
+
+func TestShutdownStopsBootstrapTasks(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("NEXTHOP_HEALTH_CHECK", nextHopHealthCheckICMP)
+	t.Setenv("ROUTE_STATE_SOCKET", filepath.Join(t.TempDir(), "routes.sock"))
+	t.Setenv("SHUTDOWN_ROUTE_POLICY", shutdownRoutePolicyKeep)
+	useFakeNetlink(t, newFakeNetlink())
+	useFakeVppAgent(t, newFakeVppAgent())
+
+	if _, err := BootstrapSliceRouterPod(); err != nil {
+		t.Fatal(err)
+	}
+	backgroundTasksMu.Lock()
+	g := backgroundTasks
+	backgroundTasksMu.Unlock()
+	if len(g.runningTasks()) == 0 {
+		t.Fatal("expected background tasks after the bootstrap")
+	}
+	if err := ShutdownSliceRouterPod(); err != nil {
+		t.Fatal(err)
+	}
+	if running := g.runningTasks(); len(running) != 0 {
+		t.Error("tasks running after shutdown:", running)
+	}
+}
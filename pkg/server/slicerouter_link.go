@@ -0,0 +1,258 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+
+	"github.com/vishvananda/netlink"
+)
+
+// Kernel link indices are reused when an interface is deleted and another one is created. A link index
+// read from a route or cached earlier may therefore point at an interface that is not the NSM link of
+// the next hop anymore. The functions below check that a link index still refers to an NSM interface
+// and re-resolve the link of the next hop when it does not.
+
+// getLinkIndexMap returns the links in the kernel by index.
+func getLinkIndexMap() (map[int]netlink.Link, error) {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	linkMap := map[int]netlink.Link{}
+	for _, link := range links {
+		linkMap[link.Attrs().Index] = link
+	}
+	return linkMap, nil
+}
+
+// isNsmLinkIndex returns true if the link index refers to an NSM interface.
+func isNsmLinkIndex(linkMap map[int]netlink.Link, linkIndex int) bool {
+	link, ok := linkMap[linkIndex]
+	return ok && isNsmInterface(link.Attrs().Name)
+}
+
+// ipFamily returns the netlink address family of the IP.
+func ipFamily(ip net.IP) int {
+	if ip.To4() == nil {
+		return netlink.FAMILY_V6
+	}
+	return netlink.FAMILY_V4
+}
+
+// checkUnicastNextHop checks that the next hop IP is a unicast address a route can go through. The
+// unspecified, loopback, broadcast, multicast and link-local addresses are rejected, a next hop with one
+// of them is a misconfiguration.
+func checkUnicastNextHop(ip net.IP) error {
+	switch {
+	case ip.IsUnspecified():
+		return fmt.Errorf("next hop %v is the unspecified address", ip)
+	case ip.IsLoopback():
+		return fmt.Errorf("next hop %v is a loopback address", ip)
+	case ip.Equal(net.IPv4bcast):
+		return fmt.Errorf("next hop %v is the broadcast address", ip)
+	case ip.IsMulticast():
+		return fmt.Errorf("next hop %v is a multicast address", ip)
+	case ip.IsLinkLocalUnicast():
+		return fmt.Errorf("next hop %v is a link-local address", ip)
+	}
+	return nil
+}
+
+// hostPrefix returns the host route destination of the IP, a /32 for IPv4 and a /128 for IPv6.
+func hostPrefix(ip net.IP) string {
+	if ip.To4() != nil {
+		return (&net.IPNet{IP: ip, Mask: net.CIDRMask(32, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}).String()
+}
+
+// resolveNextHopLink returns the index of the NSM link the next hop is reached on. The link of the
+// connected host route to the next hop is used if it is still an NSM interface, otherwise the NSM links
+// are searched for the one whose address covers the next hop. The links are resolved within the family
+// of the next hop, so that the IPv4 and IPv6 next hops of a dual-stack slice can egress different links.
+func resolveNextHopLink(nextHopIP string, routes []netlink.Route, linkMap map[int]netlink.Link) (int, error) {
+	ip := net.ParseIP(nextHopIP)
+	if ip == nil {
+		return -1, fmt.Errorf("invalid next hop %q", nextHopIP)
+	}
+	for _, route := range routes {
+		// Default route will have a Dst of nil
+		if route.Dst == nil || route.Dst.String() != hostPrefix(ip) {
+			continue
+		}
+		if isNsmLinkIndex(linkMap, route.LinkIndex) {
+			return route.LinkIndex, nil
+		}
+		logger.GlobalLogger.Infof("Route to next hop %v uses link %v which is not an nsm interface, re-resolving",
+			nextHopIP, route.LinkIndex)
+		break
+	}
+
+	for index, link := range linkMap {
+		if !isNsmInterface(link.Attrs().Name) {
+			continue
+		}
+		addrList, err := nlHandle.AddrList(link, ipFamily(ip))
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to get address list for intf: %v, err: %v", link.Attrs().Name, err)
+			continue
+		}
+		for _, addr := range addrList {
+			if (addr.Peer != nil && addr.Peer.Contains(ip)) || (addr.IPNet != nil && addr.IPNet.Contains(ip)) {
+				return index, nil
+			}
+		}
+	}
+	return -1, fmt.Errorf("link idx of nexthop not found for %v", nextHopIP)
+}
+
+// verifyNextHopLinks checks that the links of the next hops are still NSM interfaces and re-resolves
+// the ones that are not. The next hops are updated in place.
+func verifyNextHopLinks(nextHopIPSlice []*netlink.NexthopInfo) error {
+	linkMap, err := getLinkIndexMap()
+	if err != nil {
+		return err
+	}
+	var routes []netlink.Route
+	for _, nextHop := range nextHopIPSlice {
+		if isNsmLinkIndex(linkMap, nextHop.LinkIndex) {
+			continue
+		}
+		if nextHop.Gw == nil {
+			// An interface next hop has no gateway to re-resolve its link from.
+			return fmt.Errorf("link %v of interface next hop is not an nsm interface", nextHop.LinkIndex)
+		}
+		if routes == nil {
+			routes, err = nlHandle.RouteList(nil, netlink.FAMILY_ALL)
+			if err != nil {
+				return err
+			}
+		}
+		linkIndex, err := resolveNextHopLink(nextHop.Gw.String(), routes, linkMap)
+		if err != nil {
+			return err
+		}
+		logger.GlobalLogger.Infof("Next hop %v moved from stale link %v to link %v", nextHop.Gw, nextHop.LinkIndex, linkIndex)
+		nextHop.LinkIndex = linkIndex
+	}
+	return nil
+}
+
+// hasStaleNextHopLink returns true if a next hop of the installed routes uses a link that is not an
+// NSM interface.
+func hasStaleNextHopLink(routes []netlink.Route, linkMap map[int]netlink.Link) bool {
+	for _, route := range routes {
+		if len(route.MultiPath) > 0 {
+			for _, path := range route.MultiPath {
+				if !isNsmLinkIndex(linkMap, path.LinkIndex) {
+					return true
+				}
+			}
+		} else if !isNsmLinkIndex(linkMap, route.LinkIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextHopResolver resolves the links of next hops from a single snapshot of the kernel routes and
+// links, caching the link of every next hop it resolved. A batch of routes sharing a few next hops
+// therefore lists the routes once instead of once per route.
+type nextHopResolver struct {
+	routes  []netlink.Route
+	linkMap map[int]netlink.Link
+	links   map[string]int
+	loaded  bool
+	// stale is set when routes were installed since the snapshot was taken. A next hop that cannot be
+	// resolved from a stale snapshot is retried with a fresh one, since it may be reached through one
+	// of the new routes.
+	stale bool
+}
+
+func newNextHopResolver() *nextHopResolver {
+	return &nextHopResolver{links: map[string]int{}}
+}
+
+func (r *nextHopResolver) load() error {
+	routes, err := nlHandle.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return err
+	}
+	linkMap, err := getLinkIndexMap()
+	if err != nil {
+		return err
+	}
+	r.routes, r.linkMap, r.loaded, r.stale = routes, linkMap, true, false
+	return nil
+}
+
+// invalidate records that routes were installed since the snapshot was taken.
+func (r *nextHopResolver) invalidate() {
+	r.stale = true
+}
+
+// resolve returns the netlink next hop info of the next hops.
+func (r *nextHopResolver) resolve(nextHopIPList []string) ([]*netlink.NexthopInfo, error) {
+	if !r.loaded {
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	}
+	nextHopIpSlice := []*netlink.NexthopInfo{}
+	for _, nextHopIP := range nextHopIPList {
+		if name, ok := parseDevNextHop(nextHopIP); ok {
+			nextHop, err := resolveDevNextHop(name, r.linkMap)
+			if err != nil && r.stale {
+				if err := r.load(); err != nil {
+					return nil, err
+				}
+				nextHop, err = resolveDevNextHop(name, r.linkMap)
+			}
+			if err != nil {
+				return nil, err
+			}
+			nextHopIpSlice = append(nextHopIpSlice, nextHop)
+			continue
+		}
+		linkIdx, ok := r.links[nextHopIP]
+		if !ok {
+			var err error
+			linkIdx, err = resolveNextHopLink(nextHopIP, r.routes, r.linkMap)
+			if err != nil && r.stale {
+				if err := r.load(); err != nil {
+					return nil, err
+				}
+				linkIdx, err = resolveNextHopLink(nextHopIP, r.routes, r.linkMap)
+			}
+			if err != nil {
+				return nil, err
+			}
+			r.links[nextHopIP] = linkIdx
+		}
+		gwObj := &netlink.NexthopInfo{LinkIndex: linkIdx, Gw: net.ParseIP(nextHopIP), Flags: int(netlink.FLAG_ONLINK)}
+		nextHopIpSlice = append(nextHopIpSlice, gwObj)
+	}
+	if err := applyNextHopWeights(nextHopIpSlice); err != nil {
+		return nil, err
+	}
+	return nextHopIpSlice, nil
+}
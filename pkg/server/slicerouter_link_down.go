@@ -0,0 +1,263 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// The egress links of the routes are not monitored.
+	linkDownRoutePolicyNone = "none"
+	// Routes whose egress links are all down are logged and counted, and left in place.
+	linkDownRoutePolicyLog = "log"
+	// Routes whose egress links are all down are removed from the kernel until a link comes back up,
+	// so that traffic falls back to less specific routes.
+	linkDownRoutePolicyDelete = "delete"
+
+	// routeSuspendedLinkDown marks the routes withdrawn by the link down route policy among the
+	// suspended routes.
+	routeSuspendedLinkDown = "link-down"
+)
+
+// getLinkDownRoutePolicy returns what is done with the routes whose egress links all go down, read from
+// the LINK_DOWN_ROUTE_POLICY env variable. The links are not monitored by default.
+func getLinkDownRoutePolicy() string {
+	policy := os.Getenv("LINK_DOWN_ROUTE_POLICY")
+	switch policy {
+	case linkDownRoutePolicyNone, linkDownRoutePolicyLog, linkDownRoutePolicyDelete:
+		return policy
+	case "":
+		return linkDownRoutePolicyNone
+	}
+	logger.GlobalLogger.Errorf("Invalid LINK_DOWN_ROUTE_POLICY %q, using %v", policy, linkDownRoutePolicyNone)
+	return linkDownRoutePolicyNone
+}
+
+// isLinkOperDown returns true if the link cannot carry traffic. The kernel keeps the onlink routes of a
+// link that lost its carrier, only marking their next hops as linkdown.
+func isLinkOperDown(link netlink.Link) bool {
+	state := link.Attrs().OperState
+	return state == netlink.OperDown || state == netlink.OperLowerLayerDown
+}
+
+// routeEgressLinks returns the index of the links the kernel route goes out of.
+func routeEgressLinks(route netlink.Route) []int {
+	if len(route.MultiPath) == 0 {
+		if route.LinkIndex == 0 {
+			return nil
+		}
+		return []int{route.LinkIndex}
+	}
+	links := []int{}
+	for _, nextHop := range route.MultiPath {
+		links = append(links, nextHop.LinkIndex)
+	}
+	return links
+}
+
+// linkDownMonitor applies the link down route policy to the slice routes from netlink link events. It
+// is only used from the goroutine applying the events.
+type linkDownMonitor struct {
+	policy string
+	// down holds the index of the links that are down.
+	down map[int]bool
+	// blackholed holds the remote subnets whose egress links are all down, so that each is reported
+	// once per outage.
+	blackholed map[string]bool
+	// withdrawn holds the egress links of the routes removed by the delete policy, by remote subnet.
+	withdrawn map[string][]int
+}
+
+func newLinkDownMonitor(policy string) *linkDownMonitor {
+	return &linkDownMonitor{policy: policy, down: map[int]bool{}, blackholed: map[string]bool{},
+		withdrawn: map[string][]int{}}
+}
+
+// allLinksDown returns true if the links are all known to be down.
+func (m *linkDownMonitor) allLinksDown(links []int) bool {
+	for _, index := range links {
+		if !m.down[index] {
+			return false
+		}
+	}
+	return len(links) > 0
+}
+
+// fill records the links that are down from the links in the kernel.
+func (m *linkDownMonitor) fill() error {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		return err
+	}
+	m.down = map[int]bool{}
+	for _, link := range links {
+		if isLinkOperDown(link) {
+			m.down[link.Attrs().Index] = true
+		}
+	}
+	return nil
+}
+
+// handleLinkUpdate records the state of the link and applies the policy to the routes if the link went
+// down or came back up. Links that are deleted take their routes with them.
+func (m *linkDownMonitor) handleLinkUpdate(update netlink.LinkUpdate) {
+	index := update.Attrs().Index
+	if update.Header.Type == unix.RTM_DELLINK {
+		delete(m.down, index)
+		return
+	}
+	down := isLinkOperDown(update.Link)
+	if down == m.down[index] {
+		return
+	}
+	if down {
+		logger.GlobalLogger.Warnf("Link %v is down", update.Attrs().Name)
+		m.down[index] = true
+	} else {
+		logger.GlobalLogger.Infof("Link %v is up", update.Attrs().Name)
+		delete(m.down, index)
+		m.restoreRoutes()
+	}
+	m.checkRoutes()
+}
+
+// checkRoutes reports the slice routes whose egress links are all down and, with the delete policy,
+// removes them from the kernel.
+func (m *linkDownMonitor) checkRoutes() {
+	routes, err := nlHandle.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to list the routes to check their egress links: %v", err)
+		return
+	}
+	blackholed := map[string]bool{}
+	for i := range routes {
+		remoteSubnet := kernelRouteDst(routes[i])
+		if remoteSubnet == "" || !isSliceRouteMetric(routes[i]) || isRouteSuspended(remoteSubnet) {
+			continue
+		}
+		if _, ok := remoteSubnetRouteMap.Load(remoteSubnet); !ok {
+			continue
+		}
+		links := routeEgressLinks(routes[i])
+		if !m.allLinksDown(links) {
+			continue
+		}
+		blackholed[remoteSubnet] = true
+		if m.blackholed[remoteSubnet] {
+			continue
+		}
+		logger.GlobalLogger.Warnf("Egress links of the route are down, traffic is blackholed. RemoteSubnet: %v, Policy: %v",
+			remoteSubnet, m.policy)
+		linkDownRoutesCounter.Inc(m.policy)
+		if m.policy != linkDownRoutePolicyDelete {
+			continue
+		}
+		if err := nlHandle.RouteDel(&routes[i]); err != nil {
+			logger.GlobalLogger.Errorf("Failed to remove route with egress links down. RemoteSubnet: %v, Err: %v", remoteSubnet, err)
+			continue
+		}
+		logIproute2Command("del", &routes[i])
+		suspendedRoutes.Store(remoteSubnet, routeSuspendedLinkDown)
+		m.withdrawn[remoteSubnet] = links
+	}
+	m.blackholed = blackholed
+}
+
+// restoreRoutes programs again the routes removed by the delete policy that have an egress link back
+// up. Routes that were injected or deleted since they were removed are forgotten.
+func (m *linkDownMonitor) restoreRoutes() {
+	remoteSubnets := []string{}
+	for remoteSubnet := range m.withdrawn {
+		remoteSubnets = append(remoteSubnets, remoteSubnet)
+	}
+	sort.Strings(remoteSubnets)
+	for _, remoteSubnet := range remoteSubnets {
+		if policy, ok := suspendedRoutes.Load(remoteSubnet); !ok || policy != routeSuspendedLinkDown {
+			delete(m.withdrawn, remoteSubnet)
+			continue
+		}
+		if m.allLinksDown(m.withdrawn[remoteSubnet]) {
+			continue
+		}
+		delete(m.withdrawn, remoteSubnet)
+		nextHops, ok := remoteSubnetRouteMap.Load(remoteSubnet)
+		if !ok {
+			suspendedRoutes.Delete(remoteSubnet)
+			continue
+		}
+		logger.GlobalLogger.Infof("Egress link of the route is up, restoring it. RemoteSubnet: %v", remoteSubnet)
+		if err := sliceRouterProgramHealthyNextHops(remoteSubnet, nextHops.([]string)); err != nil {
+			logger.GlobalLogger.Errorf("Failed to restore route. RemoteSubnet: %v, Err: %v", remoteSubnet, err)
+		}
+	}
+}
+
+// monitor subscribes to link events and applies the policy to the routes until stop is closed or the
+// subscription ends.
+func (m *linkDownMonitor) monitor(stop <-chan struct{}) {
+	done := make(chan struct{})
+	defer close(done)
+
+	linkCh := make(chan netlink.LinkUpdate, 64)
+	if err := linkSubscribe(linkCh, done); err != nil {
+		logger.GlobalLogger.Errorf("Failed to subscribe to link events: %v", err)
+		return
+	}
+	// The links are read after subscribing so that no change is missed in between.
+	if err := m.fill(); err != nil {
+		logger.GlobalLogger.Errorf("Failed to list the links: %v", err)
+		return
+	}
+	m.restoreRoutes()
+	m.checkRoutes()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case update, ok := <-linkCh:
+			if !ok {
+				logger.GlobalLogger.Errorf("Link event subscription closed")
+				return
+			}
+			m.handleLinkUpdate(update)
+		}
+	}
+}
+
+// linkDownMonitorLoop applies the link down route policy to the slice routes from netlink link events
+// until the context is done. The subscription is retried if it fails.
+func linkDownMonitorLoop(ctx context.Context) {
+	m := newLinkDownMonitor(getLinkDownRoutePolicy())
+	for {
+		m.monitor(ctx.Done())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(connectionCacheRetryInterval):
+		}
+	}
+}
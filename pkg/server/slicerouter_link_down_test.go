@@ -0,0 +1,201 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// linkStateUpdate returns the link event of the nsm link with the given index in the given state.
+func linkStateUpdate(index int, state netlink.LinkOperState) netlink.LinkUpdate {
+	return netlink.LinkUpdate{
+		Header: unix.NlMsghdr{Type: unix.RTM_NEWLINK},
+		Link:   &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: index, Name: "vl3-1", OperState: state}},
+	}
+}
+
+func TestGetLinkDownRoutePolicy(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	tests := []struct {
+		value  string
+		expect string
+	}{
+		{"", linkDownRoutePolicyNone},
+		{"none", linkDownRoutePolicyNone},
+		{"log", linkDownRoutePolicyLog},
+		{"delete", linkDownRoutePolicyDelete},
+		{"blackhole", linkDownRoutePolicyNone},
+	}
+	for _, tt := range tests {
+		t.Setenv("LINK_DOWN_ROUTE_POLICY", tt.value)
+		if policy := getLinkDownRoutePolicy(); policy != tt.expect {
+			t.Errorf("%q: expected %v, received %v", tt.value, tt.expect, policy)
+		}
+	}
+}
+
+func TestLinkDownRoutePolicy(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName    string
+		policy      string
+		expectDown  []string
+		expectState pb.RouteForwardingState
+	}{
+		{"route logged", linkDownRoutePolicyLog, []string{"192.168.0.2"}, pb.RouteForwardingState_FORWARDING_ACTIVE},
+		{"route deleted", linkDownRoutePolicyDelete, []string{"absent"}, pb.RouteForwardingState_FORWARDING_WITHDRAWN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			linkDownRoutesCounter.Reset()
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+
+			remoteSubnet := "10.1.0.0/16"
+			if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+
+			m := newLinkDownMonitor(tt.policy)
+			if err := m.fill(); err != nil {
+				t.Fatal(err)
+			}
+			// Repeated events of a link that stays down are reported once.
+			m.handleLinkUpdate(linkStateUpdate(1, netlink.OperLowerLayerDown))
+			m.handleLinkUpdate(linkStateUpdate(1, netlink.OperLowerLayerDown))
+			if state := kernelRouteState(t, fake, remoteSubnet); !reflect.DeepEqual(state, tt.expectDown) {
+				t.Errorf("link down: expected %v, received %v", tt.expectDown, state)
+			}
+			if count := linkDownRoutesCounter.Value(tt.policy); count != 1 {
+				t.Errorf("expected 1 route counted, received %v", count)
+			}
+			if state := routeForwardingState(remoteSubnet, []string{"192.168.0.2"}); state != tt.expectState {
+				t.Errorf("expected forwarding state %v, received %v", tt.expectState, state)
+			}
+
+			// The reconcile leaves a withdrawn route alone.
+			if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+				t.Fatal(err)
+			}
+			if state := kernelRouteState(t, fake, remoteSubnet); !reflect.DeepEqual(state, tt.expectDown) {
+				t.Errorf("reconcile: expected %v, received %v", tt.expectDown, state)
+			}
+
+			m.handleLinkUpdate(linkStateUpdate(1, netlink.OperUp))
+			if state := kernelRouteState(t, fake, remoteSubnet); !reflect.DeepEqual(state, []string{"192.168.0.2"}) {
+				t.Errorf("link up: expected the route restored, received %v", state)
+			}
+			if isRouteSuspended(remoteSubnet) {
+				t.Error("link up: expected the route to be resumed")
+			}
+		})
+	}
+}
+
+func TestLinkDownRoutePolicyMultipath(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	linkDownRoutesCounter.Reset()
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.1.2", 2)
+	useFakeNetlink(t, fake)
+
+	remoteSubnet := "10.1.0.0/16"
+	if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2", "192.168.1.2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newLinkDownMonitor(linkDownRoutePolicyDelete)
+	// The route still forwards through its other link.
+	m.handleLinkUpdate(linkStateUpdate(1, netlink.OperDown))
+	if state := kernelRouteState(t, fake, remoteSubnet); len(state) != 2 {
+		t.Errorf("one link down: expected both next hops, received %v", state)
+	}
+	m.handleLinkUpdate(linkStateUpdate(2, netlink.OperDown))
+	if state := kernelRouteState(t, fake, remoteSubnet); !reflect.DeepEqual(state, []string{"absent"}) {
+		t.Errorf("all links down: expected the route removed, received %v", state)
+	}
+	if count := linkDownRoutesCounter.Value(linkDownRoutePolicyDelete); count != 1 {
+		t.Errorf("expected 1 route counted, received %v", count)
+	}
+}
+
+func TestLinkDownMonitor(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+	subs := useFakeSubscriptions(t)
+
+	remoteSubnet := "10.1.0.0/16"
+	if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		newLinkDownMonitor(linkDownRoutePolicyDelete).monitor(stop)
+		close(stopped)
+	}()
+	defer func() {
+		close(stop)
+		<-stopped
+	}()
+	linkCh := <-subs.linkCh
+
+	waitForRouteState := func(want []string) {
+		t.Helper()
+		var state []string
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if state = kernelRouteState(t, fake, remoteSubnet); reflect.DeepEqual(state, want) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Route state = %v, want %v", state, want)
+	}
+
+	linkCh <- linkStateUpdate(1, netlink.OperDown)
+	waitForRouteState([]string{"absent"})
+	linkCh <- linkStateUpdate(1, netlink.OperUp)
+	waitForRouteState([]string{"192.168.0.2"})
+}
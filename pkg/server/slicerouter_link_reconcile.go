@@ -0,0 +1,111 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+const (
+	// Number of times the reconcile of the unresolved routes is attempted while another reconcile is
+	// running.
+	unresolvedRouteReconcileAttempts = 5
+)
+
+// unresolvedRouteReconcileInterval is the wait before attempting the reconcile of the unresolved routes
+// again. It is a variable so that tests do not have to wait.
+var unresolvedRouteReconcileInterval = time.Second
+
+// unresolvedRouteSubnets holds the remote subnets whose routes are kept for retry but could not be
+// installed because their next hops did not resolve to an nsm link.
+var unresolvedRouteSubnets sync.Map
+
+// markUnresolvedRoute records that the next hops of the route to the remote subnet did not resolve.
+func markUnresolvedRoute(remoteSubnet string) {
+	unresolvedRouteSubnets.Store(remoteSubnet, struct{}{})
+}
+
+// clearUnresolvedRoute records that the route to the remote subnet was installed.
+func clearUnresolvedRoute(remoteSubnet string) {
+	unresolvedRouteSubnets.Delete(remoteSubnet)
+}
+
+// unresolvedRouteFilter returns the filter matching the unresolved routes that are still desired. The
+// routes removed since their next hops failed to resolve are forgotten.
+func unresolvedRouteFilter() prefixFilter {
+	filter := prefixFilter{}
+	unresolvedRouteSubnets.Range(func(key, value any) bool {
+		remoteSubnet := key.(string)
+		_, desired := remoteSubnetRouteMap.Load(remoteSubnet)
+		_, ipNet, err := net.ParseCIDR(remoteSubnet)
+		if !desired || err != nil {
+			unresolvedRouteSubnets.Delete(remoteSubnet)
+			return true
+		}
+		filter = append(filter, ipNet)
+		return true
+	})
+	return filter
+}
+
+// sliceRouterReconcileUnresolvedRoutes reconciles the routes whose next hops previously failed to
+// resolve, leaving the other routes to the regular reconcile.
+func sliceRouterReconcileUnresolvedRoutes() error {
+	filter := unresolvedRouteFilter()
+	if len(filter) == 0 {
+		return nil
+	}
+	logger.GlobalLogger.Infof("Reconciling routes with unresolved next hops: %v", filter)
+	return sliceRouterReconcileRoutingTable(context.Background(), filter)
+}
+
+// reconcileOnNsmLinkAdded starts a reconcile of the routes whose next hops previously failed to resolve
+// once a new nsm link is set up, as their next hops may be reachable through it. The reconcile is
+// attempted again while another reconcile is running, that one may have listed the links before the
+// new link appeared.
+func reconcileOnNsmLinkAdded(linkName string) {
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp || len(unresolvedRouteFilter()) == 0 {
+		return
+	}
+	logger.GlobalLogger.Infof("New nsm interface %v, retrying the routes with unresolved next hops", linkName)
+	startBackgroundTask("unresolved-route-reconcile", func(ctx context.Context) {
+		for attempt := 1; ; attempt++ {
+			err := sliceRouterReconcileUnresolvedRoutes()
+			if err != errReconcileInProgress {
+				if err != nil {
+					logger.GlobalLogger.Errorf("Failed to reconcile routes with unresolved next hops: %v", err)
+				}
+				return
+			}
+			if attempt >= unresolvedRouteReconcileAttempts {
+				logger.GlobalLogger.Infof("Routing table reconcile still running, leaving the unresolved routes to the next reconcile")
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(unresolvedRouteReconcileInterval):
+			}
+		}
+	})
+}
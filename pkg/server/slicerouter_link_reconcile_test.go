@@ -0,0 +1,83 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestReconcileOnNsmLinkAdded(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("RETRY_FAILED_INJECTS", "true")
+	skipReconcile(t)
+
+	tests := []struct {
+		testName string
+		// nextHop of the route injected before the link appears
+		nextHop string
+		// linkName of the link added along with the connected route to 192.168.0.2
+		linkName           string
+		expectedInstalled  bool
+		expectedUnresolved bool
+	}{
+		{"deferred route installed on a new nsm link", "192.168.0.2", "vl3-1", true, false},
+		{"route through another link stays deferred", "192.168.1.2", "vl3-1", false, true},
+		{"non nsm link does not reconcile", "192.168.0.2", "eth1", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectRoute("10.1.0.0/16", []string{tt.nextHop})
+			var rErr *routeError
+			if !errors.As(err, &rErr) || rErr.reason != routeErrorNextHopUnresolved {
+				t.Fatal("expected an unresolved next hop error, received", err)
+			}
+			if _, ok := unresolvedRouteSubnets.Load("10.1.0.0/16"); !ok {
+				t.Fatal("route not recorded as unresolved")
+			}
+
+			link := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: tt.linkName}}
+			fake.mu.Lock()
+			fake.links = append(fake.links, link)
+			fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("192.168.0.1"), Mask: net.CIDRMask(32, 32)}}}
+			fake.routes = append(fake.routes, netlink.Route{Dst: mustParseCIDR("192.168.0.2/32"), LinkIndex: 1})
+			fake.mu.Unlock()
+			connCache.handleLinkUpdate(netlink.LinkUpdate{Header: unix.NlMsghdr{Type: unix.RTM_NEWLINK}, Link: link})
+			t.Cleanup(connCache.invalidate)
+			waitForBackgroundTask(t, "unresolved-route-reconcile")
+
+			if installed := routeTo(t, fake, "10.1.0.0/16") != nil; installed != tt.expectedInstalled {
+				t.Error("route installed: expected", tt.expectedInstalled, "received", installed)
+			}
+			if _, unresolved := unresolvedRouteSubnets.Load("10.1.0.0/16"); unresolved != tt.expectedUnresolved {
+				t.Error("route unresolved: expected", tt.expectedUnresolved, "received", unresolved)
+			}
+		})
+	}
+}
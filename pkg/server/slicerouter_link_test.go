@@ -0,0 +1,227 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// reuseLinkIndex replaces the NSM link with the given index by a non NSM interface reusing the index,
+// and moves the NSM peer to a new link.
+func reuseLinkIndex(f *fakeNetlink, linkIndex int, newLinkIndex int, nextHopIP string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, link := range f.links {
+		if link.Attrs().Index == linkIndex {
+			f.links[i] = &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: linkIndex, Name: "eth5"}}
+		}
+	}
+	f.links = append(f.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: newLinkIndex, Name: "vl3-new"}})
+	f.addrs[newLinkIndex] = []netlink.Addr{{
+		IPNet: &net.IPNet{IP: net.ParseIP("192.168.0.1"), Mask: net.CIDRMask(32, 32)},
+		Peer:  &net.IPNet{IP: net.ParseIP(nextHopIP), Mask: net.CIDRMask(32, 32)},
+	}}
+}
+
+func routeLinkIndex(t *testing.T, f *fakeNetlink, remoteSubnet string) int {
+	t.Helper()
+	routes, _ := f.RouteList(nil, 0)
+	for _, route := range routes {
+		if route.Dst != nil && route.Dst.String() == remoteSubnet {
+			return route.LinkIndex
+		}
+	}
+	t.Fatal("route not installed:", remoteSubnet)
+	return -1
+}
+
+func TestLinkIndexReuse(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName     string
+		apply        func() error
+		remoteSubnet string
+	}{
+		{
+			"injection re-resolves the link of the next hop",
+			func() error {
+				return sliceRouterInjectRoute("10.2.0.0/16", []string{"192.168.0.2"})
+			},
+			"10.2.0.0/16",
+		},
+		{
+			"reconcile re-installs routes using a reused link index",
+			func() error {
+				return vl3ReconcileRoutesInKernel(nil, nil)
+			},
+			"10.1.0.0/16",
+		},
+		{
+			"stale next hop info is corrected before install",
+			func() error {
+				return vl3InjectRouteInKernel("10.1.0.0/16", []*netlink.NexthopInfo{
+					{LinkIndex: 1, Gw: net.ParseIP("192.168.0.2"), Flags: int(netlink.FLAG_ONLINK)},
+				})
+			},
+			"10.1.0.0/16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+
+			// vl3-1 is deleted and its index is taken by eth5. The connected route to the
+			// next hop still points at the old index.
+			reuseLinkIndex(fake, 1, 7, "192.168.0.2")
+
+			if err := tt.apply(); err != nil {
+				t.Fatal(err)
+			}
+			if idx := routeLinkIndex(t, fake, tt.remoteSubnet); idx != 7 {
+				t.Error("link index: expected 7, received", idx)
+			}
+		})
+	}
+}
+
+func TestDualStackNextHopLinks(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	audit := &routeAuditLog{size: 16}
+	useRouteAuditLog(t, audit)
+
+	// The IPv4 next hop is connected on link 1, the IPv6 next hop of the same slice gw on link 2 and
+	// a second IPv6 next hop is covered by the address of link 3.
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.links = append(fake.links,
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "vl3-2"}},
+		&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 3, Name: "vl3-3"}})
+	fake.routes = append(fake.routes, netlink.Route{Dst: mustParseCIDR("fd00::2/128"), LinkIndex: 2})
+	fake.addrs[3] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("fd00:1::1"), Mask: net.CIDRMask(64, 128)}}}
+	// An IPv4 address covering nothing of the IPv6 next hops must not attract them.
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("192.168.0.1"), Mask: net.CIDRMask(24, 32)}}}
+	useFakeNetlink(t, fake)
+
+	routes := []struct {
+		remoteSubnet string
+		nextHops     []string
+		linkIndex    int
+	}{
+		{"10.1.0.0/16", []string{"192.168.0.2"}, 1},
+		{"fd10::/64", []string{"fd00::2"}, 2},
+		{"fd20::/64", []string{"fd00:1::2"}, 3},
+	}
+	for _, route := range routes {
+		if err := sliceRouterInjectRoute(route.remoteSubnet, route.nextHops); err != nil {
+			t.Fatal(route.remoteSubnet, err)
+		}
+		if linkIndex := routeLinkIndex(t, fake, route.remoteSubnet); linkIndex != route.linkIndex {
+			t.Error(route.remoteSubnet, "link: expected", route.linkIndex, "received", linkIndex)
+		}
+	}
+
+	// The reconcile finds the routes of both families installed.
+	if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range audit.list() {
+		if entry.GetOperation() == routeAuditReconcile {
+			t.Error("installed route reconciled:", entry.GetRemoteSubnet())
+		}
+	}
+
+	if err := sliceRouterInjectRoute("fd10::/64", nil); err != nil {
+		t.Fatal(err)
+	}
+	if v6Routes, _ := fake.RouteList(nil, netlink.FAMILY_V6); len(getRouteNextHops(v6Routes, "fd10::/64")) != 0 {
+		t.Error("IPv6 route not deleted")
+	}
+
+	// A next hop of the other family is rejected.
+	err := sliceRouterInjectRoute("fd30::/64", []string{"192.168.0.2"})
+	var rErr *routeError
+	if !errors.As(err, &rErr) || rErr.reason != routeErrorInvalidArgument {
+		t.Error("expected an invalid argument error, received", err)
+	}
+}
+
+func TestInjectRejectsNonUnicastNextHop(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	tests := []struct {
+		testName     string
+		remoteSubnet string
+		nextHop      string
+		expectReason routeErrorReason
+	}{
+		{"unicast", "10.1.0.0/16", "192.168.0.2", ""},
+		{"ipv4 unspecified", "10.1.0.0/16", "0.0.0.0", routeErrorInvalidArgument},
+		{"ipv6 unspecified", "fd10::/64", "::", routeErrorInvalidArgument},
+		{"ipv4 loopback", "10.1.0.0/16", "127.0.0.1", routeErrorInvalidArgument},
+		{"ipv6 loopback", "fd10::/64", "::1", routeErrorInvalidArgument},
+		{"ipv4 broadcast", "10.1.0.0/16", "255.255.255.255", routeErrorInvalidArgument},
+		{"ipv4 multicast", "10.1.0.0/16", "224.0.0.5", routeErrorInvalidArgument},
+		{"ipv6 multicast", "fd10::/64", "ff02::1", routeErrorInvalidArgument},
+		{"ipv4 link-local", "10.1.0.0/16", "169.254.0.1", routeErrorInvalidArgument},
+		{"ipv6 link-local", "fd10::/64", "fe80::1", routeErrorInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			err := sliceRouterInjectRoute(tt.remoteSubnet, []string{tt.nextHop})
+			if tt.expectReason == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var rErr *routeError
+			if !errors.As(err, &rErr) || rErr.reason != tt.expectReason {
+				t.Fatal("expected reason", tt.expectReason, "received", err)
+			}
+			if !strings.Contains(err.Error(), tt.nextHop) {
+				t.Error("error does not name the next hop:", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+)
+
+// subnetsOverlap checks if one of the subnets contains the other.
+func subnetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// maskedSubnet returns the subnet the address belongs to.
+func maskedSubnet(ipNet *net.IPNet) *net.IPNet {
+	return &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask}
+}
+
+// vl3GetNsmSubnetsInKernel returns the subnets of the addresses on the nsm interfaces connecting the
+// clients to the slice router.
+func vl3GetNsmSubnetsInKernel() ([]*net.IPNet, error) {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	subnets := []*net.IPNet{}
+	for _, link := range links {
+		if !isNsmInterface(link.Attrs().Name) {
+			continue
+		}
+		addrs, err := nlHandle.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			if addr.IPNet != nil {
+				subnets = append(subnets, maskedSubnet(addr.IPNet))
+			}
+			if addr.Peer != nil {
+				subnets = append(subnets, maskedSubnet(addr.Peer))
+			}
+		}
+	}
+	return subnets, nil
+}
+
+// vl3GetNsmSubnetsInVpp returns the subnets of the addresses on the nsm interfaces in vpp.
+func vl3GetNsmSubnetsInVpp() ([]*net.IPNet, error) {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	// Only the interfaces are read, the rest of the config is skipped.
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{}, vppGetInterfacesOnly())
+	if err != nil {
+		return nil, err
+	}
+	subnets := []*net.IPNet{}
+	for _, intf := range vppConfig.GetConfig().GetVppConfig().GetInterfaces() {
+		if !isVppNsmInterface(intf.GetName()) {
+			continue
+		}
+		for _, ipAddress := range intf.GetIpAddresses() {
+			_, subnet, err := net.ParseCIDR(ipAddress)
+			if err != nil {
+				logger.GlobalLogger.Errorf("Skipping invalid address %v on vpp intf %v", ipAddress, intf.GetName())
+				continue
+			}
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets, nil
+}
+
+// checkLocalSubnetOverlap returns an error if the remote subnet overlaps the subnet of an nsm interface.
+// A route to such a remote subnet would take over the traffic to the local clients. The check is skipped
+// if the local subnets cannot be listed, the injection then fails or succeeds on its own.
+func checkLocalSubnetOverlap(remoteSubnet string) error {
+	_, remoteNet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return err
+	}
+
+	var localSubnets []*net.IPNet
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		localSubnets, err = vl3GetNsmSubnetsInVpp()
+	} else {
+		localSubnets, err = vl3GetNsmSubnetsInKernel()
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Could not get local nsm subnets, skipping overlap check: %v", err)
+		return nil
+	}
+
+	for _, localSubnet := range localSubnets {
+		if subnetsOverlap(remoteNet, localSubnet) {
+			return fmt.Errorf("remote subnet overlaps local nsm subnet %v", localSubnet)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+)
+
+func TestLocalSubnetOverlap(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	skipReconcile(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addrs[1] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: net.CIDRMask(30, 32)}}}
+	// Addresses of interfaces that are not nsm interfaces are not local nsm subnets.
+	fake.links = append(fake.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 9, Name: "eth0"}})
+	fake.addrs[9] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("10.244.0.5"), Mask: net.CIDRMask(24, 32)}}}
+	useFakeNetlink(t, fake)
+
+	vppAgent := newFakeVppAgent()
+	vppAgent.config.Interfaces = []*vpp.Interface{
+		{Name: "iperf-client", IpAddresses: []string{"10.1.1.2/30"}},
+	}
+	useFakeVppAgent(t, vppAgent)
+
+	tests := []struct {
+		testName     string
+		dataplane    string
+		remoteSubnet string
+		expectedErr  bool
+	}{
+		{"kernel subnet covering the nsm subnet", SliceRouterDataplaneKernel, "10.1.0.0/16", true},
+		{"kernel subnet inside the nsm subnet", SliceRouterDataplaneKernel, "10.1.1.1/32", true},
+		{"kernel subnet next to the nsm subnet", SliceRouterDataplaneKernel, "10.1.1.4/30", false},
+		{"kernel disjoint subnet", SliceRouterDataplaneKernel, "10.2.0.0/16", false},
+		{"kernel subnet of a non nsm interface", SliceRouterDataplaneKernel, "10.244.0.0/16", false},
+		{"vpp subnet covering the nsm subnet", SliceRouterDataplaneVpp, "10.1.0.0/16", true},
+		{"vpp disjoint subnet", SliceRouterDataplaneVpp, "10.2.0.0/16", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			resetRouteMap(t)
+
+			err := sliceRouterInjectRoute(tt.remoteSubnet, []string{"192.168.0.2"})
+			if !tt.expectedErr {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var rErr *routeError
+			if !errors.As(err, &rErr) || rErr.reason != routeErrorInvalidArgument {
+				t.Fatal("expected an invalid argument error, received", err)
+			}
+			if _, ok := remoteSubnetRouteMap.Load(tt.remoteSubnet); ok {
+				t.Error("overlapping route recorded as injected")
+			}
+			// Deleting a route is never rejected.
+			if err := sliceRouterInjectRoute(tt.remoteSubnet, nil); err != nil {
+				t.Error("delete:", err)
+			}
+		})
+	}
+}
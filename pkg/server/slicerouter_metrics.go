@@ -0,0 +1,119 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"os"
+
+	"github.com/kubeslice/router-sidecar/pkg/metrics"
+)
+
+var (
+	nextHopHealthGauge = metrics.NewGaugeVec("slicerouter_nexthop_healthy",
+		"Health of the next hops of the slice routes as seen by the health checker, 1 if healthy and 0 if not.", "nexthop")
+
+	// The counter is not labeled by subnet: remote subnets come and go with the clusters of the slice
+	// and would make the series count unbounded.
+	nextHopChangesCounter = metrics.NewCounterVec("slicerouter_nexthop_changes_total",
+		"Number of route injections that changed the next hops of an existing remote subnet route.")
+
+	// Shifts of routes between next hops point at slice gw failovers.
+	routeCountByNextHopGauge = metrics.NewGaugeVec("slicerouter_nexthop_routes",
+		"Number of remote subnet routes injected with each next hop.", "nexthop")
+
+	unknownNextHopsCounter = metrics.NewCounterVec("slicerouter_unknown_nexthops_total",
+		"Number of injections rejected in strict mode because a next hop is not the IP of a connected nsm peer.")
+
+	// Drifts point at another agent managing the same routes.
+	routeDriftCounter = metrics.NewCounterVec("slicerouter_route_drifts_total",
+		"Number of installed routes reinstalled by the reconcile because a managed attribute was changed, by attribute.", "attribute")
+
+	foreignRouteConflictsCounter = metrics.NewCounterVec("slicerouter_foreign_route_conflicts_total",
+		"Number of route writes that found a route of another routing agent to the destination, by foreign route policy.", "policy")
+
+	asymmetricNextHopsCounter = metrics.NewCounterVec("slicerouter_asymmetric_nexthops_total",
+		"Number of injected route next hops found going out of another interface than the one of their connection.")
+
+	// The vpp-agent accepted the updates but vpp did not apply them.
+	vppUpdatesNotAppliedCounter = metrics.NewCounterVec("slicerouter_vpp_updates_not_applied_total",
+		"Number of vpp-agent updates whose routes did not show up in the vpp config within the verify timeout.")
+
+	// Collapsed changes point at next hops flapping faster than the debounce window.
+	routeInjectsDebouncedCounter = metrics.NewCounterVec("slicerouter_route_injects_debounced_total",
+		"Number of route next hop changes held back by the inject debounce window, by outcome: deferred or collapsed.", "outcome")
+
+	reconcileDeferredCounter = metrics.NewCounterVec("slicerouter_reconcile_deferred_total",
+		"Number of reconcile cycles that deferred route corrections to the next cycle after reaching the correction limit.")
+
+	routeCountGauge = metrics.NewGaugeVec("slicerouter_routes",
+		"Number of remote subnet routes injected in the slice router.")
+	routeTableCapacityGauge = metrics.NewGaugeVec("slicerouter_route_table_capacity",
+		"Configured capacity of the slice route table, 0 if unknown.")
+	routeTableUtilizationGauge = metrics.NewGaugeVec("slicerouter_route_table_utilization",
+		"Fraction of the slice route table capacity in use, 0 if the capacity is unknown.")
+
+	vppAgentFailoversCounter = metrics.NewCounterVec("slicerouter_vpp_agent_failovers_total",
+		"Number of times the sidecar moved to the next vpp-agent endpoint after persistent failures.")
+
+	// Throttled retries point at a vpp-agent that keeps failing.
+	vppAgentRetriesCounter = metrics.NewCounterVec("slicerouter_vpp_agent_retries_total",
+		"Number of route operations to the vpp-agent that were retried or not retried because the retry budget was exhausted, by result.", "result")
+
+	// The breaker opens after consecutive vpp-agent push failures and rejects the injections until the
+	// vpp-agent recovers.
+	vppAgentBreakerOpenGauge = metrics.NewGaugeVec("slicerouter_vpp_agent_breaker_open",
+		"1 if route injections are rejected because the vpp-agent pushes keep failing, 0 otherwise.")
+	vppAgentBreakerRejectionsCounter = metrics.NewCounterVec("slicerouter_vpp_agent_breaker_rejections_total",
+		"Number of route injections rejected because the vpp-agent pushes keep failing.")
+
+	// Deletions are labeled by reason so that route removals requested by the controller are not
+	// confused with the delete before add of a next hop change.
+	vppRouteDeletesCounter = metrics.NewCounterVec("slicerouter_vpp_route_deletes_total",
+		"Number of route entries deleted in vpp, by reason.", "reason")
+
+	routeEventFailuresCounter = metrics.NewCounterVec("slicerouter_route_event_failures_total",
+		"Number of route events that could not be delivered to a sink after retries, by sink.", "sink")
+	routeEventsDroppedCounter = metrics.NewCounterVec("slicerouter_route_events_dropped_total",
+		"Number of route events dropped because the export queue was full.")
+	routeStatusPatchesCounter = metrics.NewCounterVec("slicerouter_route_status_patches_total",
+		"Number of patches of the route status custom resource, by result.", "result")
+
+	// Onlink routes stay in the kernel when their link loses its carrier, blackholing the traffic.
+	linkDownRoutesCounter = metrics.NewCounterVec("slicerouter_link_down_routes_total",
+		"Number of times the egress links of a slice route all went down, by link down route policy.", "policy")
+
+	grpcRequestsCounter = metrics.NewCounterVec("slicerouter_grpc_requests_total",
+		"Number of GRPC requests served by the sidecar, by method and status code.", "method", "code")
+	// Errors are also counted by method alone, so that error rates can be alerted on without summing
+	// over the status codes.
+	grpcRequestErrorsCounter = metrics.NewCounterVec("slicerouter_grpc_request_errors_total",
+		"Number of GRPC requests served by the sidecar that returned an error, by method.", "method")
+	grpcRequestDurationHistogram = metrics.NewHistogramVec("slicerouter_grpc_request_duration_seconds",
+		"Duration of the GRPC requests served by the sidecar, by method.", nil, "method")
+)
+
+// setSliceMetricsLabel attaches the name of the slice served by the sidecar, read from the SLICE_NAME
+// env variable, as a slice label of every exported metric so that the sidecars of several slices can
+// be told apart once scraped together. The slice name is returned, empty if not set.
+func setSliceMetricsLabel() string {
+	sliceName := os.Getenv("SLICE_NAME")
+	if sliceName != "" {
+		metrics.DefaultRegistry.SetConstLabels(map[string]string{"slice": sliceName})
+	}
+	return sliceName
+}
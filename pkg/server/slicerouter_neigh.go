@@ -0,0 +1,180 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"net"
+	"os"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	defaultNeighborResolveTimeout = 500 * time.Millisecond
+	neighborPollInterval          = 50 * time.Millisecond
+)
+
+// errNeighborPending is returned when a route without ONLINK is not installed because the neighbor
+// entry of one of its next hops is not resolved yet.
+var errNeighborPending = errors.New("Next hop neighbor not resolved yet")
+
+// isRouteOnlinkEnabled returns false if kernel routes should be installed without the ONLINK flag,
+// read from the KERNEL_ROUTE_ONLINK env variable. ONLINK is used by default.
+func isRouteOnlinkEnabled() bool {
+	return os.Getenv("KERNEL_ROUTE_ONLINK") != "false"
+}
+
+// getNeighborResolveTimeout returns how long an injection waits for the neighbors of its next hops to
+// be resolved before leaving the route to the reconcile loop.
+func getNeighborResolveTimeout() time.Duration {
+	return getEnvDuration("NEIGHBOR_RESOLVE_TIMEOUT", defaultNeighborResolveTimeout)
+}
+
+// triggerNeighborResolution makes the kernel resolve the neighbor entry of the next hop by sending
+// a datagram to it. It is a variable so that tests can fake neighbor resolution.
+var triggerNeighborResolution = func(nextHopIP string) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(nextHopIP, "9"))
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to trigger neighbor resolution for %v: %v", nextHopIP, err)
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte{0}); err != nil {
+		logger.GlobalLogger.Debugf("Failed to send neighbor probe to %v: %v", nextHopIP, err)
+	}
+}
+
+// isNeighborResolved returns true if the next hop has a usable neighbor entry on the link.
+func isNeighborResolved(linkIndex int, nextHopIP net.IP) (bool, error) {
+	neighs, err := nlHandle.NeighList(linkIndex, ipFamily(nextHopIP))
+	if err != nil {
+		return false, err
+	}
+	for _, neigh := range neighs {
+		if neigh.IP.Equal(nextHopIP) {
+			return neigh.State&(netlink.NUD_REACHABLE|netlink.NUD_PERMANENT) != 0, nil
+		}
+	}
+	return false, nil
+}
+
+// waitForNeighbors triggers the resolution of the neighbors of the next hops and waits for them to be
+// reachable. errNeighborPending is returned if they are not resolved within the resolve timeout.
+func waitForNeighbors(nextHopIPSlice []*netlink.NexthopInfo) error {
+	pending := []*netlink.NexthopInfo{}
+	for _, nextHop := range nextHopIPSlice {
+		// Interface next hops have no gateway to resolve.
+		if nextHop.Gw == nil {
+			continue
+		}
+		resolved, err := isNeighborResolved(nextHop.LinkIndex, nextHop.Gw)
+		if err != nil {
+			return err
+		}
+		if !resolved {
+			triggerNeighborResolution(nextHop.Gw.String())
+			pending = append(pending, nextHop)
+		}
+	}
+
+	deadline := time.Now().Add(getNeighborResolveTimeout())
+	for len(pending) > 0 {
+		if time.Now().After(deadline) {
+			logger.GlobalLogger.Infof("Neighbors not resolved yet: %v", contructArrayFromNextHop(pending))
+			return errNeighborPending
+		}
+		time.Sleep(neighborPollInterval)
+		stillPending := []*netlink.NexthopInfo{}
+		for _, nextHop := range pending {
+			resolved, err := isNeighborResolved(nextHop.LinkIndex, nextHop.Gw)
+			if err != nil {
+				return err
+			}
+			if !resolved {
+				stillPending = append(stillPending, nextHop)
+			}
+		}
+		pending = stillPending
+	}
+	return nil
+}
+
+// neighborStateToProto converts the NUD state of a neighbor entry to its reported state.
+func neighborStateToProto(state int) sidecar.NeighborState {
+	switch {
+	case state&(netlink.NUD_PERMANENT|netlink.NUD_NOARP) != 0:
+		return sidecar.NeighborState_NEIGHBOR_PERMANENT
+	case state&netlink.NUD_REACHABLE != 0:
+		return sidecar.NeighborState_NEIGHBOR_REACHABLE
+	case state&netlink.NUD_STALE != 0:
+		return sidecar.NeighborState_NEIGHBOR_STALE
+	case state&(netlink.NUD_DELAY|netlink.NUD_PROBE) != 0:
+		return sidecar.NeighborState_NEIGHBOR_PROBE
+	case state&netlink.NUD_INCOMPLETE != 0:
+		return sidecar.NeighborState_NEIGHBOR_INCOMPLETE
+	case state&netlink.NUD_FAILED != 0:
+		return sidecar.NeighborState_NEIGHBOR_FAILED
+	}
+	return sidecar.NeighborState_NEIGHBOR_NONE
+}
+
+// vl3GetNextHopNeighborsInKernel returns the neighbor entries of the next hops of the route to dstIP
+// installed in the kernel. A forwarding failure is silent while the neighbor of a next hop is not
+// resolved, the neighbor state makes it visible.
+func vl3GetNextHopNeighborsInKernel(dstIP string) ([]*sidecar.NextHopNeighbor, error) {
+	routes, err := nlHandle.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	nextHops := []*netlink.NexthopInfo{}
+	for _, route := range routes {
+		if route.Dst == nil || route.Dst.String() != dstIP {
+			continue
+		}
+		if len(route.MultiPath) > 0 {
+			nextHops = append(nextHops, route.MultiPath...)
+		} else if route.Gw != nil {
+			nextHops = append(nextHops, &netlink.NexthopInfo{LinkIndex: route.LinkIndex, Gw: route.Gw})
+		}
+	}
+
+	neighbors := []*sidecar.NextHopNeighbor{}
+	for _, nextHop := range nextHops {
+		neighbor := &sidecar.NextHopNeighbor{NextHopIP: nextHop.Gw.String()}
+		neighs, err := nlHandle.NeighList(nextHop.LinkIndex, ipFamily(nextHop.Gw))
+		if err != nil {
+			return nil, err
+		}
+		for _, neigh := range neighs {
+			if !neigh.IP.Equal(nextHop.Gw) {
+				continue
+			}
+			neighbor.State = neighborStateToProto(neigh.State)
+			if len(neigh.HardwareAddr) > 0 {
+				neighbor.HardwareAddr = neigh.HardwareAddr.String()
+			}
+			break
+		}
+		neighbors = append(neighbors, neighbor)
+	}
+	return neighbors, nil
+}
@@ -0,0 +1,111 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+func TestInjectRouteWithoutOnlink(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("KERNEL_ROUTE_ONLINK", "false")
+	t.Setenv("NEIGHBOR_RESOLVE_TIMEOUT", "100ms")
+
+	tests := []struct {
+		testName string
+		// Neighbor state before the injection.
+		state int
+		// Whether the neighbor probe resolves the neighbor.
+		probeResolves bool
+		installed     bool
+	}{
+		{"neighbor already reachable", netlink.NUD_REACHABLE, false, true},
+		{"neighbor resolved by the probe", netlink.NUD_NONE, true, true},
+		{"neighbor unresolved", netlink.NUD_INCOMPLETE, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.setNeighState(1, "192.168.0.2", tt.state)
+			useFakeNetlink(t, fake)
+
+			saved := triggerNeighborResolution
+			probed := false
+			triggerNeighborResolution = func(nextHopIP string) {
+				probed = true
+				if tt.probeResolves {
+					fake.setNeighState(1, nextHopIP, netlink.NUD_REACHABLE)
+				}
+			}
+			t.Cleanup(func() {
+				triggerNeighborResolution = saved
+			})
+
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+			if probed != (tt.state != netlink.NUD_REACHABLE) {
+				t.Error("neighbor probe: expected", tt.state != netlink.NUD_REACHABLE, "received", probed)
+			}
+			if _, ok := remoteSubnetRouteMap.Load("10.1.0.0/16"); !ok {
+				t.Error("route was not recorded")
+			}
+
+			routes, _ := fake.RouteList(nil, 0)
+			installed := getRouteNextHops(routes, "10.1.0.0/16")
+			if (len(installed) > 0) != tt.installed {
+				t.Fatal("route installed: expected", tt.installed, "received", installed)
+			}
+
+			if !tt.installed {
+				// The reconcile loop installs the route once the neighbor is resolved.
+				if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+					t.Fatal(err)
+				}
+				routes, _ = fake.RouteList(nil, 0)
+				if installed := getRouteNextHops(routes, "10.1.0.0/16"); len(installed) != 0 {
+					t.Fatal("route installed before the neighbor was resolved", installed)
+				}
+				fake.setNeighState(1, "192.168.0.2", netlink.NUD_REACHABLE)
+				if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+					t.Fatal(err)
+				}
+				routes, _ = fake.RouteList(nil, 0)
+			}
+
+			for _, route := range routes {
+				if route.Dst.String() != "10.1.0.0/16" {
+					continue
+				}
+				if route.Gw.String() != "192.168.0.2" {
+					t.Error("next hop: expected 192.168.0.2, received", route.Gw)
+				}
+				if route.Flags&int(netlink.FLAG_ONLINK) != 0 {
+					t.Error("route installed with ONLINK")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Routes whose next hops are all down are left in place, so that traffic recovers transparently.
+	nextHopDownPolicyKeep = "keep"
+	// Routes whose next hops are all down are replaced by a blackhole route, so that traffic fails fast.
+	nextHopDownPolicyBlackhole = "blackhole"
+	// Routes whose next hops are all down are removed from the dataplane, so that traffic falls back to
+	// less specific routes.
+	nextHopDownPolicyDelete = "delete"
+)
+
+// suspendedRoutes holds the routes taken out of the dataplane because all their next hops are down, with
+// the next hop down policy applied to them, or because all their egress links are down. They stay in the
+// slice route map and are restored when a next hop recovers or a link comes back up.
+var suspendedRoutes sync.Map
+
+// getNextHopDownPolicy returns what the health checker does with the routes whose next hops are all
+// down, read from the NEXTHOP_DOWN_ROUTE_POLICY env variable. Such routes are kept by default.
+func getNextHopDownPolicy() string {
+	policy := os.Getenv("NEXTHOP_DOWN_ROUTE_POLICY")
+	switch policy {
+	case nextHopDownPolicyKeep, nextHopDownPolicyBlackhole, nextHopDownPolicyDelete:
+		return policy
+	case "":
+		return nextHopDownPolicyKeep
+	}
+	logger.GlobalLogger.Errorf("Invalid NEXTHOP_DOWN_ROUTE_POLICY %q, using %v", policy, nextHopDownPolicyKeep)
+	return nextHopDownPolicyKeep
+}
+
+// allNextHopsDown returns true if the health checker reports every next hop as down. Next hops that are
+// not probed are never down.
+func allNextHopsDown(nextHopIPList []string) bool {
+	if len(nextHopIPList) == 0 {
+		return false
+	}
+	for _, nextHopIP := range nextHopIPList {
+		if nextHopHealth.isHealthy(nextHopIP) {
+			return false
+		}
+	}
+	return true
+}
+
+// isRouteSuspended returns true if the route to the remote subnet is taken out of the dataplane by the
+// next hop down policy.
+func isRouteSuspended(remoteSubnet string) bool {
+	_, ok := suspendedRoutes.Load(remoteSubnet)
+	return ok
+}
+
+// vl3BlackholeRouteInKernel replaces the route to the remote subnet with a blackhole route.
+func vl3BlackholeRouteInKernel(remoteSubnet string) error {
+	_, dst, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return err
+	}
+	route := &netlink.Route{Dst: dst, Type: unix.RTN_BLACKHOLE}
+	if err := nlHandle.RouteReplace(route); err != nil {
+		return err
+	}
+	logIproute2Command("replace", route)
+	return nil
+}
+
+// getVppDropConfig returns the vpp config of the route dropping the traffic to the remote subnet, in the
+// slice route vrf.
+func getVppDropConfig(remoteSubnet string) *vpp.ConfigData {
+	return &vpp.ConfigData{Routes: []*vpp.Route{{Type: vpp_l3.Route_DROP, VrfId: getVppSliceRouteVrf(), DstNetwork: remoteSubnet}}}
+}
+
+// sliceRouterSuspendRoute takes the route to the remote subnet out of the dataplane according to the
+// policy. In vpp every next hop is a separate route entry, they are all removed.
+func sliceRouterSuspendRoute(remoteSubnet string, nextHopIPList []string, policy string) error {
+	logger.GlobalLogger.Warnf("All next hops down, applying the %v policy. RemoteSubnet: %v, NextHops: %v",
+		policy, remoteSubnet, nextHopIPList)
+	var err error
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		for _, nextHopIP := range nextHopIPList {
+			if err = vl3DeleteRouteInVpp(context.Background(), remoteSubnet, nextHopIP, vppRouteDeleteUnhealthy); err != nil {
+				return err
+			}
+		}
+		if policy == nextHopDownPolicyBlackhole {
+			err = sendConfigToVppAgent(context.Background(), getVppDropConfig(remoteSubnet), false)
+		}
+	} else if policy == nextHopDownPolicyBlackhole {
+		err = vl3BlackholeRouteInKernel(remoteSubnet)
+	} else {
+		err = sliceRouterDeleteRouteToDst(remoteSubnet)
+		if err == errRouteNotFound {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	suspendedRoutes.Store(remoteSubnet, policy)
+	return nil
+}
+
+// sliceRouterResumeRoute forgets that the route to the remote subnet is suspended before it is
+// programmed again or deleted. The vpp drop route of a blackholed route is removed, in the kernel the
+// blackhole route is replaced by the route programmed next.
+func sliceRouterResumeRoute(remoteSubnet string) error {
+	policy, ok := suspendedRoutes.Load(remoteSubnet)
+	if !ok {
+		return nil
+	}
+	if policy == nextHopDownPolicyBlackhole && getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		if err := sendConfigToVppAgent(context.Background(), getVppDropConfig(remoteSubnet), true); err != nil {
+			return err
+		}
+	}
+	suspendedRoutes.Delete(remoteSubnet)
+	logger.GlobalLogger.Infof("Resuming route. RemoteSubnet: %v", remoteSubnet)
+	return nil
+}
+
+// routeForwardingState returns how the route to the remote subnet forwards given the health of its next
+// hops and the next hop down policy applied to it.
+func routeForwardingState(remoteSubnet string, nextHopIPList []string) sidecar.RouteForwardingState {
+	if policy, ok := suspendedRoutes.Load(remoteSubnet); ok {
+		if policy == nextHopDownPolicyBlackhole {
+			return sidecar.RouteForwardingState_FORWARDING_BLACKHOLED
+		}
+		return sidecar.RouteForwardingState_FORWARDING_WITHDRAWN
+	}
+	if allNextHopsDown(nextHopIPList) {
+		return sidecar.RouteForwardingState_FORWARDING_DOWN
+	}
+	for _, nextHopIP := range nextHopIPList {
+		if !nextHopHealth.isHealthy(nextHopIP) {
+			return sidecar.RouteForwardingState_FORWARDING_DEGRADED
+		}
+	}
+	return sidecar.RouteForwardingState_FORWARDING_ACTIVE
+}
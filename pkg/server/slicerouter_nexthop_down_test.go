@@ -0,0 +1,154 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	"golang.org/x/sys/unix"
+)
+
+// kernelRouteState returns how the fake kernel routes to the remote subnet: "blackhole", "absent" or the
+// installed next hops.
+func kernelRouteState(t *testing.T, fake *fakeNetlink, remoteSubnet string) []string {
+	t.Helper()
+	fake.mu.Lock()
+	for _, route := range fake.routes {
+		if route.Dst != nil && route.Dst.String() == remoteSubnet && route.Type == unix.RTN_BLACKHOLE {
+			fake.mu.Unlock()
+			return []string{"blackhole"}
+		}
+	}
+	fake.mu.Unlock()
+	if installed := installedNextHops(t, fake, remoteSubnet); len(installed) > 0 {
+		return installed
+	}
+	return []string{"absent"}
+}
+
+// vppRouteState returns how the fake vpp-agent routes to the remote subnet: "blackhole", "absent" or
+// the configured next hops. The fake appends repeated updates of a route, vpp-agent keys them.
+func vppRouteState(fake *fakeVppAgent, remoteSubnet string) []string {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	state := []string{}
+	seen := map[string]bool{}
+	for _, route := range fake.config.Routes {
+		if route.GetDstNetwork() != remoteSubnet {
+			continue
+		}
+		entry := route.GetNextHopAddr()
+		if route.GetType() == vpp_l3.Route_DROP {
+			entry = "blackhole"
+		}
+		if !seen[entry] {
+			seen[entry] = true
+			state = append(state, entry)
+		}
+	}
+	if len(state) == 0 {
+		return []string{"absent"}
+	}
+	return state
+}
+
+func TestNextHopDownPolicy(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("NEXTHOP_HEALTH_CHECK", nextHopHealthCheckTCP)
+	t.Setenv("NEXTHOP_HEALTH_CHECK_FAILURES", "1")
+	skipReconcile(t)
+
+	tests := []struct {
+		testName    string
+		dataplane   string
+		policy      string
+		expectDown  []string
+		expectState pb.RouteForwardingState
+	}{
+		{"kernel route kept by default", SliceRouterDataplaneKernel, "", []string{"192.168.0.2"},
+			pb.RouteForwardingState_FORWARDING_DOWN},
+		{"kernel route blackholed", SliceRouterDataplaneKernel, nextHopDownPolicyBlackhole, []string{"blackhole"},
+			pb.RouteForwardingState_FORWARDING_BLACKHOLED},
+		{"kernel route deleted", SliceRouterDataplaneKernel, nextHopDownPolicyDelete, []string{"absent"},
+			pb.RouteForwardingState_FORWARDING_WITHDRAWN},
+		{"vpp route kept", SliceRouterDataplaneVpp, nextHopDownPolicyKeep, []string{"192.168.0.2"},
+			pb.RouteForwardingState_FORWARDING_DOWN},
+		{"vpp route blackholed", SliceRouterDataplaneVpp, nextHopDownPolicyBlackhole, []string{"blackhole"},
+			pb.RouteForwardingState_FORWARDING_BLACKHOLED},
+		{"vpp route deleted", SliceRouterDataplaneVpp, nextHopDownPolicyDelete, []string{"absent"},
+			pb.RouteForwardingState_FORWARDING_WITHDRAWN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			t.Setenv("NEXTHOP_DOWN_ROUTE_POLICY", tt.policy)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+			fakeVpp := newFakeVppAgent()
+			useFakeVppAgent(t, fakeVpp)
+			down := map[string]bool{}
+			useFakeProbe(t, down)
+
+			remoteSubnet := "10.1.0.0/16"
+			if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+			routeState := func() []string {
+				if tt.dataplane == SliceRouterDataplaneVpp {
+					return vppRouteState(fakeVpp, remoteSubnet)
+				}
+				return kernelRouteState(t, fake, remoteSubnet)
+			}
+			forwardingState := func() pb.RouteForwardingState {
+				return sliceRouterGetRouteTable().GetRoutes()[0].GetForwardingState()
+			}
+
+			down["192.168.0.2"] = true
+			sliceRouterCheckNextHopHealth()
+			if state := routeState(); !sameNextHops(state, tt.expectDown) {
+				t.Error("route with its next hop down: expected", tt.expectDown, "received", state)
+			}
+			if state := forwardingState(); state != tt.expectState {
+				t.Error("forwarding state: expected", tt.expectState, "received", state)
+			}
+			// The reconcile leaves the route to the policy.
+			if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+				t.Fatal(err)
+			}
+			if state := routeState(); !sameNextHops(state, tt.expectDown) {
+				t.Error("route after the reconcile: expected", tt.expectDown, "received", state)
+			}
+
+			delete(down, "192.168.0.2")
+			sliceRouterCheckNextHopHealth()
+			if state := routeState(); !sameNextHops(state, []string{"192.168.0.2"}) {
+				t.Error("route with its next hop recovered: expected [192.168.0.2], received", state)
+			}
+			if state := forwardingState(); state != pb.RouteForwardingState_FORWARDING_ACTIVE {
+				t.Error("forwarding state: expected", pb.RouteForwardingState_FORWARDING_ACTIVE, "received", state)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+const (
+	// The client end of the nsm link is the address before the slice router end.
+	nsmAddressLayoutLower = "lower"
+	// The client end of the nsm link is the address after the slice router end.
+	nsmAddressLayoutHigher = "higher"
+	// The client end of the nsm link is the other host address of the /30 block of the slice router end.
+	nsmAddressLayoutBlock = "block"
+)
+
+// getNsmAddressLayout returns how the client end of an nsm link in vpp is derived from the slice router
+// end, read from the VPP_NSM_ADDRESS_LAYOUT env variable. The client end is the address before the
+// slice router end by default.
+func getNsmAddressLayout() string {
+	layout := os.Getenv("VPP_NSM_ADDRESS_LAYOUT")
+	switch layout {
+	case nsmAddressLayoutLower, nsmAddressLayoutHigher, nsmAddressLayoutBlock:
+		return layout
+	case "":
+		return nsmAddressLayoutLower
+	}
+	logger.GlobalLogger.Errorf("Invalid VPP_NSM_ADDRESS_LAYOUT %q, using %v", layout, nsmAddressLayoutLower)
+	return nsmAddressLayoutLower
+}
+
+// vppNsmLinkAddresses returns the client and the slice router ends of the nsm link from the address of
+// the nsm interface in vpp, according to the nsm address layout. In the lower and higher layouts the
+// client end must be in the same /24 as the slice router end, in the block layout the address must be
+// a host address of a /30.
+func vppNsmLinkAddresses(address string) (string, string, error) {
+	if getNsmAddressLayout() == nsmAddressLayoutBlock {
+		return vppNsmLinkAddressesInBlock(address)
+	}
+	nsmPeerIP := strings.TrimSuffix(address, "/30")
+	nsmIpOctetList := strings.Split(nsmPeerIP, ".")
+	if len(nsmIpOctetList) != 4 {
+		return "", "", fmt.Errorf("Invalid IP address %v", address)
+	}
+	nsmIpLastOctet, err := strconv.Atoi(nsmIpOctetList[3])
+	if err != nil || nsmIpLastOctet < 0 || nsmIpLastOctet > 255 {
+		return "", "", fmt.Errorf("Invalid IP address %v", address)
+	}
+	if getNsmAddressLayout() == nsmAddressLayoutHigher {
+		nsmIpLastOctet++
+	} else {
+		nsmIpLastOctet--
+	}
+	if nsmIpLastOctet < 0 || nsmIpLastOctet > 255 {
+		return "", "", fmt.Errorf("Invalid IP address %v, the client address is out of its /24", address)
+	}
+	nsmIpOctetList[3] = strconv.Itoa(nsmIpLastOctet)
+	return strings.Join(nsmIpOctetList, "."), nsmPeerIP, nil
+}
+
+// vppNsmLinkAddressesInBlock returns the client and the slice router ends of the nsm link, the client
+// end being the other host address of the /30 block of the slice router end.
+func vppNsmLinkAddressesInBlock(address string) (string, string, error) {
+	ip, ipNet, err := net.ParseCIDR(address)
+	if err != nil {
+		return "", "", fmt.Errorf("Invalid IP address %v", address)
+	}
+	ip = ip.To4()
+	if ones, bits := ipNet.Mask.Size(); ip == nil || ones != 30 || bits != 32 {
+		return "", "", fmt.Errorf("Invalid IP address %v, not in a /30 block", address)
+	}
+	nsmIP := make(net.IP, len(ip))
+	copy(nsmIP, ip)
+	switch ip[3] & 3 {
+	case 1:
+		nsmIP[3]++
+	case 2:
+		nsmIP[3]--
+	default:
+		return "", "", fmt.Errorf("Invalid IP address %v, not a host address of its /30 block", address)
+	}
+	return nsmIP.String(), ip.String(), nil
+}
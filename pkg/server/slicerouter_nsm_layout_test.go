@@ -0,0 +1,69 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	vpp "go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+)
+
+func TestVppNsmLinkAddresses(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName      string
+		layout        string
+		address       string
+		expectNsmIP   string
+		expectPeerIP  string
+		expectInvalid bool
+	}{
+		{"default layout", "", "10.1.1.6/30", "10.1.1.5", "10.1.1.6", false},
+		{"lower layout", nsmAddressLayoutLower, "10.1.1.6/30", "10.1.1.5", "10.1.1.6", false},
+		{"lower layout out of the /24", nsmAddressLayoutLower, "10.1.2.0/30", "", "", true},
+		{"higher layout", nsmAddressLayoutHigher, "10.1.1.5/30", "10.1.1.6", "10.1.1.5", false},
+		{"higher layout out of the /24", nsmAddressLayoutHigher, "10.1.1.255/30", "", "", true},
+		{"block layout first host", nsmAddressLayoutBlock, "10.1.1.5/30", "10.1.1.6", "10.1.1.5", false},
+		{"block layout second host", nsmAddressLayoutBlock, "10.1.1.6/30", "10.1.1.5", "10.1.1.6", false},
+		{"block layout network address", nsmAddressLayoutBlock, "10.1.1.4/30", "", "", true},
+		{"block layout broadcast address", nsmAddressLayoutBlock, "10.1.1.7/30", "", "", true},
+		{"block layout other prefix", nsmAddressLayoutBlock, "10.1.1.6/24", "", "", true},
+		{"invalid layout falls back to lower", "middle", "10.1.1.6/30", "10.1.1.5", "10.1.1.6", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_NSM_ADDRESS_LAYOUT", tt.layout)
+			connInfo, err := vppInterfaceToConnectionInfo(&vpp.Interface{Name: "memif-nsm-app1", IpAddresses: []string{tt.address}})
+			if tt.expectInvalid {
+				if err == nil {
+					t.Error("expected an error, received", connInfo)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if connInfo.NsmIP != tt.expectNsmIP || connInfo.NsmPeerIP != tt.expectPeerIP {
+				t.Error("addresses: expected", tt.expectNsmIP, tt.expectPeerIP, "received", connInfo.NsmIP, connInfo.NsmPeerIP)
+			}
+		})
+	}
+}
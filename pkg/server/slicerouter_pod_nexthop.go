@@ -0,0 +1,164 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// Controllers may only know a slice gw by its pod name. Such a next hop is given as "pod:<pod name>" in
+// place of a next hop IP and is resolved to the NSM IP of the pod every time the route is programmed, so
+// that the route follows the pod if NSM re-addresses it. Pod next hops are only supported in the kernel
+// dataplane.
+
+// podNextHopPrefix marks a next hop given as a pod name.
+const podNextHopPrefix = "pod:"
+
+// parsePodNextHop returns the pod name of a pod next hop, and false if the next hop is not a pod next hop.
+func parsePodNextHop(nextHop string) (string, bool) {
+	if !strings.HasPrefix(nextHop, podNextHopPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(nextHop, podNextHopPrefix), true
+}
+
+// validatePodNextHop checks that the pod name of a pod next hop is a valid pod name.
+func validatePodNextHop(name string) error {
+	if name == "" || strings.ContainsAny(name, "/: \t\n") {
+		return fmt.Errorf("invalid next hop pod %q", name)
+	}
+	return nil
+}
+
+// resolvePodNextHops returns the next hops with the pod next hops replaced by the current NSM IP of the
+// pod in the family of the remote subnet. The connections are only enumerated if there is a pod next hop.
+func resolvePodNextHops(remoteSubnet string, nextHopIPList []string) ([]string, error) {
+	if !hasPodNextHop(nextHopIPList) {
+		return nextHopIPList, nil
+	}
+
+	_, remoteNet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return nil, err
+	}
+	connList, err := sliceRouterGetClientConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := []string{}
+	for _, nextHopIP := range nextHopIPList {
+		name, ok := parsePodNextHop(nextHopIP)
+		if !ok {
+			resolved = append(resolved, nextHopIP)
+			continue
+		}
+		podIP := ""
+		for _, conn := range connList {
+			if conn.GetPodName() != name {
+				continue
+			}
+			// The next hop is the end of the NSM link on the pod.
+			podIP = conn.GetNsmIP()
+			if remoteNet.IP.To4() == nil {
+				podIP = conn.GetNsmIPv6()
+			}
+			break
+		}
+		if podIP == "" {
+			return nil, fmt.Errorf("next hop pod %v is not connected", name)
+		}
+		resolved = append(resolved, podIP)
+	}
+	return resolved, nil
+}
+
+// hasPodNextHop returns true if one of the next hops is a pod next hop.
+func hasPodNextHop(nextHopIPList []string) bool {
+	for _, nextHopIP := range nextHopIPList {
+		if _, ok := parsePodNextHop(nextHopIP); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceRouterRefreshPodNextHops enumerates the client connections again and reprograms the routes whose
+// next hop pods are no longer at the installed next hop IPs, without waiting for the reconcile. It
+// returns the remote subnets of the reprogrammed routes, and of the routes that could not be, which are
+// left to the reconcile. errReconcileInProgress is returned if a reconcile is running, it covers the
+// refresh.
+func sliceRouterRefreshPodNextHops() (refreshed []string, failed []string, err error) {
+	if !reconcileRunning.CompareAndSwap(false, true) {
+		return nil, nil, errReconcileInProgress
+	}
+	defer reconcileRunning.Store(false)
+
+	refreshed, failed = []string{}, []string{}
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		// Pod next hops are rejected in vpp mode.
+		return refreshed, failed, nil
+	}
+	if err := connCache.resync(); err != nil {
+		return nil, nil, err
+	}
+	installedRoutes, err := nlHandle.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		remoteSubnet, nextHopIPList := key.(string), value.([]string)
+		if !hasPodNextHop(nextHopIPList) {
+			return true
+		}
+		resolvedNextHops, err := resolvePodNextHops(remoteSubnet, healthyNextHops(nextHopIPList))
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to resolve next hop pods: dst: %v, gw: %v, err: %v", remoteSubnet, nextHopIPList, err)
+			failed = append(failed, remoteSubnet)
+			return true
+		}
+		installedNextHops := getRouteNextHops(installedRoutes, remoteSubnet)
+		current := true
+		for _, nextHopIP := range resolvedNextHops {
+			if _, ok := parseDevNextHop(nextHopIP); !ok && !contains(installedNextHops, nextHopIP) {
+				current = false
+				break
+			}
+		}
+		if current {
+			return true
+		}
+		logger.GlobalLogger.Infof("Next hop pods moved, reprogramming dst: %v, gw: %v", remoteSubnet, resolvedNextHops)
+		err = sliceRouterProgramHealthyNextHops(remoteSubnet, nextHopIPList)
+		routeAudit.recordCorrection(remoteSubnet, nextHopIPList, routeCorrectionPodMoved, err)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to reprogram route: dst: %v, gw: %v, err: %v", remoteSubnet, resolvedNextHops, err)
+			failed = append(failed, remoteSubnet)
+			return true
+		}
+		refreshed = append(refreshed, remoteSubnet)
+		return true
+	})
+	return refreshed, failed, nil
+}
@@ -0,0 +1,244 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// addressPodLink connects the pod on the nsm link, replacing the addresses the link had. The pod IP is the
+// destination of the connected route of the link and the router IP its address.
+func addressPodLink(fake *fakeNetlink, linkIndex int, podName, podIP, routerIP string) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	routes := []netlink.Route{}
+	for _, route := range fake.routes {
+		if route.LinkIndex != linkIndex {
+			routes = append(routes, route)
+		}
+	}
+	fake.routes = append(routes, netlink.Route{Dst: mustParseCIDR(podIP + "/32"), LinkIndex: linkIndex})
+	fake.addrs[linkIndex] = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP(routerIP), Mask: net.CIDRMask(32, 32)}}}
+	for _, link := range fake.links {
+		if link.Attrs().Index == linkIndex {
+			return
+		}
+	}
+	fake.links = append(fake.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{
+		Index: linkIndex,
+		Name:  fmt.Sprintf("vl3-%d", linkIndex),
+		Alias: podName,
+	}})
+}
+
+func TestInjectPodNextHop(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	skipReconcile(t)
+	connCache.invalidate()
+
+	tests := []struct {
+		testName     string
+		dataplane    string
+		nextHops     []string
+		expectReason routeErrorReason
+		expected     []string
+	}{
+		{"pod next hop", SliceRouterDataplaneKernel, []string{"pod:slicegw-a"}, "", []string{"10.1.1.1"}},
+		{"pod and ip next hops", SliceRouterDataplaneKernel, []string{"pod:slicegw-a", "10.1.2.1"}, "", []string{"10.1.1.1", "10.1.2.1"}},
+		{"empty pod name", SliceRouterDataplaneKernel, []string{"pod:"}, routeErrorInvalidArgument, nil},
+		{"invalid pod name", SliceRouterDataplaneKernel, []string{"pod:a/b"}, routeErrorInvalidArgument, nil},
+		{"vpp dataplane", SliceRouterDataplaneVpp, []string{"pod:slicegw-a"}, routeErrorInvalidArgument, nil},
+		{"pod not connected", SliceRouterDataplaneKernel, []string{"pod:slicegw-c"}, routeErrorNextHopUnresolved, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+			addressPodLink(fake, 2, "slicegw-b", "10.1.2.1", "10.1.2.2")
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectRoute("10.2.0.0/16", tt.nextHops)
+			if tt.expectReason != "" {
+				var rErr *routeError
+				if !errors.As(err, &rErr) || rErr.reason != tt.expectReason {
+					t.Fatal("expected reason", tt.expectReason, "received", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_ALL)
+			if installed := getRouteNextHops(routes, "10.2.0.0/16"); !sameNextHops(installed, tt.expected) {
+				t.Error("installed next hops: expected", tt.expected, "received", installed)
+			}
+		})
+	}
+}
+
+func TestReconcilePodNextHopFollowsPod(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	connCache.invalidate()
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+	useFakeNetlink(t, fake)
+
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"pod:slicegw-a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		testName string
+		podIP    string
+		routerIP string
+	}{
+		{"route unchanged while the pod keeps its address", "10.1.1.1", "10.1.1.2"},
+		{"route follows the re-addressed pod", "10.1.1.5", "10.1.1.6"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.testName, func(t *testing.T) {
+			addressPodLink(fake, 1, "slicegw-a", step.podIP, step.routerIP)
+			if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_ALL)
+			if installed := getRouteNextHops(routes, "10.2.0.0/16"); !sameNextHops(installed, []string{step.podIP}) {
+				t.Error("installed next hops: expected", step.podIP, "received", installed)
+			}
+			cached, _ := remoteSubnetRouteMap.Load("10.2.0.0/16")
+			if !sameNextHops(cached.([]string), []string{"pod:slicegw-a"}) {
+				t.Error("requested next hops should not change, received", cached)
+			}
+		})
+	}
+}
+
+// removePodLink disconnects the nsm link from the fake kernel.
+func removePodLink(fake *fakeNetlink, linkIndex int) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	links := []netlink.Link{}
+	for _, link := range fake.links {
+		if link.Attrs().Index != linkIndex {
+			links = append(links, link)
+		}
+	}
+	fake.links = links
+	routes := []netlink.Route{}
+	for _, route := range fake.routes {
+		if route.LinkIndex != linkIndex {
+			routes = append(routes, route)
+		}
+	}
+	fake.routes = routes
+	delete(fake.addrs, linkIndex)
+}
+
+func TestRefreshNextHops(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+	skipReconcile(t)
+
+	fake := newFakeNetlink()
+	addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+	addressPodLink(fake, 3, "slicegw-b", "10.1.3.1", "10.1.3.2")
+	useFakeNetlink(t, fake)
+	// The cache is not kept from events here, the refresh must enumerate the connections again.
+	if err := connCache.fill(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(connCache.invalidate)
+
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"pod:slicegw-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sliceRouterInjectRoute("10.3.0.0/16", []string{"10.1.3.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	tests := []struct {
+		testName          string
+		change            func()
+		expectedRefreshed []string
+		expectedFailed    []string
+		expectedNextHops  []string
+	}{
+		{"pods in place", func() {}, nil, nil, []string{"10.1.1.1"}},
+		{"pod re-connected", func() {
+			removePodLink(fake, 1)
+			addressPodLink(fake, 2, "slicegw-a", "10.1.2.1", "10.1.2.2")
+		}, []string{"10.2.0.0/16"}, nil, []string{"10.1.2.1"}},
+		{"refreshed route is current", func() {}, nil, nil, []string{"10.1.2.1"}},
+		// The kernel drops the route along with the link.
+		{"pod gone", func() {
+			removePodLink(fake, 2)
+		}, nil, []string{"10.2.0.0/16"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			tt.change()
+			resp, err := client.RefreshNextHops(ctx, &emptypb.Empty{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameNextHops(resp.GetRefreshedSubnets(), tt.expectedRefreshed) {
+				t.Error("refreshed: expected", tt.expectedRefreshed, "received", resp.GetRefreshedSubnets())
+			}
+			if !sameNextHops(resp.GetFailedSubnets(), tt.expectedFailed) {
+				t.Error("failed: expected", tt.expectedFailed, "received", resp.GetFailedSubnets())
+			}
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+			if installed := getRouteNextHops(routes, "10.2.0.0/16"); !sameNextHops(installed, tt.expectedNextHops) {
+				t.Error("next hops: expected", tt.expectedNextHops, "received", installed)
+			}
+			// Routes without pod next hops are left alone.
+			if installed := getRouteNextHops(routes, "10.3.0.0/16"); !sameNextHops(installed, []string{"10.1.3.1"}) {
+				t.Error("route without pod next hop changed:", installed)
+			}
+		})
+	}
+}
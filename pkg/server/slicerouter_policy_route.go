@@ -0,0 +1,302 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+)
+
+// errPolicyRouteUnsupported is returned when a policy route is injected outside kernel mode.
+var errPolicyRouteUnsupported = errors.New("Policy routes are only supported in kernel mode")
+
+// policyRoute is a route installed in a custom routing table along with the ip rule steering traffic
+// into the table.
+type policyRoute struct {
+	remoteSubnet  string
+	nextHopIPList []string
+	rule          *netlink.Rule
+}
+
+// policyRouteMap holds the policy routes injected by the sidecar, keyed by table and remote subnet.
+var (
+	policyRouteMu  sync.Mutex
+	policyRouteMap = map[string]*policyRoute{}
+)
+
+func policyRouteKey(table int, remoteSubnet string) string {
+	return fmt.Sprintf("%v/%v", table, remoteSubnet)
+}
+
+// newPolicyRouteRule builds the ip rule steering the traffic from the source prefix into the table.
+func newPolicyRouteRule(table uint32, srcPrefix string, priority uint32) (*netlink.Rule, error) {
+	if isReservedRouteTable(uint64(table)) {
+		return nil, fmt.Errorf("route table %v is reserved", table)
+	}
+	_, src, err := net.ParseCIDR(srcPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source prefix %q: %v", srcPrefix, err)
+	}
+
+	rule := netlink.NewRule()
+	rule.Table = int(table)
+	rule.Src = src
+	rule.Family = netlink.FAMILY_V4
+	if src.IP.To4() == nil {
+		rule.Family = netlink.FAMILY_V6
+	}
+	if priority > 0 {
+		rule.Priority = int(priority)
+	}
+	return rule, nil
+}
+
+// policyRuleInUse checks if a policy route other than the one with the given key uses the rule.
+// Must be called with policyRouteMu held.
+func policyRuleInUse(rule *netlink.Rule, key string) bool {
+	for otherKey, route := range policyRouteMap {
+		if otherKey != key && ruleMatches(*route.rule, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceRouterInjectPolicyRoute installs the route to the remote subnet in the table of the rule and
+// makes sure the rule is present. An empty next hop list deletes the route, and the rule once no other
+// policy route uses it.
+func sliceRouterInjectPolicyRoute(remoteSubnet string, nextHopIPList []string, table uint32, srcPrefix string, priority uint32) error {
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		return errPolicyRouteUnsupported
+	}
+	_, dstIPNet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	// Like the slice routes, policy routes are recorded under the canonical form of their subnet.
+	remoteSubnet = dstIPNet.String()
+	rule, err := newPolicyRouteRule(table, srcPrefix, priority)
+	if err != nil {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	if (dstIPNet.IP.To4() == nil) != (rule.Family == netlink.FAMILY_V6) {
+		return newRouteError(routeErrorInvalidArgument, remoteSubnet,
+			fmt.Errorf("source prefix %v is not in the family of the remote subnet", srcPrefix))
+	}
+	for _, nextHopIP := range nextHopIPList {
+		if name, ok := parseDevNextHop(nextHopIP); ok {
+			if err := validateDevNextHop(name); err != nil {
+				return newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+			}
+			continue
+		}
+		if net.ParseIP(nextHopIP) == nil {
+			return newRouteError(routeErrorInvalidArgument, remoteSubnet, fmt.Errorf("invalid next hop %q", nextHopIP))
+		}
+	}
+
+	policyRouteMu.Lock()
+	defer policyRouteMu.Unlock()
+
+	key := policyRouteKey(rule.Table, remoteSubnet)
+	installed, present := policyRouteMap[key]
+
+	if len(nextHopIPList) == 0 {
+		if !present {
+			logger.GlobalLogger.Infof("Policy route to delete is not installed. RemoteSubnet: %v, Table: %v", remoteSubnet, table)
+			return nil
+		}
+		err := vl3DeletePolicyRouteInKernel(key, installed)
+		routeAudit.record(routeAuditDelete, remoteSubnet, nextHopIPList, err)
+		if err != nil {
+			return newRouteError(routeErrorDataplane, remoteSubnet, err)
+		}
+		return nil
+	}
+
+	// The route is installed before the rule so that traffic is not steered into an empty table.
+	netlinkNextHopList, err := newNextHopResolver().resolve(nextHopIPList)
+	if err != nil {
+		return newRouteError(routeErrorNextHopUnresolved, remoteSubnet, err)
+	}
+	err = vl3InjectTableRouteInKernel(remoteSubnet, rule.Table, nil, netlinkNextHopList)
+	if err == nil {
+		err = ensureRule(rule)
+	}
+	routeAudit.record(routeAuditInject, remoteSubnet, nextHopIPList, err)
+	if err != nil && err != errNeighborPending {
+		logger.GlobalLogger.Errorf("Failed to inject policy route in kernel: %v", err)
+		return newRouteError(routeErrorDataplane, remoteSubnet, err)
+	}
+
+	policyRouteMap[key] = &policyRoute{remoteSubnet: remoteSubnet, nextHopIPList: nextHopIPList, rule: rule}
+	// A route moving to another source prefix or priority leaves its previous rule behind.
+	if present && !ruleMatches(*installed.rule, rule) && !policyRuleInUse(installed.rule, "") {
+		if err := nlHandle.RuleDel(installed.rule); err != nil {
+			logger.GlobalLogger.Errorf("Failed to delete previous policy route rule: %v, Err: %v", installed.rule, err)
+		}
+	}
+	return nil
+}
+
+// vl3DeletePolicyRouteInKernel deletes the policy route from its table, and its rule if no other policy
+// route uses it. Must be called with policyRouteMu held.
+func vl3DeletePolicyRouteInKernel(key string, route *policyRoute) error {
+	_, dstIPNet, err := net.ParseCIDR(route.remoteSubnet)
+	if err != nil {
+		return err
+	}
+	installedRoutes, err := listTableRoutes(route.rule.Family, route.rule.Table)
+	if err != nil {
+		return err
+	}
+	for _, installedRoute := range installedRoutes {
+		if installedRoute.Dst == nil || installedRoute.Dst.String() != dstIPNet.String() {
+			continue
+		}
+		deleted := &netlink.Route{Dst: dstIPNet, Table: route.rule.Table}
+		if err := nlHandle.RouteDel(deleted); err != nil {
+			logger.GlobalLogger.Errorf("Failed to delete policy route: dst: %v, table: %v, err: %v", dstIPNet, route.rule.Table, err)
+			return err
+		}
+		logIproute2Command("del", deleted)
+		break
+	}
+
+	if !policyRuleInUse(route.rule, key) {
+		if err := nlHandle.RuleDel(route.rule); err != nil {
+			logger.GlobalLogger.Errorf("Failed to delete policy route rule: %v, Err: %v", route.rule, err)
+			return err
+		}
+	}
+	delete(policyRouteMap, key)
+	logger.GlobalLogger.Infof("Policy route deleted. Dst: %v, Table: %v", dstIPNet, route.rule.Table)
+
+	return nil
+}
+
+// sliceRouterFlushPolicyRoutes removes all the policy routes injected by the sidecar, each rule being
+// removed along with the last route using it, and returns the first error hit. Policy routes that could
+// not be removed stay in the policy route map.
+func sliceRouterFlushPolicyRoutes() error {
+	policyRouteMu.Lock()
+	defer policyRouteMu.Unlock()
+
+	keys := []string{}
+	for key := range policyRouteMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var firstErr error
+	for _, key := range keys {
+		route := policyRouteMap[key]
+		err := vl3DeletePolicyRouteInKernel(key, route)
+		routeAudit.recordDeletion(route.remoteSubnet, nil, sidecar.DeletionReason_DELETION_SHUTDOWN_FLUSH, err)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to flush policy route to %v: %v", route.remoteSubnet, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	logger.GlobalLogger.Infof("Flushed %v policy routes", len(keys))
+	return firstErr
+}
+
+// listTableRoutes returns the routes installed in the routing table.
+func listTableRoutes(family int, table int) ([]netlink.Route, error) {
+	return nlHandle.RouteListFiltered(family, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+}
+
+// vl3ReconcilePolicyRoutesInKernel reinstalls the policy routes and rules missing from the kernel, for
+// the remote subnets matching the filter and as far as the budget allows.
+func vl3ReconcilePolicyRoutesInKernel(filter prefixFilter, budget *reconcileBudget) error {
+	policyRouteMu.Lock()
+	defer policyRouteMu.Unlock()
+	if len(policyRouteMap) == 0 {
+		return nil
+	}
+
+	linkMap, err := getLinkIndexMap()
+	if err != nil {
+		return err
+	}
+
+	for _, route := range policyRouteMap {
+		if !filter.matches(route.remoteSubnet) {
+			continue
+		}
+		installedRoutes, err := listTableRoutes(route.rule.Family, route.rule.Table)
+		if err != nil {
+			return err
+		}
+		routeList := []netlink.Route{}
+		for _, installedRoute := range installedRoutes {
+			if installedRoute.Dst != nil && installedRoute.Dst.String() == route.remoteSubnet {
+				routeList = append(routeList, installedRoute)
+			}
+		}
+
+		correction := ""
+		if hasStaleNextHopLink(routeList, linkMap) {
+			correction = routeCorrectionLink
+		}
+		if len(routeList) == 0 {
+			correction = routeCorrectionMissing
+		}
+		for _, nextHopIP := range route.nextHopIPList {
+			if !containsNextHop(routeList, nextHopIP, linkMap) {
+				correction = routeCorrectionMissing
+			}
+		}
+		if correction != "" {
+			if !budget.take() {
+				budget.deferCorrection(route.remoteSubnet)
+				return nil
+			}
+			logger.GlobalLogger.Infof("Installed policy route does not reflect slice state. Reconciling dst: %v, table: %v",
+				route.remoteSubnet, route.rule.Table)
+			err := reinstallPolicyRoute(route)
+			routeAudit.recordCorrection(route.remoteSubnet, route.nextHopIPList, correction, err)
+			if err != nil {
+				logger.GlobalLogger.Errorf("Failed to install policy route: dst: %v, table: %v, err: %v", route.remoteSubnet, route.rule.Table, err)
+				continue
+			}
+		}
+		if err := ensureRule(route.rule); err != nil {
+			logger.GlobalLogger.Errorf("Failed to install policy route rule: %v, err: %v", route.rule, err)
+		}
+	}
+	return nil
+}
+
+func reinstallPolicyRoute(route *policyRoute) error {
+	netlinkNextHopList, err := getNetlinkNextHopInfo(route.nextHopIPList)
+	if err != nil {
+		return err
+	}
+	return vl3InjectTableRouteInKernel(route.remoteSubnet, route.rule.Table, nil, netlinkNextHopList)
+}
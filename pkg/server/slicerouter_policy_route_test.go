@@ -0,0 +1,201 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// resetPolicyRouteMap clears the policy route map before and after the test.
+func resetPolicyRouteMap(t *testing.T) {
+	t.Helper()
+	clear := func() {
+		policyRouteMu.Lock()
+		policyRouteMap = map[string]*policyRoute{}
+		policyRouteMu.Unlock()
+	}
+	clear()
+	t.Cleanup(clear)
+}
+
+// tableRouteNextHops returns the next hops of the route to the subnet in the table.
+func tableRouteNextHops(t *testing.T, fake *fakeNetlink, table int, subnet string) []string {
+	t.Helper()
+	routes, err := fake.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return getRouteNextHops(routes, subnet)
+}
+
+// hasPolicyRule checks if the fake has a rule steering the source prefix into the table.
+func hasPolicyRule(fake *fakeNetlink, table int, src string) bool {
+	rules, _ := fake.RuleList(netlink.FAMILY_V4)
+	for _, rule := range rules {
+		if rule.Table == table && rule.Src != nil && rule.Src.String() == src {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPolicyRouteLifecycle(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	resetPolicyRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.1.2", 2)
+	useFakeNetlink(t, fake)
+
+	if err := sliceRouterInjectPolicyRoute("10.1.0.0/16", []string{"192.168.0.2"}, 100, "10.0.0.0/24", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := sliceRouterInjectPolicyRoute("10.2.0.0/16", []string{"192.168.1.2"}, 100, "10.0.0.0/24", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if nextHops := tableRouteNextHops(t, fake, 100, "10.1.0.0/16"); len(nextHops) != 1 || nextHops[0] != "192.168.0.2" {
+		t.Error("route in table: expected [192.168.0.2], received", nextHops)
+	}
+	routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+	if nextHops := getRouteNextHops(routes, "10.1.0.0/16"); len(nextHops) != 0 {
+		t.Error("policy route installed in the main table:", nextHops)
+	}
+	if rules, _ := fake.RuleList(netlink.FAMILY_V4); len(rules) != 1 || rules[0].Priority != 1000 {
+		t.Fatal("expected a single shared rule with priority 1000, received", rules)
+	}
+
+	// The rule is kept while another policy route uses it.
+	if err := sliceRouterInjectPolicyRoute("10.1.0.0/16", nil, 100, "10.0.0.0/24", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if nextHops := tableRouteNextHops(t, fake, 100, "10.1.0.0/16"); len(nextHops) != 0 {
+		t.Error("deleted route still in table:", nextHops)
+	}
+	if !hasPolicyRule(fake, 100, "10.0.0.0/24") {
+		t.Error("shared rule deleted with the first route")
+	}
+
+	// The reconcile restores the route and rule removed from the kernel.
+	fake.mu.Lock()
+	fake.routes = fake.routes[:0]
+	fake.rules = nil
+	fake.mu.Unlock()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.1.2", 2)
+	if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if nextHops := tableRouteNextHops(t, fake, 100, "10.2.0.0/16"); len(nextHops) != 1 || nextHops[0] != "192.168.1.2" {
+		t.Error("reconciled route: expected [192.168.1.2], received", nextHops)
+	}
+	if nextHops := tableRouteNextHops(t, fake, 100, "10.1.0.0/16"); len(nextHops) != 0 {
+		t.Error("reconcile restored a deleted route:", nextHops)
+	}
+	if !hasPolicyRule(fake, 100, "10.0.0.0/24") {
+		t.Error("reconcile did not restore the rule")
+	}
+
+	// Deleting the last route using the rule removes the rule.
+	if err := sliceRouterInjectPolicyRoute("10.2.0.0/16", nil, 100, "10.0.0.0/24", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if nextHops := tableRouteNextHops(t, fake, 100, "10.2.0.0/16"); len(nextHops) != 0 {
+		t.Error("deleted route still in table:", nextHops)
+	}
+	if hasPolicyRule(fake, 100, "10.0.0.0/24") {
+		t.Error("rule not deleted with the last route")
+	}
+}
+
+func TestPolicyRouteMoveSource(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetPolicyRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	if err := sliceRouterInjectPolicyRoute("10.1.0.0/16", []string{"192.168.0.2"}, 100, "10.0.0.0/24", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := sliceRouterInjectPolicyRoute("10.1.0.0/16", []string{"192.168.0.2"}, 100, "10.0.1.0/24", 0); err != nil {
+		t.Fatal(err)
+	}
+	if hasPolicyRule(fake, 100, "10.0.0.0/24") {
+		t.Error("previous rule left behind")
+	}
+	if !hasPolicyRule(fake, 100, "10.0.1.0/24") {
+		t.Error("new rule not installed")
+	}
+}
+
+func TestPolicyRouteValidation(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	skipReconcile(t)
+
+	tests := []struct {
+		testName     string
+		dataplane    string
+		remoteSubnet string
+		nextHops     []string
+		table        uint32
+		srcPrefix    string
+		expectedErr  error
+	}{
+		{"main table is reserved", SliceRouterDataplaneKernel, "10.1.0.0/16", []string{"192.168.0.2"}, 254, "10.0.0.0/24", nil},
+		{"unset table is reserved", SliceRouterDataplaneKernel, "10.1.0.0/16", []string{"192.168.0.2"}, 0, "10.0.0.0/24", nil},
+		{"invalid source prefix", SliceRouterDataplaneKernel, "10.1.0.0/16", []string{"192.168.0.2"}, 100, "10.0.0.0", nil},
+		{"source family mismatch", SliceRouterDataplaneKernel, "10.1.0.0/16", []string{"192.168.0.2"}, 100, "fd00::/64", nil},
+		{"invalid remote subnet", SliceRouterDataplaneKernel, "10.1.0.0", []string{"192.168.0.2"}, 100, "10.0.0.0/24", nil},
+		{"invalid next hop", SliceRouterDataplaneKernel, "10.1.0.0/16", []string{"192.168.0"}, 100, "10.0.0.0/24", nil},
+		{"vpp dataplane", SliceRouterDataplaneVpp, "10.1.0.0/16", []string{"192.168.0.2"}, 100, "10.0.0.0/24", errPolicyRouteUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			resetPolicyRouteMap(t)
+			fake := newFakeNetlink()
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectPolicyRoute(tt.remoteSubnet, tt.nextHops, tt.table, tt.srcPrefix, 0)
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Fatal("expected", tt.expectedErr, "received", err)
+				}
+			} else {
+				var rErr *routeError
+				if !errors.As(err, &rErr) || rErr.reason != routeErrorInvalidArgument {
+					t.Fatal("expected an invalid argument error, received", err)
+				}
+			}
+			if len(fake.routes) != 0 || len(fake.rules) != 0 {
+				t.Error("kernel modified by a rejected policy route")
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// getProbeMethod returns the probe used by an on demand probe of a next hop. The next hop health check
+// probe is used by default, icmp if health checking is disabled. It returns false if the method is not
+// a known probe.
+func getProbeMethod(method string) (string, bool) {
+	switch method {
+	case nextHopHealthCheckICMP, nextHopHealthCheckTCP:
+		return method, true
+	case "":
+		if mode := getNextHopHealthCheckMode(); mode == nextHopHealthCheckTCP || mode == nextHopHealthCheckICMP {
+			return mode, true
+		}
+		return nextHopHealthCheckICMP, true
+	}
+	return "", false
+}
+
+// vl3GetNeighborInKernel returns the neighbor entry of the next hop, on any link. The state is
+// NEIGHBOR_NONE if the kernel has no entry for it.
+func vl3GetNeighborInKernel(nextHopIP net.IP) (*sidecar.NextHopNeighbor, error) {
+	neighbor := &sidecar.NextHopNeighbor{NextHopIP: nextHopIP.String()}
+	neighs, err := nlHandle.NeighList(0, ipFamily(nextHopIP))
+	if err != nil {
+		return nil, err
+	}
+	for _, neigh := range neighs {
+		if !neigh.IP.Equal(nextHopIP) {
+			continue
+		}
+		neighbor.State = neighborStateToProto(neigh.State)
+		if len(neigh.HardwareAddr) > 0 {
+			neighbor.HardwareAddr = neigh.HardwareAddr.String()
+		}
+		break
+	}
+	return neighbor, nil
+}
+
+// sliceRouterProbeNextHop probes the reachability of the next hop with the given method and reports the
+// result along with the time the probe took. A failed probe is reported in the response, not as an
+// error. In kernel mode the neighbor entry of the next hop is read after the probe, so that it reflects
+// the resolution the probe triggered.
+func sliceRouterProbeNextHop(nextHopIP net.IP, method string, timeout time.Duration) (*sidecar.ProbeNextHopResponse, error) {
+	start := time.Now()
+	err := probeNextHop(method, nextHopIP.String(), timeout)
+	result := &sidecar.ProbeNextHopResponse{
+		NextHop:   nextHopIP.String(),
+		Method:    method,
+		Reachable: err == nil,
+		Latency:   durationpb.New(time.Since(start)),
+	}
+	if err != nil {
+		logger.GlobalLogger.Infof("Next hop %v is not reachable with %v: %v", nextHopIP, method, err)
+		result.Error = err.Error()
+	}
+
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneKernel {
+		neighbor, err := vl3GetNeighborInKernel(nextHopIP)
+		if err != nil {
+			return nil, err
+		}
+		result.Neighbor = neighbor
+	}
+	return result, nil
+}
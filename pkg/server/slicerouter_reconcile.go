@@ -0,0 +1,308 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// The routing table is reconciled by route injections once the reconcile interval has passed.
+	reconcileModeInline = "inline"
+	// The routing table is reconciled by a background loop every reconcile interval.
+	reconcileModeTimer = "timer"
+	// Both injections and the background loop reconcile the routing table.
+	reconcileModeBoth = "both"
+)
+
+const (
+	// An installed route without gateway on the link of a next hop is directly connected, the
+	// reconcile leaves it in place.
+	connectedRouteKeep = "keep"
+	// An installed route without gateway is replaced by the route through the next hops.
+	connectedRouteReplace = "replace"
+)
+
+// getReconcileMode returns what triggers the routing table reconcile, read from the RECONCILE_MODE
+// env variable. Injections trigger it by default.
+func getReconcileMode() string {
+	mode := os.Getenv("RECONCILE_MODE")
+	switch mode {
+	case reconcileModeInline, reconcileModeTimer, reconcileModeBoth:
+		return mode
+	case "":
+		return reconcileModeInline
+	}
+	logger.GlobalLogger.Errorf("Invalid RECONCILE_MODE %q, using %v", mode, reconcileModeInline)
+	return reconcileModeInline
+}
+
+// reconcileOnInject returns true if route injections are responsible for triggering the reconcile.
+func reconcileOnInject() bool {
+	mode := getReconcileMode()
+	return mode == reconcileModeInline || mode == reconcileModeBoth
+}
+
+// reconcileOnTimer returns true if the routing table is reconciled by the background loop.
+func reconcileOnTimer() bool {
+	mode := getReconcileMode()
+	return mode == reconcileModeTimer || mode == reconcileModeBoth
+}
+
+// getConnectedRouteMode returns how the reconcile treats an installed route without gateway to a remote
+// subnet, read from the RECONCILE_CONNECTED_ROUTES env variable. Such routes are kept by default.
+func getConnectedRouteMode() string {
+	mode := os.Getenv("RECONCILE_CONNECTED_ROUTES")
+	switch mode {
+	case connectedRouteKeep, connectedRouteReplace:
+		return mode
+	case "":
+		return connectedRouteKeep
+	}
+	logger.GlobalLogger.Errorf("Invalid RECONCILE_CONNECTED_ROUTES %q, using %v", mode, connectedRouteKeep)
+	return connectedRouteKeep
+}
+
+// isConnectedOnNextHopLink returns true if one of the routes has no gateway and egresses the link the
+// next hop is reached on. The remote subnet is then directly connected through the next hop link, and
+// reinstalling the route would never make the installed gateway match the next hop.
+func isConnectedOnNextHopLink(routeList []netlink.Route, nextHopIP string, installedRoutes []netlink.Route, linkMap map[int]netlink.Link) bool {
+	if getConnectedRouteMode() != connectedRouteKeep {
+		return false
+	}
+	for _, route := range routeList {
+		if route.Gw != nil || len(route.MultiPath) > 0 {
+			continue
+		}
+		linkIdx, err := resolveNextHopLink(nextHopIP, installedRoutes, linkMap)
+		if err != nil {
+			return false
+		}
+		if route.LinkIndex == linkIdx {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryFailedInjectsEnabled returns true if a route whose injection failed in the kernel is kept as
+// desired so that the reconcile keeps trying to install it, read from the RETRY_FAILED_INJECTS env
+// variable. Failed injections are dropped by default.
+func isRetryFailedInjectsEnabled() bool {
+	return os.Getenv("RETRY_FAILED_INJECTS") == "true"
+}
+
+// recordFailedInject keeps the requested next hops and preferred source of a route whose kernel
+// injection failed as the desired state of the route if failed injections are retried.
+func recordFailedInject(remoteSubnet string, nextHopIPList []string, src net.IP) {
+	if !isRetryFailedInjectsEnabled() {
+		return
+	}
+	logger.GlobalLogger.Infof("Route injection failed, leaving it to the reconcile. RemoteSubnet: %v, NextHops: %v",
+		remoteSubnet, nextHopIPList)
+	remoteSubnetRouteMap.Store(remoteSubnet, nextHopIPList)
+	storePreferredSrc(remoteSubnet, src)
+}
+
+// routingTableReconcileLoop reconciles the routing table periodically until ctx is done. It is started
+// when the reconcile mode includes the timer, and when routes are installed without ONLINK so that
+// routes left pending by an injection are installed once their neighbors are resolved. The fast tier
+// routes are also reconciled on their own every fast reconcile interval.
+func routingTableReconcileLoop(ctx context.Context) {
+	ticker := time.NewTicker(getReconcileInterval())
+	defer ticker.Stop()
+	fastTicker := time.NewTicker(getReconcileFastInterval())
+	defer fastTicker.Stop()
+	reconcileMu.Lock()
+	reconcileLoopStartTime = time.Now()
+	reconcileMu.Unlock()
+	defer func() {
+		reconcileMu.Lock()
+		reconcileLoopStartTime = time.Time{}
+		reconcileMu.Unlock()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sliceRouterReconcileIfDue()
+		case <-fastTicker.C:
+			sliceRouterReconcileFastTierIfDue()
+		}
+	}
+}
+
+// getReconcileInterval returns the interval between two reconciles of the routing table.
+func getReconcileInterval() time.Duration {
+	return time.Duration(routingTableReconcileInterval) * time.Second
+}
+
+// nextReconcileTime returns when the routing table is next reconciled after the reconcile at last. The
+// reconcile is due once the interval has passed. The loop started at loopStart only reconciles on its
+// ticks, so the reconcile runs at the first tick after it is due. A zero loopStart means that there is
+// no loop, and the due time is returned.
+func nextReconcileTime(last, loopStart time.Time, interval time.Duration) time.Time {
+	due := last.Add(interval)
+	if loopStart.IsZero() {
+		return due
+	}
+	// A tick at the due time itself does not reconcile, the interval must have been exceeded.
+	ticks := due.Sub(loopStart)/interval + 1
+	if ticks < 1 {
+		ticks = 1
+	}
+	return loopStart.Add(ticks * interval)
+}
+
+// sliceRouterGetReconcileStatus returns when the routing table was last reconciled and when it will
+// next be.
+func sliceRouterGetReconcileStatus() *sidecar.ReconcileStatus {
+	reconcileMu.Lock()
+	last, loopStart := lastRoutingTableReconcileTime, reconcileLoopStartTime
+	lastFast := lastFastTierReconcileTime()
+	reconcileMu.Unlock()
+
+	interval := getReconcileInterval()
+	reconcileStatus := &sidecar.ReconcileStatus{
+		Mode:              getReconcileMode(),
+		Interval:          durationpb.New(interval),
+		NextReconcileTime: timestamppb.New(nextReconcileTime(last, loopStart, interval)),
+		Scheduled:         !loopStart.IsZero(),
+		FastInterval:      durationpb.New(getReconcileFastInterval()),
+	}
+	if !last.IsZero() {
+		reconcileStatus.LastReconcileTime = timestamppb.New(last)
+	}
+	if !lastFast.IsZero() {
+		reconcileStatus.LastFastReconcileTime = timestamppb.New(lastFast)
+	}
+	return reconcileStatus
+}
+
+// getReconcileMaxCorrections returns the number of routes a reconcile cycle corrects at most, read from
+// the RECONCILE_MAX_CORRECTIONS env variable. Zero, the default, leaves the corrections unbounded.
+func getReconcileMaxCorrections() int {
+	max, err := strconv.Atoi(os.Getenv("RECONCILE_MAX_CORRECTIONS"))
+	if err != nil || max < 0 {
+		return 0
+	}
+	return max
+}
+
+// reconcileBudget bounds the route corrections of a reconcile cycle, the routes left over are corrected
+// by the next cycles. A nil budget is unbounded.
+type reconcileBudget struct {
+	max  int
+	used int
+	// corrections records the corrections of the main table routes made in the cycle, by remote subnet,
+	// if it is set.
+	corrections map[string]reconcileCorrection
+}
+
+// reconcileCorrection is the correction of a route made by a reconcile cycle and its outcome.
+type reconcileCorrection struct {
+	correction string
+	err        error
+}
+
+func newReconcileBudget() *reconcileBudget {
+	return &reconcileBudget{max: getReconcileMaxCorrections()}
+}
+
+// take accounts for one route correction, returning false if the budget of the cycle is spent.
+func (b *reconcileBudget) take() bool {
+	if b == nil || b.max == 0 {
+		return true
+	}
+	if b.used >= b.max {
+		return false
+	}
+	b.used++
+	return true
+}
+
+// recordCorrection records the correction of the route to the remote subnet if the budget records them.
+func (b *reconcileBudget) recordCorrection(remoteSubnet string, correction string, err error) {
+	if b == nil || b.corrections == nil {
+		return
+	}
+	b.corrections[remoteSubnet] = reconcileCorrection{correction: correction, err: err}
+}
+
+// deferCorrection logs and counts a route correction deferred to the next cycle.
+func (b *reconcileBudget) deferCorrection(remoteSubnet string) {
+	logger.GlobalLogger.Infof("Reconcile correction limit of %v reached, deferring dst: %v to the next cycle", b.max, remoteSubnet)
+	reconcileDeferredCounter.Inc()
+}
+
+// prefixFilter restricts a reconcile to the remote subnets within one of its prefixes. An empty filter
+// matches every remote subnet.
+type prefixFilter []*net.IPNet
+
+// newPrefixFilter parses the CIDRs of a prefix filter.
+func newPrefixFilter(prefixes []string) (prefixFilter, error) {
+	filter := prefixFilter{}
+	for _, prefix := range prefixes {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid reconcile prefix %q: %v", prefix, err)
+		}
+		filter = append(filter, ipNet)
+	}
+	return filter, nil
+}
+
+// prefixes returns the CIDRs of the filter.
+func (f prefixFilter) prefixes() []string {
+	prefixes := make([]string, 0, len(f))
+	for _, prefix := range f {
+		prefixes = append(prefixes, prefix.String())
+	}
+	return prefixes
+}
+
+// matches returns true if the remote subnet is within one of the prefixes of the filter.
+func (f prefixFilter) matches(remoteSubnet string) bool {
+	if len(f) == 0 {
+		return true
+	}
+	_, subnet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return false
+	}
+	subnetOnes, _ := subnet.Mask.Size()
+	for _, prefix := range f {
+		prefixOnes, _ := prefix.Mask.Size()
+		if prefix.Contains(subnet.IP) && prefixOnes <= subnetOnes {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,515 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestReconcileMode(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		mode     string
+		onInject bool
+		onTimer  bool
+	}{
+		{"", true, false},
+		{reconcileModeInline, true, false},
+		{reconcileModeTimer, false, true},
+		{reconcileModeBoth, true, true},
+		{"sometimes", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("mode "+tt.mode, func(t *testing.T) {
+			t.Setenv("RECONCILE_MODE", tt.mode)
+			if reconcileOnInject() != tt.onInject || reconcileOnTimer() != tt.onTimer {
+				t.Fatal("expected inject", tt.onInject, "timer", tt.onTimer,
+					"received inject", reconcileOnInject(), "timer", reconcileOnTimer())
+			}
+
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+
+			// A route recorded by the sidecar went missing from the kernel, and the reconcile
+			// interval has passed.
+			remoteSubnetRouteMap.Store("10.9.0.0/16", []string{"192.168.0.2"})
+			reconcileMu.Lock()
+			saved := lastRoutingTableReconcileTime
+			lastRoutingTableReconcileTime = time.Time{}
+			reconcileMu.Unlock()
+			t.Cleanup(func() {
+				reconcileMu.Lock()
+				lastRoutingTableReconcileTime = saved
+				reconcileMu.Unlock()
+			})
+
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+				t.Fatal(err)
+			}
+			routes, _ := fake.RouteList(nil, 0)
+			reconciled := len(getRouteNextHops(routes, "10.9.0.0/16")) > 0
+			if reconciled != tt.onInject {
+				t.Error("reconciled by the injection: expected", tt.onInject, "received", reconciled)
+			}
+		})
+	}
+}
+
+func TestReconcileRunsOneAtATime(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	remoteSubnetRouteMap.Store("10.9.0.0/16", []string{"192.168.0.2"})
+
+	// Reconciles list the kernel routes, holding them there long enough for the other triggers to
+	// overlap with them.
+	var inFlight, maxInFlight int32
+	entered := make(chan struct{}, 1)
+	fake.routeListHook = func() {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		select {
+		case entered <- struct{}{}:
+		default:
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}
+	useFakeNetlink(t, fake)
+
+	reconcileMu.Lock()
+	saved := lastRoutingTableReconcileTime
+	lastRoutingTableReconcileTime = time.Time{}
+	reconcileMu.Unlock()
+	t.Cleanup(func() {
+		reconcileMu.Lock()
+		lastRoutingTableReconcileTime = saved
+		reconcileMu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		sliceRouterReconcileIfDue()
+	}()
+	<-entered
+	if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != errReconcileInProgress {
+		t.Error("reconcile while another one runs: expected", errReconcileInProgress, "received", err)
+	}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sliceRouterReconcileIfDue()
+		}()
+	}
+	wg.Wait()
+
+	if max := atomic.LoadInt32(&maxInFlight); max != 1 {
+		t.Error("concurrent reconciles: expected 1, received", max)
+	}
+	reconcileMu.Lock()
+	reconciled := !lastRoutingTableReconcileTime.IsZero()
+	reconcileMu.Unlock()
+	if !reconciled {
+		t.Error("expected the routing table to be reconciled")
+	}
+	routes, _ := fake.RouteList(nil, 0)
+	if len(getRouteNextHops(routes, "10.9.0.0/16")) == 0 {
+		t.Error("expected the missing route to be installed by the reconcile")
+	}
+}
+
+func TestRetryFailedInjects(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName string
+		retry    string
+		// unresolved makes the injection fail on a next hop that is not connected yet, instead of a
+		// dataplane error.
+		unresolved bool
+		retried    bool
+	}{
+		{"dataplane error dropped by default", "", false, false},
+		{"dataplane error retried", "true", false, true},
+		{"unresolved next hop dropped by default", "", true, false},
+		{"unresolved next hop retried", "true", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("RETRY_FAILED_INJECTS", tt.retry)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.1.2", 2)
+			if !tt.unresolved {
+				fake.addConnectedRoute("192.168.0.2", 1)
+				fake.routeReplaceErr = errors.New("netlink failure")
+			}
+			useFakeNetlink(t, fake)
+
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err == nil {
+				t.Fatal("expected the injection to fail")
+			}
+			if _, ok := remoteSubnetRouteMap.Load("10.1.0.0/16"); ok != tt.retried {
+				t.Fatal("route kept for retry: expected", tt.retried, "received", ok)
+			}
+
+			// A route that fails for good must not hold back the reconcile of the other routes.
+			remoteSubnetRouteMap.Store("10.9.0.0/16", []string{"192.168.1.2"})
+			fake.mu.Lock()
+			fake.routeReplaceErr = nil
+			fake.mu.Unlock()
+			if tt.unresolved {
+				fake.addConnectedRoute("192.168.0.2", 1)
+			}
+			if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+			if installed := len(getRouteNextHops(routes, "10.1.0.0/16")) > 0; installed != tt.retried {
+				t.Error("route installed by the reconcile: expected", tt.retried, "received", installed)
+			}
+			if len(getRouteNextHops(routes, "10.9.0.0/16")) == 0 {
+				t.Error("expected the other route to be reconciled")
+			}
+		})
+	}
+}
+
+func TestReconcileContinuesAfterUnresolvedRoute(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.1.2", 2)
+	useFakeNetlink(t, fake)
+
+	// sync.Map ranges in random order, use enough routes for the unresolved one not to come last.
+	remoteSubnetRouteMap.Store("10.0.0.0/16", []string{"192.168.0.2"})
+	for i := 1; i <= 8; i++ {
+		remoteSubnetRouteMap.Store(fmt.Sprintf("10.%d.0.0/16", i), []string{"192.168.1.2"})
+	}
+	if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+	for i := 1; i <= 8; i++ {
+		if len(getRouteNextHops(routes, fmt.Sprintf("10.%d.0.0/16", i))) == 0 {
+			t.Errorf("route to 10.%d.0.0/16 not reconciled", i)
+		}
+	}
+}
+
+func TestPrefixFilter(t *testing.T) {
+	tests := []struct {
+		testName     string
+		prefixes     []string
+		remoteSubnet string
+		expected     bool
+	}{
+		{"empty filter matches everything", nil, "10.1.0.0/16", true},
+		{"subnet equal to the prefix", []string{"10.1.0.0/16"}, "10.1.0.0/16", true},
+		{"subnet within the prefix", []string{"10.0.0.0/8"}, "10.1.0.0/16", true},
+		{"subnet within the second prefix", []string{"10.2.0.0/16", "10.1.0.0/16"}, "10.1.4.0/24", true},
+		{"subnet outside the prefix", []string{"10.2.0.0/16"}, "10.1.0.0/16", false},
+		{"subnet wider than the prefix", []string{"10.1.0.0/24"}, "10.1.0.0/16", false},
+		{"ipv6 subnet within the prefix", []string{"fd00::/16"}, "fd00:1::/64", true},
+		{"ipv6 subnet outside an ipv4 prefix", []string{"10.0.0.0/8"}, "fd00:1::/64", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			filter, err := newPrefixFilter(tt.prefixes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter.matches(tt.remoteSubnet); got != tt.expected {
+				t.Error("expected", tt.expected, "received", got)
+			}
+		})
+	}
+}
+
+func TestReconcileNow(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+	skipReconcile(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	remoteSubnets := []string{"10.1.0.0/16", "10.2.0.0/16"}
+	for _, remoteSubnet := range remoteSubnets {
+		if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	tests := []struct {
+		testName   string
+		prefixes   []string
+		errCode    codes.Code
+		reconciled []string
+	}{
+		{"prefix filter", []string{"10.1.0.0/16"}, codes.OK, []string{"10.1.0.0/16"}},
+		{"no filter", nil, codes.OK, remoteSubnets},
+		{"invalid prefix", []string{"10.1.0.0"}, codes.InvalidArgument, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			for _, remoteSubnet := range remoteSubnets {
+				fake.RouteDel(&netlink.Route{Dst: mustParseCIDR(remoteSubnet)})
+			}
+
+			_, err := client.ReconcileNow(ctx, &pb.ReconcileRequest{Prefixes: tt.prefixes})
+			if status.Code(err) != tt.errCode {
+				t.Fatal("error code: expected", tt.errCode, "received", err)
+			}
+
+			routes, err := fake.RouteList(nil, netlink.FAMILY_ALL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, remoteSubnet := range remoteSubnets {
+				installed := len(getRouteNextHops(routes, remoteSubnet)) > 0
+				if expected := contains(tt.reconciled, remoteSubnet); installed != expected {
+					t.Error("route to", remoteSubnet, "installed: expected", expected, "received", installed)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileConnectedRoute(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName      string
+		mode          string
+		connectedLink int
+		nextHops      []string
+		reconciled    bool
+	}{
+		{"connected route on the next hop link kept", "", 1, []string{"192.168.0.2"}, false},
+		{"connected route kept in keep mode", connectedRouteKeep, 1, []string{"192.168.0.2"}, false},
+		{"connected route replaced in replace mode", connectedRouteReplace, 1, []string{"192.168.0.2"}, true},
+		{"connected route on another link replaced", "", 2, []string{"192.168.0.2"}, true},
+		{"connected route missing a next hop replaced", "", 1, []string{"192.168.0.2", "192.168.0.6"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("RECONCILE_CONNECTED_ROUTES", tt.mode)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.0.6", 2)
+			fake.routes = append(fake.routes, netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), LinkIndex: tt.connectedLink})
+			useFakeNetlink(t, fake)
+			log := &routeAuditLog{}
+			useRouteAuditLog(t, log)
+
+			remoteSubnetRouteMap.Store("10.1.0.0/16", tt.nextHops)
+			// A route that is left alone must stay so, a replaced route must converge.
+			for i := 0; i < 2; i++ {
+				if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			reconciles := len(log.list())
+			if tt.reconciled && reconciles != 1 {
+				t.Error("reconciles of the route: expected 1, received", reconciles)
+			}
+			if !tt.reconciled && reconciles != 0 {
+				t.Error("reconciles of the route: expected 0, received", reconciles)
+			}
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_ALL)
+			installed := getRouteNextHops(routes, "10.1.0.0/16")
+			if tt.reconciled && !sameNextHops(installed, tt.nextHops) {
+				t.Error("installed next hops: expected", tt.nextHops, "received", installed)
+			}
+		})
+	}
+}
+
+func TestNextReconcileTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	interval := time.Minute
+
+	tests := []struct {
+		testName  string
+		last      time.Time
+		loopStart time.Time
+		expected  time.Time
+	}{
+		{"no loop, due after the interval", start.Add(10 * time.Second), time.Time{}, start.Add(70 * time.Second)},
+		{"reconciled between ticks", start.Add(10 * time.Second), start, start.Add(2 * time.Minute)},
+		{"reconciled on a tick skips the next tick", start.Add(time.Minute), start, start.Add(3 * time.Minute)},
+		{"reconciled before the loop started", start.Add(-2 * time.Minute), start, start.Add(time.Minute)},
+		{"never reconciled", time.Time{}, start, start.Add(time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := nextReconcileTime(tt.last, tt.loopStart, interval); !got.Equal(tt.expected) {
+				t.Error("expected", tt.expected, "received", got)
+			}
+		})
+	}
+}
+
+func TestGetReconcileStatus(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("RECONCILE_MODE", reconcileModeTimer)
+
+	last := time.Now().Add(-90 * time.Second).Truncate(time.Second)
+	loopStart := last.Add(-30 * time.Second)
+	reconcileMu.Lock()
+	savedLast, savedStart := lastRoutingTableReconcileTime, reconcileLoopStartTime
+	lastRoutingTableReconcileTime, reconcileLoopStartTime = last, loopStart
+	reconcileMu.Unlock()
+	t.Cleanup(func() {
+		reconcileMu.Lock()
+		lastRoutingTableReconcileTime, reconcileLoopStartTime = savedLast, savedStart
+		reconcileMu.Unlock()
+	})
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	response, err := client.GetReconcileStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.GetMode() != reconcileModeTimer || !response.GetScheduled() {
+		t.Error("mode: expected scheduled", reconcileModeTimer, "received", response.GetMode(), response.GetScheduled())
+	}
+	if got := response.GetInterval().AsDuration(); got != time.Minute {
+		t.Error("interval: expected", time.Minute, "received", got)
+	}
+	if got := response.GetLastReconcileTime().AsTime(); !got.Equal(last) {
+		t.Error("last reconcile: expected", last, "received", got)
+	}
+	// The loop ticks 30s and 90s after the reconcile, the tick at 30s is within the interval.
+	if expected, got := last.Add(90*time.Second), response.GetNextReconcileTime().AsTime(); !got.Equal(expected) {
+		t.Error("next reconcile: expected", expected, "received", got)
+	}
+}
+
+func TestReconcileMaxCorrections(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName string
+		max      string
+		// installed is the number of routes installed after each cycle.
+		installed []int
+		deferred  float64
+	}{
+		{"unbounded by default", "", []int{5, 5}, 0},
+		{"invalid limit is unbounded", "-1", []int{5, 5}, 0},
+		{"limit above the drift", "10", []int{5, 5}, 0},
+		{"remaining drift corrected by the next cycles", "2", []int{2, 4, 5, 5}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("RECONCILE_MAX_CORRECTIONS", tt.max)
+			resetRouteMap(t)
+			reconcileDeferredCounter.Reset()
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+			for i := 1; i <= 5; i++ {
+				remoteSubnetRouteMap.Store(fmt.Sprintf("10.%d.0.0/16", i), []string{"192.168.0.2"})
+			}
+
+			for cycle, expected := range tt.installed {
+				if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+					t.Fatal(err)
+				}
+				routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+				installed := 0
+				for i := 1; i <= 5; i++ {
+					if len(getRouteNextHops(routes, fmt.Sprintf("10.%d.0.0/16", i))) > 0 {
+						installed++
+					}
+				}
+				if installed != expected {
+					t.Fatal("routes installed after cycle", cycle+1, ": expected", expected, "received", installed)
+				}
+			}
+			if got := reconcileDeferredCounter.Value(); got != tt.deferred {
+				t.Error("deferred cycles: expected", tt.deferred, "received", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+// Fast tier routes are checked every 10s by default, on top of the full reconciles.
+const defaultReconcileFastInterval = 10 * time.Second
+
+// remoteSubnetReconcilePriorityMap holds the reconcile tier of the routes injected with a priority
+// other than normal, keyed by remote subnet. Routes without an entry are in the normal tier.
+var remoteSubnetReconcilePriorityMap sync.Map
+
+// lastFastReconcileTime records the last time the fast tier routes alone were reconciled, guarded by
+// reconcileMu. A full reconcile covers the fast tier too.
+var lastFastReconcileTime time.Time
+
+// checkReconcilePriority returns an error if the priority is not a known reconcile tier.
+func checkReconcilePriority(priority sidecar.ReconcilePriority) error {
+	if _, ok := sidecar.ReconcilePriority_name[int32(priority)]; !ok {
+		return fmt.Errorf("unknown reconcile priority %v", int32(priority))
+	}
+	return nil
+}
+
+// loadReconcilePriority returns the reconcile tier of the route to the remote subnet.
+func loadReconcilePriority(remoteSubnet string) sidecar.ReconcilePriority {
+	priority, ok := remoteSubnetReconcilePriorityMap.Load(remoteSubnet)
+	if !ok {
+		return sidecar.ReconcilePriority_RECONCILE_PRIORITY_NORMAL
+	}
+	return priority.(sidecar.ReconcilePriority)
+}
+
+// storeReconcilePriority records the reconcile tier of the route to the remote subnet, the normal tier
+// removing it.
+func storeReconcilePriority(remoteSubnet string, priority sidecar.ReconcilePriority) {
+	if priority == sidecar.ReconcilePriority_RECONCILE_PRIORITY_NORMAL {
+		remoteSubnetReconcilePriorityMap.Delete(remoteSubnet)
+		return
+	}
+	remoteSubnetReconcilePriorityMap.Store(remoteSubnet, priority)
+}
+
+// getReconcileFastInterval returns the interval between two reconciles of the fast tier routes, read
+// from the RECONCILE_FAST_INTERVAL env variable.
+func getReconcileFastInterval() time.Duration {
+	return getEnvDuration("RECONCILE_FAST_INTERVAL", defaultReconcileFastInterval)
+}
+
+// fastTierFilter returns the filter selecting the fast tier routes, nil if there are none. The filter
+// matches by prefix, so routes within a fast tier subnet are checked along with it.
+func fastTierFilter() prefixFilter {
+	remoteSubnets := []string{}
+	remoteSubnetReconcilePriorityMap.Range(func(key, value any) bool {
+		if value.(sidecar.ReconcilePriority) == sidecar.ReconcilePriority_RECONCILE_PRIORITY_FAST {
+			remoteSubnets = append(remoteSubnets, key.(string))
+		}
+		return true
+	})
+	if len(remoteSubnets) == 0 {
+		return nil
+	}
+	sort.Strings(remoteSubnets)
+	filter := prefixFilter{}
+	for _, remoteSubnet := range remoteSubnets {
+		if _, ipNet, err := net.ParseCIDR(remoteSubnet); err == nil {
+			filter = append(filter, ipNet)
+		}
+	}
+	return filter
+}
+
+// lastFastTierReconcileTime returns the last time the fast tier routes were reconciled, by either a
+// fast or a full reconcile. reconcileMu must be held.
+func lastFastTierReconcileTime() time.Time {
+	if lastRoutingTableReconcileTime.After(lastFastReconcileTime) {
+		return lastRoutingTableReconcileTime
+	}
+	return lastFastReconcileTime
+}
+
+// sliceRouterReconcileFastTierIfDue reconciles the fast tier routes if they were not reconciled within
+// the fast reconcile interval. The request is skipped if there are no fast tier routes or if a
+// reconcile is already running.
+func sliceRouterReconcileFastTierIfDue() {
+	reconcileMu.Lock()
+	due := time.Since(lastFastTierReconcileTime()) > getReconcileFastInterval()
+	reconcileMu.Unlock()
+	if !due {
+		return
+	}
+	filter := fastTierFilter()
+	if filter == nil {
+		return
+	}
+	err := sliceRouterReconcileRoutingTable(context.Background(), filter)
+	if err == errReconcileInProgress {
+		logger.GlobalLogger.Debugf("Routing table reconcile already running, skipping the fast tier")
+		return
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to reconcile the fast tier routes: %v", err)
+		return
+	}
+	reconciledAt := time.Now()
+	reconcileMu.Lock()
+	lastFastReconcileTime = reconciledAt
+	reconcileMu.Unlock()
+	logger.GlobalLogger.Debugf("Fast tier routes reconciled at: %v", reconciledAt)
+}
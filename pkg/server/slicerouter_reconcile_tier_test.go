@@ -0,0 +1,135 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+// useReconcileTimes sets the last full and fast reconcile times for the duration of the test.
+func useReconcileTimes(t *testing.T, last, lastFast time.Time) {
+	t.Helper()
+	reconcileMu.Lock()
+	savedLast, savedFast := lastRoutingTableReconcileTime, lastFastReconcileTime
+	lastRoutingTableReconcileTime, lastFastReconcileTime = last, lastFast
+	reconcileMu.Unlock()
+	t.Cleanup(func() {
+		reconcileMu.Lock()
+		lastRoutingTableReconcileTime, lastFastReconcileTime = savedLast, savedFast
+		reconcileMu.Unlock()
+	})
+}
+
+func TestReconcileFastTier(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("RECONCILE_FAST_INTERVAL", "1ms")
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	errs := sliceRouterInjectRoutes([]routeRequest{
+		{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.0.2"},
+			reconcilePriority: pb.ReconcilePriority_RECONCILE_PRIORITY_FAST},
+		{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := loadReconcilePriority("10.1.0.0/16"); got != pb.ReconcilePriority_RECONCILE_PRIORITY_FAST {
+		t.Error("10.1.0.0/16 priority: expected fast, received", got)
+	}
+	if got := loadReconcilePriority("10.2.0.0/16"); got != pb.ReconcilePriority_RECONCILE_PRIORITY_NORMAL {
+		t.Error("10.2.0.0/16 priority: expected normal, received", got)
+	}
+	for _, route := range sliceRouterGetRouteTable().GetRoutes() {
+		if expected := loadReconcilePriority(route.GetRemoteSubnet()); route.GetReconcilePriority() != expected {
+			t.Error("route table priority of", route.GetRemoteSubnet(), "expected", expected, "received", route.GetReconcilePriority())
+		}
+	}
+
+	// Both routes went missing from the kernel, the full reconcile just ran and is not due.
+	for _, dst := range []string{"10.1.0.0/16", "10.2.0.0/16"} {
+		if err := sliceRouterDeleteRouteToDst(dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+	useReconcileTimes(t, time.Now(), time.Time{})
+	time.Sleep(5 * time.Millisecond)
+
+	sliceRouterReconcileFastTierIfDue()
+	routes, _ := fake.RouteList(nil, 0)
+	if got := getRouteNextHops(routes, "10.1.0.0/16"); !sameNextHops(got, []string{"192.168.0.2"}) {
+		t.Error("fast tier route: expected reconciled, received next hops", got)
+	}
+	if got := getRouteNextHops(routes, "10.2.0.0/16"); len(got) != 0 {
+		t.Error("normal tier route: expected left to the full reconcile, received next hops", got)
+	}
+	reconcileMu.Lock()
+	lastFast := lastFastReconcileTime
+	reconcileMu.Unlock()
+	if lastFast.IsZero() {
+		t.Error("expected the fast tier reconcile time to be recorded")
+	}
+
+	// Deleting the route drops it from the fast tier, and the fast reconcile has nothing to do.
+	if err := sliceRouterInjectRoute("10.1.0.0/16", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadReconcilePriority("10.1.0.0/16"); got != pb.ReconcilePriority_RECONCILE_PRIORITY_NORMAL {
+		t.Error("deleted route priority: expected normal, received", got)
+	}
+	useReconcileTimes(t, time.Now(), time.Time{})
+	time.Sleep(5 * time.Millisecond)
+	sliceRouterReconcileFastTierIfDue()
+	reconcileMu.Lock()
+	lastFast = lastFastReconcileTime
+	reconcileMu.Unlock()
+	if !lastFast.IsZero() {
+		t.Error("expected no fast tier reconcile without fast tier routes, ran at", lastFast)
+	}
+}
+
+func TestReconcilePriorityValidation(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	err := sliceRouterInjectRouteWithResolver(routeRequest{remoteSubnet: "10.1.0.0/16", nextHopIPList: []string{"192.168.0.2"},
+		reconcilePriority: pb.ReconcilePriority(7)}, newNextHopResolver())
+	var rErr *routeError
+	if !errors.As(err, &rErr) || rErr.reason != routeErrorInvalidArgument {
+		t.Fatal("error: expected", routeErrorInvalidArgument, "received", err)
+	}
+	if _, ok := remoteSubnetRouteMap.Load("10.1.0.0/16"); ok {
+		t.Error("expected the route with an unknown priority to be rejected")
+	}
+}
@@ -0,0 +1,73 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"sync"
+	"unicode"
+)
+
+// Longest description a route can carry, in bytes.
+const maxRouteDescriptionLength = 256
+
+// remoteSubnetDescriptionMap holds the descriptions supplied by the controller for the routes to the
+// remote subnets, keyed by remote subnet. Neither the kernel nor the vpp routes have room for a comment,
+// so the descriptions are only kept by the sidecar. Routes without a description have no entry.
+var remoteSubnetDescriptionMap sync.Map
+
+// loadRouteDescription returns the description of the route to the remote subnet, empty if none.
+func loadRouteDescription(remoteSubnet string) string {
+	description, ok := remoteSubnetDescriptionMap.Load(remoteSubnet)
+	if !ok {
+		return ""
+	}
+	return description.(string)
+}
+
+// storeRouteDescription records the description of the route to the remote subnet, an empty
+// description removing it.
+func storeRouteDescription(remoteSubnet string, description string) {
+	if description == "" {
+		remoteSubnetDescriptionMap.Delete(remoteSubnet)
+		return
+	}
+	remoteSubnetDescriptionMap.Store(remoteSubnet, description)
+}
+
+// checkRouteDescription returns an error if the description is too long or is not printable text. The
+// description is written as is to the logs.
+func checkRouteDescription(description string) error {
+	if len(description) > maxRouteDescriptionLength {
+		return fmt.Errorf("route description longer than %v bytes", maxRouteDescriptionLength)
+	}
+	for _, r := range description {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("route description %q is not printable", description)
+		}
+	}
+	return nil
+}
+
+// describeRoute returns the remote subnet of a route along with its description, for logs.
+func describeRoute(remoteSubnet string) string {
+	if description := loadRouteDescription(remoteSubnet); description != "" {
+		return fmt.Sprintf("%v (%v)", remoteSubnet, description)
+	}
+	return remoteSubnet
+}
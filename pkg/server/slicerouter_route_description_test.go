@@ -0,0 +1,114 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestRouteDescription(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName string
+		// update is injected after the route was injected with the description "cluster-east via gw-2".
+		update              *pb.RouteInfo
+		expectedInstalled   bool
+		expectedDescription string
+	}{
+		{
+			"description updated without next hop change",
+			&pb.RouteInfo{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}, Description: "cluster-east via gw-1"},
+			true,
+			"cluster-east via gw-1",
+		},
+		{
+			"empty description clears it",
+			&pb.RouteInfo{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			true,
+			"",
+		},
+		{
+			"unprintable description rejected",
+			&pb.RouteInfo{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}, Description: "cluster-east\nvia gw-1"},
+			false,
+			"cluster-east via gw-2",
+		},
+		{
+			"failed injection keeps the description",
+			&pb.RouteInfo{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.9.9"}, Description: "cluster-east via gw-9"},
+			false,
+			"cluster-east via gw-2",
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+
+			routes := []*pb.RouteInfo{{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}, Description: "cluster-east via gw-2"}}
+			if _, err := client.InjectRoutes(ctx, &pb.RouteBatch{Routes: routes}); err != nil {
+				t.Fatal(err)
+			}
+			response, err := client.InjectRoutes(ctx, &pb.RouteBatch{Routes: []*pb.RouteInfo{tt.update}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if installed := response.GetResults()[0].GetIsInstalled(); installed != tt.expectedInstalled {
+				t.Error("installed: expected", tt.expectedInstalled, "received", installed, response.GetResults()[0].GetErrorMsg())
+			}
+
+			table, err := client.GetRouteTable(ctx, &emptypb.Empty{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(table.GetRoutes()) != 1 || table.GetRoutes()[0].GetDescription() != tt.expectedDescription {
+				t.Fatal("route table: expected the description", tt.expectedDescription, "received", table.GetRoutes())
+			}
+
+			// Deleting the route drops its description.
+			if err := sliceRouterInjectRoute("10.1.0.0/16", nil); err != nil {
+				t.Fatal(err)
+			}
+			if description := loadRouteDescription("10.1.0.0/16"); description != "" {
+				t.Error("description of a deleted route:", description)
+			}
+		})
+	}
+}
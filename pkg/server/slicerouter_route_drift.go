@@ -0,0 +1,156 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// Attributes of the kernel routes managed by the slice router, reported when they drift.
+const (
+	routeAttrOnlink = "onlink"
+	routeAttrScope  = "scope"
+	routeAttrMetric = "metric"
+	routeAttrTable  = "table"
+	routeAttrSrc    = "src"
+)
+
+// defaultIPv6RouteMetric is the metric the kernel gives to IPv6 routes installed without one.
+const defaultIPv6RouteMetric = 1024
+
+// reconcileCompareNone disables the comparison of all the optional route attributes.
+const reconcileCompareNone = "none"
+
+// getReconcileCompareAttributes returns the optional attributes of the installed routes the reconcile
+// compares, read from the comma-separated RECONCILE_COMPARE_ATTRIBUTES env variable among onlink, scope
+// and metric, or none. All of them are compared by default. A route whose other attributes drift is
+// always corrected.
+func getReconcileCompareAttributes() map[string]bool {
+	attrs := map[string]bool{}
+	entries := getEnvList("RECONCILE_COMPARE_ATTRIBUTES")
+	if len(entries) == 0 {
+		entries = []string{routeAttrOnlink, routeAttrScope, routeAttrMetric}
+	}
+	for _, entry := range entries {
+		switch entry {
+		case routeAttrOnlink, routeAttrScope, routeAttrMetric:
+			attrs[entry] = true
+		case reconcileCompareNone:
+		default:
+			logger.GlobalLogger.Errorf("Invalid RECONCILE_COMPARE_ATTRIBUTES entry %q, ignoring it", entry)
+		}
+	}
+	return attrs
+}
+
+// routeAttributeDrift returns the first managed attribute of the installed routes to a remote subnet
+// that differs from what the slice router installs, empty if none did. src is the preferred source of
+// the route. Routes without a gateway are directly connected and keep the scope and metric the kernel
+// gave them. The optional attributes are only compared if the reconcile is configured to.
+func routeAttributeDrift(routes []netlink.Route, src net.IP) string {
+	compared := getReconcileCompareAttributes()
+	for _, route := range routes {
+		if route.Table != 0 && route.Table != unix.RT_TABLE_MAIN {
+			return routeAttrTable
+		}
+		if !route.Src.Equal(src) {
+			return routeAttrSrc
+		}
+		if route.Gw == nil && len(route.MultiPath) == 0 {
+			continue
+		}
+		if compared[routeAttrScope] && route.Scope != netlink.SCOPE_UNIVERSE {
+			return routeAttrScope
+		}
+		// The kernel reports default routes without a Dst.
+		dst := kernelRouteDst(route)
+		metric := kernelRouteMetric(dst)
+		_, dstNet, _ := net.ParseCIDR(dst)
+		isIPv6 := dstNet != nil && ipFamily(dstNet.IP) == netlink.FAMILY_V6
+		if compared[routeAttrMetric] && route.Priority != metric && !(metric == 0 && route.Priority == defaultIPv6RouteMetric && isIPv6) {
+			return routeAttrMetric
+		}
+		if !compared[routeAttrOnlink] {
+			continue
+		}
+		if route.Gw != nil && hasStaleOnlinkFlag(route.Flags) {
+			return routeAttrOnlink
+		}
+		for _, path := range route.MultiPath {
+			if path.Gw != nil && hasStaleOnlinkFlag(path.Flags) {
+				return routeAttrOnlink
+			}
+		}
+	}
+	return ""
+}
+
+// hasStaleOnlinkFlag returns true if the ONLINK flag of a gateway next hop does not match the
+// KERNEL_ROUTE_ONLINK setting.
+func hasStaleOnlinkFlag(flags int) bool {
+	return (flags&int(netlink.FLAG_ONLINK) != 0) != isRouteOnlinkEnabled()
+}
+
+// Corrections of the installed routes made by the reconcile, besides the drifted route attributes.
+const (
+	// The route or one of its next hops is not installed.
+	routeCorrectionMissing = "missing"
+	// A next hop uses a link that is not an nsm link anymore.
+	routeCorrectionLink = "link"
+	// The weight of a next hop, or the weight or preference of a vpp entry, does not match the
+	// requested one.
+	routeCorrectionWeight = "weight"
+	// A pod next hop moved to another address.
+	routeCorrectionPodMoved = "pod"
+	// Vpp has entries of the route via next hops it no longer has.
+	routeCorrectionStale = "stale"
+	// The route does not have the next hops of its route group.
+	routeCorrectionGroup = "group"
+)
+
+// driftedRouteAttribute returns the managed attribute of the installed routes to the remote subnet that
+// was changed outside of the slice router, empty if none was, counting and logging the drifted
+// attribute.
+func driftedRouteAttribute(remoteSubnet string, routes []netlink.Route) string {
+	attr := routeAttributeDrift(routes, loadPreferredSrc(remoteSubnet))
+	if attr == "" {
+		return ""
+	}
+	logger.GlobalLogger.Infof("Installed route attribute drifted. Dst: %v, Attribute: %v", remoteSubnet, attr)
+	routeDriftCounter.Inc(attr)
+	return attr
+}
+
+// routeCorrection returns what the reconcile has to correct on the installed routes to the remote
+// subnet, empty if they reflect the slice state. installed tells whether the next hop being checked is
+// installed.
+func routeCorrection(remoteSubnet string, routes []netlink.Route, installed bool, linkMap map[int]netlink.Link) string {
+	switch {
+	case !installed:
+		return routeCorrectionMissing
+	case hasStaleNextHopLink(routes, linkMap):
+		return routeCorrectionLink
+	case hasStaleNextHopWeight(routes):
+		return routeCorrectionWeight
+	}
+	return driftedRouteAttribute(remoteSubnet, routes)
+}
@@ -0,0 +1,245 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+func TestReconcileRouteAttributeDrift(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	onlink := int(netlink.FLAG_ONLINK)
+
+	tests := []struct {
+		testName string
+		nextHops []string
+		mutate   func(route *netlink.Route)
+		// drifted is the attribute reported as drifted, a route moved to another table is missing
+		// from the main table instead.
+		drifted    string
+		reconciled bool
+	}{
+		{"no drift", []string{"10.1.1.1"}, func(route *netlink.Route) {}, "", false},
+		{"onlink cleared", []string{"10.1.1.1"}, func(route *netlink.Route) {
+			route.Flags &^= onlink
+		}, routeAttrOnlink, true},
+		{"onlink cleared on a path", []string{"10.1.1.1", "10.1.2.1"}, func(route *netlink.Route) {
+			route.MultiPath[1].Flags &^= onlink
+		}, routeAttrOnlink, true},
+		{"scope changed", []string{"10.1.1.1"}, func(route *netlink.Route) {
+			route.Scope = netlink.SCOPE_LINK
+		}, routeAttrScope, true},
+		{"metric changed", []string{"10.1.1.1"}, func(route *netlink.Route) {
+			route.Priority = 100
+		}, routeAttrMetric, true},
+		{"moved to another table", []string{"10.1.1.1"}, func(route *netlink.Route) {
+			route.Table = 100
+		}, "", true},
+		{"source changed", []string{"10.1.1.1"}, func(route *netlink.Route) {
+			route.Src = net.ParseIP("10.1.2.2")
+		}, routeAttrSrc, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			routeDriftCounter.Reset()
+			fake := newFakeNetlink()
+			addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+			addressPodLink(fake, 2, "slicegw-b", "10.1.2.1", "10.1.2.2")
+			useFakeNetlink(t, fake)
+			if err := sliceRouterInjectRoute("10.2.0.0/16", tt.nextHops); err != nil {
+				t.Fatal(err)
+			}
+			expected := *routeTo(t, fake, "10.2.0.0/16")
+			log := &routeAuditLog{}
+			useRouteAuditLog(t, log)
+
+			fake.mu.Lock()
+			for i := range fake.routes {
+				if fake.routes[i].Dst != nil && fake.routes[i].Dst.String() == "10.2.0.0/16" {
+					route := fake.routes[i]
+					route.MultiPath = nil
+					for _, path := range fake.routes[i].MultiPath {
+						p := *path
+						route.MultiPath = append(route.MultiPath, &p)
+					}
+					tt.mutate(&route)
+					fake.routes[i] = route
+				}
+			}
+			fake.mu.Unlock()
+			// A corrected route must stay so on the next reconcile.
+			for i := 0; i < 2; i++ {
+				if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			reconciles := len(log.list())
+			if !tt.reconciled && reconciles != 0 {
+				t.Error("reconciles of the route: expected 0, received", reconciles)
+			}
+			if tt.reconciled && reconciles != 1 {
+				t.Error("reconciles of the route: expected 1, received", reconciles)
+			}
+			if tt.drifted != "" {
+				if got := routeDriftCounter.Value(tt.drifted); got != 1 {
+					t.Error("drifts of", tt.drifted, ": expected 1, received", got)
+				}
+			}
+			route := routeTo(t, fake, "10.2.0.0/16")
+			if route == nil {
+				t.Fatal("route not installed")
+			}
+			if attr := routeAttributeDrift([]netlink.Route{*route}, nil); attr != "" {
+				t.Error("route still drifted:", attr)
+			}
+			if !sameNextHops(getRouteNextHops([]netlink.Route{*route}, "10.2.0.0/16"), tt.nextHops) {
+				t.Error("installed route: expected", expected, "received", route)
+			}
+		})
+	}
+}
+
+func TestRouteAttributeDrift(t *testing.T) {
+	onlink := int(netlink.FLAG_ONLINK)
+
+	tests := []struct {
+		testName      string
+		disableOnlink bool
+		route         netlink.Route
+		expected      string
+	}{
+		{"gateway route", false, netlink.Route{Dst: mustParseCIDR("10.2.0.0/16"), Gw: net.ParseIP("10.1.1.1"), Flags: onlink}, ""},
+		{"gateway route in the main table", false,
+			netlink.Route{Dst: mustParseCIDR("10.2.0.0/16"), Gw: net.ParseIP("10.1.1.1"), Flags: onlink, Table: 254}, ""},
+		{"ipv6 route with the default metric", false,
+			netlink.Route{Dst: mustParseCIDR("fd02::/64"), Gw: net.ParseIP("fd01::1"), Flags: onlink, Priority: 1024}, ""},
+		{"ipv4 route with the ipv6 default metric", false,
+			netlink.Route{Dst: mustParseCIDR("10.2.0.0/16"), Gw: net.ParseIP("10.1.1.1"), Flags: onlink, Priority: 1024}, routeAttrMetric},
+		{"connected route keeps its scope and metric", false,
+			netlink.Route{Dst: mustParseCIDR("10.2.0.0/16"), LinkIndex: 1, Scope: netlink.SCOPE_LINK, Priority: 100}, ""},
+		{"onlink route without onlink", true, netlink.Route{Dst: mustParseCIDR("10.2.0.0/16"), Gw: net.ParseIP("10.1.1.1"), Flags: onlink}, routeAttrOnlink},
+		{"route without onlink", true, netlink.Route{Dst: mustParseCIDR("10.2.0.0/16"), Gw: net.ParseIP("10.1.1.1")}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if tt.disableOnlink {
+				t.Setenv("KERNEL_ROUTE_ONLINK", "false")
+			}
+			if got := routeAttributeDrift([]netlink.Route{tt.route}, nil); got != tt.expected {
+				t.Errorf("expected %q, received %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetReconcileCompareAttributes(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	all := map[string]bool{routeAttrOnlink: true, routeAttrScope: true, routeAttrMetric: true}
+
+	tests := []struct {
+		value    string
+		expected map[string]bool
+	}{
+		{"", all},
+		{"onlink,scope,metric", all},
+		{"metric", map[string]bool{routeAttrMetric: true}},
+		{" scope , onlink ", map[string]bool{routeAttrScope: true, routeAttrOnlink: true}},
+		{"none", map[string]bool{}},
+		{"metric,table", map[string]bool{routeAttrMetric: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("RECONCILE_COMPARE_ATTRIBUTES", tt.value)
+			if attrs := getReconcileCompareAttributes(); !reflect.DeepEqual(attrs, tt.expected) {
+				t.Error("expected", tt.expected, "received", attrs)
+			}
+		})
+	}
+}
+
+func TestReconcileComparePolicy(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	clearOnlink := func(route *netlink.Route) { route.Flags &^= int(netlink.FLAG_ONLINK) }
+	changeScope := func(route *netlink.Route) { route.Scope = netlink.SCOPE_LINK }
+	changeMetric := func(route *netlink.Route) { route.Priority = 100 }
+	changeSrc := func(route *netlink.Route) { route.Src = net.ParseIP("10.1.1.2") }
+
+	tests := []struct {
+		testName   string
+		compare    string
+		mutate     func(route *netlink.Route)
+		reconciled bool
+	}{
+		{"flags compared by default", "", clearOnlink, true},
+		{"scope compared by default", "", changeScope, true},
+		{"metric compared by default", "", changeMetric, true},
+		{"flags ignored", "scope,metric", clearOnlink, false},
+		{"scope ignored", "onlink,metric", changeScope, false},
+		{"metric ignored", "onlink,scope", changeMetric, false},
+		{"only flags compared", "onlink", clearOnlink, true},
+		{"only scope compared", "scope", changeScope, true},
+		{"only metric compared", "metric", changeMetric, true},
+		{"nothing optional compared", "none", changeMetric, false},
+		{"source always compared", "none", changeSrc, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("RECONCILE_COMPARE_ATTRIBUTES", tt.compare)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+			useFakeNetlink(t, fake)
+			if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"10.1.1.1"}); err != nil {
+				t.Fatal(err)
+			}
+			log := &routeAuditLog{}
+			useRouteAuditLog(t, log)
+
+			fake.mu.Lock()
+			for i := range fake.routes {
+				if fake.routes[i].Dst != nil && fake.routes[i].Dst.String() == "10.2.0.0/16" {
+					tt.mutate(&fake.routes[i])
+				}
+			}
+			fake.mu.Unlock()
+			if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if reconciled := len(log.list()) > 0; reconciled != tt.reconciled {
+				t.Error("reconciled: expected", tt.reconciled, "received", reconciled)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+// routeGroup is a named group of routes sharing their next hops, such as the pod and service CIDRs of
+// a remote cluster reached through the same slice gw. The routes of a group are installed, deleted and
+// reconciled as one unit.
+type routeGroup struct {
+	name string
+	// members are the remote subnets of the group, in request order.
+	members       []string
+	nextHopIPList []string
+}
+
+var (
+	// routeGroupOpMu serializes the changes made to the route groups.
+	routeGroupOpMu sync.Mutex
+	// routeGroups holds the route groups by name, guarded by routeGroupsMu.
+	routeGroupsMu sync.Mutex
+	routeGroups   = map[string]*routeGroup{}
+)
+
+// loadRouteGroup returns the name of the route group the remote subnet belongs to, empty if none.
+func loadRouteGroup(remoteSubnet string) string {
+	_, prefix, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return ""
+	}
+	routeGroupsMu.Lock()
+	defer routeGroupsMu.Unlock()
+	for name, group := range routeGroups {
+		if contains(group.members, prefix.String()) {
+			return name
+		}
+	}
+	return ""
+}
+
+// listRouteGroups returns a copy of the route groups, sorted by name.
+func listRouteGroups() []*routeGroup {
+	routeGroupsMu.Lock()
+	defer routeGroupsMu.Unlock()
+	groups := []*routeGroup{}
+	for _, group := range routeGroups {
+		groups = append(groups, &routeGroup{name: group.name, members: append([]string{}, group.members...),
+			nextHopIPList: append([]string{}, group.nextHopIPList...)})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].name < groups[j].name
+	})
+	return groups
+}
+
+// storeRouteGroup records the route group, a group with no members is forgotten.
+func storeRouteGroup(group *routeGroup) {
+	routeGroupsMu.Lock()
+	defer routeGroupsMu.Unlock()
+	if len(group.members) == 0 {
+		delete(routeGroups, group.name)
+		return
+	}
+	routeGroups[group.name] = group
+}
+
+// forgetRouteGroupMember removes the remote subnet from its route group once its route is deleted
+// outside of a group request, the shutdown flush deleting every route.
+func forgetRouteGroupMember(remoteSubnet string) {
+	_, prefix, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return
+	}
+	routeGroupsMu.Lock()
+	defer routeGroupsMu.Unlock()
+	for name, group := range routeGroups {
+		members := []string{}
+		for _, member := range group.members {
+			if member != prefix.String() {
+				members = append(members, member)
+			}
+		}
+		if len(members) == 0 {
+			delete(routeGroups, name)
+			continue
+		}
+		group.members = members
+	}
+}
+
+// requests returns the injections of the routes of the group.
+func (g *routeGroup) requests(ctx context.Context) []routeRequest {
+	routes := []routeRequest{}
+	for _, member := range g.members {
+		routes = append(routes, routeRequest{ctx: ctx, remoteSubnet: member, nextHopIPList: g.nextHopIPList, group: g.name})
+	}
+	return routes
+}
+
+// matches returns true if a member of the group matches the filter.
+func (g *routeGroup) matches(filter prefixFilter) bool {
+	for _, member := range g.members {
+		if filter.matches(member) {
+			return true
+		}
+	}
+	return false
+}
+
+// strayedMembers returns the members of the group whose route is not recorded with the next hops of
+// the group in the slice route map.
+func (g *routeGroup) strayedMembers() []string {
+	strayed := []string{}
+	for _, member := range g.members {
+		cached, ok := remoteSubnetRouteMap.Load(member)
+		if !ok || !sameNextHops(cached.([]string), g.nextHopIPList) {
+			strayed = append(strayed, member)
+		}
+	}
+	return strayed
+}
+
+// sliceRouterInjectRouteGroup routes the remote subnets of the named group via the next hops as one
+// unit and returns the members of the group along with the subnets that left it. The routes of the
+// subnets left out of the request are deleted with the same unit. An empty next hop list deletes the
+// whole group. If any route fails, the routes of the group are rolled back to their state before the
+// request, as for an atomic batch.
+func sliceRouterInjectRouteGroup(ctx context.Context, name string, remoteSubnets []string, nextHopIPList []string) ([]string, []string, error) {
+	if name == "" {
+		return nil, nil, newRouteError(routeErrorInvalidArgument, "", errors.New("route group name is required"))
+	}
+	members := []string{}
+	if len(nextHopIPList) > 0 {
+		for _, remoteSubnet := range remoteSubnets {
+			_, prefix, err := net.ParseCIDR(remoteSubnet)
+			if err != nil {
+				return nil, nil, newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+			}
+			if !contains(members, prefix.String()) {
+				members = append(members, prefix.String())
+			}
+		}
+		if len(members) == 0 {
+			return nil, nil, newRouteError(routeErrorInvalidArgument, "", fmt.Errorf("route group %q has no subnets", name))
+		}
+	}
+
+	routeGroupOpMu.Lock()
+	defer routeGroupOpMu.Unlock()
+
+	for _, member := range members {
+		if group := loadRouteGroup(member); group != "" && group != name {
+			return nil, nil, newRouteError(routeErrorInvalidArgument, member, fmt.Errorf("subnet is in route group %q", group))
+		}
+	}
+	group := &routeGroup{name: name, members: members, nextHopIPList: nextHopIPList}
+	routes := group.requests(ctx)
+	deleted := []string{}
+	routeGroupsMu.Lock()
+	if previous, ok := routeGroups[name]; ok {
+		for _, member := range previous.members {
+			if !contains(members, member) {
+				deleted = append(deleted, member)
+				routes = append(routes, routeRequest{ctx: ctx, remoteSubnet: member, group: name})
+			}
+		}
+	}
+	routeGroupsMu.Unlock()
+	if len(routes) == 0 {
+		return members, deleted, nil
+	}
+
+	if err := sliceRouterInjectRoutesAtomic(routes); err != nil {
+		return nil, nil, err
+	}
+	storeRouteGroup(group)
+	logger.GlobalLogger.Infof("Injected route group. Name: %v, Subnets: %v, NextHops: %v, Deleted: %v", name, members, nextHopIPList, deleted)
+	return members, deleted, nil
+}
+
+// routeGroupFilter returns the filter extended with the members of the route groups matching it, so
+// that the routes of a group are reconciled together. An empty filter matches every route already.
+func routeGroupFilter(filter prefixFilter) prefixFilter {
+	if len(filter) == 0 {
+		return filter
+	}
+	extended := append(prefixFilter{}, filter...)
+	for _, group := range listRouteGroups() {
+		if !group.matches(filter) {
+			continue
+		}
+		for _, member := range group.members {
+			if _, prefix, err := net.ParseCIDR(member); err == nil {
+				extended = append(extended, prefix)
+			}
+		}
+	}
+	return extended
+}
+
+// reconcileRouteGroups brings the route groups matching the filter whose routes strayed from the next
+// hops of their group back to them, e.g. after a group request whose rollback failed. Each group is
+// injected again as one unit and counts as one correction of the budget. The groups being changed by a
+// group request are left to the request.
+func reconcileRouteGroups(ctx context.Context, filter prefixFilter, budget *reconcileBudget) {
+	if !routeGroupOpMu.TryLock() {
+		logger.GlobalLogger.Debugf("Route group request in progress, skipping the route group reconcile")
+		return
+	}
+	defer routeGroupOpMu.Unlock()
+
+	for _, group := range listRouteGroups() {
+		if !group.matches(filter) {
+			continue
+		}
+		strayed := group.strayedMembers()
+		if len(strayed) == 0 {
+			continue
+		}
+		if !budget.take() {
+			budget.deferCorrection(strayed[0])
+			return
+		}
+		logger.GlobalLogger.Infof("Routes of route group %v do not have the next hops of the group. Reconciling dst: %v, gw: %v",
+			group.name, strayed, group.nextHopIPList)
+		err := sliceRouterInjectRoutesAtomic(group.requests(ctx))
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to reconcile route group %v: %v", group.name, err)
+		}
+		for _, member := range strayed {
+			routeAudit.recordCorrection(member, group.nextHopIPList, routeCorrectionGroup, err)
+			budget.recordCorrection(member, routeCorrectionGroup, err)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+)
+
+// installedRouteGroups returns the members of the route groups by name.
+func installedRouteGroups() map[string][]string {
+	groups := map[string][]string{}
+	for _, group := range listRouteGroups() {
+		groups[group.name] = group.members
+	}
+	return groups
+}
+
+func TestInjectRouteGroup(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	a, b := []string{"192.168.0.2"}, []string{"192.168.0.6"}
+	cluster := []string{"10.1.0.0/16", "10.96.0.0/12"}
+	type step struct {
+		// Injects a route on its own when group is empty.
+		group    string
+		subnets  []string
+		subnet   string
+		nextHops []string
+		fails    bool
+	}
+	tests := []struct {
+		testName string
+		steps    []step
+		expected map[string][]string
+		groups   map[string][]string
+	}{
+		{
+			testName: "routes of the group installed",
+			steps:    []step{{group: "cluster-2", subnets: cluster, nextHops: a}},
+			expected: map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": a},
+			groups:   map[string][]string{"cluster-2": cluster},
+		},
+		{
+			testName: "routes of the group moved together",
+			steps: []step{
+				{group: "cluster-2", subnets: cluster, nextHops: a},
+				{group: "cluster-2", subnets: cluster, nextHops: b},
+			},
+			expected: map[string][]string{"10.1.0.0/16": b, "10.96.0.0/12": b},
+			groups:   map[string][]string{"cluster-2": cluster},
+		},
+		{
+			testName: "subnet left out of the group deleted",
+			steps: []step{
+				{group: "cluster-2", subnets: cluster, nextHops: a},
+				{group: "cluster-2", subnets: []string{"10.1.0.0/16"}, nextHops: a},
+			},
+			expected: map[string][]string{"10.1.0.0/16": a},
+			groups:   map[string][]string{"cluster-2": {"10.1.0.0/16"}},
+		},
+		{
+			testName: "group deleted",
+			steps: []step{
+				{group: "cluster-2", subnets: cluster, nextHops: a},
+				{group: "cluster-2"},
+			},
+			expected: map[string][]string{},
+			groups:   map[string][]string{},
+		},
+		{
+			testName: "route injected on its own joins a group",
+			steps: []step{
+				{subnet: "10.1.0.0/16", nextHops: b},
+				{group: "cluster-2", subnets: cluster, nextHops: a},
+			},
+			expected: map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": a},
+			groups:   map[string][]string{"cluster-2": cluster},
+		},
+		{
+			testName: "route of a group not injected on its own",
+			steps: []step{
+				{group: "cluster-2", subnets: cluster, nextHops: a},
+				{subnet: "10.1.0.0/16", nextHops: b, fails: true},
+				{subnet: "10.96.0.0/12", fails: true},
+			},
+			expected: map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": a},
+			groups:   map[string][]string{"cluster-2": cluster},
+		},
+		{
+			testName: "subnet of another group rejected",
+			steps: []step{
+				{group: "cluster-2", subnets: cluster, nextHops: a},
+				{group: "cluster-3", subnets: []string{"10.3.0.0/16", "10.1.0.0/16"}, nextHops: b, fails: true},
+			},
+			expected: map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": a},
+			groups:   map[string][]string{"cluster-2": cluster},
+		},
+		{
+			testName: "group with no subnets rejected",
+			steps:    []step{{group: "cluster-2", nextHops: a, fails: true}},
+			expected: map[string][]string{},
+			groups:   map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.0.6", 2)
+			useFakeNetlink(t, fake)
+
+			for _, s := range tt.steps {
+				var err error
+				if s.group != "" {
+					_, _, err = sliceRouterInjectRouteGroup(context.Background(), s.group, s.subnets, s.nextHops)
+				} else {
+					err = sliceRouterInjectRoute(s.subnet, s.nextHops)
+				}
+				if s.fails != (err != nil) {
+					t.Fatal("unexpected error:", err)
+				}
+			}
+
+			if routes := installedRoutes(); !reflect.DeepEqual(routes, tt.expected) {
+				t.Error("routes: expected", tt.expected, "received", routes)
+			}
+			for remoteSubnet, nextHops := range tt.expected {
+				if installed, _ := vl3GetInstalledNextHopsInKernel(remoteSubnet); !sameNextHops(installed, nextHops) {
+					t.Error("route to", remoteSubnet, ": expected", nextHops, "installed", installed)
+				}
+			}
+			if groups := installedRouteGroups(); !reflect.DeepEqual(groups, tt.groups) {
+				t.Error("route groups: expected", tt.groups, "received", groups)
+			}
+		})
+	}
+}
+
+func TestInjectRouteGroupRollbackInVpp(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	skipReconcile(t)
+	resetRouteMap(t)
+	fake := newFakeVppAgent()
+	useFakeVppAgent(t, fake)
+
+	cluster := []string{"10.1.0.0/16", "10.96.0.0/12"}
+	if _, _, err := sliceRouterInjectRouteGroup(context.Background(), "cluster-2", cluster, []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	fake.mu.Lock()
+	fake.rejectedRoutes = map[string]bool{"10.97.0.0/16": true}
+	fake.mu.Unlock()
+
+	// The subnet joining the group fails once the others moved to the new next hop.
+	if _, _, err := sliceRouterInjectRouteGroup(context.Background(), "cluster-2", append(cluster, "10.97.0.0/16"), []string{"192.168.0.6"}); err == nil {
+		t.Fatal("group request with a rejected route succeeded")
+	}
+	for _, remoteSubnet := range cluster {
+		if state := vppRouteState(fake, remoteSubnet); !sameNextHops(state, []string{"192.168.0.2"}) {
+			t.Error("configured next hops of", remoteSubnet, ": expected [192.168.0.2] received", state)
+		}
+		cached, _ := remoteSubnetRouteMap.Load(remoteSubnet)
+		if cached == nil || !sameNextHops(cached.([]string), []string{"192.168.0.2"}) {
+			t.Error("cached next hops of", remoteSubnet, ": expected [192.168.0.2] received", cached)
+		}
+	}
+	if groups := installedRouteGroups(); !reflect.DeepEqual(groups, map[string][]string{"cluster-2": cluster}) {
+		t.Error("route groups: expected the group unchanged, received", groups)
+	}
+	if _, ok := remoteSubnetRouteMap.Load("10.97.0.0/16"); ok {
+		t.Error("rolled back route is still cached")
+	}
+}
+
+func TestReconcileRouteGroup(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	a, b := []string{"192.168.0.2"}, []string{"192.168.0.6"}
+	tests := []struct {
+		testName string
+		// drift breaks the group installed via a, along with the route to 10.2.0.0/16 injected on its
+		// own via a.
+		drift  func(t *testing.T)
+		filter []string
+		// groupOpInProgress runs the reconcile while a group request holds the route groups.
+		groupOpInProgress bool
+		expected          map[string][]string
+		corrections       map[string]string
+	}{
+		{
+			testName: "strayed route brought back to the next hops of its group",
+			drift: func(t *testing.T) {
+				remoteSubnetRouteMap.Store("10.96.0.0/12", b)
+			},
+			expected:    map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": a, "10.2.0.0/16": a},
+			corrections: map[string]string{"10.96.0.0/12": routeCorrectionGroup},
+		},
+		{
+			testName: "missing route of the group restored",
+			drift: func(t *testing.T) {
+				remoteSubnetRouteMap.Delete("10.96.0.0/12")
+				if err := sliceRouterDeleteRouteToDst("10.96.0.0/12"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected:    map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": a, "10.2.0.0/16": a},
+			corrections: map[string]string{"10.96.0.0/12": routeCorrectionGroup},
+		},
+		{
+			testName: "filtered reconcile covers the whole group",
+			drift: func(t *testing.T) {
+				for _, remoteSubnet := range []string{"10.96.0.0/12", "10.2.0.0/16"} {
+					if err := sliceRouterDeleteRouteToDst(remoteSubnet); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			filter:      []string{"10.1.0.0/16"},
+			expected:    map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": a, "10.2.0.0/16": nil},
+			corrections: map[string]string{"10.96.0.0/12": routeCorrectionMissing},
+		},
+		{
+			testName: "group left to the group request in progress",
+			drift: func(t *testing.T) {
+				remoteSubnetRouteMap.Store("10.96.0.0/12", b)
+			},
+			groupOpInProgress: true,
+			expected:          map[string][]string{"10.1.0.0/16": a, "10.96.0.0/12": b, "10.2.0.0/16": a},
+			corrections:       map[string]string{"10.96.0.0/12": routeCorrectionMissing},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			useRouteAuditLog(t, &routeAuditLog{})
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.0.6", 2)
+			useFakeNetlink(t, fake)
+			if _, _, err := sliceRouterInjectRouteGroup(context.Background(), "cluster-2", []string{"10.1.0.0/16", "10.96.0.0/12"}, a); err != nil {
+				t.Fatal(err)
+			}
+			if err := sliceRouterInjectRoute("10.2.0.0/16", a); err != nil {
+				t.Fatal(err)
+			}
+			tt.drift(t)
+
+			filter, err := newPrefixFilter(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.groupOpInProgress {
+				routeGroupOpMu.Lock()
+			}
+			budget := &reconcileBudget{corrections: map[string]reconcileCorrection{}}
+			err = reconcileRoutingTableWithBudget(context.Background(), filter, budget)
+			if tt.groupOpInProgress {
+				routeGroupOpMu.Unlock()
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+			for remoteSubnet, nextHops := range tt.expected {
+				if installed := getRouteNextHops(routes, remoteSubnet); !sameNextHops(installed, nextHops) {
+					t.Error("route to", remoteSubnet, ": expected", nextHops, "installed", installed)
+				}
+				if nextHops == nil {
+					continue
+				}
+				cached, _ := remoteSubnetRouteMap.Load(remoteSubnet)
+				if cached == nil || !sameNextHops(cached.([]string), nextHops) {
+					t.Error("cached next hops of", remoteSubnet, ": expected", nextHops, "received", cached)
+				}
+			}
+			corrections := map[string]string{}
+			for remoteSubnet, correction := range budget.corrections {
+				corrections[remoteSubnet] = correction.correction
+			}
+			if !reflect.DeepEqual(corrections, tt.corrections) {
+				t.Error("corrections: expected", tt.corrections, "received", corrections)
+			}
+		})
+	}
+}
+
+func TestSyncRoutesLeavesRouteGroups(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	cluster := []string{"10.1.0.0/16", "10.96.0.0/12"}
+	if _, _, err := sliceRouterInjectRouteGroup(context.Background(), "cluster-2", cluster, []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := sliceRouterSyncRoutes(context.Background(), []routeRequest{
+		{remoteSubnet: "10.3.0.0/16", nextHopIPList: []string{"192.168.0.2"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted := []string{}
+	for _, result := range results {
+		if result.err != nil {
+			t.Error("sync of", result.remoteSubnet, "failed:", result.err)
+		}
+		if result.action == sidecar.RouteSyncAction_ROUTE_SYNC_DELETED {
+			deleted = append(deleted, result.remoteSubnet)
+		}
+	}
+	if !reflect.DeepEqual(deleted, []string{"10.2.0.0/16"}) {
+		t.Error("deleted routes: expected [10.2.0.0/16] received", deleted)
+	}
+	expected := map[string][]string{"10.1.0.0/16": {"192.168.0.2"}, "10.96.0.0/12": {"192.168.0.2"}, "10.3.0.0/16": {"192.168.0.2"}}
+	if routes := installedRoutes(); !reflect.DeepEqual(routes, expected) {
+		t.Error("routes: expected", expected, "received", routes)
+	}
+}
@@ -0,0 +1,106 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// getRouteStateSocket returns the path of the unix socket the route state is served on for the local
+// tools of the pod, read from the ROUTE_STATE_SOCKET env variable. The route state is not served on a
+// socket if it is not set.
+func getRouteStateSocket() string {
+	return os.Getenv("ROUTE_STATE_SOCKET")
+}
+
+// routeStateHandler serves the route table in JSON at /routes, the same content as GetRouteTable, and
+// the route audit log at /audit, the same content as GetRouteAuditLog, so that they can be read with
+// curl --unix-socket. The audit log is limited to the operations after the sequence number given in the
+// since query parameter, if any, so that it can be followed without reading it all again.
+func routeStateHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/routes", func(w http.ResponseWriter, req *http.Request) {
+		writeRouteStateJSON(w, sliceRouterGetRouteTable())
+	})
+	mux.HandleFunc("/audit", func(w http.ResponseWriter, req *http.Request) {
+		since := uint64(0)
+		if value := req.URL.Query().Get("since"); value != "" {
+			var err error
+			if since, err = strconv.ParseUint(value, 10, 64); err != nil {
+				http.Error(w, fmt.Sprintf("invalid since %q", value), http.StatusBadRequest)
+				return
+			}
+		}
+		auditLog := &sidecar.RouteAuditLog{}
+		for _, entry := range routeAudit.list() {
+			if entry.GetSequence() > since {
+				auditLog.Entries = append(auditLog.Entries, entry)
+			}
+		}
+		writeRouteStateJSON(w, auditLog)
+	})
+	return mux
+}
+
+// writeRouteStateJSON writes the message as the JSON response.
+func writeRouteStateJSON(w http.ResponseWriter, m proto.Message) {
+	b, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// startRouteStateServer serves the route state on the unix socket at path. The socket left behind by a
+// previous run of the sidecar is removed first. The server runs until the returned listener is closed or
+// the background tasks are stopped.
+func startRouteStateServer(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	startBackgroundTask("route-state-server", func(ctx context.Context) {
+		err := http.Serve(listener, routeStateHandler())
+		if err != nil && !errors.Is(err, net.ErrClosed) {
+			logger.GlobalLogger.Errorf("Route state server on %v failed: %v", path, err)
+		}
+	})
+	startBackgroundTask("route-state-server-close", func(ctx context.Context) {
+		<-ctx.Done()
+		listener.Close()
+	})
+	logger.GlobalLogger.Infof("Serving the route state on unix socket %v", path)
+	return listener, nil
+}
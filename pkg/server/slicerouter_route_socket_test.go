@@ -0,0 +1,165 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// startTestRouteStateServer serves the route state on a unix socket for the duration of the test and
+// returns an HTTP client connecting to it.
+func startTestRouteStateServer(t *testing.T) *http.Client {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "routes.sock")
+	// The socket of a previous run is in the way.
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := startRouteStateServer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		listener.Close()
+	})
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+}
+
+func TestRouteStateSocket(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	resetRouteMap(t)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2"})
+	remoteSubnetRouteMap.Store("10.2.0.0/16", []string{"192.168.0.2", "192.168.0.6"})
+
+	client := startTestRouteStateServer(t)
+
+	tests := []struct {
+		testName   string
+		url        string
+		statusCode int
+	}{
+		{"route dump", "http://unix/routes", http.StatusOK},
+		{"unknown path", "http://unix/other", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resp, err := client.Get(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.statusCode {
+				t.Fatal("status: expected", tt.statusCode, "received", resp.StatusCode)
+			}
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+			if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+				t.Error("content type: expected application/json, received", contentType)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			table := &pb.RouteTable{}
+			if err := protojson.Unmarshal(body, table); err != nil {
+				t.Fatal(err)
+			}
+			if len(table.GetRoutes()) != 2 {
+				t.Fatal("routes: expected 2, received", table.GetRoutes())
+			}
+			for _, route := range table.GetRoutes() {
+				if route.GetRemoteSubnet() == "10.2.0.0/16" && len(route.GetNextHopIPList()) != 2 {
+					t.Error("next hops: expected 2, received", route.GetNextHopIPList())
+				}
+			}
+		})
+	}
+}
+
+func TestRouteStateSocketAuditLog(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	log := &routeAuditLog{size: 10}
+	useRouteAuditLog(t, log)
+	log.record(routeAuditInject, "10.1.0.0/16", []string{"192.168.0.2"}, nil)
+	log.recordCorrection("10.1.0.0/16", []string{"192.168.0.2"}, routeCorrectionMissing, nil)
+	log.record(routeAuditDelete, "10.1.0.0/16", nil, nil)
+	client := startTestRouteStateServer(t)
+
+	tests := []struct {
+		testName   string
+		url        string
+		statusCode int
+		sequences  []uint64
+	}{
+		{"whole log", "http://unix/audit", http.StatusOK, []uint64{1, 2, 3}},
+		{"operations after a sequence", "http://unix/audit?since=1", http.StatusOK, []uint64{2, 3}},
+		{"no new operation", "http://unix/audit?since=3", http.StatusOK, []uint64{}},
+		{"invalid sequence", "http://unix/audit?since=last", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resp, err := client.Get(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.statusCode {
+				t.Fatal("status: expected", tt.statusCode, "received", resp.StatusCode)
+			}
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auditLog := &pb.RouteAuditLog{}
+			if err := protojson.Unmarshal(body, auditLog); err != nil {
+				t.Fatal(err)
+			}
+			sequences := []uint64{}
+			for _, entry := range auditLog.GetEntries() {
+				sequences = append(sequences, entry.GetSequence())
+			}
+			if !reflect.DeepEqual(sequences, tt.sequences) {
+				t.Error("sequences: expected", tt.sequences, "received", sequences)
+			}
+			if len(auditLog.GetEntries()) > 1 && auditLog.GetEntries()[len(auditLog.GetEntries())-2].GetCorrection() != routeCorrectionMissing {
+				t.Error("correction: expected", routeCorrectionMissing, "received", auditLog.GetEntries())
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/vishvananda/netlink"
+)
+
+// remoteSubnetSrcMap holds the preferred source address requested for the routes to the remote subnets,
+// keyed by remote subnet. The kernel uses it as the source of the traffic the slice router originates
+// towards the subnet. Routes without a preferred source have no entry.
+var remoteSubnetSrcMap sync.Map
+
+// loadPreferredSrc returns the preferred source address of the route to the remote subnet, nil if none.
+func loadPreferredSrc(remoteSubnet string) net.IP {
+	src, ok := remoteSubnetSrcMap.Load(remoteSubnet)
+	if !ok {
+		return nil
+	}
+	return src.(net.IP)
+}
+
+// storePreferredSrc records the preferred source address of the route to the remote subnet, a nil
+// source removing it.
+func storePreferredSrc(remoteSubnet string, src net.IP) {
+	if src == nil {
+		remoteSubnetSrcMap.Delete(remoteSubnet)
+		return
+	}
+	remoteSubnetSrcMap.Store(remoteSubnet, src)
+}
+
+// parsePreferredSrc parses the preferred source address of the route to the remote subnet. An empty
+// source returns nil. The source must be in the family of the remote subnet.
+func parsePreferredSrc(remoteNet *net.IPNet, preferredSrc string) (net.IP, error) {
+	if preferredSrc == "" {
+		return nil, nil
+	}
+	src := net.ParseIP(preferredSrc)
+	if src == nil {
+		return nil, fmt.Errorf("invalid preferred source %q", preferredSrc)
+	}
+	if ipFamily(src) != ipFamily(remoteNet.IP) {
+		return nil, fmt.Errorf("preferred source %v is not in the family of the remote subnet", preferredSrc)
+	}
+	return src, nil
+}
+
+// checkLocalAddress checks that the IP is assigned to one of the links of the slice router, the kernel
+// rejects a preferred source that is not.
+func checkLocalAddress(ip net.IP) error {
+	link, err := findAddressLink(ip)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		return fmt.Errorf("preferred source %v is not a local address", ip)
+	}
+	return nil
+}
+
+// findAddressLink returns the link of the slice router the IP is assigned to, nil if none.
+func findAddressLink(ip net.IP) (netlink.Link, error) {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		addrs, err := nlHandle.AddrList(link, ipFamily(ip))
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			if addr.IPNet != nil && addr.IP.Equal(ip) {
+				return link, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,157 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestInjectRoutePreferredSrc(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName     string
+		remoteSubnet string
+		src          string
+		expectReason routeErrorReason
+		expectSrc    net.IP
+	}{
+		{"no preferred source", "10.2.0.0/16", "", "", nil},
+		{"local preferred source", "10.2.0.0/16", "10.1.1.2", "", net.ParseIP("10.1.1.2")},
+		{"preferred source on another link", "10.2.0.0/16", "10.1.2.2", "", net.ParseIP("10.1.2.2")},
+		{"invalid preferred source", "10.2.0.0/16", "10.1.1", routeErrorInvalidArgument, nil},
+		{"non local preferred source", "10.2.0.0/16", "10.1.1.1", routeErrorInvalidArgument, nil},
+		{"preferred source of another family", "10.2.0.0/16", "fd00::2", routeErrorInvalidArgument, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+			addressPodLink(fake, 2, "slicegw-b", "10.1.2.1", "10.1.2.2")
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectRouteWithResolver(routeRequest{remoteSubnet: tt.remoteSubnet, nextHopIPList: []string{"10.1.1.1"}, preferredSrc: tt.src}, newNextHopResolver())
+			route := routeTo(t, fake, tt.remoteSubnet)
+			if tt.expectReason != "" {
+				var rErr *routeError
+				if !errors.As(err, &rErr) || rErr.reason != tt.expectReason {
+					t.Fatal("expected reason", tt.expectReason, "received", err)
+				}
+				if route != nil {
+					t.Error("route installed for a rejected injection")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if route == nil {
+				t.Fatal("route not installed")
+			}
+			if !route.Src.Equal(tt.expectSrc) {
+				t.Error("source: expected", tt.expectSrc, "received", route.Src)
+			}
+		})
+	}
+}
+
+func TestInjectRoutePreferredSrcChange(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+	addressPodLink(fake, 2, "slicegw-b", "10.1.2.1", "10.1.2.2")
+	useFakeNetlink(t, fake)
+
+	// The same next hops with another source reinstall the route.
+	for _, src := range []string{"10.1.1.2", "10.1.2.2", ""} {
+		if err := sliceRouterInjectRouteWithResolver(routeRequest{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"10.1.1.1"}, preferredSrc: src}, newNextHopResolver()); err != nil {
+			t.Fatal(err)
+		}
+		route := routeTo(t, fake, "10.2.0.0/16")
+		if route == nil || !route.Src.Equal(net.ParseIP(src)) {
+			t.Fatal("source: expected", src, "received", route)
+		}
+	}
+}
+
+func TestReconcilePreferredSrc(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName   string
+		mutate     func(fake *fakeNetlink)
+		reconciled bool
+	}{
+		{"installed source kept", func(fake *fakeNetlink) {}, false},
+		{"removed source restored", func(fake *fakeNetlink) {
+			fake.routes[len(fake.routes)-1].Src = nil
+		}, true},
+		{"changed source restored", func(fake *fakeNetlink) {
+			fake.routes[len(fake.routes)-1].Src = net.ParseIP("10.1.2.2")
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+			addressPodLink(fake, 2, "slicegw-b", "10.1.2.1", "10.1.2.2")
+			useFakeNetlink(t, fake)
+			if err := sliceRouterInjectRouteWithResolver(routeRequest{remoteSubnet: "10.2.0.0/16", nextHopIPList: []string{"10.1.1.1"}, preferredSrc: "10.1.1.2"}, newNextHopResolver()); err != nil {
+				t.Fatal(err)
+			}
+			log := &routeAuditLog{}
+			useRouteAuditLog(t, log)
+
+			fake.mu.Lock()
+			tt.mutate(fake)
+			fake.mu.Unlock()
+			// A reconciled route must converge, the next reconcile leaving it alone.
+			for i := 0; i < 2; i++ {
+				if err := vl3ReconcileRoutesInKernel(nil, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			reconciles := len(log.list())
+			if tt.reconciled && reconciles != 1 {
+				t.Error("reconciles of the route: expected 1, received", reconciles)
+			}
+			if !tt.reconciled && reconciles != 0 {
+				t.Error("reconciles of the route: expected 0, received", reconciles)
+			}
+			if route := routeTo(t, fake, "10.2.0.0/16"); route == nil || !route.Src.Equal(net.ParseIP("10.1.1.2")) {
+				t.Error("source: expected 10.1.1.2, received", route)
+			}
+		})
+	}
+}
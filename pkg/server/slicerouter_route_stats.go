@@ -0,0 +1,66 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"sort"
+
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+// sliceRouterGetRouteStatistics returns the counters of the egress interfaces of the slice routes
+// installed in the kernel, sorted by remote subnet and interface name. The counters of an interface
+// are reported under every route going out of it, along with the number of such routes.
+func sliceRouterGetRouteStatistics() ([]*sidecar.RouteStatistics, error) {
+	intfMap, err := vl3GetRoutesByInterfaceInKernel()
+	if err != nil {
+		return nil, err
+	}
+
+	routeMap := map[string]*sidecar.RouteStatistics{}
+	for name, routes := range intfMap {
+		counters := &sidecar.InterfaceCounters{
+			InterfaceName: name,
+			RouteCount:    int32(len(routes.remoteSubnets)),
+		}
+		if routes.stats != nil {
+			counters.TxBytes = routes.stats.TxBytes
+			counters.TxPackets = routes.stats.TxPackets
+			counters.RxBytes = routes.stats.RxBytes
+			counters.RxPackets = routes.stats.RxPackets
+		}
+		for remoteSubnet := range routes.remoteSubnets {
+			if routeMap[remoteSubnet] == nil {
+				routeMap[remoteSubnet] = &sidecar.RouteStatistics{RemoteSubnet: remoteSubnet}
+			}
+			routeMap[remoteSubnet].Interfaces = append(routeMap[remoteSubnet].Interfaces, counters)
+		}
+	}
+
+	result := []*sidecar.RouteStatistics{}
+	for _, routeStats := range routeMap {
+		sort.Slice(routeStats.Interfaces, func(i, j int) bool {
+			return routeStats.Interfaces[i].InterfaceName < routeStats.Interfaces[j].InterfaceName
+		})
+		result = append(result, routeStats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RemoteSubnet < result[j].RemoteSubnet
+	})
+	return result, nil
+}
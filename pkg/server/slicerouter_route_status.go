@@ -0,0 +1,149 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+)
+
+// getRouteNextHops returns the gateways of the route to dstIP from a netlink route list.
+func getRouteNextHops(routes []netlink.Route, dstIP string) []string {
+	nextHops := []string{}
+	for _, route := range routes {
+		if route.Dst == nil || route.Dst.String() != dstIP {
+			continue
+		}
+		if len(route.MultiPath) > 0 {
+			nextHops = append(nextHops, contructArrayFromNextHop(route.MultiPath)...)
+		} else if route.Gw != nil {
+			nextHops = append(nextHops, route.Gw.String())
+		}
+	}
+	return nextHops
+}
+
+// vl3GetInstalledNextHopsInKernel returns the next hops of the route to dstIP installed in the kernel.
+func vl3GetInstalledNextHopsInKernel(dstIP string) ([]string, error) {
+	routes, err := nlHandle.RouteList(nil, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, err
+	}
+	return getRouteNextHops(routes, dstIP), nil
+}
+
+// vl3GetInstalledNextHopsInVpp returns the next hops of the routes to dstIP configured in vpp.
+func vl3GetInstalledNextHopsInVpp(dstIP string) ([]string, error) {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		logger.GlobalLogger.Errorf("can't dial grpc server: %v", err)
+		return nil, err
+	}
+	defer closeConn()
+
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{})
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get vpp config: %v", err)
+		return nil, err
+	}
+	recordVppConfig(vppConfig.GetConfig().GetVppConfig())
+
+	nextHops := []string{}
+	for _, route := range vppConfig.GetConfig().GetVppConfig().GetRoutes() {
+		if route.GetDstNetwork() == dstIP && isSliceVppRoute(route) {
+			nextHops = append(nextHops, route.GetNextHopAddr())
+		}
+	}
+	return nextHops, nil
+}
+
+func sliceRouterGetInstalledNextHops(dstIP string) ([]string, error) {
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		return vl3GetInstalledNextHopsInVpp(dstIP)
+	}
+	return vl3GetInstalledNextHopsInKernel(dstIP)
+}
+
+// sliceRouterGetRouteStatus checks if the route to the remote subnet is installed in the dataplane.
+// If nextHopIP is empty, the route is expected to have the healthy next hops recorded in remoteSubnetRouteMap.
+// Otherwise it is expected to go through nextHopIP. A route that is present in the dataplane but does
+// not match the expected next hops is reported as drifted. With includeNeighbors, the neighbor state of
+// the installed next hops is reported in the kernel dataplane. The remote subnet is looked up in its
+// canonical form, the one routes are recorded under.
+func sliceRouterGetRouteStatus(remoteSubnet string, nextHopIP string, includeNeighbors bool) (*sidecar.RouteStatusResponse, error) {
+	_, dstIPNet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return nil, newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+
+	cachedNextHops := []string{}
+	if value, ok := remoteSubnetRouteMap.Load(dstIPNet.String()); ok {
+		cachedNextHops = value.([]string)
+	}
+
+	installedNextHops, err := sliceRouterGetInstalledNextHops(dstIPNet.String())
+	if err != nil {
+		return nil, err
+	}
+
+	state := sidecar.RouteState_ROUTE_INSTALLED
+	if len(installedNextHops) == 0 {
+		state = sidecar.RouteState_ROUTE_ABSENT
+	} else if nextHopIP != "" {
+		if !contains(installedNextHops, nextHopIP) {
+			state = sidecar.RouteState_ROUTE_DRIFTED
+		}
+	} else if !sameNextHops(healthyNextHops(cachedNextHops), installedNextHops) {
+		state = sidecar.RouteState_ROUTE_DRIFTED
+	}
+
+	routeStatus := &sidecar.RouteStatusResponse{
+		IsInstalled:            state == sidecar.RouteState_ROUTE_INSTALLED,
+		State:                  state,
+		CachedNextHopIPList:    cachedNextHops,
+		InstalledNextHopIPList: installedNextHops,
+	}
+	if includeNeighbors && getSliceRouterDataplaneMode() != SliceRouterDataplaneVpp {
+		routeStatus.NextHopNeighbors, err = vl3GetNextHopNeighborsInKernel(dstIPNet.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return routeStatus, nil
+}
+
+// sameNextHops checks if the two next hop lists contain the same IPs regardless of order.
+func sameNextHops(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, nextHop := range a {
+		if !contains(b, nextHop) {
+			return false
+		}
+	}
+	return true
+}
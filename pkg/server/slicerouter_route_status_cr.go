@@ -0,0 +1,156 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/kube"
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	defaultRouteStatusInterval = 30 * time.Second
+	routeStatusPatchTimeout    = 10 * time.Second
+)
+
+// routeStatusPatcher patches the status of a Kubernetes resource.
+type routeStatusPatcher interface {
+	PatchStatus(ctx context.Context, ref kube.ResourceRef, status interface{}) error
+}
+
+// newRouteStatusClient returns the client of the API server the route status is patched through, along
+// with the namespace of the sidecar. The service account of the pod is used. It is a variable so that
+// tests can substitute a fake.
+var newRouteStatusClient = func() (routeStatusPatcher, string, error) {
+	config, err := kube.InClusterConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	return kube.NewClient(config, routeStatusPatchTimeout), config.Namespace, nil
+}
+
+// getRouteStatusResource returns the custom resource whose status the route state is exported to, read
+// from the ROUTE_STATUS_CR_API_VERSION, ROUTE_STATUS_CR_RESOURCE, ROUTE_STATUS_CR_NAME and
+// ROUTE_STATUS_CR_NAMESPACE env variables. The route state is not exported unless the name is set. The
+// namespace defaults to the one of the sidecar.
+func getRouteStatusResource() (kube.ResourceRef, bool) {
+	ref := kube.ResourceRef{
+		APIVersion: os.Getenv("ROUTE_STATUS_CR_API_VERSION"),
+		Resource:   os.Getenv("ROUTE_STATUS_CR_RESOURCE"),
+		Namespace:  os.Getenv("ROUTE_STATUS_CR_NAMESPACE"),
+		Name:       os.Getenv("ROUTE_STATUS_CR_NAME"),
+	}
+	return ref, ref.Name != ""
+}
+
+// getRouteStatusInterval returns how often the status of the custom resource is patched, read from the
+// ROUTE_STATUS_CR_INTERVAL env variable.
+func getRouteStatusInterval() time.Duration {
+	return getEnvDuration("ROUTE_STATUS_CR_INTERVAL", defaultRouteStatusInterval)
+}
+
+// routeStatus is the status the custom resource is patched with. The route table and the reconcile
+// status have the JSON form of GetRouteTable and GetReconcileStatus.
+type routeStatus struct {
+	DataplaneMode  string          `json:"dataplaneMode"`
+	RouteTable     json.RawMessage `json:"routeTable"`
+	Reconcile      json.RawMessage `json:"reconcile"`
+	LastUpdateTime string          `json:"lastUpdateTime"`
+}
+
+// newRouteStatus returns the current route state of the slice router.
+func newRouteStatus() (*routeStatus, error) {
+	marshal := protojson.MarshalOptions{EmitUnpopulated: true}
+	routeTable, err := marshal.Marshal(sliceRouterGetRouteTable())
+	if err != nil {
+		return nil, err
+	}
+	reconcile, err := marshal.Marshal(sliceRouterGetReconcileStatus())
+	if err != nil {
+		return nil, err
+	}
+	return &routeStatus{
+		DataplaneMode:  getSliceRouterDataplaneMode(),
+		RouteTable:     routeTable,
+		Reconcile:      reconcile,
+		LastUpdateTime: time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// patchRouteStatus patches the status of the custom resource with the current route state.
+func patchRouteStatus(ctx context.Context, client routeStatusPatcher, ref kube.ResourceRef) error {
+	status, err := newRouteStatus()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(ctx, routeStatusPatchTimeout)
+		defer cancel()
+		err = client.PatchStatus(ctx, ref, status)
+	}
+	if err != nil {
+		routeStatusPatchesCounter.Inc("failure")
+		return err
+	}
+	routeStatusPatchesCounter.Inc("success")
+	return nil
+}
+
+// startRouteStatusExport patches the status of the configured custom resource with the route state
+// every route status interval, and returns the resource. Nothing is started if no resource is
+// configured.
+func startRouteStatusExport() (string, error) {
+	ref, ok := getRouteStatusResource()
+	if !ok {
+		return "", nil
+	}
+	if ref.APIVersion == "" || ref.Resource == "" {
+		return "", errors.New("ROUTE_STATUS_CR_API_VERSION and ROUTE_STATUS_CR_RESOURCE must be set along ROUTE_STATUS_CR_NAME")
+	}
+	client, namespace, err := newRouteStatusClient()
+	if err != nil {
+		return "", err
+	}
+	if ref.Namespace == "" {
+		ref.Namespace = namespace
+	}
+	if ref.Namespace == "" {
+		return "", errors.New("ROUTE_STATUS_CR_NAMESPACE must be set outside a pod")
+	}
+
+	interval := getRouteStatusInterval()
+	startBackgroundTask("route-status-cr", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := patchRouteStatus(ctx, client, ref); err != nil && ctx.Err() == nil {
+				logger.GlobalLogger.Errorf("Failed to patch the status of %v: %v", ref, err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+	logger.GlobalLogger.Infof("Exporting the route state to the status of %v every %v", ref, interval)
+	return ref.String(), nil
+}
@@ -0,0 +1,194 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/kube"
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// fakeKubeClient records the status patches instead of sending them to an API server.
+type fakeKubeClient struct {
+	mu       sync.Mutex
+	refs     []kube.ResourceRef
+	statuses [][]byte
+	patchErr error
+	patched  chan struct{}
+}
+
+func newFakeKubeClient() *fakeKubeClient {
+	return &fakeKubeClient{patched: make(chan struct{}, 16)}
+}
+
+func (f *fakeKubeClient) PatchStatus(ctx context.Context, ref kube.ResourceRef, status interface{}) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.refs = append(f.refs, ref)
+	f.statuses = append(f.statuses, data)
+	f.mu.Unlock()
+	select {
+	case f.patched <- struct{}{}:
+	default:
+	}
+	return f.patchErr
+}
+
+// useFakeKubeClient makes the route status export use the fake client for the duration of the test,
+// the sidecar running in the namespace.
+func useFakeKubeClient(t *testing.T, f *fakeKubeClient, namespace string) {
+	t.Helper()
+	orig := newRouteStatusClient
+	newRouteStatusClient = func() (routeStatusPatcher, string, error) {
+		return f, namespace, nil
+	}
+	t.Cleanup(func() {
+		newRouteStatusClient = orig
+	})
+}
+
+func TestPatchRouteStatus(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+	if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	ref := kube.ResourceRef{APIVersion: "kubeslice.io/v1beta1", Resource: "routestatuses", Namespace: "kubeslice-system", Name: "red"}
+
+	tests := []struct {
+		testName string
+		patchErr error
+		result   string
+	}{
+		{"status patched", nil, "success"},
+		{"patch rejected", &kube.StatusError{Code: 404, Message: "not found"}, "failure"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			routeStatusPatchesCounter.Reset()
+			client := newFakeKubeClient()
+			client.patchErr = tt.patchErr
+
+			err := patchRouteStatus(context.Background(), client, ref)
+			if !errors.Is(err, tt.patchErr) {
+				t.Fatal("expected", tt.patchErr, "received", err)
+			}
+			if v := routeStatusPatchesCounter.Value(tt.result); v != 1 {
+				t.Error(tt.result, "patches: expected 1, received", v)
+			}
+			if len(client.refs) != 1 || client.refs[0] != ref {
+				t.Fatal("expected a patch of", ref, "received", client.refs)
+			}
+
+			status := &routeStatus{}
+			if err := json.Unmarshal(client.statuses[0], status); err != nil {
+				t.Fatal(err)
+			}
+			routeTable := &pb.RouteTable{}
+			if err := protojson.Unmarshal(status.RouteTable, routeTable); err != nil {
+				t.Fatal(err)
+			}
+			reconcile := &pb.ReconcileStatus{}
+			if err := protojson.Unmarshal(status.Reconcile, reconcile); err != nil {
+				t.Fatal(err)
+			}
+			if status.DataplaneMode != SliceRouterDataplaneKernel || len(routeTable.GetRoutes()) != 1 ||
+				routeTable.GetRoutes()[0].GetRemoteSubnet() != "10.1.0.0/16" || reconcile.GetMode() != getReconcileMode() {
+				t.Error("unexpected status", string(client.statuses[0]))
+			}
+			if _, err := time.Parse(time.RFC3339, status.LastUpdateTime); err != nil {
+				t.Error("last update time:", err)
+			}
+		})
+	}
+}
+
+func TestStartRouteStatusExport(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+
+	tests := []struct {
+		testName    string
+		env         map[string]string
+		expected    string
+		expectedErr bool
+	}{
+		{"not configured", map[string]string{}, "", false},
+		{"namespace of the sidecar", map[string]string{"ROUTE_STATUS_CR_API_VERSION": "kubeslice.io/v1beta1",
+			"ROUTE_STATUS_CR_RESOURCE": "routestatuses", "ROUTE_STATUS_CR_NAME": "red"}, "routestatuses kubeslice-system/red", false},
+		{"configured namespace", map[string]string{"ROUTE_STATUS_CR_API_VERSION": "kubeslice.io/v1beta1",
+			"ROUTE_STATUS_CR_RESOURCE": "routestatuses", "ROUTE_STATUS_CR_NAME": "red", "ROUTE_STATUS_CR_NAMESPACE": "kubeslice-red"},
+			"routestatuses kubeslice-red/red", false},
+		{"resource missing", map[string]string{"ROUTE_STATUS_CR_NAME": "red"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			for _, key := range []string{"ROUTE_STATUS_CR_API_VERSION", "ROUTE_STATUS_CR_RESOURCE", "ROUTE_STATUS_CR_NAME", "ROUTE_STATUS_CR_NAMESPACE"} {
+				t.Setenv(key, tt.env[key])
+			}
+			t.Setenv("ROUTE_STATUS_CR_INTERVAL", "10ms")
+			client := newFakeKubeClient()
+			useFakeKubeClient(t, client, "kubeslice-system")
+			t.Cleanup(func() {
+				stopBackgroundTasks(time.Second)
+			})
+
+			resource, err := startRouteStatusExport()
+			if (err != nil) != tt.expectedErr {
+				t.Fatal("error: expected", tt.expectedErr, "received", err)
+			}
+			if resource != tt.expected {
+				t.Fatal("resource: expected", tt.expected, "received", resource)
+			}
+			if resource == "" {
+				return
+			}
+			// The status is patched right away and then every interval.
+			for i := 0; i < 2; i++ {
+				select {
+				case <-client.patched:
+				case <-time.After(5 * time.Second):
+					t.Fatal("status not patched")
+				}
+			}
+			client.mu.Lock()
+			defer client.mu.Unlock()
+			if client.refs[0].String() != tt.expected {
+				t.Error("patched resource: expected", tt.expected, "received", client.refs[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"sort"
+	"sync"
+
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+// sliceRouterGetRouteTable returns the routes injected in the slice router, sorted by remote subnet,
+// along with the utilization of the slice route table.
+func sliceRouterGetRouteTable() *sidecar.RouteTable {
+	routes := []*sidecar.RouteEntry{}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		nextHopIPList := value.([]string)
+		nextHopStatus := []*sidecar.NextHopStatus{}
+		for _, nextHopIP := range nextHopIPList {
+			nextHopStatus = append(nextHopStatus, &sidecar.NextHopStatus{
+				NextHopIP: nextHopIP,
+				Health:    nextHopHealth.health(nextHopIP),
+			})
+		}
+		routes = append(routes, &sidecar.RouteEntry{
+			RemoteSubnet:      key.(string),
+			NextHopIPList:     nextHopIPList,
+			NextHopStatus:     nextHopStatus,
+			Description:       loadRouteDescription(key.(string)),
+			AggregatedSubnets: loadAggregatedSubnets(key.(string)),
+			ForwardingState:   routeForwardingState(key.(string), nextHopIPList),
+			ReconcilePriority: loadReconcilePriority(key.(string)),
+			VppWeight:         loadVppRouteAttrs(key.(string)).weight,
+			VppPreference:     loadVppRouteAttrs(key.(string)).preference,
+			RouteGroup:        loadRouteGroup(key.(string)),
+		})
+		return true
+	})
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].RemoteSubnet < routes[j].RemoteSubnet
+	})
+	capacity := getRouteTableCapacity()
+	return &sidecar.RouteTable{
+		Routes:      routes,
+		RouteCount:  uint32(len(routes)),
+		Capacity:    uint32(capacity),
+		Utilization: routeTableUtilization(len(routes), capacity),
+	}
+}
+
+// routeCountMu serializes the updates of the per next hop route count gauge. routeCountNextHops holds
+// the next hops currently in the gauge.
+var (
+	routeCountMu       sync.Mutex
+	routeCountNextHops = map[string]bool{}
+)
+
+// updateRouteCountGauge sets the route count of each next hop from the remote subnet route map. The next
+// hops no longer used by any route are removed from the gauge. The route table utilization is updated
+// along.
+func updateRouteCountGauge() {
+	routeCountMu.Lock()
+	defer routeCountMu.Unlock()
+
+	routeCount := 0
+	counts := map[string]int{}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		routeCount++
+		seen := map[string]bool{}
+		for _, nextHopIP := range value.([]string) {
+			if !seen[nextHopIP] {
+				seen[nextHopIP] = true
+				counts[nextHopIP]++
+			}
+		}
+		return true
+	})
+
+	for nextHopIP, count := range counts {
+		routeCountByNextHopGauge.Set(float64(count), nextHopIP)
+	}
+	for nextHopIP := range routeCountNextHops {
+		if _, ok := counts[nextHopIP]; !ok {
+			routeCountByNextHopGauge.Delete(nextHopIP)
+		}
+	}
+	routeCountNextHops = map[string]bool{}
+	for nextHopIP := range counts {
+		routeCountNextHops[nextHopIP] = true
+	}
+	checkRouteTableUtilization(routeCount)
+}
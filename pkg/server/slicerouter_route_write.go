@@ -0,0 +1,165 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Routes are written with RouteReplace, overwriting any route to the same destination.
+	routeWriteModeReplace = "replace"
+	// Routes are written with RouteAdd, a route to the same destination not injected by the sidecar
+	// is left in place and the injection fails.
+	routeWriteModeAdd = "add"
+)
+
+const (
+	// Routes installed by other routing agents are replaced like any other route.
+	foreignRoutePolicyReplace = "replace"
+	// Routes installed by other routing agents are left in place and the injection fails.
+	foreignRoutePolicyRefuse = "refuse"
+	// Routes installed by other routing agents are replaced and the conflict is logged.
+	foreignRoutePolicyLog = "log"
+)
+
+// errRouteConflict is returned in add mode when the kernel already has a route to the destination
+// that was not injected by the sidecar.
+var errRouteConflict = errors.New("Conflicting route to the destination already exists")
+
+// getRouteWriteMode returns how routes are written to the kernel, read from the KERNEL_ROUTE_WRITE_MODE
+// env variable. Routes are replaced by default.
+func getRouteWriteMode() string {
+	mode := os.Getenv("KERNEL_ROUTE_WRITE_MODE")
+	switch mode {
+	case routeWriteModeReplace, routeWriteModeAdd:
+		return mode
+	case "":
+		return routeWriteModeReplace
+	}
+	logger.GlobalLogger.Errorf("Invalid KERNEL_ROUTE_WRITE_MODE %q, using %v", mode, routeWriteModeReplace)
+	return routeWriteModeReplace
+}
+
+// getForeignRoutePolicy returns what to do when a route replace would overwrite a route installed by
+// another routing agent, read from the KERNEL_FOREIGN_ROUTE_POLICY env variable. Such routes are
+// replaced by default.
+func getForeignRoutePolicy() string {
+	policy := os.Getenv("KERNEL_FOREIGN_ROUTE_POLICY")
+	switch policy {
+	case foreignRoutePolicyReplace, foreignRoutePolicyRefuse, foreignRoutePolicyLog:
+		return policy
+	case "":
+		return foreignRoutePolicyReplace
+	}
+	logger.GlobalLogger.Errorf("Invalid KERNEL_FOREIGN_ROUTE_POLICY %q, using %v", policy, foreignRoutePolicyReplace)
+	return foreignRoutePolicyReplace
+}
+
+// isForeignRouteProtocol returns true if routes of the protocol were not installed by the sidecar. The
+// sidecar leaves the protocol unset, the kernel records its routes with the boot protocol, as it does
+// for routes added with iproute2 without a proto.
+func isForeignRouteProtocol(protocol int) bool {
+	return protocol != unix.RTPROT_UNSPEC && protocol != unix.RTPROT_BOOT
+}
+
+// vl3FindForeignRoute returns the route to the destination of the route, in the same table, that was
+// installed by another routing agent, nil if there is none.
+func vl3FindForeignRoute(route *netlink.Route) (*netlink.Route, error) {
+	table := route.Table
+	if table == 0 {
+		table = unix.RT_TABLE_MAIN
+	}
+	routes, err := nlHandle.RouteListFiltered(ipFamily(route.Dst.IP), &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, err
+	}
+	for i := range routes {
+		existing := &routes[i]
+		if existing.Dst == nil || existing.Dst.String() != route.Dst.String() {
+			continue
+		}
+		// Routes with another metric are not replaced, like the default route of the pod network
+		// next to the default next hop route.
+		if route.Priority != 0 && existing.Priority != route.Priority {
+			continue
+		}
+		if isForeignRouteProtocol(existing.Protocol) {
+			return existing, nil
+		}
+	}
+	return nil, nil
+}
+
+// vl3CheckForeignRoute applies the foreign route policy before the route is replaced. It returns
+// errRouteConflict if the replace would overwrite a route of another routing agent and the policy
+// refuses it.
+func vl3CheckForeignRoute(route *netlink.Route) error {
+	policy := getForeignRoutePolicy()
+	if policy == foreignRoutePolicyReplace {
+		return nil
+	}
+	foreign, err := vl3FindForeignRoute(route)
+	if err != nil || foreign == nil {
+		return err
+	}
+	foreignRouteConflictsCounter.Inc(policy)
+	if policy == foreignRoutePolicyRefuse {
+		logger.GlobalLogger.Errorf("Not replacing the route to %v installed by protocol %v", route.Dst, foreign.Protocol)
+		return fmt.Errorf("%w: %v installed by protocol %v", errRouteConflict, route.Dst, foreign.Protocol)
+	}
+	logger.GlobalLogger.Warnf("Replacing the route to %v installed by protocol %v", route.Dst, foreign.Protocol)
+	return nil
+}
+
+// vl3WriteRouteInKernel writes the route to the remote subnet in the kernel according to the route
+// write mode. In add mode, the routes the sidecar already injected are still replaced so that their next
+// hops can be updated. Routes left in the kernel by a previous run of the sidecar are seen as conflicts,
+// SHUTDOWN_ROUTE_POLICY=flush removes them on shutdown. Routes in the tables of policy routes are
+// always replaced, those tables are dedicated to the sidecar. Before a route of the main table is
+// replaced, the foreign route policy decides whether a route of another routing agent may be overwritten.
+func vl3WriteRouteInKernel(remoteSubnet string, route *netlink.Route) error {
+	_, injected := remoteSubnetRouteMap.Load(remoteSubnet)
+	if getRouteWriteMode() == routeWriteModeReplace || route.Table != 0 || injected {
+		if route.Table == 0 {
+			if err := vl3CheckForeignRoute(route); err != nil {
+				return err
+			}
+		}
+		if err := nlHandle.RouteReplace(route); err != nil {
+			return err
+		}
+		logIproute2Command("replace", route)
+		return nil
+	}
+	err := nlHandle.RouteAdd(route)
+	if errors.Is(err, unix.EEXIST) {
+		return fmt.Errorf("%w: %v", errRouteConflict, route.Dst)
+	}
+	if err != nil {
+		return err
+	}
+	logIproute2Command("add", route)
+	return nil
+}
@@ -0,0 +1,143 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestRouteWriteMode(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName string
+		mode     string
+		// existingGw is the gateway of the route to the remote subnet installed by another controller.
+		existingGw       string
+		expectedErr      bool
+		expectedNextHops []string
+	}{
+		{"replace overwrites an existing route", routeWriteModeReplace, "172.16.0.1", false, []string{"192.168.0.2"}},
+		{"default mode replaces", "", "172.16.0.1", false, []string{"192.168.0.2"}},
+		{"add fails on an existing route", routeWriteModeAdd, "172.16.0.1", true, []string{"172.16.0.1"}},
+		{"add installs a new route", routeWriteModeAdd, "", false, []string{"192.168.0.2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("KERNEL_ROUTE_WRITE_MODE", tt.mode)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.1.2", 2)
+			if tt.existingGw != "" {
+				fake.routes = append(fake.routes, netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"), Gw: mustParseCIDR(tt.existingGw + "/32").IP})
+			}
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"})
+			var rErr *routeError
+			if tt.expectedErr {
+				if !errors.As(err, &rErr) || rErr.reason != routeErrorConflict || !errors.Is(err, errRouteConflict) {
+					t.Fatal("expected a route conflict error, received", err)
+				}
+				if _, ok := remoteSubnetRouteMap.Load("10.1.0.0/16"); ok {
+					t.Error("conflicting route recorded as injected")
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+			nextHops := getRouteNextHops(routes, "10.1.0.0/16")
+			if len(nextHops) != len(tt.expectedNextHops) || (len(nextHops) > 0 && nextHops[0] != tt.expectedNextHops[0]) {
+				t.Error("next hops: expected", tt.expectedNextHops, "received", nextHops)
+			}
+			if tt.expectedErr {
+				return
+			}
+
+			// The sidecar updates the next hops of the routes it injected in both modes.
+			if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.1.2"}); err != nil {
+				t.Fatal(err)
+			}
+			routes, _ = fake.RouteList(nil, netlink.FAMILY_V4)
+			if nextHops := getRouteNextHops(routes, "10.1.0.0/16"); len(nextHops) != 1 || nextHops[0] != "192.168.1.2" {
+				t.Error("next hops after update: expected [192.168.1.2], received", nextHops)
+			}
+		})
+	}
+}
+
+func TestForeignRoutePolicy(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName string
+		policy   string
+		// protocol is the protocol of the existing route to the remote subnet.
+		protocol         int
+		expectedErr      bool
+		expectedNextHops []string
+		expectedConflict bool
+	}{
+		{"default policy replaces a foreign route", "", unix.RTPROT_BGP, false, []string{"192.168.0.2"}, false},
+		{"replace overwrites a foreign route", foreignRoutePolicyReplace, unix.RTPROT_BGP, false, []string{"192.168.0.2"}, false},
+		{"refuse keeps a foreign route", foreignRoutePolicyRefuse, unix.RTPROT_BGP, true, []string{"172.16.0.1"}, true},
+		{"log overwrites a foreign route", foreignRoutePolicyLog, unix.RTPROT_STATIC, false, []string{"192.168.0.2"}, true},
+		{"refuse replaces a boot protocol route", foreignRoutePolicyRefuse, unix.RTPROT_BOOT, false, []string{"192.168.0.2"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("KERNEL_FOREIGN_ROUTE_POLICY", tt.policy)
+			resetRouteMap(t)
+			foreignRouteConflictsCounter.Reset()
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.routes = append(fake.routes, netlink.Route{Dst: mustParseCIDR("10.1.0.0/16"),
+				Gw: mustParseCIDR("172.16.0.1/32").IP, Protocol: tt.protocol})
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"})
+			var rErr *routeError
+			if tt.expectedErr {
+				if !errors.As(err, &rErr) || rErr.reason != routeErrorConflict || !errors.Is(err, errRouteConflict) {
+					t.Fatal("expected a route conflict error, received", err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_V4)
+			if nextHops := getRouteNextHops(routes, "10.1.0.0/16"); !sameNextHops(nextHops, tt.expectedNextHops) {
+				t.Error("next hops: expected", tt.expectedNextHops, "received", nextHops)
+			}
+			if conflict := foreignRouteConflictsCounter.Value(getForeignRoutePolicy()) == 1; conflict != tt.expectedConflict {
+				t.Error("conflict counted: expected", tt.expectedConflict, "received", conflict)
+			}
+		})
+	}
+}
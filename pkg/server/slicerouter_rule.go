@@ -0,0 +1,167 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// getSliceRouteTable returns the ID of the custom routing table used for slice routes.
+// It is read from the SLICE_ROUTE_TABLE env variable. Zero means no custom table is configured.
+func getSliceRouteTable() (int, error) {
+	tableStr := os.Getenv("SLICE_ROUTE_TABLE")
+	if tableStr == "" {
+		return 0, nil
+	}
+	table, err := strconv.ParseUint(tableStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid slice route table %q: %v", tableStr, err)
+	}
+	if isReservedRouteTable(table) {
+		return 0, fmt.Errorf("slice route table %v is reserved", table)
+	}
+	return int(table), nil
+}
+
+// isReservedRouteTable checks if the routing table is one the kernel reserves.
+func isReservedRouteTable(table uint64) bool {
+	switch table {
+	case 0, unix.RT_TABLE_DEFAULT, unix.RT_TABLE_MAIN, unix.RT_TABLE_LOCAL:
+		return true
+	}
+	return false
+}
+
+// isSliceRouteRuleEnabled checks if the sidecar should create the ip rule for the slice route
+// table during bootstrap.
+func isSliceRouteRuleEnabled() bool {
+	return os.Getenv("BOOTSTRAP_SLICE_ROUTE_RULE") == "true"
+}
+
+// getSliceRouteRule builds the ip rule that steers traffic into the slice route table. The rule
+// selects traffic by fwmark (SLICE_ROUTE_RULE_FWMARK), source prefix (SLICE_ROUTE_RULE_SRC) or both.
+// The rule priority can be set with SLICE_ROUTE_RULE_PRIORITY.
+func getSliceRouteRule() (*netlink.Rule, error) {
+	table, err := getSliceRouteTable()
+	if err != nil {
+		return nil, err
+	}
+	if table == 0 {
+		return nil, errors.New("slice route table is not configured")
+	}
+
+	rule := netlink.NewRule()
+	rule.Table = table
+	rule.Family = netlink.FAMILY_V4
+
+	fwmarkStr := os.Getenv("SLICE_ROUTE_RULE_FWMARK")
+	srcStr := os.Getenv("SLICE_ROUTE_RULE_SRC")
+	if fwmarkStr == "" && srcStr == "" {
+		return nil, errors.New("slice route rule needs a fwmark or a source prefix")
+	}
+	if fwmarkStr != "" {
+		fwmark, err := strconv.ParseUint(fwmarkStr, 0, 32)
+		if err != nil || fwmark == 0 {
+			return nil, fmt.Errorf("invalid slice route rule fwmark %q", fwmarkStr)
+		}
+		rule.Mark = int(fwmark)
+	}
+	if srcStr != "" {
+		_, src, err := net.ParseCIDR(srcStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slice route rule source %q: %v", srcStr, err)
+		}
+		if src.IP.To4() == nil {
+			rule.Family = netlink.FAMILY_V6
+		}
+		rule.Src = src
+	}
+	if priorityStr := os.Getenv("SLICE_ROUTE_RULE_PRIORITY"); priorityStr != "" {
+		priority, err := strconv.ParseUint(priorityStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slice route rule priority %q: %v", priorityStr, err)
+		}
+		rule.Priority = int(priority)
+	}
+
+	return rule, nil
+}
+
+// ruleMatches checks if an installed rule selects the same traffic into the same table as the
+// wanted rule. The priority is only compared if one was configured.
+func ruleMatches(installed netlink.Rule, wanted *netlink.Rule) bool {
+	if installed.Table != wanted.Table || installed.Invert {
+		return false
+	}
+	if wanted.Priority != -1 && installed.Priority != wanted.Priority {
+		return false
+	}
+	if wanted.Mark != -1 && installed.Mark != wanted.Mark {
+		return false
+	}
+	if wanted.Mark == -1 && installed.Mark > 0 {
+		return false
+	}
+	if wanted.Src == nil {
+		return installed.Src == nil
+	}
+	return installed.Src != nil && installed.Src.String() == wanted.Src.String()
+}
+
+// sliceRouterEnsureRouteRule creates the ip rule pointing at the slice route table if it does not
+// exist already. It is safe to call multiple times.
+func sliceRouterEnsureRouteRule() error {
+	rule, err := getSliceRouteRule()
+	if err != nil {
+		logger.GlobalLogger.Errorf("Invalid slice route rule config: %v", err)
+		return err
+	}
+
+	return ensureRule(rule)
+}
+
+// ensureRule adds the ip rule if no matching rule is installed.
+func ensureRule(rule *netlink.Rule) error {
+	installedRules, err := nlHandle.RuleList(rule.Family)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Could not get rule list, Err: %v", err)
+		return err
+	}
+	for _, installedRule := range installedRules {
+		if ruleMatches(installedRule, rule) {
+			logger.GlobalLogger.Infof("Route rule already present: %v", installedRule)
+			return nil
+		}
+	}
+
+	if err := nlHandle.RuleAdd(rule); err != nil {
+		logger.GlobalLogger.Errorf("Failed to add route rule: %v, Err: %v", rule, err)
+		return err
+	}
+	logger.GlobalLogger.Infof("Added route rule: %v, fwmark: %v", rule, rule.Mark)
+
+	return nil
+}
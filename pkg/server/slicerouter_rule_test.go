@@ -0,0 +1,93 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestSliceRouterEnsureRouteRule(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName  string
+		env       map[string]string
+		numRules  int
+		expectErr bool
+	}{
+		{
+			"rule by fwmark",
+			map[string]string{"SLICE_ROUTE_TABLE": "100", "SLICE_ROUTE_RULE_FWMARK": "0x10"},
+			1,
+			false,
+		},
+		{
+			"rule by source prefix with priority",
+			map[string]string{"SLICE_ROUTE_TABLE": "100", "SLICE_ROUTE_RULE_SRC": "10.1.0.0/16", "SLICE_ROUTE_RULE_PRIORITY": "1000"},
+			1,
+			false,
+		},
+		{
+			"missing table",
+			map[string]string{"SLICE_ROUTE_RULE_FWMARK": "16"},
+			0,
+			true,
+		},
+		{
+			"reserved table",
+			map[string]string{"SLICE_ROUTE_TABLE": "254", "SLICE_ROUTE_RULE_FWMARK": "16"},
+			0,
+			true,
+		},
+		{
+			"missing selector",
+			map[string]string{"SLICE_ROUTE_TABLE": "100"},
+			0,
+			true,
+		},
+		{
+			"invalid source prefix",
+			map[string]string{"SLICE_ROUTE_TABLE": "100", "SLICE_ROUTE_RULE_SRC": "10.1.0.0"},
+			0,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			for _, key := range []string{"SLICE_ROUTE_TABLE", "SLICE_ROUTE_RULE_FWMARK", "SLICE_ROUTE_RULE_SRC", "SLICE_ROUTE_RULE_PRIORITY"} {
+				t.Setenv(key, tt.env[key])
+			}
+			fake := newFakeNetlink()
+			useFakeNetlink(t, fake)
+
+			// The second call must find the rule created by the first one and not add a duplicate.
+			for i := 0; i < 2; i++ {
+				err := sliceRouterEnsureRouteRule()
+				if (err != nil) != tt.expectErr {
+					t.Fatal("error: expected", tt.expectErr, "received", err)
+				}
+			}
+			if len(fake.rules) != tt.numRules {
+				t.Error("number of rules: expected", tt.numRules, "received", len(fake.rules))
+			}
+		})
+	}
+}
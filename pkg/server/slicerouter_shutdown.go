@@ -0,0 +1,117 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+const (
+	// Routes are left in the dataplane on shutdown so that traffic keeps flowing while the sidecar
+	// restarts.
+	shutdownRoutePolicyKeep = "keep"
+	// Routes injected by the sidecar are removed from the dataplane on shutdown.
+	shutdownRoutePolicyFlush = "flush"
+)
+
+// getShutdownRoutePolicy returns how the routes are handled on shutdown, read from the
+// SHUTDOWN_ROUTE_POLICY env variable. Routes are kept by default.
+func getShutdownRoutePolicy() string {
+	if os.Getenv("SHUTDOWN_ROUTE_POLICY") == shutdownRoutePolicyFlush {
+		return shutdownRoutePolicyFlush
+	}
+	return shutdownRoutePolicyKeep
+}
+
+// sliceRouterFlushRoutes removes all the routes injected by the sidecar from the dataplane, the policy
+// routes and their rules included, and returns the first error hit. Routes that could not be removed
+// stay in the slice route map.
+func sliceRouterFlushRoutes() error {
+	remoteSubnets := []string{}
+	routes := map[string][]string{}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		remoteSubnets = append(remoteSubnets, key.(string))
+		routes[key.(string)] = value.([]string)
+		return true
+	})
+	sort.Strings(remoteSubnets)
+
+	var firstErr error
+	for _, remoteSubnet := range remoteSubnets {
+		// The vpp drop route of a blackholed route is not among the next hop entries.
+		err := sliceRouterResumeRoute(remoteSubnet)
+		if err == nil && getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+			for _, nextHopIP := range routes[remoteSubnet] {
+				if err = vl3DeleteRouteInVpp(context.Background(), remoteSubnet, nextHopIP, vppRouteDeleteFlush); err != nil {
+					break
+				}
+			}
+		} else if err == nil {
+			err = sliceRouterDeleteRouteToDst(remoteSubnet)
+			if err == errRouteNotFound {
+				err = nil
+			}
+		}
+		routeAudit.recordDeletion(remoteSubnet, nil, sidecar.DeletionReason_DELETION_SHUTDOWN_FLUSH, err)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to flush route to %v: %v", remoteSubnet, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		remoteSubnetRouteMap.Delete(remoteSubnet)
+		storePreferredSrc(remoteSubnet, nil)
+		storeRouteDescription(remoteSubnet, "")
+		storeReconcilePriority(remoteSubnet, sidecar.ReconcilePriority_RECONCILE_PRIORITY_NORMAL)
+		storeVppRouteAttrs(remoteSubnet, vppRouteAttrs{})
+		forgetAggregateRoute(remoteSubnet)
+		forgetRouteGroupMember(remoteSubnet)
+	}
+	updateRouteCountGauge()
+	logger.GlobalLogger.Infof("Flushed %v slice routes", len(remoteSubnets))
+	if err := sliceRouterFlushPolicyRoutes(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+// ShutdownSliceRouterPod stops the background tasks of the sidecar and handles the routes injected by
+// the sidecar according to the shutdown route policy. It is called when the sidecar receives a
+// termination signal, once the GRPC server is stopped so that no injection lands after the routes are
+// flushed. The spans left are exported once the routes are handled.
+func ShutdownSliceRouterPod() error {
+	defer stopTracing()
+	// The tasks are stopped first so that the reconcile does not install the routes being flushed.
+	if err := stopBackgroundTasks(defaultBackgroundTaskStopTimeout); err != nil {
+		logger.GlobalLogger.Errorf("Failed to stop the background tasks: %v", err)
+	}
+	// The changes held back by the debounce window are dropped so that they are not written after the
+	// flush.
+	routeDebounce.reset()
+	if getShutdownRoutePolicy() == shutdownRoutePolicyKeep {
+		logger.GlobalLogger.Infof("Keeping slice routes on shutdown")
+		return nil
+	}
+	return sliceRouterFlushRoutes()
+}
@@ -0,0 +1,138 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+func TestShutdownSliceRouterPod(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName  string
+		dataplane string
+		policy    string
+		kept      bool
+	}{
+		{"kernel routes kept by default", SliceRouterDataplaneKernel, "", true},
+		{"kernel routes kept", SliceRouterDataplaneKernel, shutdownRoutePolicyKeep, true},
+		{"kernel routes flushed", SliceRouterDataplaneKernel, shutdownRoutePolicyFlush, false},
+		{"vpp routes kept", SliceRouterDataplaneVpp, shutdownRoutePolicyKeep, true},
+		{"vpp routes flushed", SliceRouterDataplaneVpp, shutdownRoutePolicyFlush, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			t.Setenv("SHUTDOWN_ROUTE_POLICY", tt.policy)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			fake.addConnectedRoute("192.168.0.6", 2)
+			useFakeNetlink(t, fake)
+			useFakeVppAgent(t, newFakeVppAgent())
+
+			routes := map[string][]string{
+				"10.1.0.0/16": {"192.168.0.2"},
+				"10.2.0.0/16": {"192.168.0.2", "192.168.0.6"},
+			}
+			for remoteSubnet, nextHops := range routes {
+				if err := sliceRouterInjectRoute(remoteSubnet, nextHops); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := ShutdownSliceRouterPod(); err != nil {
+				t.Fatal(err)
+			}
+
+			for remoteSubnet, nextHops := range routes {
+				installed, err := sliceRouterGetInstalledNextHops(remoteSubnet)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected := []string{}
+				if tt.kept {
+					expected = nextHops
+				}
+				if !sameNextHops(installed, expected) {
+					t.Error(remoteSubnet, "installed next hops: expected", expected, "received", installed)
+				}
+				if _, ok := remoteSubnetRouteMap.Load(remoteSubnet); ok != tt.kept {
+					t.Error(remoteSubnet, "cached: expected", tt.kept, "received", ok)
+				}
+			}
+			connected := 0
+			kernelRoutes, _ := fake.RouteList(nil, 0)
+			for _, route := range kernelRoutes {
+				if ones, _ := route.Dst.Mask.Size(); ones == 32 {
+					connected++
+				}
+			}
+			if connected != 2 {
+				t.Error("connected routes: expected 2, received", connected)
+			}
+		})
+	}
+}
+
+func TestShutdownFlushesPolicyRoutes(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("SHUTDOWN_ROUTE_POLICY", shutdownRoutePolicyFlush)
+	skipReconcile(t)
+	resetRouteMap(t)
+	resetPolicyRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	useFakeNetlink(t, fake)
+
+	for _, remoteSubnet := range []string{"10.1.0.0/16", "10.2.0.0/16"} {
+		if err := sliceRouterInjectPolicyRoute(remoteSubnet, []string{"192.168.0.2"}, 100, "10.0.0.0/24", 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sliceRouterInjectPolicyRoute("10.3.0.0/16", []string{"192.168.0.2"}, 101, "10.0.1.0/24", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ShutdownSliceRouterPod(); err != nil {
+		t.Fatal(err)
+	}
+
+	for table, remoteSubnet := range map[int]string{100: "10.1.0.0/16", 101: "10.3.0.0/16"} {
+		if nextHops := tableRouteNextHops(t, fake, table, remoteSubnet); len(nextHops) != 0 {
+			t.Error("policy route to", remoteSubnet, "still in table", table, nextHops)
+		}
+	}
+	if nextHops := tableRouteNextHops(t, fake, 100, "10.2.0.0/16"); len(nextHops) != 0 {
+		t.Error("policy route to 10.2.0.0/16 still in table 100", nextHops)
+	}
+	if rules, _ := fake.RuleList(netlink.FAMILY_V4); len(rules) != 0 {
+		t.Error("expected the policy route rules flushed, received", rules)
+	}
+	policyRouteMu.Lock()
+	defer policyRouteMu.Unlock()
+	if len(policyRouteMap) != 0 {
+		t.Error("expected the policy route map flushed, received", policyRouteMap)
+	}
+}
@@ -0,0 +1,105 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+)
+
+const (
+	// Default time to wait for the vpp-agent to become reachable before bootstrapping.
+	defaultVppAgentReadyTimeout = 2 * time.Minute
+	// Default interval between two vpp-agent reachability checks.
+	defaultVppAgentReadyPollInterval = time.Second
+	// Default time to wait for the first nsm link in kernel mode before bootstrapping.
+	defaultNsmLinkReadyTimeout = 2 * time.Minute
+	// Default interval between two nsm link checks.
+	defaultNsmLinkReadyPollInterval = time.Second
+)
+
+// vppAgentReady checks if the vpp-agent answers configurator requests.
+func vppAgentReady() error {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	_, err = client.Get(ctx, &configurator.GetRequest{})
+	return err
+}
+
+// nsmLinkReady checks if the slice router has at least one nsm link, route injections having no next
+// hop to route through until then.
+func nsmLinkReady() error {
+	links, err := nlHandle.LinkList()
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if isNsmInterface(link.Attrs().Name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no nsm link with prefix %v", getNsmInterfacePrefixes())
+}
+
+// WaitForDataplaneReady blocks until the dataplane can take route injections, so that the bootstrap
+// does not program it, and the GRPC server is not started, before then. The optional STARTUP_DELAY is waited first. In vpp mode, the vpp-agent is
+// then polled every VPP_AGENT_READY_POLL_INTERVAL until it answers, for at most VPP_AGENT_READY_TIMEOUT.
+// In kernel mode, the links are polled every NSM_LINK_READY_POLL_INTERVAL until an nsm link shows up,
+// for at most NSM_LINK_READY_TIMEOUT. An error is returned if the dataplane is still not ready after
+// the timeout.
+func WaitForDataplaneReady() error {
+	if delay := getEnvDuration("STARTUP_DELAY", 0); delay > 0 {
+		logger.GlobalLogger.Infof("Delaying startup by %v", delay)
+		time.Sleep(delay)
+	}
+	if getSliceRouterDataplaneMode() != SliceRouterDataplaneVpp {
+		return waitUntilReady("nsm link", nsmLinkReady,
+			getEnvDuration("NSM_LINK_READY_TIMEOUT", defaultNsmLinkReadyTimeout),
+			getEnvDuration("NSM_LINK_READY_POLL_INTERVAL", defaultNsmLinkReadyPollInterval))
+	}
+	return waitUntilReady("vpp agent", vppAgentReady,
+		getEnvDuration("VPP_AGENT_READY_TIMEOUT", defaultVppAgentReadyTimeout),
+		getEnvDuration("VPP_AGENT_READY_POLL_INTERVAL", defaultVppAgentReadyPollInterval))
+}
+
+// waitUntilReady polls the readiness check every interval until it succeeds, for at most the timeout.
+func waitUntilReady(what string, ready func() error, timeout, interval time.Duration) error {
+	start := time.Now()
+	for {
+		err := ready()
+		if err == nil {
+			logger.GlobalLogger.Infof("Dataplane %v ready after %v", what, time.Since(start))
+			return nil
+		}
+		if time.Since(start) >= timeout {
+			return fmt.Errorf("%v not ready after %v: %v", what, timeout, err)
+		}
+		logger.GlobalLogger.Infof("Waiting for the %v to be ready: %v", what, err)
+		time.Sleep(interval)
+	}
+}
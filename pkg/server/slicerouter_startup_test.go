@@ -0,0 +1,180 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+func TestWaitForDataplaneReady(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName  string
+		dataplane string
+		delay     string
+		// readyAfter is how long the vpp-agent stays unreachable, negative for never.
+		readyAfter   time.Duration
+		expectedErr  bool
+		minWait      time.Duration
+		expectedPoll bool
+	}{
+		{"vpp agent ready", SliceRouterDataplaneVpp, "", 0, false, 0, true},
+		{"vpp agent ready after a delay", SliceRouterDataplaneVpp, "", 200 * time.Millisecond, false, 200 * time.Millisecond, true},
+		{"vpp agent never ready", SliceRouterDataplaneVpp, "", -1, true, 300 * time.Millisecond, true},
+		{"startup delay", SliceRouterDataplaneVpp, "100ms", 0, false, 100 * time.Millisecond, true},
+		{"kernel mode does not poll", SliceRouterDataplaneKernel, "", -1, false, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			t.Setenv("STARTUP_DELAY", tt.delay)
+			t.Setenv("VPP_AGENT_READY_TIMEOUT", "300ms")
+			t.Setenv("VPP_AGENT_READY_POLL_INTERVAL", "20ms")
+			// The nsm link is up in kernel mode.
+			fakeNl := newFakeNetlink()
+			fakeNl.addConnectedRoute("10.1.1.1", 1)
+			useFakeNetlink(t, fakeNl)
+			fake := newFakeVppAgent()
+			if tt.readyAfter != 0 {
+				fake.getErr = errors.New("connection refused")
+			}
+			useFakeVppAgent(t, fake)
+			if tt.readyAfter > 0 {
+				timer := time.AfterFunc(tt.readyAfter, func() {
+					fake.mu.Lock()
+					fake.getErr = nil
+					fake.mu.Unlock()
+				})
+				defer timer.Stop()
+			}
+
+			start := time.Now()
+			err := WaitForDataplaneReady()
+			if (err != nil) != tt.expectedErr {
+				t.Fatal("expected error", tt.expectedErr, "received", err)
+			}
+			if waited := time.Since(start); waited < tt.minWait {
+				t.Error("expected to wait at least", tt.minWait, "waited", waited)
+			}
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			if (fake.getCalls > 0) != tt.expectedPoll {
+				t.Error("vpp agent polled: expected", tt.expectedPoll, "calls", fake.getCalls)
+			}
+			if tt.readyAfter > 0 && fake.getCalls < 2 {
+				t.Error("expected the vpp agent to be polled until ready, calls", fake.getCalls)
+			}
+		})
+	}
+}
+
+func TestWaitForNsmLink(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("STARTUP_DELAY", "")
+	t.Setenv("NSM_LINK_READY_TIMEOUT", "300ms")
+	t.Setenv("NSM_LINK_READY_POLL_INTERVAL", "20ms")
+
+	tests := []struct {
+		testName string
+		linkName string
+		// linkAfter is how long the link takes to show up.
+		linkAfter   time.Duration
+		expectedErr bool
+		minWait     time.Duration
+	}{
+		{"nsm link up", "vl3-1", 0, false, 0},
+		{"nsm link up after a delay", "vl3-1", 200 * time.Millisecond, false, 200 * time.Millisecond},
+		{"other link only", "eth0", 0, true, 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			fake := newFakeNetlink()
+			useFakeNetlink(t, fake)
+			addLink := func() {
+				fake.mu.Lock()
+				defer fake.mu.Unlock()
+				fake.links = append(fake.links, &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: tt.linkName}})
+			}
+			if tt.linkAfter == 0 {
+				addLink()
+			} else {
+				timer := time.AfterFunc(tt.linkAfter, addLink)
+				defer timer.Stop()
+			}
+
+			start := time.Now()
+			err := WaitForDataplaneReady()
+			if (err != nil) != tt.expectedErr {
+				t.Fatal("expected error", tt.expectedErr, "received", err)
+			}
+			if waited := time.Since(start); waited < tt.minWait {
+				t.Error("expected to wait at least", tt.minWait, "waited", waited)
+			}
+		})
+	}
+}
+
+func TestBootstrapWaitsForDataplane(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("NEXTHOP_HEALTH_CHECK", "")
+	t.Setenv("STARTUP_DELAY", "")
+	t.Setenv("VPP_AGENT_READY_TIMEOUT", "5s")
+	t.Setenv("VPP_AGENT_READY_POLL_INTERVAL", "20ms")
+	t.Setenv("DEFAULT_NEXTHOPS", "192.168.0.2")
+	resetRouteMap(t)
+	skipReconcile(t)
+	t.Cleanup(func() {
+		stopBackgroundTasks(time.Second)
+	})
+
+	fake := newFakeVppAgent()
+	fake.getErr = errors.New("connection refused")
+	useFakeVppAgent(t, fake)
+	// Updates pushed before the vpp-agent answers mean the dataplane was programmed before it was ready.
+	updatesBeforeReady := -1
+	timer := time.AfterFunc(100*time.Millisecond, func() {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		updatesBeforeReady = fake.updateCalls
+		fake.getErr = nil
+	})
+	defer timer.Stop()
+
+	result, err := BootstrapSliceRouterPod()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if updatesBeforeReady != 0 {
+		t.Error("expected no vpp-agent update before the vpp-agent is ready, received", updatesBeforeReady)
+	}
+	if result.DefaultNextHopPrefix == "" || fake.updateCalls == 0 {
+		t.Error("expected the default next hop route pushed once the vpp-agent is ready")
+	}
+}
@@ -0,0 +1,70 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+// isStrictNextHopValidationEnabled returns true if injected next hops must be the IP of a client
+// connected to the slice router over nsm, read from the STRICT_NEXTHOP_VALIDATION env variable.
+// Next hops are not checked by default.
+func isStrictNextHopValidationEnabled() bool {
+	return os.Getenv("STRICT_NEXTHOP_VALIDATION") == "true"
+}
+
+// checkNsmPeerNextHops checks that the IP next hops are the nsm IP of a connected client, the end of
+// an nsm link on the client pod. Interface and pod next hops are checked when they are resolved.
+func checkNsmPeerNextHops(nextHopIPList []string) error {
+	ipNextHops := []string{}
+	for _, nextHopIP := range nextHopIPList {
+		if _, ok := parseDevNextHop(nextHopIP); ok {
+			continue
+		}
+		if _, ok := parsePodNextHop(nextHopIP); ok {
+			continue
+		}
+		ipNextHops = append(ipNextHops, nextHopIP)
+	}
+	if len(ipNextHops) == 0 {
+		return nil
+	}
+
+	connList, err := sliceRouterGetClientConnections()
+	if err != nil {
+		return err
+	}
+	peers := map[string]bool{}
+	for _, conn := range connList {
+		peers[conn.GetNsmIP()] = true
+		if conn.GetNsmIPv6() != "" {
+			peers[conn.GetNsmIPv6()] = true
+		}
+	}
+	for _, nextHopIP := range ipNextHops {
+		if !peers[nextHopIP] {
+			logger.GlobalLogger.Warnf("Rejecting next hop that is not an nsm peer: %v", nextHopIP)
+			unknownNextHopsCounter.Inc()
+			return fmt.Errorf("next hop %v is not the IP of a connected nsm peer", nextHopIP)
+		}
+	}
+	return nil
+}
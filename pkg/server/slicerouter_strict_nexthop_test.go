@@ -0,0 +1,84 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+func TestStrictNextHopValidation(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	connCache.invalidate()
+
+	tests := []struct {
+		testName     string
+		strict       string
+		nextHops     []string
+		expectReason routeErrorReason
+	}{
+		{"unknown next hop accepted by default", "", []string{"10.1.3.1"}, ""},
+		{"nsm peer accepted", "true", []string{"10.1.1.1"}, ""},
+		{"nsm peers accepted", "true", []string{"10.1.1.1", "10.1.2.1"}, ""},
+		{"pod next hop accepted", "true", []string{"pod:slicegw-a"}, ""},
+		{"unknown next hop rejected", "true", []string{"10.1.3.1"}, routeErrorNextHopUnresolved},
+		{"unknown next hop among peers rejected", "true", []string{"10.1.1.1", "10.1.3.1"}, routeErrorNextHopUnresolved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("STRICT_NEXTHOP_VALIDATION", tt.strict)
+			resetRouteMap(t)
+			unknownNextHopsCounter.Reset()
+			fake := newFakeNetlink()
+			addressPodLink(fake, 1, "slicegw-a", "10.1.1.1", "10.1.1.2")
+			addressPodLink(fake, 2, "slicegw-b", "10.1.2.1", "10.1.2.2")
+			// The link without address is not an nsm connection, its next hop is still routable.
+			fake.addConnectedRoute("10.1.3.1", 3)
+			useFakeNetlink(t, fake)
+
+			err := sliceRouterInjectRoute("10.2.0.0/16", tt.nextHops)
+			routes, _ := fake.RouteList(nil, netlink.FAMILY_ALL)
+			installed := len(getRouteNextHops(routes, "10.2.0.0/16")) > 0
+			if tt.expectReason != "" {
+				var rErr *routeError
+				if !errors.As(err, &rErr) || rErr.reason != tt.expectReason {
+					t.Fatal("expected reason", tt.expectReason, "received", err)
+				}
+				if installed {
+					t.Error("route installed for a rejected injection")
+				}
+				if got := unknownNextHopsCounter.Value(); got != 1 {
+					t.Error("rejections: expected 1, received", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !installed {
+				t.Error("route not installed")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// vl3GetVppConfigJSON returns the config reported by the vpp agent in JSON.
+func vl3GetVppConfigJSON() (string, error) {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		return "", err
+	}
+	defer closeConn()
+
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{})
+	if err != nil {
+		return "", err
+	}
+	recordVppConfig(vppConfig.GetConfig().GetVppConfig())
+
+	b, err := protojson.Marshal(vppConfig.GetConfig().GetVppConfig())
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// sliceRouterGetSupportBundle collects the state of the sidecar using the same enumeration the sidecar
+// uses internally. Collection errors are recorded in the bundle instead of failing it, so that a broken
+// dataplane still yields a useful bundle.
+func sliceRouterGetSupportBundle() *sidecar.SupportBundle {
+	bundle := &sidecar.SupportBundle{
+		GeneratedAt:     timestamppb.Now(),
+		DataplaneMode:   getSliceRouterDataplaneMode(),
+		RouteTable:      sliceRouterGetRouteTable(),
+		ReconcileStatus: sliceRouterGetReconcileStatus(),
+		LogLevel:        logger.GlobalLogger.Level(),
+	}
+	collectionErr := func(what string, err error) {
+		logger.GlobalLogger.Errorf("Support bundle: failed to get %v: %v", what, err)
+		bundle.CollectionErrors = append(bundle.CollectionErrors, fmt.Sprintf("%v: %v", what, err))
+	}
+
+	connections, err := sliceRouterGetClientConnections()
+	if err != nil {
+		collectionErr("client connections", err)
+	}
+	bundle.Connections = connections
+
+	if getSliceRouterDataplaneMode() == SliceRouterDataplaneVpp {
+		vppConfig, err := vl3GetVppConfigJSON()
+		if err != nil {
+			collectionErr("vpp config", err)
+		}
+		bundle.VppConfig = vppConfig
+		bundle.VppAgentEndpoint = vppAgentEndpoints.activeEndpoint()
+	} else {
+		routes, err := nlHandle.RouteList(nil, netlink.FAMILY_V4)
+		if err != nil {
+			collectionErr("kernel routes", err)
+		}
+		for _, route := range routes {
+			bundle.KernelRoutes = append(bundle.KernelRoutes, route.String())
+		}
+	}
+
+	// Recent errors are collected last so that the collection errors above are part of them.
+	for _, entry := range logger.RecentErrors() {
+		bundle.RecentErrors = append(bundle.RecentErrors, &sidecar.ErrorEntry{
+			Time:    timestamppb.New(entry.Time),
+			Message: entry.Message,
+		})
+	}
+	return bundle
+}
@@ -0,0 +1,159 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+)
+
+const (
+	// Number of times the drift check of a SyncRoutes request is attempted while another reconcile is
+	// running, and the wait between attempts.
+	syncRoutesReconcileAttempts = 5
+	syncRoutesReconcileInterval = time.Second
+)
+
+// syncRouteResult is the change made to a route by sliceRouterSyncRoutes.
+type syncRouteResult struct {
+	remoteSubnet string
+	action       sidecar.RouteSyncAction
+	// Next hops recorded before the request, and requested.
+	previous []string
+	nextHops []string
+	// What the drift check corrected on the installed route, for corrected routes.
+	correction string
+	err        error
+}
+
+// routeSyncAction returns the change needed to bring the route to the requested state, as far as the
+// slice route map tells. Drift in the dataplane is found by the reconcile.
+func routeSyncAction(route routeRequest) sidecar.RouteSyncAction {
+	cached, ok := remoteSubnetRouteMap.Load(route.remoteSubnet)
+	if !ok {
+		return sidecar.RouteSyncAction_ROUTE_SYNC_ADDED
+	}
+	if !sameNextHops(cached.([]string), route.nextHopIPList) ||
+		!loadPreferredSrc(route.remoteSubnet).Equal(net.ParseIP(route.preferredSrc)) ||
+		loadRouteDescription(route.remoteSubnet) != route.description ||
+		loadReconcilePriority(route.remoteSubnet) != route.reconcilePriority ||
+		loadVppRouteAttrs(route.remoteSubnet) != route.vppAttrs {
+		return sidecar.RouteSyncAction_ROUTE_SYNC_UPDATED
+	}
+	return sidecar.RouteSyncAction_ROUTE_SYNC_UNCHANGED
+}
+
+// sliceRouterSyncRoutes converges the routes injected by the sidecar to the desired set and returns the
+// change made to each route: the results of the desired routes in request order, followed by the ones
+// of the routes deleted because they are not desired. Unlike EnsureRoutes, changes are applied without
+// waiting for the inject debounce window, and the desired routes that were already injected are
+// checked for drift in the dataplane and corrected before returning, by the reconcile.
+func sliceRouterSyncRoutes(ctx context.Context, desired []routeRequest) ([]*syncRouteResult, error) {
+	desiredSet, err := desiredRouteSet(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	ensureMu.Lock()
+	defer ensureMu.Unlock()
+
+	results := []*syncRouteResult{}
+	batch := []routeRequest{}
+	for _, route := range desired {
+		result := &syncRouteResult{remoteSubnet: route.remoteSubnet, action: routeSyncAction(route),
+			nextHops: route.nextHopIPList}
+		if cached, ok := remoteSubnetRouteMap.Load(route.remoteSubnet); ok {
+			result.previous = cached.([]string)
+		}
+		results = append(results, result)
+		route.immediate = true
+		batch = append(batch, route)
+	}
+	// Deletions are routes with no next hops.
+	for _, remoteSubnet := range undesiredRoutes(desiredSet) {
+		result := &syncRouteResult{remoteSubnet: remoteSubnet, action: sidecar.RouteSyncAction_ROUTE_SYNC_DELETED}
+		if cached, ok := remoteSubnetRouteMap.Load(remoteSubnet); ok {
+			result.previous = cached.([]string)
+		}
+		results = append(results, result)
+		batch = append(batch, routeRequest{ctx: ctx, remoteSubnet: remoteSubnet, immediate: true,
+			deletionReason: sidecar.DeletionReason_DELETION_NOT_DESIRED})
+	}
+	for i, err := range sliceRouterInjectRoutes(batch) {
+		results[i].err = err
+	}
+
+	// The routes the request did not change may have drifted in the dataplane.
+	unchanged := []*syncRouteResult{}
+	filter := prefixFilter{}
+	for _, result := range results {
+		if result.action != sidecar.RouteSyncAction_ROUTE_SYNC_UNCHANGED || result.err != nil {
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(result.remoteSubnet); err == nil {
+			unchanged = append(unchanged, result)
+			filter = append(filter, ipNet)
+		}
+	}
+	if len(filter) > 0 {
+		corrections, err := reconcileSyncedRoutes(ctx, filter)
+		for _, result := range unchanged {
+			if err != nil {
+				result.err = fmt.Errorf("failed to check the installed route for drift: %w", err)
+				continue
+			}
+			if c, ok := corrections[result.remoteSubnet]; ok {
+				result.action = sidecar.RouteSyncAction_ROUTE_SYNC_CORRECTED
+				result.correction, result.err = c.correction, c.err
+			}
+		}
+	}
+
+	counts := map[sidecar.RouteSyncAction]int{}
+	for _, result := range results {
+		counts[result.action]++
+	}
+	logger.GlobalLogger.Infof("Synced routes. Added: %v, Updated: %v, Corrected: %v, Deleted: %v, Unchanged: %v",
+		counts[sidecar.RouteSyncAction_ROUTE_SYNC_ADDED], counts[sidecar.RouteSyncAction_ROUTE_SYNC_UPDATED],
+		counts[sidecar.RouteSyncAction_ROUTE_SYNC_CORRECTED], counts[sidecar.RouteSyncAction_ROUTE_SYNC_DELETED],
+		counts[sidecar.RouteSyncAction_ROUTE_SYNC_UNCHANGED])
+	return results, nil
+}
+
+// reconcileSyncedRoutes reconciles the routes matching the filter and returns the corrections made, by
+// remote subnet. The corrections are not bounded by the reconcile correction limit, the request asked
+// for them. The reconcile is attempted again while another reconcile is running.
+func reconcileSyncedRoutes(ctx context.Context, filter prefixFilter) (map[string]reconcileCorrection, error) {
+	for attempt := 1; ; attempt++ {
+		budget := &reconcileBudget{corrections: map[string]reconcileCorrection{}}
+		err := reconcileRoutingTableWithBudget(ctx, filter, budget)
+		if err != errReconcileInProgress || attempt >= syncRoutesReconcileAttempts {
+			return budget.corrections, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(syncRoutesReconcileInterval):
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/lorenzosaino/go-sysctl"
+)
+
+const (
+	ipv4ForwardingSysctl = "net.ipv4.ip_forward"
+	ipv6ForwardingSysctl = "net.ipv6.conf.all.forwarding"
+)
+
+// sysctlAccessor is the subset of the sysctl calls used by the sidecar.
+type sysctlAccessor interface {
+	Get(name string) (string, error)
+	Set(name string, value string) error
+}
+
+type procSysctl struct{}
+
+func (procSysctl) Get(name string) (string, error) {
+	return sysctl.Get(name)
+}
+
+func (procSysctl) Set(name string, value string) error {
+	return sysctl.Set(name, value)
+}
+
+// sysctlHandle is used for all kernel parameter changes. It is a variable so that tests can
+// substitute a fake.
+var sysctlHandle sysctlAccessor = procSysctl{}
+
+// isIPv6Enabled returns true if the slice carries IPv6 traffic, read from the ENABLE_IPV6 env variable.
+func isIPv6Enabled() bool {
+	return os.Getenv("ENABLE_IPV6") == "true"
+}
+
+// isIPForwardManaged returns false if IP forwarding is left to the node or an init container instead of
+// being turned on by the sidecar, read from the MANAGE_IP_FORWARD env variable. The sidecar manages it
+// by default.
+func isIPForwardManaged() bool {
+	return os.Getenv("MANAGE_IP_FORWARD") != "false"
+}
+
+// enableSysctl sets the kernel parameter to 1 and reads it back to confirm the change took effect.
+func enableSysctl(name string) error {
+	if err := sysctlHandle.Set(name, "1"); err != nil {
+		return err
+	}
+	val, err := sysctlHandle.Get(name)
+	if err != nil {
+		return err
+	}
+	if val != "1" {
+		return fmt.Errorf("%v is %q after enabling it", name, val)
+	}
+	logger.GlobalLogger.Infof("Enabled %v", name)
+	return nil
+}
+
+// sliceRouterEnableForwarding turns on IP forwarding in the kernel, for IPv6 as well if the slice
+// carries IPv6 traffic.
+func sliceRouterEnableForwarding() error {
+	if err := enableSysctl(ipv4ForwardingSysctl); err != nil {
+		return err
+	}
+	if isIPv6Enabled() {
+		if err := enableSysctl(ipv6ForwardingSysctl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSysctlEnabled returns an error if the kernel parameter is not set to 1.
+func checkSysctlEnabled(name string) error {
+	val, err := sysctlHandle.Get(name)
+	if err != nil {
+		return err
+	}
+	if val != "1" {
+		return fmt.Errorf("%v is %q", name, val)
+	}
+	return nil
+}
+
+// sliceRouterCheckForwarding returns an error if IP forwarding is not on in the kernel, for IPv6 as well
+// if the slice carries IPv6 traffic. It is used when forwarding is managed outside the sidecar.
+func sliceRouterCheckForwarding() error {
+	if err := checkSysctlEnabled(ipv4ForwardingSysctl); err != nil {
+		return err
+	}
+	if isIPv6Enabled() {
+		if err := checkSysctlEnabled(ipv6ForwardingSysctl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestSliceRouterEnableForwarding(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName    string
+		ipv6        string
+		readOnly    map[string]bool
+		setErr      error
+		expectedErr bool
+		expected    map[string]string
+	}{
+		{
+			"ipv4 only",
+			"",
+			nil,
+			nil,
+			false,
+			map[string]string{ipv4ForwardingSysctl: "1", ipv6ForwardingSysctl: "0"},
+		},
+		{
+			"ipv6 enabled",
+			"true",
+			nil,
+			nil,
+			false,
+			map[string]string{ipv4ForwardingSysctl: "1", ipv6ForwardingSysctl: "1"},
+		},
+		{
+			"ipv6 forwarding does not read back",
+			"true",
+			map[string]bool{ipv6ForwardingSysctl: true},
+			nil,
+			true,
+			map[string]string{ipv4ForwardingSysctl: "1", ipv6ForwardingSysctl: "0"},
+		},
+		{
+			"set fails",
+			"true",
+			nil,
+			errors.New("permission denied"),
+			true,
+			map[string]string{ipv4ForwardingSysctl: "0", ipv6ForwardingSysctl: "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("ENABLE_IPV6", tt.ipv6)
+			fake := &fakeSysctl{
+				values:   map[string]string{ipv4ForwardingSysctl: "0", ipv6ForwardingSysctl: "0"},
+				readOnly: tt.readOnly,
+				setErr:   tt.setErr,
+			}
+			useFakeSysctl(t, fake)
+
+			err := sliceRouterEnableForwarding()
+			if (err != nil) != tt.expectedErr {
+				t.Fatal("error: expected", tt.expectedErr, "received", err)
+			}
+			for name, val := range tt.expected {
+				if fake.values[name] != val {
+					t.Error(name, "expected", val, "received", fake.values[name])
+				}
+			}
+		})
+	}
+}
+
+func TestSliceRouterCheckForwarding(t *testing.T) {
+	tests := []struct {
+		testName    string
+		ipv6        string
+		values      map[string]string
+		expectedErr bool
+	}{
+		{"ipv4 forwarding on", "", map[string]string{ipv4ForwardingSysctl: "1", ipv6ForwardingSysctl: "0"}, false},
+		{"ipv4 forwarding off", "", map[string]string{ipv4ForwardingSysctl: "0", ipv6ForwardingSysctl: "1"}, true},
+		{"ipv6 forwarding off", "true", map[string]string{ipv4ForwardingSysctl: "1", ipv6ForwardingSysctl: "0"}, true},
+		{"ipv6 forwarding on", "true", map[string]string{ipv4ForwardingSysctl: "1", ipv6ForwardingSysctl: "1"}, false},
+		{"forwarding unreadable", "", map[string]string{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("ENABLE_IPV6", tt.ipv6)
+			fake := &fakeSysctl{values: tt.values, setErr: errors.New("read-only file system")}
+			useFakeSysctl(t, fake)
+
+			err := sliceRouterCheckForwarding()
+			if (err != nil) != tt.expectedErr {
+				t.Error("error: expected", tt.expectedErr, "received", err)
+			}
+		})
+	}
+}
+
+func TestIsIPForwardManaged(t *testing.T) {
+	for value, expected := range map[string]bool{"": true, "true": true, "false": false} {
+		t.Setenv("MANAGE_IP_FORWARD", value)
+		if managed := isIPForwardManaged(); managed != expected {
+			t.Error("MANAGE_IP_FORWARD", value, "expected", expected, "received", managed)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTracingServiceName   = "router-sidecar"
+	defaultTracingFlushInterval = 5 * time.Second
+	tracingShutdownTimeout      = 5 * time.Second
+	// Name the spans of the sidecar are created under.
+	tracerName = "github.com/kubeslice/router-sidecar/pkg/server"
+)
+
+// Names of the spans of the route operations.
+const (
+	spanInjectRoute          = "slicerouter.InjectRoute"
+	spanSendConfigToVppAgent = "slicerouter.SendConfigToVppAgent"
+	spanReconcile            = "slicerouter.Reconcile"
+)
+
+// Attributes of the spans. The grpc server spans follow the OpenTelemetry rpc conventions.
+const (
+	spanAttrDataplane         = "slicerouter.dataplane"
+	spanAttrRemoteSubnet      = "slicerouter.remote_subnet"
+	spanAttrNextHops          = "slicerouter.next_hops"
+	spanAttrVppDelete         = "slicerouter.vpp.delete"
+	spanAttrVppRoutes         = "slicerouter.vpp.routes"
+	spanAttrReconcilePrefixes = "slicerouter.reconcile.prefixes"
+	spanAttrRPCSystem         = "rpc.system"
+	spanAttrRPCService        = "rpc.service"
+	spanAttrRPCMethod         = "rpc.method"
+	spanAttrRPCStatusCode     = "rpc.grpc.status_code"
+)
+
+var (
+	// tracerProvider exports the spans while tracing is on, nil otherwise.
+	tracerProvider   *sdktrace.TracerProvider
+	tracerProviderMu sync.Mutex
+	// tracePropagator reads the W3C trace context propagated by the grpc clients.
+	tracePropagator = propagation.TraceContext{}
+)
+
+// getTracingEndpoint returns the url the spans are exported to over OTLP/HTTP, read from the
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT env variable, or from OTEL_EXPORTER_OTLP_ENDPOINT with the traces
+// path appended. Tracing is off if neither is set.
+func getTracingEndpoint() string {
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	}
+	return ""
+}
+
+// getTracingServiceName returns the service the spans are exported as, read from the OTEL_SERVICE_NAME
+// env variable.
+func getTracingServiceName() string {
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		return name
+	}
+	return defaultTracingServiceName
+}
+
+// newTracingExporterOptions returns the options of the OTLP/HTTP exporter sending the spans to the url.
+func newTracingExporterOptions(endpoint string) ([]otlptracehttp.Option, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("expected an http or https url, received %q", endpoint)
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host), otlptracehttp.WithURLPath(u.Path)}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	return opts, nil
+}
+
+// startTracing turns tracing on if an OTLP endpoint is set and returns the endpoint, empty if tracing
+// is off. The spans are batched by the OpenTelemetry SDK and exported over OTLP/HTTP.
+func startTracing() string {
+	endpoint := getTracingEndpoint()
+	if endpoint == "" {
+		return ""
+	}
+	opts, err := newTracingExporterOptions(endpoint)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Invalid OTLP traces endpoint, tracing is off: %v", err)
+		return ""
+	}
+	exporter, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to create the OTLP traces exporter, tracing is off: %v", err)
+		return ""
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(defaultTracingFlushInterval)),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(getTracingServiceName()))))
+	setTracerProvider(provider)
+	logger.GlobalLogger.Infof("Exporting traces to %v", endpoint)
+	return endpoint
+}
+
+// setTracerProvider makes the provider create the spans of the sidecar, a nil provider turning tracing
+// off. It returns the provider replaced.
+func setTracerProvider(provider *sdktrace.TracerProvider) *sdktrace.TracerProvider {
+	tracerProviderMu.Lock()
+	defer tracerProviderMu.Unlock()
+	previous := tracerProvider
+	tracerProvider = provider
+	if provider == nil {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	} else {
+		otel.SetTracerProvider(provider)
+	}
+	return previous
+}
+
+// stopTracing exports the spans left and turns tracing off.
+func stopTracing() {
+	provider := setTracerProvider(nil)
+	if provider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := provider.Shutdown(ctx); err != nil {
+		logger.GlobalLogger.Errorf("Failed to export the last spans: %v", err)
+	}
+}
+
+// startSpan starts a span of the sidecar, a child of the span in the context if any. The span does
+// nothing while tracing is off. Routes injected outside of a request have no context.
+func startSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// endSpan records the outcome of the operation in the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// metadataCarrier reads and writes the trace context in grpc metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// tracingUnaryInterceptor traces the requests, as children of the trace context propagated by the
+// client in the traceparent metadata if any.
+func tracingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = tracePropagator.Extract(ctx, metadataCarrier(md))
+	}
+	name := strings.TrimPrefix(info.FullMethod, "/")
+	service, method, _ := strings.Cut(name, "/")
+	ctx, span := startSpan(ctx, name, trace.SpanKindServer,
+		attribute.String(spanAttrRPCSystem, "grpc"),
+		attribute.String(spanAttrRPCService, service),
+		attribute.String(spanAttrRPCMethod, method))
+	resp, err := handler(ctx, req)
+	span.SetAttributes(attribute.Int(spanAttrRPCStatusCode, int(status.Code(err))))
+	endSpan(span, err)
+	return resp, err
+}
@@ -0,0 +1,263 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// useTracer turns tracing on for the duration of the test and returns a func returning the spans ended
+// since the previous call.
+func useTracer(t *testing.T) (flush func() []sdktrace.ReadOnlySpan) {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	previous := setTracerProvider(provider)
+	t.Cleanup(func() {
+		setTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	var mu sync.Mutex
+	flushed := 0
+	return func() []sdktrace.ReadOnlySpan {
+		mu.Lock()
+		defer mu.Unlock()
+		ended := rec.Ended()
+		spans := ended[flushed:]
+		flushed = len(ended)
+		return spans
+	}
+}
+
+// findSpan returns the span with the name, failing the test if there is none.
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	t.Fatal("expected span", name, "received", spans)
+	return nil
+}
+
+// spanAttribute returns the value of the attribute of the span, nil if it is not set.
+func spanAttribute(span sdktrace.ReadOnlySpan, key string) interface{} {
+	for _, attr := range span.Attributes() {
+		if string(attr.Key) == key {
+			return attr.Value.AsInterface()
+		}
+	}
+	return nil
+}
+
+// remoteSpanContext returns the span context propagated in the traceparent header.
+func remoteSpanContext(traceparent string) trace.SpanContext {
+	ctx := propagation.TraceContext{}.Extract(context.Background(),
+		propagation.MapCarrier{"traceparent": traceparent})
+	return trace.SpanContextFromContext(ctx)
+}
+
+func TestGetTracingEndpoint(t *testing.T) {
+	tests := []struct {
+		testName       string
+		tracesEndpoint string
+		endpoint       string
+		expected       string
+	}{
+		{"tracing off by default", "", "", ""},
+		{"traces endpoint used as is", "http://collector:4318/custom", "http://other:4318", "http://collector:4318/custom"},
+		{"traces path appended to the endpoint", "", "http://collector:4318/", "http://collector:4318/v1/traces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", tt.tracesEndpoint)
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tt.endpoint)
+			if endpoint := getTracingEndpoint(); endpoint != tt.expected {
+				t.Error("expected", tt.expected, "received", endpoint)
+			}
+		})
+	}
+}
+
+func TestTracingRouteOperations(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	skipReconcile(t)
+	flush := useTracer(t)
+	remote := remoteSpanContext(testTraceparent)
+
+	conn, err := grpc.DialContext(context.Background(), "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", testTraceparent)
+
+	tests := []struct {
+		testName string
+		mode     string
+	}{
+		{"kernel", SliceRouterDataplaneKernel},
+		{"vpp", SliceRouterDataplaneVpp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.mode)
+			resetRouteMap(t)
+			fake := newFakeNetlink()
+			fake.addConnectedRoute("192.168.0.2", 1)
+			useFakeNetlink(t, fake)
+			useFakeVppAgent(t, newFakeVppAgent())
+			flush()
+
+			_, err := client.InjectRoutes(ctx, &pb.RouteBatch{Routes: []*pb.RouteInfo{
+				{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			spans := flush()
+
+			server := findSpan(t, spans, "router.SliceRouterSidecarService/InjectRoutes")
+			if server.SpanKind() != trace.SpanKindServer || server.SpanContext().TraceID() != remote.TraceID() ||
+				server.Parent().SpanID() != remote.SpanID() {
+				t.Error("server span: expected child of the incoming trace, received", server)
+			}
+			if spanAttribute(server, spanAttrRPCMethod) != "InjectRoutes" || spanAttribute(server, spanAttrRPCStatusCode) != int64(0) {
+				t.Error("server span: unexpected attributes", server.Attributes())
+			}
+			inject := findSpan(t, spans, spanInjectRoute)
+			if inject.SpanContext().TraceID() != remote.TraceID() || inject.Parent().SpanID() != server.SpanContext().SpanID() {
+				t.Error("inject span: expected child of the server span, received", inject)
+			}
+			if spanAttribute(inject, spanAttrRemoteSubnet) != "10.1.0.0/16" || spanAttribute(inject, spanAttrDataplane) != tt.mode ||
+				!reflect.DeepEqual(spanAttribute(inject, spanAttrNextHops), []string{"192.168.0.2"}) || inject.Status().Code != codes.Unset {
+				t.Error("inject span: unexpected attributes", inject.Attributes(), inject.Status())
+			}
+			if tt.mode != SliceRouterDataplaneVpp {
+				return
+			}
+			send := findSpan(t, spans, spanSendConfigToVppAgent)
+			if send.SpanKind() != trace.SpanKindClient || send.Parent().SpanID() != inject.SpanContext().SpanID() {
+				t.Error("vpp-agent span: expected child of the inject span, received", send)
+			}
+			if spanAttribute(send, spanAttrVppDelete) != false || spanAttribute(send, spanAttrVppRoutes) != int64(1) {
+				t.Error("vpp-agent span: unexpected attributes", send.Attributes())
+			}
+		})
+	}
+
+	t.Run("reconcile", func(t *testing.T) {
+		t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+		resetRouteMap(t)
+		fake := newFakeNetlink()
+		fake.addConnectedRoute("192.168.0.2", 1)
+		useFakeNetlink(t, fake)
+		flush()
+
+		if _, err := client.ReconcileNow(ctx, &pb.ReconcileRequest{Prefixes: []string{"10.0.0.0/8"}}); err != nil {
+			t.Fatal(err)
+		}
+		spans := flush()
+
+		server := findSpan(t, spans, "router.SliceRouterSidecarService/ReconcileNow")
+		reconcile := findSpan(t, spans, spanReconcile)
+		if reconcile.SpanContext().TraceID() != remote.TraceID() || reconcile.Parent().SpanID() != server.SpanContext().SpanID() {
+			t.Error("reconcile span: expected child of the server span, received", reconcile)
+		}
+		if !reflect.DeepEqual(spanAttribute(reconcile, spanAttrReconcilePrefixes), []string{"10.0.0.0/8"}) ||
+			spanAttribute(reconcile, spanAttrDataplane) != SliceRouterDataplaneKernel {
+			t.Error("reconcile span: unexpected attributes", reconcile.Attributes())
+		}
+	})
+
+	t.Run("no spans with tracing off", func(t *testing.T) {
+		t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+		resetRouteMap(t)
+		fake := newFakeNetlink()
+		fake.addConnectedRoute("192.168.0.2", 1)
+		useFakeNetlink(t, fake)
+		flush()
+		provider := setTracerProvider(nil)
+		defer setTracerProvider(provider)
+
+		if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+			t.Fatal(err)
+		}
+		if spans := flush(); len(spans) != 0 {
+			t.Error("expected no spans, received", spans)
+		}
+	})
+}
+
+func TestStartTracingExportsOTLP(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	requests := make(chan *http.Request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		io.Copy(io.Discard, req.Body)
+		requests <- req
+	}))
+	defer srv.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", srv.URL+"/custom/traces")
+	previous := setTracerProvider(nil)
+	defer setTracerProvider(previous)
+
+	if endpoint := startTracing(); endpoint != srv.URL+"/custom/traces" {
+		t.Fatal("expected tracing on, received endpoint", endpoint)
+	}
+	_, span := startSpan(context.Background(), spanReconcile, trace.SpanKindInternal)
+	endSpan(span, nil)
+	// The spans left are exported when tracing stops.
+	stopTracing()
+
+	select {
+	case req := <-requests:
+		if req.Method != http.MethodPost || req.URL.Path != "/custom/traces" || req.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Error("unexpected export request", req.Method, req.URL.Path, req.Header)
+		}
+	default:
+		t.Error("expected the spans exported to the endpoint")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "collector:4318")
+	if endpoint := startTracing(); endpoint != "" {
+		t.Error("expected tracing off with an invalid endpoint, received", endpoint)
+	}
+}
@@ -0,0 +1,262 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/proto"
+)
+
+// errAtomicBatchUnsupported is returned when a transactional batch is requested outside the kernel and
+// vpp modes.
+var errAtomicBatchUnsupported = errors.New("Transactional route batches are only supported in kernel and vpp modes")
+
+// routeSnapshot is the state of a route before a transactional batch touched it.
+type routeSnapshot struct {
+	remoteSubnet string
+	// Route installed in the kernel, nil if there was none.
+	installed *netlink.Route
+	// inVpp is set for the routes of the vpp dataplane, whose entries configured in vpp for the
+	// destination are in vppRoutes.
+	inVpp     bool
+	vppRoutes []*vpp.Route
+	// Next hops recorded in the slice route map, nil if the route was not recorded.
+	cached []string
+	// Preferred source recorded for the route, nil if none.
+	src net.IP
+	// Description recorded for the route, empty if none.
+	description string
+	// Reconcile tier recorded for the route.
+	reconcilePriority sidecar.ReconcilePriority
+	// Path attributes recorded for the vpp entries of the route.
+	vppAttrs vppRouteAttrs
+}
+
+// snapshotKernelRoute records the kernel and cached state of the route to the remote subnet, under the
+// canonical form of the subnet the injection records the route under.
+func snapshotKernelRoute(remoteSubnet string) (*routeSnapshot, error) {
+	_, dstIPNet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return nil, newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	remoteSubnet = dstIPNet.String()
+	routes, err := nlHandle.RouteList(nil, ipFamily(dstIPNet.IP))
+	if err != nil {
+		return nil, newRouteError(routeErrorDataplane, remoteSubnet, err)
+	}
+
+	snapshot := &routeSnapshot{remoteSubnet: remoteSubnet, src: loadPreferredSrc(remoteSubnet),
+		description: loadRouteDescription(remoteSubnet), reconcilePriority: loadReconcilePriority(remoteSubnet)}
+	// Only the route the batch replaces is recorded, not a route to the same destination in another
+	// table or with another metric, like the default route of the pod network next to the default next
+	// hop route.
+	for _, route := range routes {
+		if kernelRouteDst(route) == dstIPNet.String() && isSliceRouteMetric(route) &&
+			(route.Table == 0 || route.Table == unix.RT_TABLE_MAIN) {
+			installed := route
+			snapshot.installed = &installed
+			break
+		}
+	}
+	if cached, ok := remoteSubnetRouteMap.Load(remoteSubnet); ok {
+		snapshot.cached = cached.([]string)
+	}
+	return snapshot, nil
+}
+
+// snapshotVppRoute records the vpp and cached state of the route to the remote subnet, the vpp entries
+// being taken from the vpp config read before the batch. Like in the kernel, the route is recorded under
+// the canonical form of the subnet.
+func snapshotVppRoute(remoteSubnet string, config *vpp.ConfigData) (*routeSnapshot, error) {
+	_, dstIPNet, err := net.ParseCIDR(remoteSubnet)
+	if err != nil {
+		return nil, newRouteError(routeErrorInvalidArgument, remoteSubnet, err)
+	}
+	remoteSubnet = dstIPNet.String()
+	snapshot := &routeSnapshot{remoteSubnet: remoteSubnet, src: loadPreferredSrc(remoteSubnet),
+		description: loadRouteDescription(remoteSubnet), reconcilePriority: loadReconcilePriority(remoteSubnet),
+		vppAttrs: loadVppRouteAttrs(remoteSubnet), inVpp: true, vppRoutes: vppRoutesTo(config, remoteSubnet)}
+	if cached, ok := remoteSubnetRouteMap.Load(remoteSubnet); ok {
+		snapshot.cached = cached.([]string)
+	}
+	return snapshot, nil
+}
+
+// vppRoutesTo returns the slice route entries of the vpp config to the remote subnet. The entries are
+// configured with the canonical form of their subnet, the remote subnet is compared in that form.
+func vppRoutesTo(config *vpp.ConfigData, remoteSubnet string) []*vpp.Route {
+	if _, dstIPNet, err := net.ParseCIDR(remoteSubnet); err == nil {
+		remoteSubnet = dstIPNet.String()
+	}
+	routes := []*vpp.Route{}
+	for _, route := range config.GetRoutes() {
+		if route.GetDstNetwork() == remoteSubnet && isSliceVppRoute(route) {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// diffVppRoutes returns the routes of from that are not in to.
+func diffVppRoutes(from, to []*vpp.Route) []*vpp.Route {
+	diff := []*vpp.Route{}
+	for _, route := range from {
+		found := false
+		for _, other := range to {
+			if sameVppRoute(route, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, route)
+		}
+	}
+	return diff
+}
+
+// restore brings the route back to the recorded state.
+func (s *routeSnapshot) restore() error {
+	if s.cached != nil {
+		remoteSubnetRouteMap.Store(s.remoteSubnet, s.cached)
+	} else {
+		remoteSubnetRouteMap.Delete(s.remoteSubnet)
+	}
+	storePreferredSrc(s.remoteSubnet, s.src)
+	storeRouteDescription(s.remoteSubnet, s.description)
+	storeReconcilePriority(s.remoteSubnet, s.reconcilePriority)
+	storeVppRouteAttrs(s.remoteSubnet, s.vppAttrs)
+	updateRouteCountGauge()
+	if s.inVpp {
+		return s.restoreInVpp()
+	}
+	if s.installed != nil {
+		if err := nlHandle.RouteReplace(s.installed); err != nil {
+			return err
+		}
+		logIproute2Command("replace", s.installed)
+		return nil
+	}
+	err := sliceRouterDeleteRouteToDst(s.remoteSubnet)
+	if err == errRouteNotFound {
+		return nil
+	}
+	return err
+}
+
+// restoreInVpp brings the vpp entries of the route back to the recorded ones. The entries are compared
+// with the current vpp config as a failed route may have left part of its entries behind.
+func (s *routeSnapshot) restoreInVpp() error {
+	config, err := getVppRoutesConfig()
+	if err != nil {
+		return err
+	}
+	current := vppRoutesTo(config, s.remoteSubnet)
+	if added := diffVppRoutes(current, s.vppRoutes); len(added) > 0 {
+		if err := sendConfigToVppAgent(context.Background(), &vpp.ConfigData{Routes: added}, true); err != nil {
+			return err
+		}
+	}
+	// Entries whose path attributes were changed by the batch are configured again, as modifies.
+	restored := []*vpp.Route{}
+	for _, route := range s.vppRoutes {
+		found := false
+		for _, other := range current {
+			if proto.Equal(route, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			restored = append(restored, route)
+		}
+	}
+	if len(restored) > 0 {
+		return sendConfigToVppAgent(context.Background(), &vpp.ConfigData{Routes: restored}, false)
+	}
+	return nil
+}
+
+// sliceRouterInjectRoutesAtomic injects a batch of routes in the dataplane as a single transaction. If
+// any route fails, the routes already changed by the batch are rolled back to their previous state and
+// the error of the failed route is returned, leaving the routing table as it was before the batch.
+// Neither netlink nor the vpp-agent configurator have transactions, so routes changed concurrently by
+// other requests may be overwritten by the rollback. In vpp mode, the entries of the routes are taken
+// from the vpp config read before the batch.
+func sliceRouterInjectRoutesAtomic(routes []routeRequest) error {
+	var vppConfig *vpp.ConfigData
+	switch getSliceRouterDataplaneMode() {
+	case SliceRouterDataplaneKernel:
+	case SliceRouterDataplaneVpp:
+		var err error
+		if vppConfig, err = getVppRoutesConfig(); err != nil {
+			return fmt.Errorf("failed to get vpp config before the route batch: %w", err)
+		}
+	default:
+		return errAtomicBatchUnsupported
+	}
+
+	applied := []*routeSnapshot{}
+	for _, i := range orderRouteRequests(routes) {
+		var snapshot *routeSnapshot
+		var err error
+		if vppConfig != nil {
+			snapshot, err = snapshotVppRoute(routes[i].remoteSubnet, vppConfig)
+		} else {
+			snapshot, err = snapshotKernelRoute(routes[i].remoteSubnet)
+		}
+		if err == nil {
+			applied = append(applied, snapshot)
+			// The batch is applied as a whole, none of its routes waits for the debounce window.
+			route := routes[i]
+			route.immediate = true
+			err = sliceRouterInjectRouteWithResolver(route, newNextHopResolver())
+		}
+		if err != nil {
+			logger.GlobalLogger.Errorf("Route batch failed, rolling back %v routes. Err: %v", len(applied), err)
+			if rollbackErr := rollbackRoutes(applied); rollbackErr != nil {
+				return fmt.Errorf("%w, rollback failed: %v", err, rollbackErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// rollbackRoutes restores the routes in reverse order of application and returns the first error.
+func rollbackRoutes(applied []*routeSnapshot) error {
+	var firstErr error
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := applied[i].restore(); err != nil {
+			logger.GlobalLogger.Errorf("Failed to roll back route to %v: %v", applied[i].remoteSubnet, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,195 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Default time between two probes of the vpp-agent while injections are rejected.
+const defaultVppAgentBreakerProbeInterval = 5 * time.Second
+
+// errDataplaneUnhealthy is returned for the injections rejected while the vpp-agent pushes keep failing.
+var errDataplaneUnhealthy = errors.New("Dataplane unhealthy")
+
+// vppAgentBreaker is a circuit breaker on the route pushes to the vpp-agent. Once consecutive pushes
+// failed, accepting more injections only grows the backlog of routes that are not applied, so the
+// breaker opens and injections are rejected. The reconcile is not affected: a loop keeps reconciling the
+// routing table every probe interval while the breaker is open.
+// The breaker closes on the first push that succeeds, or when a probe finds the vpp-agent answering
+// again. Probes are sent by the rejected injections, at most one every probe interval.
+type vppAgentBreaker struct {
+	mu       sync.Mutex
+	failures int
+	open     bool
+	// lastErr is the error of the last failed push.
+	lastErr error
+	// lastProbe is the time of the last probe, or of the opening of the breaker.
+	lastProbe time.Time
+	now       func() time.Time
+}
+
+var vppAgentPushBreaker = newVppAgentBreaker()
+
+func newVppAgentBreaker() *vppAgentBreaker {
+	return &vppAgentBreaker{now: time.Now}
+}
+
+// getVppAgentBreakerThreshold returns the number of consecutive failed vpp-agent pushes after which
+// injections are rejected, read from the VPP_AGENT_BREAKER_THRESHOLD env variable. Injections are never
+// rejected if it is not set.
+func getVppAgentBreakerThreshold() int {
+	threshold, err := strconv.Atoi(os.Getenv("VPP_AGENT_BREAKER_THRESHOLD"))
+	if err != nil || threshold < 0 {
+		return 0
+	}
+	return threshold
+}
+
+// getVppAgentBreakerProbeInterval returns the time between two probes of the vpp-agent while injections
+// are rejected, read from the VPP_AGENT_BREAKER_PROBE_INTERVAL env variable.
+func getVppAgentBreakerProbeInterval() time.Duration {
+	return getEnvDuration("VPP_AGENT_BREAKER_PROBE_INTERVAL", defaultVppAgentBreakerProbeInterval)
+}
+
+// isVppPushFailure returns true if the push failed because of the state of the dataplane: the vpp-agent
+// could not be reached, did not answer in time or vpp did not apply the config. A config rejected by the
+// vpp-agent says nothing about the other routes.
+func isVppPushFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, errVppConfigNotApplied)
+}
+
+// record reports the outcome of a push to the vpp-agent.
+func (b *vppAgentBreaker) record(err error) {
+	if err == nil {
+		b.close("push succeeded")
+		return
+	}
+	if !isVppPushFailure(err) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.lastErr = err
+	threshold := getVppAgentBreakerThreshold()
+	if b.open || threshold == 0 || b.failures < threshold {
+		return
+	}
+	b.open = true
+	b.lastProbe = b.now()
+	vppAgentBreakerOpenGauge.Set(1)
+	logger.GlobalLogger.Errorf("Rejecting route injections after %v consecutive vpp-agent push failures: %v", b.failures, err)
+}
+
+// close resets the failures and accepts injections again.
+func (b *vppAgentBreaker) close(reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.lastErr = nil
+	if !b.open {
+		return
+	}
+	b.open = false
+	vppAgentBreakerOpenGauge.Set(0)
+	logger.GlobalLogger.Infof("Vpp agent %v, accepting route injections again", reason)
+}
+
+// allow returns an error wrapping errDataplaneUnhealthy if injections are rejected. Once the probe
+// interval elapsed, the vpp-agent is probed first and the breaker closes if it answers.
+func (b *vppAgentBreaker) allow() error {
+	b.mu.Lock()
+	if !b.open {
+		b.mu.Unlock()
+		return nil
+	}
+	probe := b.now().Sub(b.lastProbe) >= getVppAgentBreakerProbeInterval()
+	if probe {
+		b.lastProbe = b.now()
+	}
+	failures, lastErr := b.failures, b.lastErr
+	b.mu.Unlock()
+
+	if probe {
+		err := vppAgentReady()
+		if err == nil {
+			b.close("probe succeeded")
+			return nil
+		}
+		logger.GlobalLogger.Errorf("Vpp agent probe failed, still rejecting route injections: %v", err)
+	}
+	vppAgentBreakerRejectionsCounter.Inc()
+	return fmt.Errorf("%w: the last %v vpp-agent pushes failed, rejecting injections until the vpp-agent recovers. Last error: %v",
+		errDataplaneUnhealthy, failures, lastErr)
+}
+
+// isOpen returns true if injections are rejected.
+func (b *vppAgentBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open
+}
+
+// vppAgentBreakerReconcileLoop reconciles the routing table every probe interval while the breaker is
+// open. The rejected injections do not reconcile the routing table, whatever the reconcile mode, so the
+// loop keeps pushing the routes that were not applied until a push succeeds and closes the breaker.
+func vppAgentBreakerReconcileLoop(ctx context.Context) {
+	ticker := time.NewTicker(getVppAgentBreakerProbeInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reconcileWhileBreakerOpen()
+		}
+	}
+}
+
+// reconcileWhileBreakerOpen reconciles the routing table if injections are rejected.
+func reconcileWhileBreakerOpen() {
+	if !vppAgentPushBreaker.isOpen() {
+		return
+	}
+	err := sliceRouterReconcileRoutingTable(context.Background(), nil)
+	if err == errReconcileInProgress {
+		return
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to reconcile routing table while rejecting injections: %v", err)
+		return
+	}
+	reconcileMu.Lock()
+	lastRoutingTableReconcileTime = time.Now()
+	reconcileMu.Unlock()
+}
@@ -0,0 +1,225 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// useVppAgentBreaker gives the test a closed push breaker whose clock is advanced by the returned func.
+func useVppAgentBreaker(t *testing.T) (advance func(time.Duration)) {
+	t.Helper()
+	orig := vppAgentPushBreaker
+	now := time.Now()
+	vppAgentPushBreaker = newVppAgentBreaker()
+	vppAgentPushBreaker.now = func() time.Time { return now }
+	vppAgentBreakerOpenGauge.Reset()
+	vppAgentBreakerRejectionsCounter.Reset()
+	t.Cleanup(func() {
+		vppAgentPushBreaker = orig
+	})
+	return func(d time.Duration) {
+		vppAgentPushBreaker.mu.Lock()
+		defer vppAgentPushBreaker.mu.Unlock()
+		now = now.Add(d)
+	}
+}
+
+func TestVppAgentBreaker(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_AGENT_MAX_RETRIES", "0")
+	t.Setenv("VPP_AGENT_BREAKER_THRESHOLD", "3")
+	t.Setenv("VPP_AGENT_BREAKER_PROBE_INTERVAL", "10s")
+	resetRouteMap(t)
+	skipReconcile(t)
+	advance := useVppAgentBreaker(t)
+
+	fake := newFakeVppAgent()
+	fake.updateErr = status.Error(codes.Unavailable, "vpp-agent down")
+	fake.getErr = status.Error(codes.Unavailable, "vpp-agent down")
+	useFakeVppAgent(t, fake)
+
+	// The pushes fail until the threshold is reached.
+	for i := 0; i < 3; i++ {
+		err := sliceRouterInjectRoute(fmt.Sprintf("10.%v.0.0/16", i+1), []string{"192.168.0.2"})
+		var rErr *routeError
+		if !errors.As(err, &rErr) || rErr.reason != routeErrorDataplane {
+			t.Fatal("injection", i, ": expected a dataplane error, received", err)
+		}
+	}
+	if vppAgentBreakerOpenGauge.Value() != 1 {
+		t.Fatal("expected the breaker open after 3 failed pushes")
+	}
+
+	// Injections are rejected without reaching the vpp-agent.
+	fake.mu.Lock()
+	updateCalls, getCalls := fake.updateCalls, fake.getCalls
+	fake.mu.Unlock()
+	err := sliceRouterInjectRoute("10.4.0.0/16", []string{"192.168.0.2"})
+	if !errors.Is(err, errDataplaneUnhealthy) || status.Code(routeErrorToStatus(err)) != codes.Unavailable {
+		t.Fatal("expected the injection rejected as dataplane unhealthy, received", err)
+	}
+	fake.mu.Lock()
+	if fake.updateCalls != updateCalls || fake.getCalls != getCalls {
+		t.Error("rejected injection reached the vpp-agent:", fake.updateCalls-updateCalls, "updates,", fake.getCalls-getCalls, "probes")
+	}
+	fake.mu.Unlock()
+
+	// A failed probe keeps the breaker open.
+	advance(10 * time.Second)
+	if err := sliceRouterInjectRoute("10.4.0.0/16", []string{"192.168.0.2"}); !errors.Is(err, errDataplaneUnhealthy) {
+		t.Fatal("expected the injection rejected after a failed probe, received", err)
+	}
+	fake.mu.Lock()
+	if fake.getCalls != getCalls+1 {
+		t.Error("expected a single probe, received", fake.getCalls-getCalls)
+	}
+	// The vpp-agent recovers.
+	fake.updateErr = nil
+	fake.getErr = nil
+	fake.mu.Unlock()
+
+	// The next probe is only sent once the probe interval elapsed.
+	if err := sliceRouterInjectRoute("10.4.0.0/16", []string{"192.168.0.2"}); !errors.Is(err, errDataplaneUnhealthy) {
+		t.Fatal("expected the injection rejected until the next probe, received", err)
+	}
+	advance(10 * time.Second)
+	if err := sliceRouterInjectRoute("10.4.0.0/16", []string{"192.168.0.2"}); err != nil {
+		t.Fatal("expected the injection accepted after a successful probe, received", err)
+	}
+	if vppAgentBreakerOpenGauge.Value() != 0 {
+		t.Error("expected the breaker closed after a successful probe")
+	}
+	if got := vppAgentBreakerRejectionsCounter.Value(); got != 3 {
+		t.Error("rejections: expected 3, received", got)
+	}
+	if state := vppRouteState(fake, "10.4.0.0/16"); len(state) != 1 {
+		t.Error("expected the route configured once the breaker closed, received", state)
+	}
+}
+
+func TestVppAgentBreakerClosedByReconcile(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_AGENT_MAX_RETRIES", "0")
+	t.Setenv("VPP_AGENT_BREAKER_THRESHOLD", "1")
+	resetRouteMap(t)
+	useVppAgentBreaker(t)
+
+	fake := newFakeVppAgent()
+	fake.updateErr = status.Error(codes.DeadlineExceeded, "vpp-agent stuck")
+	useFakeVppAgent(t, fake)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2"})
+
+	// The reconcile keeps pushing while injections are rejected.
+	if err := vl3ReconcileRoutesInVpp(context.Background(), nil, newReconcileBudget()); err != nil {
+		t.Fatal(err)
+	}
+	if err := vppAgentPushBreaker.allow(); !errors.Is(err, errDataplaneUnhealthy) {
+		t.Fatal("expected the breaker open after the failed reconcile push, received", err)
+	}
+	fake.mu.Lock()
+	fake.updateErr = nil
+	fake.mu.Unlock()
+	if err := vl3ReconcileRoutesInVpp(context.Background(), nil, newReconcileBudget()); err != nil {
+		t.Fatal(err)
+	}
+	if err := vppAgentPushBreaker.allow(); err != nil {
+		t.Error("expected the breaker closed by the successful reconcile push, received", err)
+	}
+}
+
+func TestVppAgentBreakerIgnoresRejectedConfigs(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("VPP_AGENT_BREAKER_THRESHOLD", "1")
+	useVppAgentBreaker(t)
+
+	vppAgentPushBreaker.record(status.Error(codes.InvalidArgument, "invalid route"))
+	if err := vppAgentPushBreaker.allow(); err != nil {
+		t.Error("expected a rejected config not to open the breaker, received", err)
+	}
+	t.Setenv("VPP_AGENT_BREAKER_THRESHOLD", "")
+	vppAgentPushBreaker.record(status.Error(codes.Unavailable, "vpp-agent down"))
+	if err := vppAgentPushBreaker.allow(); err != nil {
+		t.Error("expected the breaker disabled without a threshold, received", err)
+	}
+}
+
+func TestReconcileWhileBreakerOpen(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_AGENT_MAX_RETRIES", "0")
+	t.Setenv("VPP_AGENT_BREAKER_THRESHOLD", "1")
+	t.Setenv("VPP_AGENT_BREAKER_PROBE_INTERVAL", "1h")
+	t.Setenv("RECONCILE_MODE", reconcileModeInline)
+	resetRouteMap(t)
+	skipReconcile(t)
+	useVppAgentBreaker(t)
+
+	fake := newFakeVppAgent()
+	fake.updateErr = status.Error(codes.Unavailable, "vpp-agent down")
+	useFakeVppAgent(t, fake)
+	if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err == nil {
+		t.Fatal("expected the injection to fail")
+	}
+	if !vppAgentPushBreaker.isOpen() {
+		t.Fatal("expected the breaker open")
+	}
+	// The route is recorded for the reconcile to apply it once the vpp-agent recovers.
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2"})
+
+	// The reconcile keeps retrying while injections are rejected, although the reconcile is not due.
+	fake.mu.Lock()
+	updateCalls := fake.updateCalls
+	fake.mu.Unlock()
+	reconcileWhileBreakerOpen()
+	fake.mu.Lock()
+	if fake.updateCalls == updateCalls {
+		t.Error("expected the reconcile to push the route while the breaker is open")
+	}
+	fake.updateErr = nil
+	fake.mu.Unlock()
+	reconcileWhileBreakerOpen()
+	if vppAgentPushBreaker.isOpen() {
+		t.Fatal("expected the breaker closed by the reconcile push")
+	}
+	if state := vppRouteState(fake, "10.1.0.0/16"); !sameNextHops(state, []string{"192.168.0.2"}) {
+		t.Error("configured next hops: expected [192.168.0.2] received", state)
+	}
+
+	// Once injections are accepted again, the reconcile is left to the reconcile mode.
+	fake.mu.Lock()
+	updateCalls = fake.updateCalls
+	fake.config.Routes = nil
+	fake.mu.Unlock()
+	reconcileWhileBreakerOpen()
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if fake.updateCalls != updateCalls {
+		t.Error("expected no reconcile with the breaker closed")
+	}
+}
@@ -0,0 +1,201 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+)
+
+// vppRouteCleanup is the outcome of converging the vpp entries of a route, by next hop.
+type vppRouteCleanup struct {
+	kept    []string
+	removed []string
+	added   []string
+	updated []string
+}
+
+// desiredVppNextHops returns the next hops the vpp entries to the remote subnet should go through: the
+// healthy next hops recorded in the slice route map, none if the route is not injected.
+func desiredVppNextHops(remoteSubnet string) []string {
+	cached, ok := remoteSubnetRouteMap.Load(remoteSubnet)
+	if !ok {
+		return nil
+	}
+	return healthyNextHops(cached.([]string))
+}
+
+// vppNextHopDiff compares the vpp entries of a route with the desired next hops.
+type vppNextHopDiff struct {
+	// stale holds the entries via next hops that are not desired.
+	stale []*vpp.Route
+	// kept holds the desired next hops whose entry is as requested.
+	kept []string
+	// missing holds the desired next hops that have no entry.
+	missing []string
+	// updated holds the desired next hops whose entry has other path attributes than requested.
+	updated []string
+}
+
+// converged returns true if the vpp entries of the route are the desired ones.
+func (d *vppNextHopDiff) converged() bool {
+	return len(d.stale) == 0 && len(d.missing) == 0 && len(d.updated) == 0
+}
+
+// correction returns what the reconcile corrects on the route, the stale entries first.
+func (d *vppNextHopDiff) correction() string {
+	switch {
+	case len(d.stale) > 0:
+		return routeCorrectionStale
+	case len(d.missing) > 0:
+		return routeCorrectionMissing
+	}
+	return routeCorrectionWeight
+}
+
+// diffVppNextHops compares the entries of the vpp config to the remote subnet with the desired next
+// hops and path attributes. Only the slice entries the sidecar configures are considered, and entries
+// are reported stale only if the slice routes are in a dedicated vrf.
+func diffVppNextHops(config *vpp.ConfigData, remoteSubnet string, desired []string, attrs vppRouteAttrs) *vppNextHopDiff {
+	diff := &vppNextHopDiff{stale: []*vpp.Route{}, kept: []string{}, missing: []string{}, updated: []string{}}
+	present := map[string]bool{}
+	matching := map[string]bool{}
+	dedicatedVrf := hasDedicatedVppSliceRouteVrf()
+	for _, route := range vppRoutesTo(config, remoteSubnet) {
+		if contains(desired, route.GetNextHopAddr()) {
+			present[route.GetNextHopAddr()] = true
+			if attrs.matches(route) {
+				matching[route.GetNextHopAddr()] = true
+			}
+			continue
+		}
+		if !dedicatedVrf {
+			continue
+		}
+		// The vpp-agent keys the entries, an entry listed twice is deleted once.
+		if len(missingVppRoutes([]*vpp.Route{route}, &vpp.ConfigData{Routes: diff.stale})) > 0 {
+			diff.stale = append(diff.stale, route)
+		}
+	}
+	for _, nextHopIP := range desired {
+		switch {
+		case matching[nextHopIP]:
+			diff.kept = append(diff.kept, nextHopIP)
+		case present[nextHopIP]:
+			diff.updated = append(diff.updated, nextHopIP)
+		default:
+			diff.missing = append(diff.missing, nextHopIP)
+		}
+	}
+	return diff
+}
+
+// sliceRouterConvergeVppRoute brings the vpp entries to the remote subnet back to the next hops recorded
+// in the slice route map, given the vpp config read before. vpp treats a route modify as an add, so the
+// entries of next hops replaced over time may accumulate as stale equal cost members. The stale entries
+// are deleted, the missing ones added and the ones with other path attributes than recorded configured
+// again, which the vpp-agent applies as a modify. The vpp config is then read again to verify that the
+// route converged. Routes that are not injected have no desired entries.
+func sliceRouterConvergeVppRoute(ctx context.Context, remoteSubnet string, config *vpp.ConfigData) (*vppRouteCleanup, error) {
+	desired := desiredVppNextHops(remoteSubnet)
+	attrs := loadVppRouteAttrs(remoteSubnet)
+	diff := diffVppNextHops(config, remoteSubnet, desired, attrs)
+	cleanup := &vppRouteCleanup{kept: diff.kept, removed: []string{}, added: []string{}, updated: []string{}}
+	if diff.converged() {
+		return cleanup, nil
+	}
+
+	if len(diff.stale) > 0 {
+		for _, route := range diff.stale {
+			cleanup.removed = append(cleanup.removed, route.GetNextHopAddr())
+		}
+		logger.GlobalLogger.Infof("Deleting stale vpp route entries. RemoteSubnet: %v, NextHops: %v, Desired: %v",
+			remoteSubnet, cleanup.removed, desired)
+		if err := sendConfigToVppAgent(ctx, &vpp.ConfigData{Routes: diff.stale}, true); err != nil {
+			return cleanup, err
+		}
+		vppRouteDeletesCounter.Add(float64(len(diff.stale)), vppRouteDeleteStale)
+	}
+	if len(diff.missing) > 0 || len(diff.updated) > 0 {
+		logger.GlobalLogger.Infof("Configuring vpp route entries. RemoteSubnet: %v, Missing: %v, Updated: %v, Weight: %v, Preference: %v",
+			remoteSubnet, diff.missing, diff.updated, attrs.weight, attrs.preference)
+		routes := []*vpp.Route{}
+		for _, nextHopIP := range append(append([]string{}, diff.missing...), diff.updated...) {
+			routes = append(routes, getVppConfig(remoteSubnet, nextHopIP, attrs).GetRoutes()...)
+		}
+		if err := sendConfigToVppAgent(ctx, &vpp.ConfigData{Routes: routes}, false); err != nil {
+			return cleanup, err
+		}
+		cleanup.added = diff.missing
+		cleanup.updated = diff.updated
+	}
+
+	config, err := getVppRoutesConfig()
+	if err != nil {
+		return cleanup, fmt.Errorf("failed to get vpp config to verify the route: %w", err)
+	}
+	if diff := diffVppNextHops(config, remoteSubnet, desired, attrs); !diff.converged() {
+		return cleanup, fmt.Errorf("%w: %v stale, %v missing and %v outdated entries to %v left", errVppConfigNotApplied,
+			len(diff.stale), len(diff.missing), len(diff.updated), remoteSubnet)
+	}
+	return cleanup, nil
+}
+
+// vl3ReconcileRoutesInVpp converges the vpp entries of the injected routes to the remote subnets
+// matching the filter, correcting at most the routes the budget allows. Routes suspended by the next
+// hop down or link down policies are left to them.
+func vl3ReconcileRoutesInVpp(ctx context.Context, filter prefixFilter, budget *reconcileBudget) error {
+	config, err := getVppRoutesConfig()
+	if err != nil {
+		return err
+	}
+
+	remoteSubnets := []string{}
+	remoteSubnetRouteMap.Range(func(key, value any) bool {
+		remoteSubnet := key.(string)
+		if filter.matches(remoteSubnet) && !isRouteSuspended(remoteSubnet) {
+			remoteSubnets = append(remoteSubnets, remoteSubnet)
+		}
+		return true
+	})
+	sort.Strings(remoteSubnets)
+
+	for _, remoteSubnet := range remoteSubnets {
+		desired := desiredVppNextHops(remoteSubnet)
+		diff := diffVppNextHops(config, remoteSubnet, desired, loadVppRouteAttrs(remoteSubnet))
+		if diff.converged() {
+			continue
+		}
+		if !budget.take() {
+			budget.deferCorrection(remoteSubnet)
+			break
+		}
+		correction := diff.correction()
+		_, err := sliceRouterConvergeVppRoute(ctx, remoteSubnet, config)
+		routeAudit.recordCorrection(remoteSubnet, desired, correction, err)
+		budget.recordCorrection(remoteSubnet, correction, err)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to converge vpp route entries: dst: %v, err: %v", remoteSubnet, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+const (
+	// The controller asked for the route to the remote subnet to be removed.
+	vppRouteDeleteExplicit = "delete"
+	// The route entry via an old next hop is removed before the entry via the new next hop is added,
+	// vpp would otherwise keep both as equal cost multipath routes.
+	vppRouteDeleteReplace = "replace"
+	// The next hop was reported down by the health checker.
+	vppRouteDeleteUnhealthy = "unhealthy"
+	// The routes injected by the sidecar are flushed on shutdown.
+	vppRouteDeleteFlush = "flush"
+	// The entry goes through a next hop the route no longer has, left behind by next hop changes.
+	vppRouteDeleteStale = "stale"
+)
+
+// sliceRouterDeleteRouteToDstInVpp removes every route entry to the remote subnet from vpp, one per
+// next hop recorded in the slice route map, and then the map entry. If a deletion fails, the next hops
+// still installed are kept in the map so that a later delete request retries them. Deleting a route
+// that is not in the map is not an error.
+func sliceRouterDeleteRouteToDstInVpp(ctx context.Context, remoteSubnet string) error {
+	cachedNextHopValue, routePresent := remoteSubnetRouteMap.Load(remoteSubnet)
+	if !routePresent {
+		logger.GlobalLogger.Infof("Route to delete is not installed. RemoteSubnet: %v", remoteSubnet)
+		return nil
+	}
+	cachedNextHopList := cachedNextHopValue.([]string)
+	for i, nextHopIP := range cachedNextHopList {
+		if err := vl3DeleteRouteInVpp(ctx, remoteSubnet, nextHopIP, vppRouteDeleteExplicit); err != nil {
+			logger.GlobalLogger.Errorf("Failed to delete route in vpp: %v", err)
+			remoteSubnetRouteMap.Store(remoteSubnet, cachedNextHopList[i:])
+			return err
+		}
+	}
+	remoteSubnetRouteMap.Delete(remoteSubnet)
+	return nil
+}
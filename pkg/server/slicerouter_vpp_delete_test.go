@@ -0,0 +1,123 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestVppRouteDelete(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	skipReconcile(t)
+
+	tests := []struct {
+		testName      string
+		cached        []string
+		nextHops      []string
+		deleteErr     error
+		expectErr     bool
+		expectCached  []string
+		expectVpp     []string
+		expectDeletes map[string]float64
+	}{
+		{
+			testName:      "explicit delete of the only route",
+			cached:        []string{"192.168.0.2"},
+			expectVpp:     []string{},
+			expectDeletes: map[string]float64{vppRouteDeleteExplicit: 1},
+		},
+		{
+			testName:      "explicit delete of a multipath route",
+			cached:        []string{"192.168.0.2", "192.168.0.6"},
+			expectVpp:     []string{},
+			expectDeletes: map[string]float64{vppRouteDeleteExplicit: 2},
+		},
+		{
+			testName:  "explicit delete of a route that is not installed",
+			expectVpp: []string{},
+		},
+		{
+			testName:     "explicit delete fails",
+			cached:       []string{"192.168.0.2"},
+			deleteErr:    errors.New("vpp-agent unavailable"),
+			expectErr:    true,
+			expectCached: []string{"192.168.0.2"},
+			expectVpp:    []string{"192.168.0.2"},
+		},
+		{
+			testName:      "next hop change deletes the old next hop before the add",
+			cached:        []string{"192.168.0.2"},
+			nextHops:      []string{"192.168.0.6"},
+			expectCached:  []string{"192.168.0.6"},
+			expectVpp:     []string{"192.168.0.6"},
+			expectDeletes: map[string]float64{vppRouteDeleteReplace: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			resetRouteMap(t)
+			fake := newFakeVppAgent()
+			for _, nextHopIP := range tt.cached {
+				fake.config.Routes = append(fake.config.Routes, getVppConfig("10.1.0.0/16", nextHopIP, vppRouteAttrs{}).Routes...)
+			}
+			fake.deleteErr = tt.deleteErr
+			useFakeVppAgent(t, fake)
+			if tt.cached != nil {
+				remoteSubnetRouteMap.Store("10.1.0.0/16", tt.cached)
+			}
+			vppRouteDeletesCounter.Reset()
+
+			err := sliceRouterInjectRoute("10.1.0.0/16", tt.nextHops)
+			if tt.expectErr {
+				var rErr *routeError
+				if !errors.As(err, &rErr) || rErr.reason != routeErrorDataplane {
+					t.Fatal("error: expected", routeErrorDataplane, "received", err)
+				}
+			} else if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+
+			cached, ok := remoteSubnetRouteMap.Load("10.1.0.0/16")
+			if tt.expectCached == nil && ok {
+				t.Error("route map: expected no entry, received", cached)
+			}
+			if tt.expectCached != nil && (!ok || !sameNextHops(cached.([]string), tt.expectCached)) {
+				t.Error("route map: expected", tt.expectCached, "received", cached)
+			}
+
+			installed, err := vl3GetInstalledNextHopsInVpp("10.1.0.0/16")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameNextHops(installed, tt.expectVpp) {
+				t.Error("vpp routes: expected", tt.expectVpp, "received", installed)
+			}
+
+			for _, reason := range []string{vppRouteDeleteExplicit, vppRouteDeleteReplace} {
+				if v := vppRouteDeletesCounter.Value(reason); v != tt.expectDeletes[reason] {
+					t.Error("deletes with reason", reason, ": expected", tt.expectDeletes[reason], "received", v)
+				}
+			}
+		})
+	}
+}
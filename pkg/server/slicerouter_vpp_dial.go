@@ -0,0 +1,96 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+const defaultVppAgentKeepaliveTimeout = 20 * time.Second
+
+// getVppAgentKeepaliveTime returns how long a connection to the vpp-agent stays without activity before
+// it is pinged, read from the VPP_AGENT_KEEPALIVE_TIME env variable. Connections are not pinged by
+// default. The vpp-agent closes the connections pinging it more often than its keepalive policy allows,
+// 5 minutes by default.
+func getVppAgentKeepaliveTime() time.Duration {
+	return getEnvDuration("VPP_AGENT_KEEPALIVE_TIME", 0)
+}
+
+// getVppAgentKeepaliveTimeout returns how long a keepalive ping waits for its ack before the connection
+// is closed, read from the VPP_AGENT_KEEPALIVE_TIMEOUT env variable.
+func getVppAgentKeepaliveTimeout() time.Duration {
+	return getEnvDuration("VPP_AGENT_KEEPALIVE_TIMEOUT", defaultVppAgentKeepaliveTimeout)
+}
+
+// getVppAgentMaxMessageSize returns the largest message in bytes sent to or received from the vpp-agent,
+// read from the VPP_AGENT_MAX_MESSAGE_SIZE env variable. Zero, the default, keeps the grpc limits.
+func getVppAgentMaxMessageSize() int {
+	size, err := strconv.Atoi(os.Getenv("VPP_AGENT_MAX_MESSAGE_SIZE"))
+	if err != nil || size < 0 {
+		return 0
+	}
+	return size
+}
+
+// vppAgentTransportCredentials returns the credentials the connections to the vpp-agent are secured
+// with. The vpp-agent runs in the same pod and serves plaintext grpc.
+func vppAgentTransportCredentials() credentials.TransportCredentials {
+	return insecure.NewCredentials()
+}
+
+// vppAgentDialOptions returns the options of the connections to the vpp-agent, so that the credentials,
+// keepalive and message sizes of all of them are configured in one place.
+func vppAgentDialOptions() []grpc.DialOption {
+	callOptions := vppAgentCallOptions()
+	if size := getVppAgentMaxMessageSize(); size > 0 {
+		callOptions = append(callOptions, grpc.MaxCallRecvMsgSize(size), grpc.MaxCallSendMsgSize(size))
+	}
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(vppAgentTransportCredentials()),
+		grpc.WithDefaultCallOptions(callOptions...),
+	}
+	if keepaliveTime := getVppAgentKeepaliveTime(); keepaliveTime > 0 {
+		options = append(options, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    keepaliveTime,
+			Timeout: getVppAgentKeepaliveTimeout(),
+		}))
+	}
+	return options
+}
+
+// newVppAgentConn creates a connection to the vpp-agent at the endpoint with the vpp-agent dial options,
+// followed by the extra options. Like grpc.NewClient, which the vendored grpc predates, the connection
+// does not block: it is established by the first call, which fails if the vpp-agent is unreachable. The
+// endpoint is used as the address as is, without name resolution by grpc.
+func newVppAgentConn(endpoint string, extra ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return grpc.Dial(endpoint, append(vppAgentDialOptions(), extra...)...)
+}
+
+// NewSidecarConn creates a connection to the sidecar GRPC server at the endpoint, which runs in the
+// same pod as the vpp-agent and is dialled with the same options. Like newVppAgentConn, the connection
+// does not block.
+func NewSidecarConn(endpoint string) (*grpc.ClientConn, error) {
+	return newVppAgentConn(endpoint)
+}
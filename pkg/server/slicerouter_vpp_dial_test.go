@@ -0,0 +1,135 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestVppAgentDialOptions(t *testing.T) {
+	config := largeVppConfig(0, 2000)
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	configurator.RegisterConfiguratorServiceServer(srv, &vppConfigServer{config: config})
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	tests := []struct {
+		testName       string
+		maxMessageSize string
+		keepaliveTime  string
+		errCode        codes.Code
+	}{
+		{"grpc defaults", "", "", codes.OK},
+		{"config larger than the max message size", "16384", "", codes.ResourceExhausted},
+		{"config within the max message size", "1048576", "", codes.OK},
+		{"keepalive", "", "1m", codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_AGENT_MAX_MESSAGE_SIZE", tt.maxMessageSize)
+			t.Setenv("VPP_AGENT_KEEPALIVE_TIME", tt.keepaliveTime)
+			conn, err := newVppAgentConn("bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return listener.Dial()
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			_, err = configurator.NewConfiguratorServiceClient(conn).Get(context.Background(), &configurator.GetRequest{})
+			if status.Code(err) != tt.errCode {
+				t.Error("error code: expected", tt.errCode, "received", err)
+			}
+		})
+	}
+}
+
+func TestNewVppAgentConnIsLazy(t *testing.T) {
+	// Nothing listens on the endpoint, the connection is still created and the call fails instead.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint := listener.Addr().String()
+	listener.Close()
+
+	conn, err := newVppAgentConn(endpoint)
+	if err != nil {
+		t.Fatal("expected the connection to be created, received", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = configurator.NewConfiguratorServiceClient(conn).Get(ctx, &configurator.GetRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Error("expected", codes.Unavailable, "received", err)
+	}
+}
+
+func TestNewSidecarConn(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := NewSidecarConn(listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// The server starts after the connection is created, the call waits for it.
+	srv := grpc.NewServer(ServerOptions()...)
+	pb.RegisterSliceRouterSidecarServiceServer(srv, &SliceRouterSidecar{})
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	capabilities, err := pb.NewSliceRouterSidecarServiceClient(conn).GetCapabilities(ctx, &emptypb.Empty{}, grpc.WaitForReady(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capabilities.GetDataplaneMode() != SliceRouterDataplaneKernel {
+		t.Error("expected", SliceRouterDataplaneKernel, "received", capabilities.GetDataplaneMode())
+	}
+}
+
+func TestGetVppAgentMaxMessageSize(t *testing.T) {
+	for value, expected := range map[string]int{"": 0, "1048576": 1048576, "-1": 0, "big": 0} {
+		t.Setenv("VPP_AGENT_MAX_MESSAGE_SIZE", value)
+		if size := getVppAgentMaxMessageSize(); size != expected {
+			t.Error("VPP_AGENT_MAX_MESSAGE_SIZE", value, "expected", expected, "received", size)
+		}
+	}
+}
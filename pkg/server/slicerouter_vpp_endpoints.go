@@ -0,0 +1,169 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Endpoint of the vpp-agent running in the slice router pod.
+	defaultVppAgentEndpoint = "localhost:9113"
+	// Default number of consecutive failed calls after which the next vpp-agent endpoint is used.
+	defaultVppAgentFailoverThreshold = 3
+)
+
+// vppAgentEndpointList is the ordered list of vpp-agent endpoints along with the one in use. Calls go to
+// the active endpoint and move to the next one, wrapping around, once it failed persistently.
+type vppAgentEndpointList struct {
+	mu sync.Mutex
+	// endpoints is read from VPP_AGENT_ENDPOINTS on first use if nil.
+	endpoints []string
+	index     int
+	failures  int
+	// unreachable is set when the last call to the endpoint in use found it unreachable.
+	unreachable bool
+}
+
+var vppAgentEndpoints = &vppAgentEndpointList{}
+
+// getVppAgentEndpoints returns the vpp-agent endpoints in order of preference, read from the
+// comma-separated VPP_AGENT_ENDPOINTS env variable.
+func getVppAgentEndpoints() []string {
+	endpoints := []string{}
+	for _, endpoint := range strings.Split(os.Getenv("VPP_AGENT_ENDPOINTS"), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		return []string{defaultVppAgentEndpoint}
+	}
+	return endpoints
+}
+
+// getVppAgentFailoverThreshold returns the number of consecutive failed calls to a vpp-agent endpoint
+// after which the next endpoint is used, read from the VPP_AGENT_FAILOVER_THRESHOLD env variable.
+func getVppAgentFailoverThreshold() int {
+	threshold, err := strconv.Atoi(os.Getenv("VPP_AGENT_FAILOVER_THRESHOLD"))
+	if err != nil || threshold <= 0 {
+		return defaultVppAgentFailoverThreshold
+	}
+	return threshold
+}
+
+// active returns the position and address of the endpoint in use.
+func (l *vppAgentEndpointList) active() (int, string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.endpoints == nil {
+		l.endpoints = getVppAgentEndpoints()
+	}
+	return l.index, l.endpoints[l.index]
+}
+
+// activeEndpoint returns the address of the endpoint in use.
+func (l *vppAgentEndpointList) activeEndpoint() string {
+	_, endpoint := l.active()
+	return endpoint
+}
+
+// reachable returns false if the last call to the endpoint in use found it unreachable.
+func (l *vppAgentEndpointList) reachable() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.unreachable
+}
+
+// recordSuccess resets the failures of the endpoint if it is still in use.
+func (l *vppAgentEndpointList) recordSuccess(index int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index == l.index {
+		l.failures = 0
+		l.unreachable = false
+	}
+}
+
+// recordFailure counts a failed call to the endpoint and fails over to the next endpoint once the
+// failures reach the threshold. Failures of an endpoint that is no longer in use are ignored.
+func (l *vppAgentEndpointList) recordFailure(index int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index != l.index {
+		return
+	}
+	l.unreachable = true
+	if len(l.endpoints) < 2 {
+		return
+	}
+	l.failures++
+	if l.failures < getVppAgentFailoverThreshold() {
+		return
+	}
+	failed := l.endpoints[l.index]
+	l.index = (l.index + 1) % len(l.endpoints)
+	l.failures = 0
+	vppAgentFailoversCounter.Inc()
+	logger.GlobalLogger.Errorf("Vpp agent %v unreachable, failing over to %v", failed, l.endpoints[l.index])
+}
+
+// record reports the outcome of a call to the endpoint. Only an unreachable vpp-agent counts as a
+// failure, the vpp-agent rejecting a config is not a reason to change endpoints.
+func (l *vppAgentEndpointList) record(index int, err error) {
+	if status.Code(err) == codes.Unavailable {
+		l.recordFailure(index)
+		return
+	}
+	l.recordSuccess(index)
+}
+
+// failoverVppAgentClient is a configurator client of a vpp-agent endpoint reporting the outcome of its
+// calls to the endpoint list.
+type failoverVppAgentClient struct {
+	configurator.ConfiguratorServiceClient
+	index int
+}
+
+func (c *failoverVppAgentClient) Get(ctx context.Context, in *configurator.GetRequest, opts ...grpc.CallOption) (*configurator.GetResponse, error) {
+	resp, err := c.ConfiguratorServiceClient.Get(ctx, in, opts...)
+	vppAgentEndpoints.record(c.index, err)
+	return resp, err
+}
+
+func (c *failoverVppAgentClient) Update(ctx context.Context, in *configurator.UpdateRequest, opts ...grpc.CallOption) (*configurator.UpdateResponse, error) {
+	resp, err := c.ConfiguratorServiceClient.Update(ctx, in, opts...)
+	vppAgentEndpoints.record(c.index, err)
+	return resp, err
+}
+
+func (c *failoverVppAgentClient) Delete(ctx context.Context, in *configurator.DeleteRequest, opts ...grpc.CallOption) (*configurator.DeleteResponse, error) {
+	resp, err := c.ConfiguratorServiceClient.Delete(ctx, in, opts...)
+	vppAgentEndpoints.record(c.index, err)
+	return resp, err
+}
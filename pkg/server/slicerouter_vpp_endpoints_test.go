@@ -0,0 +1,116 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// useFakeVppAgentEndpoints makes the sidecar talk to the fake vpp-agents through the endpoint list for
+// the duration of the test.
+func useFakeVppAgentEndpoints(t *testing.T, agents map[string]*fakeVppAgent) {
+	t.Helper()
+	origEndpoints, origDial := vppAgentEndpoints, dialVppAgentEndpoint
+	vppAgentEndpoints = &vppAgentEndpointList{}
+	dialVppAgentEndpoint = func(endpoint string) (configurator.ConfiguratorServiceClient, func(), error) {
+		agent, ok := agents[endpoint]
+		if !ok {
+			return nil, nil, errors.New("unknown endpoint")
+		}
+		return agent, func() {}, nil
+	}
+	t.Cleanup(func() {
+		vppAgentEndpoints, dialVppAgentEndpoint = origEndpoints, origDial
+	})
+}
+
+func TestVppAgentEndpointFailover(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_AGENT_FAILOVER_THRESHOLD", "2")
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	rejected := status.Error(codes.InvalidArgument, "invalid config")
+
+	tests := []struct {
+		testName  string
+		endpoints string
+		// primaryErr is returned by the vpp-agent at the first endpoint.
+		primaryErr error
+		calls      int
+		// expected endpoint in use after the calls
+		expectedEndpoint string
+		expectedErr      bool
+	}{
+		{"default endpoint", "", nil, 3, defaultVppAgentEndpoint, false},
+		{"primary healthy", "primary:9113,secondary:9113", nil, 3, "primary:9113", false},
+		{"below the threshold", "primary:9113,secondary:9113", unavailable, 1, "primary:9113", true},
+		{"failover to the secondary", "primary:9113, secondary:9113", unavailable, 2, "secondary:9113", true},
+		{"rejected config is not a failure", "primary:9113,secondary:9113", rejected, 3, "primary:9113", true},
+		{"single endpoint is kept", "primary:9113", unavailable, 3, "primary:9113", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_AGENT_ENDPOINTS", tt.endpoints)
+			primary := newFakeVppAgent()
+			primary.getErr = tt.primaryErr
+			agents := map[string]*fakeVppAgent{
+				defaultVppAgentEndpoint: primary,
+				"primary:9113":          primary,
+				"secondary:9113":        newFakeVppAgent(),
+			}
+			useFakeVppAgentEndpoints(t, agents)
+			failovers := vppAgentFailoversCounter.Value()
+
+			var err error
+			for i := 0; i < tt.calls; i++ {
+				_, err = vl3GetVppConfigJSON()
+			}
+			if (err != nil) != tt.expectedErr {
+				t.Error("last call error: expected", tt.expectedErr, "received", err)
+			}
+			if endpoint := vppAgentEndpoints.activeEndpoint(); endpoint != tt.expectedEndpoint {
+				t.Fatal("endpoint: expected", tt.expectedEndpoint, "received", endpoint)
+			}
+			if tt.expectedEndpoint != "secondary:9113" {
+				return
+			}
+			if v := vppAgentFailoversCounter.Value() - failovers; v != 1 {
+				t.Error("failovers: expected 1, received", v)
+			}
+			// The next calls go to the secondary and the summary reports it.
+			summary, err := sliceRouterGetVppConfigSummary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary.GetVppAgentEndpoint() != "secondary:9113" {
+				t.Error("summary endpoint: expected secondary:9113, received", summary.GetVppAgentEndpoint())
+			}
+			if bundle := sliceRouterGetSupportBundle(); bundle.GetVppAgentEndpoint() != "secondary:9113" {
+				t.Error("support bundle endpoint: expected secondary:9113, received", bundle.GetVppAgentEndpoint())
+			}
+		})
+	}
+}
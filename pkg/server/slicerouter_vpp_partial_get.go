@@ -0,0 +1,124 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// Field numbers of the path from a configurator GetResponse to the vpp interfaces.
+const (
+	getResponseConfigField   protowire.Number = 1
+	configVppConfigField     protowire.Number = 1
+	vppConfigInterfacesField protowire.Number = 10
+)
+
+// errMalformedVppConfig is returned when the wire format of a vpp config cannot be parsed.
+var errMalformedVppConfig = errors.New("Malformed vpp config")
+
+// vppInterfacesCodec is the grpc codec of the vpp-agent Get calls that only need the vpp interfaces.
+// The configurator Get has no way to scope the config returned, so the codec skips over the rest of the
+// config in the wire format instead of unmarshalling it. Only the interfaces are allocated, which keeps
+// the reads cheap on vpp-agents holding a large number of routes. Other messages are handled like the
+// default proto codec.
+type vppInterfacesCodec struct{}
+
+func (vppInterfacesCodec) Marshal(v interface{}) ([]byte, error) {
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (vppInterfacesCodec) Unmarshal(data []byte, v interface{}) error {
+	resp, ok := v.(*configurator.GetResponse)
+	if !ok {
+		return proto.Unmarshal(data, v.(proto.Message))
+	}
+	intfs, err := unmarshalVppInterfaces(data)
+	if err != nil {
+		return err
+	}
+	resp.Reset()
+	resp.Config = &configurator.Config{VppConfig: &vpp.ConfigData{Interfaces: intfs}}
+	return nil
+}
+
+// Name is the name of the default proto codec so that the vpp-agent decodes the requests as usual.
+func (vppInterfacesCodec) Name() string {
+	return "proto"
+}
+
+// vppGetInterfacesOnly is the call option of the vpp-agent Get calls that only read the vpp interfaces.
+// The response holds no other part of the config, it must not be used to summarize the vpp config.
+func vppGetInterfacesOnly() grpc.CallOption {
+	return grpc.ForceCodec(vppInterfacesCodec{})
+}
+
+// unmarshalVppInterfaces returns the vpp interfaces of a configurator GetResponse in wire format.
+func unmarshalVppInterfaces(data []byte) ([]*vpp.Interface, error) {
+	intfs := []*vpp.Interface{}
+	err := forEachWireField(data, getResponseConfigField, func(config []byte) error {
+		return forEachWireField(config, configVppConfigField, func(vppConfig []byte) error {
+			return forEachWireField(vppConfig, vppConfigInterfacesField, func(intfData []byte) error {
+				intf := &vpp.Interface{}
+				if err := proto.Unmarshal(intfData, intf); err != nil {
+					return err
+				}
+				intfs = append(intfs, intf)
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return intfs, nil
+}
+
+// forEachWireField calls fn with the value of every length delimited field of the message with the given
+// field number, in order, skipping the other fields.
+func forEachWireField(data []byte, field protowire.Number, fn func([]byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return errMalformedVppConfig
+		}
+		data = data[n:]
+		if num == field && typ == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return errMalformedVppConfig
+			}
+			if err := fn(value); err != nil {
+				return err
+			}
+			data = data[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return errMalformedVppConfig
+		}
+		data = data[n:]
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// largeVppConfig returns a vpp config holding the given number of nsm interfaces and routes.
+func largeVppConfig(interfaces, routes int) *configurator.GetResponse {
+	config := &vpp.ConfigData{}
+	for i := 0; i < interfaces; i++ {
+		config.Interfaces = append(config.Interfaces, &vpp.Interface{
+			Name:        fmt.Sprintf("client-%d", i),
+			Enabled:     true,
+			IpAddresses: []string{fmt.Sprintf("10.%d.%d.1/30", i/256, i%256)},
+		})
+	}
+	for i := 0; i < routes; i++ {
+		config.Routes = append(config.Routes, &vpp.Route{
+			Type:        vpp_l3.Route_INTER_VRF,
+			DstNetwork:  fmt.Sprintf("172.%d.%d.0/24", i/256%256, i%256),
+			NextHopAddr: "192.168.0.2",
+		})
+	}
+	return &configurator.GetResponse{Config: &configurator.Config{VppConfig: config}}
+}
+
+// vppConfigServer is a vpp-agent configurator server returning a fixed config.
+type vppConfigServer struct {
+	configurator.UnimplementedConfiguratorServiceServer
+	config *configurator.GetResponse
+}
+
+func (s *vppConfigServer) Get(ctx context.Context, in *configurator.GetRequest) (*configurator.GetResponse, error) {
+	return s.config, nil
+}
+
+func TestVppGetInterfacesOnly(t *testing.T) {
+	config := largeVppConfig(3, 100)
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	configurator.RegisterConfiguratorServiceServer(srv, &vppConfigServer{config: config})
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	conn, err := newVppAgentConn("bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := configurator.NewConfiguratorServiceClient(conn)
+
+	resp, err := client.Get(context.Background(), &configurator.GetRequest{}, vppGetInterfacesOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &configurator.GetResponse{Config: &configurator.Config{
+		VppConfig: &vpp.ConfigData{Interfaces: config.GetConfig().GetVppConfig().GetInterfaces()},
+	}}
+	if !proto.Equal(resp, expected) {
+		t.Error("expected the interfaces only, received", resp)
+	}
+
+	// The other calls of the connection decode the whole config.
+	resp, err = client.Get(context.Background(), &configurator.GetRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(resp, config) {
+		t.Error("expected the whole config, received", resp)
+	}
+}
+
+func TestUnmarshalVppInterfaces(t *testing.T) {
+	data, err := proto.Marshal(largeVppConfig(2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	intfs, err := unmarshalVppInterfaces(data)
+	if err != nil || len(intfs) != 2 {
+		t.Fatal("expected 2 interfaces, received", intfs, err)
+	}
+	if _, err := unmarshalVppInterfaces(data[:len(data)-1]); err == nil {
+		t.Error("expected an error for a truncated config")
+	}
+	intfs, err = unmarshalVppInterfaces(nil)
+	if err != nil || len(intfs) != 0 {
+		t.Error("expected no interfaces in an empty config, received", intfs, err)
+	}
+}
+
+// BenchmarkVppConfigGetDecode compares decoding the whole vpp config with decoding its interfaces only,
+// on a vpp-agent with a few nsm interfaces and a large number of routes.
+func BenchmarkVppConfigGetDecode(b *testing.B) {
+	data, err := proto.Marshal(largeVppConfig(64, 20000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	codecs := []struct {
+		name  string
+		codec interface {
+			Unmarshal([]byte, interface{}) error
+		}
+	}{
+		{"whole config", protoCodec{}},
+		{"interfaces only", vppInterfacesCodec{}},
+	}
+	for _, c := range codecs {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				resp := &configurator.GetResponse{}
+				if err := c.codec.Unmarshal(data, resp); err != nil {
+					b.Fatal(err)
+				}
+				if len(resp.GetConfig().GetVppConfig().GetInterfaces()) != 64 {
+					b.Fatal("unexpected interfaces", len(resp.GetConfig().GetVppConfig().GetInterfaces()))
+				}
+			}
+		})
+	}
+}
+
+// protoCodec decodes messages like the default grpc proto codec.
+type protoCodec struct{}
+
+func (protoCodec) Unmarshal(data []byte, v interface{}) error {
+	return proto.Unmarshal(data, v.(proto.Message))
+}
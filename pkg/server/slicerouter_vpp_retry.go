@@ -0,0 +1,135 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Default number of times a route operation is retried when the vpp-agent is unreachable.
+	defaultVppAgentMaxRetries = 2
+	// Default number of retries the route operations can use at once, and the time it takes to regain
+	// one. Together they bound the rate of retries sent to a vpp-agent that keeps failing.
+	defaultVppAgentRetryBudget       = 10
+	defaultVppAgentRetryBudgetRefill = time.Second
+)
+
+// vppAgentRetryBackoff is the wait before the first retry of a route operation, later retries wait
+// longer. It is a variable so that tests do not have to wait.
+var vppAgentRetryBackoff = 200 * time.Millisecond
+
+// retryBudget is a token bucket shared by the route operations. Every retry takes a token, the tokens
+// are regained at a fixed rate up to the size of the budget. Once the budget is exhausted, operations
+// fail on their first error instead of retrying.
+type retryBudget struct {
+	mu     sync.Mutex
+	size   float64
+	tokens float64
+	// refill is the time it takes to regain one token.
+	refill time.Duration
+	last   time.Time
+	now    func() time.Time
+}
+
+// newRetryBudget returns a full retry budget of size tokens.
+func newRetryBudget(size int, refill time.Duration) *retryBudget {
+	return &retryBudget{size: float64(size), tokens: float64(size), refill: refill, last: time.Now(), now: time.Now}
+}
+
+// take returns true and uses a token if the budget allows a retry.
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	b.tokens += float64(now.Sub(b.last)) / float64(b.refill)
+	if b.tokens > b.size {
+		b.tokens = b.size
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+var (
+	vppAgentRetryBudgetMu sync.Mutex
+	// vppAgentRetryBudget is created on first use if nil.
+	vppAgentRetryBudget *retryBudget
+)
+
+// getVppAgentRetryBudget returns the retry budget of the route operations sent to the vpp-agent. Its
+// size is read from the VPP_AGENT_RETRY_BUDGET env variable and the time to regain a retry from
+// VPP_AGENT_RETRY_BUDGET_REFILL on first use.
+func getVppAgentRetryBudget() *retryBudget {
+	vppAgentRetryBudgetMu.Lock()
+	defer vppAgentRetryBudgetMu.Unlock()
+	if vppAgentRetryBudget == nil {
+		size, err := strconv.Atoi(os.Getenv("VPP_AGENT_RETRY_BUDGET"))
+		if err != nil || size < 0 {
+			size = defaultVppAgentRetryBudget
+		}
+		vppAgentRetryBudget = newRetryBudget(size, getEnvDuration("VPP_AGENT_RETRY_BUDGET_REFILL", defaultVppAgentRetryBudgetRefill))
+	}
+	return vppAgentRetryBudget
+}
+
+// getVppAgentMaxRetries returns the number of times a route operation is retried when the vpp-agent is
+// unreachable, read from the VPP_AGENT_MAX_RETRIES env variable.
+func getVppAgentMaxRetries() int {
+	retries, err := strconv.Atoi(os.Getenv("VPP_AGENT_MAX_RETRIES"))
+	if err != nil || retries < 0 {
+		return defaultVppAgentMaxRetries
+	}
+	return retries
+}
+
+// withVppAgentRetries runs the route operation and retries it while the vpp-agent is unreachable, up to
+// the max retries and as long as the shared retry budget allows it. Other errors are returned at once,
+// retrying a config the vpp-agent rejected does not help.
+func withVppAgentRetries(ctx context.Context, op func() error) error {
+	maxRetries := getVppAgentMaxRetries()
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || status.Code(err) != codes.Unavailable || attempt >= maxRetries {
+			return err
+		}
+		if !getVppAgentRetryBudget().take() {
+			vppAgentRetriesCounter.Inc("throttled")
+			logger.GlobalLogger.Errorf("Vpp agent retry budget exhausted, not retrying: %v", err)
+			return err
+		}
+		vppAgentRetriesCounter.Inc("retried")
+		logger.GlobalLogger.Debugf("Vpp agent unreachable, retrying. Attempt: %v, Err: %v", attempt+1, err)
+		select {
+		case <-time.After(vppAgentRetryBackoff * time.Duration(attempt+1)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// useVppAgentRetryBudget makes the route operations share the retry budget, without waiting between
+// retries, for the duration of the test.
+func useVppAgentRetryBudget(t *testing.T, b *retryBudget) {
+	t.Helper()
+	origBudget, origBackoff := vppAgentRetryBudget, vppAgentRetryBackoff
+	vppAgentRetryBudget, vppAgentRetryBackoff = b, 0
+	t.Cleanup(func() {
+		vppAgentRetryBudget, vppAgentRetryBackoff = origBudget, origBackoff
+	})
+}
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Now()
+	b := newRetryBudget(2, time.Second)
+	b.now, b.last = func() time.Time { return now }, now
+
+	for i, expected := range []bool{true, true, false} {
+		if ok := b.take(); ok != expected {
+			t.Fatal("take", i, "expected", expected, "received", ok)
+		}
+	}
+	// A token is regained every refill interval, up to the size of the budget.
+	now = now.Add(time.Second)
+	if !b.take() || b.take() {
+		t.Error("expected a single retry after one refill interval")
+	}
+	now = now.Add(time.Hour)
+	if !b.take() || !b.take() || b.take() {
+		t.Error("expected the budget to refill up to its size")
+	}
+}
+
+func TestVppAgentRetryBudget(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("VPP_AGENT_MAX_RETRIES", "2")
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	rejected := status.Error(codes.InvalidArgument, "invalid config")
+
+	tests := []struct {
+		testName       string
+		budget         int
+		updateErr      error
+		updateErrCalls int
+		injections     int
+		expectedErr    bool
+		// expected Update calls received by the vpp-agent over all the injections
+		expectedCalls     int
+		expectedThrottled float64
+	}{
+		{"transient failure is retried", 10, unavailable, 1, 1, false, 2, 0},
+		{"rejected config is not retried", 10, rejected, 0, 1, true, 1, 0},
+		{"retries bounded per operation", 10, unavailable, 0, 1, true, 3, 0},
+		{"retries throttled once the budget is exhausted", 2, unavailable, 0, 3, true, 5, 2},
+		{"no budget, no retries", 0, unavailable, 0, 2, true, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			// The budget is not refilled during the test.
+			useVppAgentRetryBudget(t, newRetryBudget(tt.budget, time.Hour))
+			vppAgentRetriesCounter.Reset()
+			fake := newFakeVppAgent()
+			fake.updateErr = tt.updateErr
+			fake.updateErrCalls = tt.updateErrCalls
+			useFakeVppAgent(t, fake)
+
+			var err error
+			for i := 0; i < tt.injections; i++ {
+				err = vl3InjectRouteInVpp(context.Background(), "10.1.0.0/16", "192.168.0.2", vppRouteAttrs{})
+			}
+			if (err != nil) != tt.expectedErr {
+				t.Error("last injection error: expected", tt.expectedErr, "received", err)
+			}
+			if fake.updateCalls != tt.expectedCalls {
+				t.Error("update calls: expected", tt.expectedCalls, "received", fake.updateCalls)
+			}
+			if v := vppAgentRetriesCounter.Value("throttled"); v != tt.expectedThrottled {
+				t.Error("throttled retries: expected", tt.expectedThrottled, "received", v)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+)
+
+// maxVppPathAttr is the largest weight or preference vpp can store for a route path.
+const maxVppPathAttr = 255
+
+// vppRouteAttrs are the path attributes of the vpp entries of a route. Zero values are left unset so
+// that vpp applies its defaults: equal cost paths of the same preference.
+type vppRouteAttrs struct {
+	// weight is used for unequal cost load balancing between the entries of the route.
+	weight uint32
+	// preference selects the entries that forward, the lower the preferred. Entries of a worse
+	// preference only forward when the better ones are gone.
+	preference uint32
+}
+
+// remoteSubnetVppAttrsMap holds the path attributes of the routes injected with a weight or preference,
+// keyed by remote subnet. Routes without an entry leave both unset.
+var remoteSubnetVppAttrsMap sync.Map
+
+// checkVppRouteAttrs returns an error if the weight or preference do not fit in a vpp route path, or
+// are set outside of the vpp dataplane.
+func checkVppRouteAttrs(attrs vppRouteAttrs) error {
+	if attrs == (vppRouteAttrs{}) {
+		return nil
+	}
+	if getSliceRouterDataplaneMode() != SliceRouterDataplaneVpp {
+		return fmt.Errorf("route weight and preference are only supported in the vpp dataplane")
+	}
+	if attrs.weight > maxVppPathAttr || attrs.preference > maxVppPathAttr {
+		return fmt.Errorf("route weight %v or preference %v larger than %v", attrs.weight, attrs.preference, maxVppPathAttr)
+	}
+	return nil
+}
+
+// loadVppRouteAttrs returns the path attributes of the vpp entries of the route to the remote subnet.
+func loadVppRouteAttrs(remoteSubnet string) vppRouteAttrs {
+	attrs, ok := remoteSubnetVppAttrsMap.Load(remoteSubnet)
+	if !ok {
+		return vppRouteAttrs{}
+	}
+	return attrs.(vppRouteAttrs)
+}
+
+// storeVppRouteAttrs records the path attributes of the vpp entries of the route to the remote subnet,
+// unset attributes removing them.
+func storeVppRouteAttrs(remoteSubnet string, attrs vppRouteAttrs) {
+	if attrs == (vppRouteAttrs{}) {
+		remoteSubnetVppAttrsMap.Delete(remoteSubnet)
+		return
+	}
+	remoteSubnetVppAttrsMap.Store(remoteSubnet, attrs)
+}
+
+// matches returns true if the vpp entry has the path attributes.
+func (a vppRouteAttrs) matches(route *vpp.Route) bool {
+	return route.GetWeight() == a.weight && route.GetPreference() == a.preference
+}
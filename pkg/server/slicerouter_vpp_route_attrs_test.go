@@ -0,0 +1,176 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// vppRouteAttrsTo returns the path attributes of the vpp entries to the remote subnet, by next hop.
+func vppRouteAttrsTo(f *fakeVppAgent, remoteSubnet string) map[string]vppRouteAttrs {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	attrs := map[string]vppRouteAttrs{}
+	for _, route := range vppRoutesTo(f.config, remoteSubnet) {
+		attrs[route.GetNextHopAddr()] = vppRouteAttrs{weight: route.GetWeight(), preference: route.GetPreference()}
+	}
+	return attrs
+}
+
+func TestGetVppConfigRouteAttrs(t *testing.T) {
+	tests := []struct {
+		testName string
+		attrs    vppRouteAttrs
+	}{
+		{"attributes unset", vppRouteAttrs{}},
+		{"weight and preference set", vppRouteAttrs{weight: 20, preference: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			routes := getVppConfig("10.1.0.0/16", "192.168.0.2", tt.attrs).GetRoutes()
+			if len(routes) != 1 {
+				t.Fatal("expected a single route, received", routes)
+			}
+			if routes[0].GetWeight() != tt.attrs.weight || routes[0].GetPreference() != tt.attrs.preference {
+				t.Error("route attributes: expected", tt.attrs, "received", routes[0])
+			}
+		})
+	}
+}
+
+func TestCheckVppRouteAttrs(t *testing.T) {
+	tests := []struct {
+		testName  string
+		dataplane string
+		attrs     vppRouteAttrs
+		wantErr   bool
+	}{
+		{"unset in the kernel dataplane", SliceRouterDataplaneKernel, vppRouteAttrs{}, false},
+		{"set in the kernel dataplane", SliceRouterDataplaneKernel, vppRouteAttrs{weight: 2}, true},
+		{"set in the vpp dataplane", SliceRouterDataplaneVpp, vppRouteAttrs{weight: 255, preference: 3}, false},
+		{"weight out of range", SliceRouterDataplaneVpp, vppRouteAttrs{weight: 256}, true},
+		{"preference out of range", SliceRouterDataplaneVpp, vppRouteAttrs{preference: 1000}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("DATAPLANE", tt.dataplane)
+			if err := checkVppRouteAttrs(tt.attrs); (err != nil) != tt.wantErr {
+				t.Error("expected error:", tt.wantErr, "received", err)
+			}
+		})
+	}
+}
+
+func TestInjectRoutesVppAttrs(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	resetRouteMap(t)
+	skipReconcile(t)
+	fakeVpp := newFakeVppAgent()
+	useFakeVppAgent(t, fakeVpp)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	steps := []struct {
+		testName string
+		route    *pb.RouteInfo
+		expected vppRouteAttrs
+	}{
+		{"route injected with attributes",
+			&pb.RouteInfo{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2", "192.168.0.6"}, VppWeight: 10, VppPreference: 2},
+			vppRouteAttrs{weight: 10, preference: 2}},
+		{"attributes changed on the same next hops",
+			&pb.RouteInfo{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2", "192.168.0.6"}, VppWeight: 30},
+			vppRouteAttrs{weight: 30}},
+		{"attributes unset",
+			&pb.RouteInfo{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2", "192.168.0.6"}},
+			vppRouteAttrs{}},
+	}
+	for _, step := range steps {
+		response, err := client.InjectRoutes(ctx, &pb.RouteBatch{Routes: []*pb.RouteInfo{step.route}})
+		if err != nil {
+			t.Fatal(step.testName, err)
+		}
+		if result := response.GetResults()[0]; !result.GetIsInstalled() {
+			t.Fatal(step.testName, ": route not installed:", result)
+		}
+		attrs := vppRouteAttrsTo(fakeVpp, "10.1.0.0/16")
+		if len(attrs) != 2 || attrs["192.168.0.2"] != step.expected || attrs["192.168.0.6"] != step.expected {
+			t.Error(step.testName, ": vpp entries: expected", step.expected, "received", attrs)
+		}
+		entry := sliceRouterGetRouteTable().GetRoutes()[0]
+		if entry.GetVppWeight() != step.expected.weight || entry.GetVppPreference() != step.expected.preference {
+			t.Error(step.testName, ": route table: expected", step.expected, "received", entry)
+		}
+	}
+}
+
+func TestReconcileVppRouteAttrs(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	resetRouteMap(t)
+	useRouteAuditLog(t, &routeAuditLog{})
+
+	fakeVpp := newFakeVppAgent()
+	// The entry via 192.168.0.6 lost its weight and preference.
+	fakeVpp.config.Routes = append(getVppConfig("10.1.0.0/16", "192.168.0.2", vppRouteAttrs{weight: 5, preference: 1}).GetRoutes(),
+		getVppConfig("10.1.0.0/16", "192.168.0.6", vppRouteAttrs{}).GetRoutes()...)
+	useFakeVppAgent(t, fakeVpp)
+	remoteSubnetRouteMap.Store("10.1.0.0/16", []string{"192.168.0.2", "192.168.0.6"})
+	storeVppRouteAttrs("10.1.0.0/16", vppRouteAttrs{weight: 5, preference: 1})
+
+	budget := newReconcileBudget()
+	budget.corrections = map[string]reconcileCorrection{}
+	if err := vl3ReconcileRoutesInVpp(context.Background(), nil, budget); err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := budget.corrections["10.1.0.0/16"]; !ok || c.correction != routeCorrectionWeight || c.err != nil {
+		t.Error("expected the weight of 10.1.0.0/16 corrected, received", budget.corrections)
+	}
+	expected := vppRouteAttrs{weight: 5, preference: 1}
+	if attrs := vppRouteAttrsTo(fakeVpp, "10.1.0.0/16"); len(attrs) != 2 || attrs["192.168.0.2"] != expected || attrs["192.168.0.6"] != expected {
+		t.Error("vpp entries: expected", expected, "received", attrs)
+	}
+
+	// The converged route is left alone.
+	budget = newReconcileBudget()
+	budget.corrections = map[string]reconcileCorrection{}
+	if err := vl3ReconcileRoutesInVpp(context.Background(), nil, budget); err != nil {
+		t.Fatal(err)
+	}
+	if len(budget.corrections) != 0 {
+		t.Error("expected no correction, received", budget.corrections)
+	}
+	if entries := routeAudit.list(); len(entries) != 1 || entries[0].GetCorrection() != routeCorrectionWeight {
+		t.Error("audit log: expected a single weight correction, received", entries)
+	}
+}
@@ -0,0 +1,62 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+)
+
+// getVppSliceRouteVrf returns the vrf the slice routes are configured in, read from the
+// VPP_SLICE_ROUTE_VRF env variable. The slice routes are in vrf 0 by default. A non-zero vrf must be
+// created in vpp by the dataplane setup, the sidecar does not create it.
+func getVppSliceRouteVrf() uint32 {
+	value := os.Getenv("VPP_SLICE_ROUTE_VRF")
+	if value == "" {
+		return 0
+	}
+	vrf, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Invalid VPP_SLICE_ROUTE_VRF %q, using vrf 0", value)
+		return 0
+	}
+	return uint32(vrf)
+}
+
+// isSliceVppRoute returns true if the vpp route is a slice route configured by the sidecar. The vpp-agent
+// route model has no tag or protocol to mark the routes with, the slice routes are told apart by their
+// shape instead: inter vrf routes of the slice route vrf whose next hops are looked up in the same vrf.
+// The routes of other agents, the intra vrf ones and those leaking into or from another vrf, are left
+// alone by the reconcile and the flushes. In vrf 0 the inter vrf routes of other agents have the same
+// shape, a dedicated VPP_SLICE_ROUTE_VRF keeps the slice routes apart from them. Slice routes configured in
+// another vrf before VPP_SLICE_ROUTE_VRF changed are not recognized and must be removed by hand.
+func isSliceVppRoute(route *vpp.Route) bool {
+	vrf := getVppSliceRouteVrf()
+	return route.GetType() == vpp_l3.Route_INTER_VRF && route.GetVrfId() == vrf && route.GetViaVrfId() == vrf
+}
+
+// hasDedicatedVppSliceRouteVrf returns true if the slice routes are configured in a vrf of their own.
+// In vrf 0 a route of the slice shape may as well belong to another agent, so the entries the sidecar
+// does not know it configured, stale entries and the routes left by an earlier run, are not removed.
+func hasDedicatedVppSliceRouteVrf() bool {
+	return getVppSliceRouteVrf() != 0
+}
@@ -0,0 +1,109 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+)
+
+func TestIsSliceVppRoute(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+
+	tests := []struct {
+		testName string
+		vrf      string
+		route    *vpp.Route
+		expected bool
+	}{
+		{"slice route", "", getVppConfig("10.1.0.0/16", "192.168.0.2", vppRouteAttrs{}).GetRoutes()[0], true},
+		{"intra vrf route", "", &vpp.Route{Type: vpp_l3.Route_INTRA_VRF, DstNetwork: "10.1.0.0/16", NextHopAddr: "192.168.0.2"}, false},
+		{"route leaking into another vrf", "", &vpp.Route{Type: vpp_l3.Route_INTER_VRF, DstNetwork: "10.1.0.0/16", ViaVrfId: 2}, false},
+		{"route of another vrf", "5", &vpp.Route{Type: vpp_l3.Route_INTER_VRF, DstNetwork: "10.1.0.0/16", NextHopAddr: "192.168.0.2"}, false},
+		{"route of the slice route vrf", "5", &vpp.Route{Type: vpp_l3.Route_INTER_VRF, VrfId: 5, ViaVrfId: 5, DstNetwork: "10.1.0.0/16"}, true},
+		{"invalid vrf", "red", getVppConfig("10.1.0.0/16", "192.168.0.2", vppRouteAttrs{}).GetRoutes()[0], true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_SLICE_ROUTE_VRF", tt.vrf)
+			if isSlice := isSliceVppRoute(tt.route); isSlice != tt.expected {
+				t.Error("slice route: expected", tt.expected, "received", isSlice)
+			}
+		})
+	}
+}
+
+func TestVppSliceRouteVrf(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_SLICE_ROUTE_VRF", "5")
+	resetRouteMap(t)
+	skipReconcile(t)
+	useRouteAuditLog(t, &routeAuditLog{})
+
+	fake := newFakeVppAgent()
+	useFakeVppAgent(t, fake)
+	if err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	fake.mu.Lock()
+	if len(fake.config.Routes) != 1 || fake.config.Routes[0].GetVrfId() != 5 || fake.config.Routes[0].GetViaVrfId() != 5 {
+		t.Error("expected the route configured in vrf 5, received", fake.config.Routes)
+	}
+	foreign := []*vpp.Route{
+		{Type: vpp_l3.Route_INTER_VRF, DstNetwork: "10.1.0.0/16", NextHopAddr: "192.168.0.9"},
+		{Type: vpp_l3.Route_INTRA_VRF, VrfId: 5, DstNetwork: "10.1.0.0/16", NextHopAddr: "192.168.0.10"},
+	}
+	// A stale slice entry, and routes of other agents to the same remote subnet.
+	fake.config.Routes = append(fake.config.Routes, getVppConfig("10.1.0.0/16", "192.168.0.6", vppRouteAttrs{}).GetRoutes()...)
+	fake.config.Routes = append(fake.config.Routes, foreign...)
+	fake.mu.Unlock()
+
+	// The reconcile removes the stale slice entry only.
+	budget := newReconcileBudget()
+	budget.corrections = map[string]reconcileCorrection{}
+	if err := vl3ReconcileRoutesInVpp(context.Background(), nil, budget); err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := budget.corrections["10.1.0.0/16"]; !ok || c.correction != routeCorrectionStale || c.err != nil {
+		t.Error("expected the stale entry of 10.1.0.0/16 corrected, received", budget.corrections)
+	}
+	if nextHops, err := sliceRouterGetInstalledNextHops("10.1.0.0/16"); err != nil || !reflect.DeepEqual(nextHops, []string{"192.168.0.2"}) {
+		t.Error("installed next hops: expected [192.168.0.2] received", nextHops, err)
+	}
+
+	// The flush on a dataplane change leaves the routes of other agents too.
+	flushed, err := vl3FlushSliceRoutesInVpp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flushed != 1 {
+		t.Error("flushed routes: expected 1, received", flushed)
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if !reflect.DeepEqual(fake.config.Routes, foreign) {
+		t.Error("vpp routes: expected", foreign, "received", fake.config.Routes)
+	}
+}
@@ -0,0 +1,111 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	sidecar "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// vppConfigSummary summarizes the last config read from the vpp agent.
+type vppConfigSummary struct {
+	hash       string
+	interfaces int
+	routes     int
+	capturedAt time.Time
+	changedAt  time.Time
+}
+
+var (
+	vppConfigSummaryMu   sync.Mutex
+	lastVppConfigSummary vppConfigSummary
+)
+
+// hashVppConfig returns the hex encoded SHA-256 hash of the deterministic encoding of the vpp config.
+func hashVppConfig(config *vpp.ConfigData) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// recordVppConfig records the summary of the config returned by the vpp agent. It is called every
+// time the sidecar reads the whole vpp config so that unexpected changes in the vpp state are noticed.
+// Reads of the interfaces only are not recorded.
+func recordVppConfig(config *vpp.ConfigData) vppConfigSummary {
+	hash, err := hashVppConfig(config)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to hash vpp config: %v", err)
+	}
+
+	vppConfigSummaryMu.Lock()
+	defer vppConfigSummaryMu.Unlock()
+	now := time.Now()
+	if hash != lastVppConfigSummary.hash {
+		if lastVppConfigSummary.hash != "" {
+			logger.GlobalLogger.Infof("Vpp config changed. Hash: %v, PrevHash: %v", hash, lastVppConfigSummary.hash)
+		}
+		lastVppConfigSummary.changedAt = now
+	}
+	lastVppConfigSummary.hash = hash
+	lastVppConfigSummary.interfaces = len(config.GetInterfaces())
+	lastVppConfigSummary.routes = len(config.GetRoutes())
+	lastVppConfigSummary.capturedAt = now
+
+	return lastVppConfigSummary
+}
+
+// sliceRouterGetVppConfigSummary reads the config from the vpp agent and returns its summary.
+func sliceRouterGetVppConfigSummary() (*sidecar.VppConfigSummary, error) {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		logger.GlobalLogger.Errorf("can't dial grpc server: %v", err)
+		return nil, err
+	}
+	defer closeConn()
+
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{})
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to get vpp config: %v", err)
+		return nil, err
+	}
+
+	summary := recordVppConfig(vppConfig.GetConfig().GetVppConfig())
+	return &sidecar.VppConfigSummary{
+		ConfigHash:       summary.hash,
+		InterfaceCount:   int32(summary.interfaces),
+		RouteCount:       int32(summary.routes),
+		CapturedAt:       timestamppb.New(summary.capturedAt),
+		ChangedAt:        timestamppb.New(summary.changedAt),
+		VppAgentEndpoint: vppAgentEndpoints.activeEndpoint(),
+	}, nil
+}
@@ -0,0 +1,48 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// vppAgentOp identifies the vpp-agent configurator call a timeout applies to.
+type vppAgentOp string
+
+const (
+	vppAgentOpGet    vppAgentOp = "GET"
+	vppAgentOpUpdate vppAgentOp = "UPDATE"
+	vppAgentOpDelete vppAgentOp = "DELETE"
+
+	// Default timeout of the calls to the vpp-agent. A slow vpp-agent only stalls the injection that
+	// is waiting on it, so it is kept short enough for the controller to retry.
+	defaultVppAgentTimeout = 10 * time.Second
+)
+
+// getVppAgentTimeout returns the timeout of a vpp-agent call. It is read from the
+// VPP_AGENT_<OP>_TIMEOUT env variable, falling back to VPP_AGENT_TIMEOUT and then to the default.
+func getVppAgentTimeout(op vppAgentOp) time.Duration {
+	timeout := getEnvDuration("VPP_AGENT_TIMEOUT", defaultVppAgentTimeout)
+	return getEnvDuration("VPP_AGENT_"+string(op)+"_TIMEOUT", timeout)
+}
+
+// newVppAgentContext returns the context used for a call to the vpp-agent.
+func newVppAgentContext(op vppAgentOp) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), getVppAgentTimeout(op))
+}
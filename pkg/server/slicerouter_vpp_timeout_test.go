@@ -0,0 +1,101 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestGetVppAgentTimeout(t *testing.T) {
+	tests := []struct {
+		testName string
+		env      map[string]string
+		op       vppAgentOp
+		expected time.Duration
+	}{
+		{"default", nil, vppAgentOpUpdate, defaultVppAgentTimeout},
+		{"global override", map[string]string{"VPP_AGENT_TIMEOUT": "3s"}, vppAgentOpUpdate, 3 * time.Second},
+		{"per call override", map[string]string{"VPP_AGENT_TIMEOUT": "3s", "VPP_AGENT_GET_TIMEOUT": "500ms"}, vppAgentOpGet, 500 * time.Millisecond},
+		{"per call override of another call", map[string]string{"VPP_AGENT_GET_TIMEOUT": "500ms"}, vppAgentOpDelete, defaultVppAgentTimeout},
+		{"invalid value", map[string]string{"VPP_AGENT_TIMEOUT": "soon"}, vppAgentOpUpdate, defaultVppAgentTimeout},
+	}
+
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if got := getVppAgentTimeout(tt.op); got != tt.expected {
+				t.Error("expected", tt.expected, "received", got)
+			}
+		})
+	}
+}
+
+func TestSliceRouterInjectRouteSlowVppAgent(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_AGENT_UPDATE_TIMEOUT", "300ms")
+	resetRouteMap(t)
+
+	fake := newFakeVppAgent()
+	fake.slowRoutes = map[string]bool{"10.1.0.0/16": true}
+	fake.updateDelay = time.Minute
+	useFakeVppAgent(t, fake)
+
+	start := time.Now()
+	slowDone := make(chan error, 1)
+	go func() {
+		slowDone <- sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"})
+	}()
+
+	// An unrelated injection completes while the slow one is still waiting on the vpp-agent.
+	if err := sliceRouterInjectRoute("10.2.0.0/16", []string{"192.168.0.6"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-slowDone:
+		t.Fatal("slow injection returned before the unrelated one, err:", err)
+	default:
+	}
+
+	select {
+	case err := <-slowDone:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("expected deadline exceeded, received", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow injection was not bounded by the vpp-agent timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Error("slow injection took", elapsed)
+	}
+
+	if _, ok := remoteSubnetRouteMap.Load("10.2.0.0/16"); !ok {
+		t.Error("unrelated route was not recorded")
+	}
+	if cached, ok := remoteSubnetRouteMap.Load("10.1.0.0/16"); ok && len(cached.([]string)) != 0 {
+		t.Error("timed out route should not be recorded as installed, received", cached)
+	}
+}
@@ -0,0 +1,119 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
+)
+
+const (
+	// Default time the routes of an update are waited for in the vpp config.
+	defaultVppUpdateVerifyTimeout = 5 * time.Second
+	// Default interval between two reads of the vpp config while verifying an update.
+	defaultVppUpdateVerifyInterval = 200 * time.Millisecond
+)
+
+// errVppConfigNotApplied is returned when the vpp-agent accepted an update but the routes of the update
+// did not show up in the vpp config.
+var errVppConfigNotApplied = errors.New("Vpp config not applied")
+
+// isVppUpdateVerifyEnabled returns true if the routes of the updates accepted by the vpp-agent are looked
+// up in the vpp config before the update is reported as applied, read from the VPP_UPDATE_VERIFY env
+// variable. The vpp-agent programs vpp asynchronously and may accept an update that vpp then fails to
+// apply. Updates are not verified by default, verifying costs a Get per update at least.
+func isVppUpdateVerifyEnabled() bool {
+	return os.Getenv("VPP_UPDATE_VERIFY") == "true"
+}
+
+// sameVppRoute returns true if the routes have the same key in the vpp-agent.
+func sameVppRoute(a, b *vpp.Route) bool {
+	return a.GetType() == b.GetType() && a.GetVrfId() == b.GetVrfId() && a.GetViaVrfId() == b.GetViaVrfId() &&
+		a.GetDstNetwork() == b.GetDstNetwork() && a.GetNextHopAddr() == b.GetNextHopAddr() &&
+		a.GetOutgoingInterface() == b.GetOutgoingInterface()
+}
+
+// missingVppRoutes returns the routes that are not in the vpp config.
+func missingVppRoutes(routes []*vpp.Route, config *vpp.ConfigData) []*vpp.Route {
+	missing := []*vpp.Route{}
+	for _, route := range routes {
+		found := false
+		for _, applied := range config.GetRoutes() {
+			if sameVppRoute(route, applied) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, route)
+		}
+	}
+	return missing
+}
+
+// getVppRoutesConfig reads the vpp config from the vpp-agent.
+func getVppRoutesConfig() (*vpp.ConfigData, error) {
+	ctx, cancel := newVppAgentContext(vppAgentOpGet)
+	defer cancel()
+
+	client, closeConn, err := dialVppAgent()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	vppConfig, err := client.Get(ctx, &configurator.GetRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return vppConfig.GetConfig().GetVppConfig(), nil
+}
+
+// verifyVppRoutesApplied reads the vpp config every VPP_UPDATE_VERIFY_INTERVAL until the routes show up
+// in it, for at most VPP_UPDATE_VERIFY_TIMEOUT. errVppConfigNotApplied is returned if some are still
+// missing after the timeout.
+func verifyVppRoutesApplied(routes []*vpp.Route) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	timeout := getEnvDuration("VPP_UPDATE_VERIFY_TIMEOUT", defaultVppUpdateVerifyTimeout)
+	interval := getEnvDuration("VPP_UPDATE_VERIFY_INTERVAL", defaultVppUpdateVerifyInterval)
+	start := time.Now()
+	for {
+		config, err := getVppRoutesConfig()
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to get vpp config to verify the update: %v", err)
+		} else if missing := missingVppRoutes(routes, config); len(missing) == 0 {
+			return nil
+		} else {
+			err = fmt.Errorf("%v of %v routes missing", len(missing), len(routes))
+		}
+		if time.Since(start) >= timeout {
+			vppUpdatesNotAppliedCounter.Inc()
+			logger.GlobalLogger.Errorf("Vpp update not verified after %v: %v", timeout, err)
+			return fmt.Errorf("%w after %v: %v", errVppConfigNotApplied, timeout, err)
+		}
+		time.Sleep(interval)
+	}
+}
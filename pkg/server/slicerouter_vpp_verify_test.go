@@ -0,0 +1,78 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+)
+
+func TestVppUpdateVerify(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneVpp)
+	t.Setenv("VPP_UPDATE_VERIFY_TIMEOUT", "50ms")
+	t.Setenv("VPP_UPDATE_VERIFY_INTERVAL", "10ms")
+	skipReconcile(t)
+
+	tests := []struct {
+		testName    string
+		verify      string
+		unapplied   bool
+		expectErr   bool
+		expectedGet bool
+	}{
+		{"not verified by default", "", true, false, false},
+		{"applied update", "true", false, false, true},
+		{"update not applied", "true", true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("VPP_UPDATE_VERIFY", tt.verify)
+			resetRouteMap(t)
+			fake := newFakeVppAgent()
+			if tt.unapplied {
+				fake.unappliedRoutes = map[string]bool{"10.1.0.0/16": true}
+			}
+			useFakeVppAgent(t, fake)
+			vppUpdatesNotAppliedCounter.Reset()
+
+			err := sliceRouterInjectRoute("10.1.0.0/16", []string{"192.168.0.2"})
+			if tt.expectErr != (err != nil) {
+				t.Fatal("unexpected error:", err)
+			}
+			if tt.expectErr {
+				var rErr *routeError
+				if !errors.Is(err, errVppConfigNotApplied) || !errors.As(err, &rErr) || rErr.reason != routeErrorDataplane {
+					t.Error("expected a dataplane error for the unapplied config, received", err)
+				}
+				if vppUpdatesNotAppliedCounter.Value() != 1 {
+					t.Error("updates not applied: expected 1, received", vppUpdatesNotAppliedCounter.Value())
+				}
+			}
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			// The local subnet overlap check reads the vpp config once.
+			if (fake.getCalls > 1) != tt.expectedGet {
+				t.Error("vpp config reads beyond the overlap check: expected any", tt.expectedGet, "received", fake.getCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	defaultNextHopHealthyWeight  = 10
+	defaultNextHopDegradedWeight = 1
+	// maxNextHopWeight is the largest weight the kernel can store for a next hop.
+	maxNextHopWeight = 256
+)
+
+// isNextHopWeightDecayEnabled returns true if the ECMP weight of next hops with an unhealthy neighbor
+// entry should be reduced, read from the NEXTHOP_WEIGHT_DECAY env variable.
+func isNextHopWeightDecayEnabled() bool {
+	return os.Getenv("NEXTHOP_WEIGHT_DECAY") == "true"
+}
+
+// getNextHopWeight returns the weight in an env variable, or def if it is not set or out of range.
+func getNextHopWeight(key string, def int) int {
+	weight, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || weight <= 0 || weight > maxNextHopWeight {
+		return def
+	}
+	return weight
+}
+
+// getNextHopHealthyWeight returns the ECMP weight of next hops with a healthy neighbor entry, read from
+// the NEXTHOP_HEALTHY_WEIGHT env variable.
+func getNextHopHealthyWeight() int {
+	return getNextHopWeight("NEXTHOP_HEALTHY_WEIGHT", defaultNextHopHealthyWeight)
+}
+
+// getNextHopDegradedWeight returns the ECMP weight of next hops whose neighbor entry failed, read from
+// the NEXTHOP_DEGRADED_WEIGHT env variable.
+func getNextHopDegradedWeight() int {
+	return getNextHopWeight("NEXTHOP_DEGRADED_WEIGHT", defaultNextHopDegradedWeight)
+}
+
+// isNeighborUnhealthy returns true if the neighbor entry of the next hop failed to resolve. Next hops
+// without a neighbor entry are not known to be unhealthy.
+func isNeighborUnhealthy(linkIndex int, nextHopIP net.IP) (bool, error) {
+	neighs, err := nlHandle.NeighList(linkIndex, ipFamily(nextHopIP))
+	if err != nil {
+		return false, err
+	}
+	for _, neigh := range neighs {
+		if neigh.IP.Equal(nextHopIP) {
+			return neigh.State&(netlink.NUD_FAILED|netlink.NUD_INCOMPLETE) != 0, nil
+		}
+	}
+	return false, nil
+}
+
+// nextHopWeight returns the ECMP weight the next hop should have given the state of its neighbor.
+func nextHopWeight(nextHop *netlink.NexthopInfo) (int, error) {
+	// Interface next hops have no neighbor to check.
+	if nextHop.Gw == nil {
+		return getNextHopHealthyWeight(), nil
+	}
+	unhealthy, err := isNeighborUnhealthy(nextHop.LinkIndex, nextHop.Gw)
+	if err != nil {
+		return 0, err
+	}
+	if unhealthy {
+		return getNextHopDegradedWeight(), nil
+	}
+	return getNextHopHealthyWeight(), nil
+}
+
+// applyNextHopWeights sets the ECMP weight of the next hops from the state of their neighbors. The
+// kernel stores the weight of a next hop as its hop count plus one.
+func applyNextHopWeights(nextHopIPSlice []*netlink.NexthopInfo) error {
+	if !isNextHopWeightDecayEnabled() {
+		return nil
+	}
+	for _, nextHop := range nextHopIPSlice {
+		weight, err := nextHopWeight(nextHop)
+		if err != nil {
+			return err
+		}
+		nextHop.Hops = weight - 1
+	}
+	return nil
+}
+
+// hasStaleNextHopWeight returns true if the weight of a next hop of the installed multipath routes no
+// longer reflects the state of its neighbor. A route with a single next hop has no weight.
+func hasStaleNextHopWeight(routes []netlink.Route) bool {
+	if !isNextHopWeightDecayEnabled() {
+		return false
+	}
+	for _, route := range routes {
+		for _, path := range route.MultiPath {
+			weight, err := nextHopWeight(path)
+			if err != nil {
+				return true
+			}
+			if path.Hops+1 != weight {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	"github.com/vishvananda/netlink"
+)
+
+// installedNextHopWeights returns the ECMP weight of every next hop of the route to the remote subnet.
+func installedNextHopWeights(t *testing.T, fake *fakeNetlink, remoteSubnet string) map[string]int {
+	t.Helper()
+	routes, err := fake.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights := map[string]int{}
+	for _, route := range routes {
+		if route.Dst == nil || route.Dst.String() != remoteSubnet {
+			continue
+		}
+		for _, path := range route.MultiPath {
+			weights[path.Gw.String()] = path.Hops + 1
+		}
+	}
+	return weights
+}
+
+func TestNextHopWeightDecay(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	t.Setenv("NEXTHOP_WEIGHT_DECAY", "true")
+	t.Setenv("NEXTHOP_HEALTHY_WEIGHT", "8")
+	t.Setenv("NEXTHOP_DEGRADED_WEIGHT", "2")
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+
+	remoteSubnet := "10.1.0.0/16"
+	fake.setNeighState(2, "192.168.0.6", netlink.NUD_FAILED)
+	if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2", "192.168.0.6"}); err != nil {
+		t.Fatal(err)
+	}
+	weights := installedNextHopWeights(t, fake, remoteSubnet)
+	if weights["192.168.0.2"] != 8 || weights["192.168.0.6"] != 2 {
+		t.Fatal("weights of the injected route: expected 8 and 2, received", weights)
+	}
+
+	steps := []struct {
+		testName string
+		state    int
+		expected int
+	}{
+		{"weight restored when the neighbor is reachable", netlink.NUD_REACHABLE, 8},
+		{"weight kept while the neighbor is stale", netlink.NUD_STALE, 8},
+		{"weight reduced when the neighbor is incomplete", netlink.NUD_INCOMPLETE, 2},
+		{"weight reduced when the neighbor failed", netlink.NUD_FAILED, 2},
+		{"weight restored again", netlink.NUD_REACHABLE, 8},
+	}
+
+	for _, step := range steps {
+		t.Run(step.testName, func(t *testing.T) {
+			fake.setNeighState(2, "192.168.0.6", step.state)
+			if err := sliceRouterReconcileRoutingTable(context.Background(), nil); err != nil {
+				t.Fatal(err)
+			}
+			weights := installedNextHopWeights(t, fake, remoteSubnet)
+			if weights["192.168.0.6"] != step.expected {
+				t.Error("weight of the next hop: expected", step.expected, "received", weights["192.168.0.6"])
+			}
+			if weights["192.168.0.2"] != 8 {
+				t.Error("weight of the healthy next hop: expected 8, received", weights["192.168.0.2"])
+			}
+		})
+	}
+}
+
+func TestNextHopWeightDecayDisabled(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+	fake.setNeighState(2, "192.168.0.6", netlink.NUD_FAILED)
+
+	remoteSubnet := "10.1.0.0/16"
+	if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2", "192.168.0.6"}); err != nil {
+		t.Fatal(err)
+	}
+	weights := installedNextHopWeights(t, fake, remoteSubnet)
+	if weights["192.168.0.2"] != 1 || weights["192.168.0.6"] != 1 {
+		t.Error("weights without decay: expected 1 and 1, received", weights)
+	}
+}
+
+func TestGetNextHopWeight(t *testing.T) {
+	tests := []struct {
+		testName string
+		value    string
+		expected int
+	}{
+		{"unset", "", 10},
+		{"valid", "4", 4},
+		{"maximum", "256", 256},
+		{"zero", "0", 10},
+		{"too large", "257", 10},
+		{"invalid", "heavy", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv("NEXTHOP_HEALTHY_WEIGHT", tt.value)
+			if got := getNextHopHealthyWeight(); got != tt.expected {
+				t.Error("expected", tt.expected, "received", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+/*  Copyright (c) 2022 Avesha, Inc. All rights reserved.
+ *
+ *  SPDX-License-Identifier: Apache-2.0
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kubeslice/router-sidecar/pkg/logger"
+	pb "github.com/kubeslice/router-sidecar/pkg/sidecar/sidecarpb"
+	"github.com/vishvananda/netlink"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestSyncRoutes(t *testing.T) {
+	logger.GlobalLogger = logger.NewLogger("INFO")
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	skipReconcile(t)
+	resetRouteMap(t)
+
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.0.2", 1)
+	fake.addConnectedRoute("192.168.0.6", 2)
+	useFakeNetlink(t, fake)
+
+	for _, remoteSubnet := range []string{"10.1.0.0/16", "10.2.0.0/16", "10.3.0.0/16", "10.5.0.0/16"} {
+		if err := sliceRouterInjectRoute(remoteSubnet, []string{"192.168.0.2"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The route to 10.1.0.0/16 drifts outside of the sidecar.
+	fake.mu.Lock()
+	for i := range fake.routes {
+		if fake.routes[i].Dst != nil && fake.routes[i].Dst.String() == "10.1.0.0/16" {
+			fake.routes[i].Scope = netlink.SCOPE_LINK
+		}
+	}
+	fake.mu.Unlock()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSliceRouterSidecarServiceClient(conn)
+
+	t.Run("invalid desired set", func(t *testing.T) {
+		_, err := client.SyncRoutes(ctx, &pb.SyncRoutesRequest{Routes: []*pb.RouteInfo{
+			{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+		}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Error("duplicate route: expected InvalidArgument, received", err)
+		}
+		_, err = client.SyncRoutes(ctx, &pb.SyncRoutesRequest{Routes: []*pb.RouteInfo{
+			{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			{RemoteSubnet: "10.1.0.1/16", NextHopIPList: []string{"192.168.0.6"}},
+		}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Error("duplicate non canonical route: expected InvalidArgument, received", err)
+		}
+		_, err = client.SyncRoutes(ctx, &pb.SyncRoutesRequest{Routes: []*pb.RouteInfo{{RemoteSubnet: "10.1.0.0/16"}}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Error("no next hops: expected InvalidArgument, received", err)
+		}
+	})
+
+	t.Run("adds, deletes and corrections", func(t *testing.T) {
+		response, err := client.SyncRoutes(ctx, &pb.SyncRoutesRequest{Routes: []*pb.RouteInfo{
+			{RemoteSubnet: "10.1.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+			{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+			{RemoteSubnet: "10.4.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+			{RemoteSubnet: "10.5.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []struct {
+			remoteSubnet string
+			action       pb.RouteSyncAction
+			previous     []string
+			correction   string
+		}{
+			{"10.1.0.0/16", pb.RouteSyncAction_ROUTE_SYNC_CORRECTED, []string{"192.168.0.2"}, routeAttrScope},
+			{"10.3.0.0/16", pb.RouteSyncAction_ROUTE_SYNC_UPDATED, []string{"192.168.0.2"}, ""},
+			{"10.4.0.0/16", pb.RouteSyncAction_ROUTE_SYNC_ADDED, nil, ""},
+			{"10.5.0.0/16", pb.RouteSyncAction_ROUTE_SYNC_UNCHANGED, []string{"192.168.0.2"}, ""},
+			{"10.2.0.0/16", pb.RouteSyncAction_ROUTE_SYNC_DELETED, []string{"192.168.0.2"}, ""},
+		}
+		results := response.GetResults()
+		if len(results) != len(expected) {
+			t.Fatalf("expected %v results, received %v", len(expected), results)
+		}
+		for i, want := range expected {
+			result := results[i]
+			if result.GetRemoteSubnet() != want.remoteSubnet || result.GetAction() != want.action {
+				t.Errorf("result %v: expected %v %v, received %v %v", i, want.remoteSubnet, want.action,
+					result.GetRemoteSubnet(), result.GetAction())
+			}
+			if !result.GetIsInstalled() || result.GetErrorMsg() != "" {
+				t.Errorf("%v: expected success, received %v", want.remoteSubnet, result.GetErrorMsg())
+			}
+			if !reflect.DeepEqual(result.GetPreviousNextHopIPList(), want.previous) {
+				t.Errorf("%v: expected previous next hops %v, received %v", want.remoteSubnet, want.previous,
+					result.GetPreviousNextHopIPList())
+			}
+			if result.GetCorrection() != want.correction {
+				t.Errorf("%v: expected correction %q, received %q", want.remoteSubnet, want.correction, result.GetCorrection())
+			}
+		}
+
+		for remoteSubnet, nextHops := range map[string][]string{
+			"10.1.0.0/16": {"192.168.0.2"},
+			"10.2.0.0/16": {},
+			"10.3.0.0/16": {"192.168.0.6"},
+			"10.4.0.0/16": {"192.168.0.6"},
+			"10.5.0.0/16": {"192.168.0.2"},
+		} {
+			if installed := installedNextHops(t, fake, remoteSubnet); !reflect.DeepEqual(installed, nextHops) {
+				t.Errorf("%v: expected %v installed, received %v", remoteSubnet, nextHops, installed)
+			}
+		}
+		if attr := driftedRouteAttribute("10.1.0.0/16", kernelRoutesTo(t, fake, "10.1.0.0/16")); attr != "" {
+			t.Error("expected the drift to be corrected, received", attr)
+		}
+	})
+
+	t.Run("non canonical subnets are the recorded routes", func(t *testing.T) {
+		response, err := client.SyncRoutes(ctx, &pb.SyncRoutesRequest{Routes: []*pb.RouteInfo{
+			{RemoteSubnet: "10.1.0.5/16", NextHopIPList: []string{"192.168.0.2"}},
+			{RemoteSubnet: "10.3.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+			{RemoteSubnet: "10.4.0.0/16", NextHopIPList: []string{"192.168.0.6"}},
+			{RemoteSubnet: "10.5.0.0/16", NextHopIPList: []string{"192.168.0.2"}},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"10.1.0.0/16", "10.3.0.0/16", "10.4.0.0/16", "10.5.0.0/16"}
+		results := response.GetResults()
+		if len(results) != len(expected) {
+			t.Fatalf("expected %v results, received %v", len(expected), results)
+		}
+		for i, remoteSubnet := range expected {
+			if results[i].GetRemoteSubnet() != remoteSubnet || results[i].GetAction() != pb.RouteSyncAction_ROUTE_SYNC_UNCHANGED {
+				t.Errorf("result %v: expected %v unchanged, received %v", i, remoteSubnet, results[i])
+			}
+		}
+		if installed := installedNextHops(t, fake, "10.1.0.0/16"); !reflect.DeepEqual(installed, []string{"192.168.0.2"}) {
+			t.Error("10.1.0.0/16: expected 192.168.0.2 installed, received", installed)
+		}
+	})
+}
+
+// kernelRoutesTo returns the fake kernel routes to the remote subnet.
+func kernelRoutesTo(t *testing.T, fake *fakeNetlink, remoteSubnet string) []netlink.Route {
+	t.Helper()
+	routes, err := fake.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matching := []netlink.Route{}
+	for _, route := range routes {
+		if route.Dst != nil && route.Dst.String() == remoteSubnet {
+			matching = append(matching, route)
+		}
+	}
+	return matching
+}
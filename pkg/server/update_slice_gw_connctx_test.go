@@ -70,6 +70,13 @@ func TestUpdateConnCtx(t *testing.T) {
 			false,
 		},
 	}
+	t.Setenv("DATAPLANE", SliceRouterDataplaneKernel)
+	resetRouteMap(t)
+	fake := newFakeNetlink()
+	fake.addConnectedRoute("192.168.1.1", 1)
+	fake.addConnectedRoute("192.168.1.2", 2)
+	useFakeNetlink(t, fake)
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	conn, err := grpc.DialContext(ctx, "", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(dialer()))
@@ -23,7 +23,9 @@
 package sidecar
 
 import (
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -84,6 +86,406 @@ func (SliceGwHostType) EnumDescriptor() ([]byte, []int) {
 	return file_router_sidecar_proto_rawDescGZIP(), []int{0}
 }
 
+// RouteState - State of a remote subnet route in the slice router
+type RouteState int32
+
+const (
+	// Route is not installed in the dataplane
+	RouteState_ROUTE_ABSENT RouteState = 0
+	// Route is installed in the dataplane with the expected next hops
+	RouteState_ROUTE_INSTALLED RouteState = 1
+	// Route is installed in the dataplane but its next hops differ from the expected ones
+	RouteState_ROUTE_DRIFTED RouteState = 2
+)
+
+// Enum value maps for RouteState.
+var (
+	RouteState_name = map[int32]string{
+		0: "ROUTE_ABSENT",
+		1: "ROUTE_INSTALLED",
+		2: "ROUTE_DRIFTED",
+	}
+	RouteState_value = map[string]int32{
+		"ROUTE_ABSENT":    0,
+		"ROUTE_INSTALLED": 1,
+		"ROUTE_DRIFTED":   2,
+	}
+)
+
+func (x RouteState) Enum() *RouteState {
+	p := new(RouteState)
+	*p = x
+	return p
+}
+
+func (x RouteState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RouteState) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_sidecar_proto_enumTypes[1].Descriptor()
+}
+
+func (RouteState) Type() protoreflect.EnumType {
+	return &file_router_sidecar_proto_enumTypes[1]
+}
+
+func (x RouteState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RouteState.Descriptor instead.
+func (RouteState) EnumDescriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{1}
+}
+
+// NeighborState - State of the ARP/NDP resolution of a next hop
+type NeighborState int32
+
+const (
+	// No neighbor entry for the next hop
+	NeighborState_NEIGHBOR_NONE NeighborState = 0
+	// Resolution in progress
+	NeighborState_NEIGHBOR_INCOMPLETE NeighborState = 1
+	NeighborState_NEIGHBOR_REACHABLE  NeighborState = 2
+	// Resolved but not confirmed recently
+	NeighborState_NEIGHBOR_STALE NeighborState = 3
+	// Waiting for or running a reachability confirmation
+	NeighborState_NEIGHBOR_PROBE NeighborState = 4
+	// Resolution failed
+	NeighborState_NEIGHBOR_FAILED NeighborState = 5
+	// Static entry
+	NeighborState_NEIGHBOR_PERMANENT NeighborState = 6
+)
+
+// Enum value maps for NeighborState.
+var (
+	NeighborState_name = map[int32]string{
+		0: "NEIGHBOR_NONE",
+		1: "NEIGHBOR_INCOMPLETE",
+		2: "NEIGHBOR_REACHABLE",
+		3: "NEIGHBOR_STALE",
+		4: "NEIGHBOR_PROBE",
+		5: "NEIGHBOR_FAILED",
+		6: "NEIGHBOR_PERMANENT",
+	}
+	NeighborState_value = map[string]int32{
+		"NEIGHBOR_NONE":       0,
+		"NEIGHBOR_INCOMPLETE": 1,
+		"NEIGHBOR_REACHABLE":  2,
+		"NEIGHBOR_STALE":      3,
+		"NEIGHBOR_PROBE":      4,
+		"NEIGHBOR_FAILED":     5,
+		"NEIGHBOR_PERMANENT":  6,
+	}
+)
+
+func (x NeighborState) Enum() *NeighborState {
+	p := new(NeighborState)
+	*p = x
+	return p
+}
+
+func (x NeighborState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NeighborState) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_sidecar_proto_enumTypes[2].Descriptor()
+}
+
+func (NeighborState) Type() protoreflect.EnumType {
+	return &file_router_sidecar_proto_enumTypes[2]
+}
+
+func (x NeighborState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NeighborState.Descriptor instead.
+func (NeighborState) EnumDescriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{2}
+}
+
+// ReconcilePriority - Tier of the reconcile checking a route
+type ReconcilePriority int32
+
+const (
+	// The route is checked every reconcile interval
+	ReconcilePriority_RECONCILE_PRIORITY_NORMAL ReconcilePriority = 0
+	// The route is also checked every fast reconcile interval, for critical services
+	ReconcilePriority_RECONCILE_PRIORITY_FAST ReconcilePriority = 1
+)
+
+// Enum value maps for ReconcilePriority.
+var (
+	ReconcilePriority_name = map[int32]string{
+		0: "RECONCILE_PRIORITY_NORMAL",
+		1: "RECONCILE_PRIORITY_FAST",
+	}
+	ReconcilePriority_value = map[string]int32{
+		"RECONCILE_PRIORITY_NORMAL": 0,
+		"RECONCILE_PRIORITY_FAST":   1,
+	}
+)
+
+func (x ReconcilePriority) Enum() *ReconcilePriority {
+	p := new(ReconcilePriority)
+	*p = x
+	return p
+}
+
+func (x ReconcilePriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReconcilePriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_sidecar_proto_enumTypes[3].Descriptor()
+}
+
+func (ReconcilePriority) Type() protoreflect.EnumType {
+	return &file_router_sidecar_proto_enumTypes[3]
+}
+
+func (x ReconcilePriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReconcilePriority.Descriptor instead.
+func (ReconcilePriority) EnumDescriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{3}
+}
+
+// RouteSyncAction - Change made to a route to bring it to the desired state
+type RouteSyncAction int32
+
+const (
+	// The route was already in the desired state
+	RouteSyncAction_ROUTE_SYNC_UNCHANGED RouteSyncAction = 0
+	// The route was missing and was injected
+	RouteSyncAction_ROUTE_SYNC_ADDED RouteSyncAction = 1
+	// The next hops, preferred source, description or reconcile tier of the route were changed
+	RouteSyncAction_ROUTE_SYNC_UPDATED RouteSyncAction = 2
+	// The route was already desired but the installed route had drifted and was corrected
+	RouteSyncAction_ROUTE_SYNC_CORRECTED RouteSyncAction = 3
+	// The route is not in the desired set and was deleted
+	RouteSyncAction_ROUTE_SYNC_DELETED RouteSyncAction = 4
+)
+
+// Enum value maps for RouteSyncAction.
+var (
+	RouteSyncAction_name = map[int32]string{
+		0: "ROUTE_SYNC_UNCHANGED",
+		1: "ROUTE_SYNC_ADDED",
+		2: "ROUTE_SYNC_UPDATED",
+		3: "ROUTE_SYNC_CORRECTED",
+		4: "ROUTE_SYNC_DELETED",
+	}
+	RouteSyncAction_value = map[string]int32{
+		"ROUTE_SYNC_UNCHANGED": 0,
+		"ROUTE_SYNC_ADDED":     1,
+		"ROUTE_SYNC_UPDATED":   2,
+		"ROUTE_SYNC_CORRECTED": 3,
+		"ROUTE_SYNC_DELETED":   4,
+	}
+)
+
+func (x RouteSyncAction) Enum() *RouteSyncAction {
+	p := new(RouteSyncAction)
+	*p = x
+	return p
+}
+
+func (x RouteSyncAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RouteSyncAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_sidecar_proto_enumTypes[4].Descriptor()
+}
+
+func (RouteSyncAction) Type() protoreflect.EnumType {
+	return &file_router_sidecar_proto_enumTypes[4]
+}
+
+func (x RouteSyncAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RouteSyncAction.Descriptor instead.
+func (RouteSyncAction) EnumDescriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{4}
+}
+
+// NextHopHealth - Health of a next hop as seen by the next hop health checker
+type NextHopHealth int32
+
+const (
+	// Health checking is disabled or the next hop was not probed yet
+	NextHopHealth_NEXTHOP_HEALTH_UNKNOWN NextHopHealth = 0
+	NextHopHealth_NEXTHOP_HEALTHY        NextHopHealth = 1
+	NextHopHealth_NEXTHOP_UNHEALTHY      NextHopHealth = 2
+)
+
+// Enum value maps for NextHopHealth.
+var (
+	NextHopHealth_name = map[int32]string{
+		0: "NEXTHOP_HEALTH_UNKNOWN",
+		1: "NEXTHOP_HEALTHY",
+		2: "NEXTHOP_UNHEALTHY",
+	}
+	NextHopHealth_value = map[string]int32{
+		"NEXTHOP_HEALTH_UNKNOWN": 0,
+		"NEXTHOP_HEALTHY":        1,
+		"NEXTHOP_UNHEALTHY":      2,
+	}
+)
+
+func (x NextHopHealth) Enum() *NextHopHealth {
+	p := new(NextHopHealth)
+	*p = x
+	return p
+}
+
+func (x NextHopHealth) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NextHopHealth) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_sidecar_proto_enumTypes[5].Descriptor()
+}
+
+func (NextHopHealth) Type() protoreflect.EnumType {
+	return &file_router_sidecar_proto_enumTypes[5]
+}
+
+func (x NextHopHealth) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NextHopHealth.Descriptor instead.
+func (NextHopHealth) EnumDescriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{5}
+}
+
+// RouteForwardingState - How a route forwards given the health of its next hops
+type RouteForwardingState int32
+
+const (
+	// The route forwards via all its next hops
+	RouteForwardingState_FORWARDING_ACTIVE RouteForwardingState = 0
+	// Some next hops are down, the route forwards via the others
+	RouteForwardingState_FORWARDING_DEGRADED RouteForwardingState = 1
+	// All next hops are down, the route is left in place until they recover
+	RouteForwardingState_FORWARDING_DOWN RouteForwardingState = 2
+	// All next hops are down, the traffic to the route is dropped until they recover
+	RouteForwardingState_FORWARDING_BLACKHOLED RouteForwardingState = 3
+	// All next hops are down, the route is removed from the dataplane until they recover
+	RouteForwardingState_FORWARDING_WITHDRAWN RouteForwardingState = 4
+)
+
+// Enum value maps for RouteForwardingState.
+var (
+	RouteForwardingState_name = map[int32]string{
+		0: "FORWARDING_ACTIVE",
+		1: "FORWARDING_DEGRADED",
+		2: "FORWARDING_DOWN",
+		3: "FORWARDING_BLACKHOLED",
+		4: "FORWARDING_WITHDRAWN",
+	}
+	RouteForwardingState_value = map[string]int32{
+		"FORWARDING_ACTIVE":     0,
+		"FORWARDING_DEGRADED":   1,
+		"FORWARDING_DOWN":       2,
+		"FORWARDING_BLACKHOLED": 3,
+		"FORWARDING_WITHDRAWN":  4,
+	}
+)
+
+func (x RouteForwardingState) Enum() *RouteForwardingState {
+	p := new(RouteForwardingState)
+	*p = x
+	return p
+}
+
+func (x RouteForwardingState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RouteForwardingState) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_sidecar_proto_enumTypes[6].Descriptor()
+}
+
+func (RouteForwardingState) Type() protoreflect.EnumType {
+	return &file_router_sidecar_proto_enumTypes[6]
+}
+
+func (x RouteForwardingState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RouteForwardingState.Descriptor instead.
+func (RouteForwardingState) EnumDescriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{6}
+}
+
+// DeletionReason - Why a route was removed from the slice router
+type DeletionReason int32
+
+const (
+	// The operation is not a deletion
+	DeletionReason_DELETION_NONE DeletionReason = 0
+	// The route was injected with no next hops
+	DeletionReason_DELETION_EXPLICIT DeletionReason = 1
+	// The route is not in the desired set of an EnsureRoutes or SyncRoutes request
+	DeletionReason_DELETION_NOT_DESIRED DeletionReason = 2
+	// The routes were flushed on shutdown per the shutdown route policy
+	DeletionReason_DELETION_SHUTDOWN_FLUSH DeletionReason = 3
+)
+
+// Enum value maps for DeletionReason.
+var (
+	DeletionReason_name = map[int32]string{
+		0: "DELETION_NONE",
+		1: "DELETION_EXPLICIT",
+		2: "DELETION_NOT_DESIRED",
+		3: "DELETION_SHUTDOWN_FLUSH",
+	}
+	DeletionReason_value = map[string]int32{
+		"DELETION_NONE":           0,
+		"DELETION_EXPLICIT":       1,
+		"DELETION_NOT_DESIRED":    2,
+		"DELETION_SHUTDOWN_FLUSH": 3,
+	}
+)
+
+func (x DeletionReason) Enum() *DeletionReason {
+	p := new(DeletionReason)
+	*p = x
+	return p
+}
+
+func (x DeletionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeletionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_sidecar_proto_enumTypes[7].Descriptor()
+}
+
+func (DeletionReason) Type() protoreflect.EnumType {
+	return &file_router_sidecar_proto_enumTypes[7]
+}
+
+func (x DeletionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeletionReason.Descriptor instead.
+func (DeletionReason) EnumDescriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{7}
+}
+
 // SidecarResponse represents the Sidecar response format.
 type SidecarResponse struct {
 	state         protoimpl.MessageState
@@ -349,19 +751,21 @@ func (x *VerifyRouteAddResponse) GetIsRoutePresent() bool {
 	return false
 }
 
-type EcmpUpdateInfo struct {
+type RouteStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// Remote slice-gw NSM subnet
-	RemoteSliceGwNsmSubnet string `protobuf:"bytes,1,opt,name=remoteSliceGwNsmSubnet,proto3" json:"remoteSliceGwNsmSubnet,omitempty"`
-	// Local NSM ip to remove
-	NsmIPToRemove string `protobuf:"bytes,8,opt,name=nsmIPToRemove,proto3" json:"nsmIPToRemove,omitempty"`
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// Expected next hop IP. If empty, the next hops recorded by the sidecar are expected.
+	NextHopIP string `protobuf:"bytes,2,opt,name=nextHopIP,proto3" json:"nextHopIP,omitempty"`
+	// Report the neighbor state of the installed next hops. Kernel dataplane only.
+	IncludeNeighborState bool `protobuf:"varint,3,opt,name=includeNeighborState,proto3" json:"includeNeighborState,omitempty"`
 }
 
-func (x *EcmpUpdateInfo) Reset() {
-	*x = EcmpUpdateInfo{}
+func (x *RouteStatusRequest) Reset() {
+	*x = RouteStatusRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_router_sidecar_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -369,13 +773,13 @@ func (x *EcmpUpdateInfo) Reset() {
 	}
 }
 
-func (x *EcmpUpdateInfo) String() string {
+func (x *RouteStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EcmpUpdateInfo) ProtoMessage() {}
+func (*RouteStatusRequest) ProtoMessage() {}
 
-func (x *EcmpUpdateInfo) ProtoReflect() protoreflect.Message {
+func (x *RouteStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_router_sidecar_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -387,43 +791,46 @@ func (x *EcmpUpdateInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EcmpUpdateInfo.ProtoReflect.Descriptor instead.
-func (*EcmpUpdateInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use RouteStatusRequest.ProtoReflect.Descriptor instead.
+func (*RouteStatusRequest) Descriptor() ([]byte, []int) {
 	return file_router_sidecar_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *EcmpUpdateInfo) GetRemoteSliceGwNsmSubnet() string {
+func (x *RouteStatusRequest) GetRemoteSubnet() string {
 	if x != nil {
-		return x.RemoteSliceGwNsmSubnet
+		return x.RemoteSubnet
 	}
 	return ""
 }
 
-func (x *EcmpUpdateInfo) GetNsmIPToRemove() string {
+func (x *RouteStatusRequest) GetNextHopIP() string {
 	if x != nil {
-		return x.NsmIPToRemove
+		return x.NextHopIP
 	}
 	return ""
 }
 
-// ConnectionInfo - Slice Router client connection information
-type ConnectionInfo struct {
+func (x *RouteStatusRequest) GetIncludeNeighborState() bool {
+	if x != nil {
+		return x.IncludeNeighborState
+	}
+	return false
+}
+
+// NextHopNeighbor - Neighbor entry of a next hop of an installed route
+type NextHopNeighbor struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Pod Name of the client
-	PodName string `protobuf:"bytes,1,opt,name=podName,proto3" json:"podName,omitempty"`
-	// Name of the nsm interface created after successful connection
-	NsmInterface string `protobuf:"bytes,2,opt,name=nsmInterface,proto3" json:"nsmInterface,omitempty"`
-	// IP address on the nsm interface on the client
-	NsmIP string `protobuf:"bytes,3,opt,name=nsmIP,proto3" json:"nsmIP,omitempty"`
-	// IP address on the nsm interface on the slice router
-	NsmPeerIP string `protobuf:"bytes,4,opt,name=nsmPeerIP,proto3" json:"nsmPeerIP,omitempty"`
+	NextHopIP string        `protobuf:"bytes,1,opt,name=nextHopIP,proto3" json:"nextHopIP,omitempty"`
+	State     NeighborState `protobuf:"varint,2,opt,name=state,proto3,enum=router.NeighborState" json:"state,omitempty"`
+	// MAC address of the next hop, if resolved
+	HardwareAddr string `protobuf:"bytes,3,opt,name=hardwareAddr,proto3" json:"hardwareAddr,omitempty"`
 }
 
-func (x *ConnectionInfo) Reset() {
-	*x = ConnectionInfo{}
+func (x *NextHopNeighbor) Reset() {
+	*x = NextHopNeighbor{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_router_sidecar_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -431,13 +838,13 @@ func (x *ConnectionInfo) Reset() {
 	}
 }
 
-func (x *ConnectionInfo) String() string {
+func (x *NextHopNeighbor) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectionInfo) ProtoMessage() {}
+func (*NextHopNeighbor) ProtoMessage() {}
 
-func (x *ConnectionInfo) ProtoReflect() protoreflect.Message {
+func (x *NextHopNeighbor) ProtoReflect() protoreflect.Message {
 	mi := &file_router_sidecar_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -449,51 +856,51 @@ func (x *ConnectionInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectionInfo.ProtoReflect.Descriptor instead.
-func (*ConnectionInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use NextHopNeighbor.ProtoReflect.Descriptor instead.
+func (*NextHopNeighbor) Descriptor() ([]byte, []int) {
 	return file_router_sidecar_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ConnectionInfo) GetPodName() string {
-	if x != nil {
-		return x.PodName
-	}
-	return ""
-}
-
-func (x *ConnectionInfo) GetNsmInterface() string {
+func (x *NextHopNeighbor) GetNextHopIP() string {
 	if x != nil {
-		return x.NsmInterface
+		return x.NextHopIP
 	}
 	return ""
 }
 
-func (x *ConnectionInfo) GetNsmIP() string {
+func (x *NextHopNeighbor) GetState() NeighborState {
 	if x != nil {
-		return x.NsmIP
+		return x.State
 	}
-	return ""
+	return NeighborState_NEIGHBOR_NONE
 }
 
-func (x *ConnectionInfo) GetNsmPeerIP() string {
+func (x *NextHopNeighbor) GetHardwareAddr() string {
 	if x != nil {
-		return x.NsmPeerIP
+		return x.HardwareAddr
 	}
 	return ""
 }
 
-// ClientConnectionInfo - Consolidated client connection information.
-// Represents all clients connected to the slice router.
-type ClientConnectionInfo struct {
+type RouteStatusResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Connection []*ConnectionInfo `protobuf:"bytes,1,rep,name=connection,proto3" json:"connection,omitempty"`
+	// isInstalled is true if the route is installed with the expected next hop
+	IsInstalled bool `protobuf:"varint,1,opt,name=isInstalled,proto3" json:"isInstalled,omitempty"`
+	// State of the route in the dataplane
+	State RouteState `protobuf:"varint,2,opt,name=state,proto3,enum=router.RouteState" json:"state,omitempty"`
+	// Next hops recorded by the sidecar for the remote subnet
+	CachedNextHopIPList []string `protobuf:"bytes,3,rep,name=cachedNextHopIPList,proto3" json:"cachedNextHopIPList,omitempty"`
+	// Next hops of the route installed in the dataplane
+	InstalledNextHopIPList []string `protobuf:"bytes,4,rep,name=installedNextHopIPList,proto3" json:"installedNextHopIPList,omitempty"`
+	// Neighbor state of the installed next hops, if requested
+	NextHopNeighbors []*NextHopNeighbor `protobuf:"bytes,5,rep,name=nextHopNeighbors,proto3" json:"nextHopNeighbors,omitempty"`
 }
 
-func (x *ClientConnectionInfo) Reset() {
-	*x = ClientConnectionInfo{}
+func (x *RouteStatusResponse) Reset() {
+	*x = RouteStatusResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_router_sidecar_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -501,13 +908,13 @@ func (x *ClientConnectionInfo) Reset() {
 	}
 }
 
-func (x *ClientConnectionInfo) String() string {
+func (x *RouteStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientConnectionInfo) ProtoMessage() {}
+func (*RouteStatusResponse) ProtoMessage() {}
 
-func (x *ClientConnectionInfo) ProtoReflect() protoreflect.Message {
+func (x *RouteStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_router_sidecar_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -519,111 +926,4167 @@ func (x *ClientConnectionInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientConnectionInfo.ProtoReflect.Descriptor instead.
-func (*ClientConnectionInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use RouteStatusResponse.ProtoReflect.Descriptor instead.
+func (*RouteStatusResponse) Descriptor() ([]byte, []int) {
 	return file_router_sidecar_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ClientConnectionInfo) GetConnection() []*ConnectionInfo {
+func (x *RouteStatusResponse) GetIsInstalled() bool {
 	if x != nil {
-		return x.Connection
+		return x.IsInstalled
 	}
-	return nil
+	return false
 }
 
-var File_router_sidecar_proto protoreflect.FileDescriptor
-
-var file_router_sidecar_proto_rawDesc = []byte{
-	0x0a, 0x14, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x1a, 0x1b,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2f, 0x0a, 0x0f, 0x53,
-	0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x22, 0x9e, 0x03, 0x0a,
-	0x11, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x43, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x78, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x49, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65,
-	0x47, 0x77, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69,
-	0x63, 0x65, 0x47, 0x77, 0x56, 0x70, 0x6e, 0x49, 0x50, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x11, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x56, 0x70, 0x6e,
-	0x49, 0x50, 0x12, 0x4b, 0x0a, 0x14, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65,
-	0x47, 0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47,
-	0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x14, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
-	0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x34, 0x0a, 0x15, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e,
-	0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53,
-	0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x36, 0x0a, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53,
-	0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x6c, 0x69,
-	0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x2a, 0x0a,
-	0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x73, 0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72, 0x49,
-	0x50, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x73,
-	0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x12, 0x32, 0x0a, 0x14, 0x6c, 0x6f, 0x63,
-	0x61, 0x6c, 0x4e, 0x73, 0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x4c, 0x69, 0x73,
-	0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x73,
-	0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x43, 0x0a,
-	0x15, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x64, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x12, 0x14, 0x0a, 0x05,
-	0x64, 0x73, 0x74, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x73, 0x74,
-	0x49, 0x50, 0x22, 0x40, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x6f, 0x75, 0x74,
-	0x65, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x0e,
-	0x69, 0x73, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x73, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x50, 0x72, 0x65,
-	0x73, 0x65, 0x6e, 0x74, 0x22, 0x6e, 0x0a, 0x0e, 0x45, 0x63, 0x6d, 0x70, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
-	0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x6c,
-	0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x24,
-	0x0a, 0x0d, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x54, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x54, 0x6f, 0x52, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x22, 0x82, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x73, 0x6d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x73, 0x6d, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
-	0x73, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x6e, 0x73, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x22, 0x4e, 0x0a, 0x14, 0x43, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x36, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x3b, 0x0a, 0x0f, 0x53, 0x6c, 0x69,
-	0x63, 0x65, 0x47, 0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x13, 0x0a, 0x0f,
-	0x53, 0x4c, 0x49, 0x43, 0x45, 0x5f, 0x47, 0x57, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10,
-	0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4c, 0x49, 0x43, 0x45, 0x5f, 0x47, 0x57, 0x5f, 0x43, 0x4c,
-	0x49, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x32, 0xed, 0x02, 0x0a, 0x19, 0x53, 0x6c, 0x69, 0x63, 0x65,
-	0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6c,
-	0x69, 0x63, 0x65, 0x47, 0x77, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
-	0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x43, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x1a, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63,
-	0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x22,
-	0x47, 0x65, 0x74, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x72, 0x6f, 0x75,
-	0x74, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x10, 0x47, 0x65,
-	0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x1d,
-	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x6f,
-	0x75, 0x74, 0x65, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
-	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x6f, 0x75,
-	0x74, 0x65, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x45, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x63, 0x6d, 0x70, 0x52, 0x6f, 0x75,
-	0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x45, 0x63, 0x6d,
-	0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x17, 0x2e, 0x72, 0x6f,
-	0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2f, 0x3b, 0x73, 0x69, 0x64,
-	0x65, 0x63, 0x61, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *RouteStatusResponse) GetState() RouteState {
+	if x != nil {
+		return x.State
+	}
+	return RouteState_ROUTE_ABSENT
+}
+
+func (x *RouteStatusResponse) GetCachedNextHopIPList() []string {
+	if x != nil {
+		return x.CachedNextHopIPList
+	}
+	return nil
+}
+
+func (x *RouteStatusResponse) GetInstalledNextHopIPList() []string {
+	if x != nil {
+		return x.InstalledNextHopIPList
+	}
+	return nil
+}
+
+func (x *RouteStatusResponse) GetNextHopNeighbors() []*NextHopNeighbor {
+	if x != nil {
+		return x.NextHopNeighbors
+	}
+	return nil
+}
+
+// RouteInfo - Route to a remote subnet
+type RouteInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnet
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// Next hop IPs of the route. An empty list deletes the route. A next hop given as
+	// "dev:<interface name>" routes out of the nsm interface without a gateway, and one given as
+	// "pod:<pod name>" routes to the current nsm IP of the pod, in the kernel dataplane only.
+	NextHopIPList []string `protobuf:"bytes,2,rep,name=nextHopIPList,proto3" json:"nextHopIPList,omitempty"`
+	// Preferred source address of the traffic routed to the remote subnet, must be a local address.
+	// The kernel picks the source if empty. Kernel dataplane only.
+	PreferredSrc string `protobuf:"bytes,3,opt,name=preferredSrc,proto3" json:"preferredSrc,omitempty"`
+	// Human-readable description of the route, e.g. "cluster-east via gw-2". It is kept by the sidecar
+	// and shown in the route table and logs, the dataplane does not see it.
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// How often the reconcile checks the route, normal by default
+	ReconcilePriority ReconcilePriority `protobuf:"varint,5,opt,name=reconcilePriority,proto3,enum=router.ReconcilePriority" json:"reconcilePriority,omitempty"`
+	// Weight of the vpp entries of the route, for unequal cost load balancing between the routes of
+	// the next hops. Unset by default, up to 255. Vpp dataplane only.
+	VppWeight uint32 `protobuf:"varint,6,opt,name=vppWeight,proto3" json:"vppWeight,omitempty"`
+	// Preference of the vpp entries of the route, the lower the preferred. Only the entries of the best
+	// preference forward. Unset by default, up to 255. Vpp dataplane only.
+	VppPreference uint32 `protobuf:"varint,7,opt,name=vppPreference,proto3" json:"vppPreference,omitempty"`
+}
+
+func (x *RouteInfo) Reset() {
+	*x = RouteInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteInfo) ProtoMessage() {}
+
+func (x *RouteInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteInfo.ProtoReflect.Descriptor instead.
+func (*RouteInfo) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RouteInfo) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *RouteInfo) GetNextHopIPList() []string {
+	if x != nil {
+		return x.NextHopIPList
+	}
+	return nil
+}
+
+func (x *RouteInfo) GetPreferredSrc() string {
+	if x != nil {
+		return x.PreferredSrc
+	}
+	return ""
+}
+
+func (x *RouteInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RouteInfo) GetReconcilePriority() ReconcilePriority {
+	if x != nil {
+		return x.ReconcilePriority
+	}
+	return ReconcilePriority_RECONCILE_PRIORITY_NORMAL
+}
+
+func (x *RouteInfo) GetVppWeight() uint32 {
+	if x != nil {
+		return x.VppWeight
+	}
+	return 0
+}
+
+func (x *RouteInfo) GetVppPreference() uint32 {
+	if x != nil {
+		return x.VppPreference
+	}
+	return 0
+}
+
+// PolicyRoute - Route to a remote subnet installed in a custom routing table, along with the ip rule
+// steering the traffic from a source prefix into the table. Only supported in kernel mode.
+type PolicyRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnet
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// Next hop IPs of the route. An empty list deletes the route, and the rule once no other
+	// policy route uses it.
+	NextHopIPList []string `protobuf:"bytes,2,rep,name=nextHopIPList,proto3" json:"nextHopIPList,omitempty"`
+	// Routing table of the route. The reserved tables cannot be used.
+	Table uint32 `protobuf:"varint,3,opt,name=table,proto3" json:"table,omitempty"`
+	// Source prefix of the traffic steered into the table
+	SrcPrefix string `protobuf:"bytes,4,opt,name=srcPrefix,proto3" json:"srcPrefix,omitempty"`
+	// Priority of the ip rule. Zero lets the kernel pick one.
+	RulePriority uint32 `protobuf:"varint,5,opt,name=rulePriority,proto3" json:"rulePriority,omitempty"`
+}
+
+func (x *PolicyRoute) Reset() {
+	*x = PolicyRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyRoute) ProtoMessage() {}
+
+func (x *PolicyRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyRoute.ProtoReflect.Descriptor instead.
+func (*PolicyRoute) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PolicyRoute) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *PolicyRoute) GetNextHopIPList() []string {
+	if x != nil {
+		return x.NextHopIPList
+	}
+	return nil
+}
+
+func (x *PolicyRoute) GetTable() uint32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+func (x *PolicyRoute) GetSrcPrefix() string {
+	if x != nil {
+		return x.SrcPrefix
+	}
+	return ""
+}
+
+func (x *PolicyRoute) GetRulePriority() uint32 {
+	if x != nil {
+		return x.RulePriority
+	}
+	return 0
+}
+
+// ReconcileRequest - On-demand reconcile of the slice router routing table
+type ReconcileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CIDRs the reconciled remote subnets must be within. All routes are reconciled if empty.
+	Prefixes []string `protobuf:"bytes,1,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+}
+
+func (x *ReconcileRequest) Reset() {
+	*x = ReconcileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconcileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRequest) ProtoMessage() {}
+
+func (x *ReconcileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReconcileRequest) GetPrefixes() []string {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+// RefreshNextHopsResponse - Routes reprogrammed after resolving their next hop pods again
+type RefreshNextHopsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnets whose routes were reprogrammed with the new IPs of their next hop pods
+	RefreshedSubnets []string `protobuf:"bytes,1,rep,name=refreshedSubnets,proto3" json:"refreshedSubnets,omitempty"`
+	// Remote subnets whose routes could not be reprogrammed, left to the reconcile
+	FailedSubnets []string `protobuf:"bytes,2,rep,name=failedSubnets,proto3" json:"failedSubnets,omitempty"`
+}
+
+func (x *RefreshNextHopsResponse) Reset() {
+	*x = RefreshNextHopsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshNextHopsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshNextHopsResponse) ProtoMessage() {}
+
+func (x *RefreshNextHopsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshNextHopsResponse.ProtoReflect.Descriptor instead.
+func (*RefreshNextHopsResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RefreshNextHopsResponse) GetRefreshedSubnets() []string {
+	if x != nil {
+		return x.RefreshedSubnets
+	}
+	return nil
+}
+
+func (x *RefreshNextHopsResponse) GetFailedSubnets() []string {
+	if x != nil {
+		return x.FailedSubnets
+	}
+	return nil
+}
+
+// RouteBatch - Routes to be injected in the slice router together
+type RouteBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*RouteInfo `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	// Apply the batch as a single transaction, rolling back all routes if any of them fails.
+	// Supported in the kernel and vpp modes.
+	Atomic bool `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+}
+
+func (x *RouteBatch) Reset() {
+	*x = RouteBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteBatch) ProtoMessage() {}
+
+func (x *RouteBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteBatch.ProtoReflect.Descriptor instead.
+func (*RouteBatch) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RouteBatch) GetRoutes() []*RouteInfo {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *RouteBatch) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+// RouteResult - Result of injecting a single route
+type RouteResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnet
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// isInstalled is true if the route is now in the requested state
+	IsInstalled bool `protobuf:"varint,2,opt,name=isInstalled,proto3" json:"isInstalled,omitempty"`
+	// Error encountered while injecting the route
+	ErrorMsg string `protobuf:"bytes,3,opt,name=errorMsg,proto3" json:"errorMsg,omitempty"`
+}
+
+func (x *RouteResult) Reset() {
+	*x = RouteResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteResult) ProtoMessage() {}
+
+func (x *RouteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteResult.ProtoReflect.Descriptor instead.
+func (*RouteResult) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RouteResult) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *RouteResult) GetIsInstalled() bool {
+	if x != nil {
+		return x.IsInstalled
+	}
+	return false
+}
+
+func (x *RouteResult) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+// RouteBatchResponse - Results of injecting a batch of routes, in request order
+type RouteBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*RouteResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *RouteBatchResponse) Reset() {
+	*x = RouteBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteBatchResponse) ProtoMessage() {}
+
+func (x *RouteBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteBatchResponse.ProtoReflect.Descriptor instead.
+func (*RouteBatchResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RouteBatchResponse) GetResults() []*RouteResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// EnsureRoutesRequest - Complete set of routes the slice router should have
+type EnsureRoutesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*RouteInfo `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	// Generation of the desired state. Requests older than the last applied generation are ignored.
+	Generation uint64 `protobuf:"varint,2,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (x *EnsureRoutesRequest) Reset() {
+	*x = EnsureRoutesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnsureRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnsureRoutesRequest) ProtoMessage() {}
+
+func (x *EnsureRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnsureRoutesRequest.ProtoReflect.Descriptor instead.
+func (*EnsureRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EnsureRoutesRequest) GetRoutes() []*RouteInfo {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *EnsureRoutesRequest) GetGeneration() uint64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+// EnsureRoutesResponse - Result of bringing the slice router routes to the desired state
+type EnsureRoutesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Results of the desired routes in request order, followed by the results of the removed routes
+	Results []*RouteResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Last generation applied by the slice router
+	AppliedGeneration uint64 `protobuf:"varint,2,opt,name=appliedGeneration,proto3" json:"appliedGeneration,omitempty"`
+	// True if the request was ignored because its generation is older than the applied generation
+	Stale bool `protobuf:"varint,3,opt,name=stale,proto3" json:"stale,omitempty"`
+}
+
+func (x *EnsureRoutesResponse) Reset() {
+	*x = EnsureRoutesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnsureRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnsureRoutesResponse) ProtoMessage() {}
+
+func (x *EnsureRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnsureRoutesResponse.ProtoReflect.Descriptor instead.
+func (*EnsureRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EnsureRoutesResponse) GetResults() []*RouteResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *EnsureRoutesResponse) GetAppliedGeneration() uint64 {
+	if x != nil {
+		return x.AppliedGeneration
+	}
+	return 0
+}
+
+func (x *EnsureRoutesResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+// SyncRoutesRequest - Complete set of routes the slice router should converge to
+type SyncRoutesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*RouteInfo `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+}
+
+func (x *SyncRoutesRequest) Reset() {
+	*x = SyncRoutesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRoutesRequest) ProtoMessage() {}
+
+func (x *SyncRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRoutesRequest.ProtoReflect.Descriptor instead.
+func (*SyncRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SyncRoutesRequest) GetRoutes() []*RouteInfo {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+// RouteSyncResult - Result of bringing a route to the desired state
+type RouteSyncResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnet
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// Change made to the route
+	Action RouteSyncAction `protobuf:"varint,2,opt,name=action,proto3,enum=router.RouteSyncAction" json:"action,omitempty"`
+	// isInstalled is true if the route is now in the desired state
+	IsInstalled bool `protobuf:"varint,3,opt,name=isInstalled,proto3" json:"isInstalled,omitempty"`
+	// Error encountered while changing the route
+	ErrorMsg string `protobuf:"bytes,4,opt,name=errorMsg,proto3" json:"errorMsg,omitempty"`
+	// Next hop IPs of the route before the request, empty if the route was missing
+	PreviousNextHopIPList []string `protobuf:"bytes,5,rep,name=previousNextHopIPList,proto3" json:"previousNextHopIPList,omitempty"`
+	// Next hop IPs of the route requested, empty if the route was deleted
+	NextHopIPList []string `protobuf:"bytes,6,rep,name=nextHopIPList,proto3" json:"nextHopIPList,omitempty"`
+	// What was corrected on the installed route, for corrected routes: missing, link, weight, pod,
+	// stale or the drifted route attribute
+	Correction string `protobuf:"bytes,7,opt,name=correction,proto3" json:"correction,omitempty"`
+}
+
+func (x *RouteSyncResult) Reset() {
+	*x = RouteSyncResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteSyncResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteSyncResult) ProtoMessage() {}
+
+func (x *RouteSyncResult) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteSyncResult.ProtoReflect.Descriptor instead.
+func (*RouteSyncResult) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RouteSyncResult) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *RouteSyncResult) GetAction() RouteSyncAction {
+	if x != nil {
+		return x.Action
+	}
+	return RouteSyncAction_ROUTE_SYNC_UNCHANGED
+}
+
+func (x *RouteSyncResult) GetIsInstalled() bool {
+	if x != nil {
+		return x.IsInstalled
+	}
+	return false
+}
+
+func (x *RouteSyncResult) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *RouteSyncResult) GetPreviousNextHopIPList() []string {
+	if x != nil {
+		return x.PreviousNextHopIPList
+	}
+	return nil
+}
+
+func (x *RouteSyncResult) GetNextHopIPList() []string {
+	if x != nil {
+		return x.NextHopIPList
+	}
+	return nil
+}
+
+func (x *RouteSyncResult) GetCorrection() string {
+	if x != nil {
+		return x.Correction
+	}
+	return ""
+}
+
+// VppRouteCleanupRequest - Prefix whose vpp route entries are converged to the next hops of its route
+type VppRouteCleanupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnet
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+}
+
+func (x *VppRouteCleanupRequest) Reset() {
+	*x = VppRouteCleanupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VppRouteCleanupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VppRouteCleanupRequest) ProtoMessage() {}
+
+func (x *VppRouteCleanupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VppRouteCleanupRequest.ProtoReflect.Descriptor instead.
+func (*VppRouteCleanupRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *VppRouteCleanupRequest) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+// VppRouteCleanupResponse - Vpp route entries of a prefix converged to the next hops of its route
+type VppRouteCleanupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnet
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// Next hops of the entries that were already desired
+	KeptNextHopIPList []string `protobuf:"bytes,2,rep,name=keptNextHopIPList,proto3" json:"keptNextHopIPList,omitempty"`
+	// Next hops of the stale entries deleted
+	RemovedNextHopIPList []string `protobuf:"bytes,3,rep,name=removedNextHopIPList,proto3" json:"removedNextHopIPList,omitempty"`
+	// Next hops of the desired entries that were missing and were added
+	AddedNextHopIPList []string `protobuf:"bytes,4,rep,name=addedNextHopIPList,proto3" json:"addedNextHopIPList,omitempty"`
+	// Next hops of the desired entries whose weight or preference were corrected
+	UpdatedNextHopIPList []string `protobuf:"bytes,5,rep,name=updatedNextHopIPList,proto3" json:"updatedNextHopIPList,omitempty"`
+}
+
+func (x *VppRouteCleanupResponse) Reset() {
+	*x = VppRouteCleanupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VppRouteCleanupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VppRouteCleanupResponse) ProtoMessage() {}
+
+func (x *VppRouteCleanupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VppRouteCleanupResponse.ProtoReflect.Descriptor instead.
+func (*VppRouteCleanupResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VppRouteCleanupResponse) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *VppRouteCleanupResponse) GetKeptNextHopIPList() []string {
+	if x != nil {
+		return x.KeptNextHopIPList
+	}
+	return nil
+}
+
+func (x *VppRouteCleanupResponse) GetRemovedNextHopIPList() []string {
+	if x != nil {
+		return x.RemovedNextHopIPList
+	}
+	return nil
+}
+
+func (x *VppRouteCleanupResponse) GetAddedNextHopIPList() []string {
+	if x != nil {
+		return x.AddedNextHopIPList
+	}
+	return nil
+}
+
+func (x *VppRouteCleanupResponse) GetUpdatedNextHopIPList() []string {
+	if x != nil {
+		return x.UpdatedNextHopIPList
+	}
+	return nil
+}
+
+// SyncRoutesResponse - Changes made to bring the slice router routes to the desired state
+type SyncRoutesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Results of the desired routes in request order, followed by the results of the removed routes
+	Results []*RouteSyncResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SyncRoutesResponse) Reset() {
+	*x = SyncRoutesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRoutesResponse) ProtoMessage() {}
+
+func (x *SyncRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRoutesResponse.ProtoReflect.Descriptor instead.
+func (*SyncRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SyncRoutesResponse) GetResults() []*RouteSyncResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// NextHopStatus - Status of a next hop of a route
+type NextHopStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NextHopIP string        `protobuf:"bytes,1,opt,name=nextHopIP,proto3" json:"nextHopIP,omitempty"`
+	Health    NextHopHealth `protobuf:"varint,2,opt,name=health,proto3,enum=router.NextHopHealth" json:"health,omitempty"`
+}
+
+func (x *NextHopStatus) Reset() {
+	*x = NextHopStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextHopStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextHopStatus) ProtoMessage() {}
+
+func (x *NextHopStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextHopStatus.ProtoReflect.Descriptor instead.
+func (*NextHopStatus) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NextHopStatus) GetNextHopIP() string {
+	if x != nil {
+		return x.NextHopIP
+	}
+	return ""
+}
+
+func (x *NextHopStatus) GetHealth() NextHopHealth {
+	if x != nil {
+		return x.Health
+	}
+	return NextHopHealth_NEXTHOP_HEALTH_UNKNOWN
+}
+
+// RouteEntry - Route to a remote subnet injected in the slice router
+type RouteEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnet
+	RemoteSubnet string `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// Next hop IPs requested for the route
+	NextHopIPList []string `protobuf:"bytes,2,rep,name=nextHopIPList,proto3" json:"nextHopIPList,omitempty"`
+	// Status of each next hop
+	NextHopStatus []*NextHopStatus `protobuf:"bytes,3,rep,name=nextHopStatus,proto3" json:"nextHopStatus,omitempty"`
+	// Description of the route supplied by the controller
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Subnets the route aggregates, if it was installed by InjectAggregateRoute
+	AggregatedSubnets []string `protobuf:"bytes,5,rep,name=aggregatedSubnets,proto3" json:"aggregatedSubnets,omitempty"`
+	// How the route forwards given the health of its next hops
+	ForwardingState RouteForwardingState `protobuf:"varint,6,opt,name=forwardingState,proto3,enum=router.RouteForwardingState" json:"forwardingState,omitempty"`
+	// Reconcile tier of the route
+	ReconcilePriority ReconcilePriority `protobuf:"varint,7,opt,name=reconcilePriority,proto3,enum=router.ReconcilePriority" json:"reconcilePriority,omitempty"`
+	// Weight and preference of the vpp entries of the route, zero if unset
+	VppWeight     uint32 `protobuf:"varint,8,opt,name=vppWeight,proto3" json:"vppWeight,omitempty"`
+	VppPreference uint32 `protobuf:"varint,9,opt,name=vppPreference,proto3" json:"vppPreference,omitempty"`
+	// Route group of the route, if it was installed by InjectRouteGroup
+	RouteGroup string `protobuf:"bytes,10,opt,name=routeGroup,proto3" json:"routeGroup,omitempty"`
+}
+
+func (x *RouteEntry) Reset() {
+	*x = RouteEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteEntry) ProtoMessage() {}
+
+func (x *RouteEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteEntry.ProtoReflect.Descriptor instead.
+func (*RouteEntry) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RouteEntry) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *RouteEntry) GetNextHopIPList() []string {
+	if x != nil {
+		return x.NextHopIPList
+	}
+	return nil
+}
+
+func (x *RouteEntry) GetNextHopStatus() []*NextHopStatus {
+	if x != nil {
+		return x.NextHopStatus
+	}
+	return nil
+}
+
+func (x *RouteEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RouteEntry) GetAggregatedSubnets() []string {
+	if x != nil {
+		return x.AggregatedSubnets
+	}
+	return nil
+}
+
+func (x *RouteEntry) GetForwardingState() RouteForwardingState {
+	if x != nil {
+		return x.ForwardingState
+	}
+	return RouteForwardingState_FORWARDING_ACTIVE
+}
+
+func (x *RouteEntry) GetReconcilePriority() ReconcilePriority {
+	if x != nil {
+		return x.ReconcilePriority
+	}
+	return ReconcilePriority_RECONCILE_PRIORITY_NORMAL
+}
+
+func (x *RouteEntry) GetVppWeight() uint32 {
+	if x != nil {
+		return x.VppWeight
+	}
+	return 0
+}
+
+func (x *RouteEntry) GetVppPreference() uint32 {
+	if x != nil {
+		return x.VppPreference
+	}
+	return 0
+}
+
+func (x *RouteEntry) GetRouteGroup() string {
+	if x != nil {
+		return x.RouteGroup
+	}
+	return ""
+}
+
+// AggregateRouteRequest - Remote subnets routed via the same next hops, installed as aggregate routes
+type AggregateRouteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote subnets to aggregate
+	Subnets []string `protobuf:"bytes,1,rep,name=subnets,proto3" json:"subnets,omitempty"`
+	// Next hop IPs of the subnets. An empty list deletes the routes to the subnets.
+	NextHopIPList []string `protobuf:"bytes,2,rep,name=nextHopIPList,proto3" json:"nextHopIPList,omitempty"`
+}
+
+func (x *AggregateRouteRequest) Reset() {
+	*x = AggregateRouteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateRouteRequest) ProtoMessage() {}
+
+func (x *AggregateRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateRouteRequest.ProtoReflect.Descriptor instead.
+func (*AggregateRouteRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AggregateRouteRequest) GetSubnets() []string {
+	if x != nil {
+		return x.Subnets
+	}
+	return nil
+}
+
+func (x *AggregateRouteRequest) GetNextHopIPList() []string {
+	if x != nil {
+		return x.NextHopIPList
+	}
+	return nil
+}
+
+// AggregateRouteResponse - Routes installed for an aggregate route request
+type AggregateRouteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Aggregates covering the requested subnets
+	Aggregates []string `protobuf:"bytes,1,rep,name=aggregates,proto3" json:"aggregates,omitempty"`
+}
+
+func (x *AggregateRouteResponse) Reset() {
+	*x = AggregateRouteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateRouteResponse) ProtoMessage() {}
+
+func (x *AggregateRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateRouteResponse.ProtoReflect.Descriptor instead.
+func (*AggregateRouteResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AggregateRouteResponse) GetAggregates() []string {
+	if x != nil {
+		return x.Aggregates
+	}
+	return nil
+}
+
+// RouteGroupRequest - Named group of remote subnets routed via the same next hops, installed, deleted
+// and reconciled as one unit
+type RouteGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the group, e.g. the remote cluster whose subnets it routes
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Remote subnets of the group, e.g. the pod and service CIDRs of the remote cluster. Subnets of the
+	// group left out of the request are deleted.
+	RemoteSubnets []string `protobuf:"bytes,2,rep,name=remoteSubnets,proto3" json:"remoteSubnets,omitempty"`
+	// Next hop IPs shared by the routes of the group. An empty list deletes the group.
+	NextHopIPList []string `protobuf:"bytes,3,rep,name=nextHopIPList,proto3" json:"nextHopIPList,omitempty"`
+}
+
+func (x *RouteGroupRequest) Reset() {
+	*x = RouteGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteGroupRequest) ProtoMessage() {}
+
+func (x *RouteGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteGroupRequest.ProtoReflect.Descriptor instead.
+func (*RouteGroupRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RouteGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RouteGroupRequest) GetRemoteSubnets() []string {
+	if x != nil {
+		return x.RemoteSubnets
+	}
+	return nil
+}
+
+func (x *RouteGroupRequest) GetNextHopIPList() []string {
+	if x != nil {
+		return x.NextHopIPList
+	}
+	return nil
+}
+
+// RouteGroupResponse - Routes of a route group after the request
+type RouteGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Remote subnets of the group, empty if the group was deleted
+	RemoteSubnets []string `protobuf:"bytes,2,rep,name=remoteSubnets,proto3" json:"remoteSubnets,omitempty"`
+	// Remote subnets that left the group and were deleted
+	DeletedSubnets []string `protobuf:"bytes,3,rep,name=deletedSubnets,proto3" json:"deletedSubnets,omitempty"`
+}
+
+func (x *RouteGroupResponse) Reset() {
+	*x = RouteGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteGroupResponse) ProtoMessage() {}
+
+func (x *RouteGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteGroupResponse.ProtoReflect.Descriptor instead.
+func (*RouteGroupResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RouteGroupResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RouteGroupResponse) GetRemoteSubnets() []string {
+	if x != nil {
+		return x.RemoteSubnets
+	}
+	return nil
+}
+
+func (x *RouteGroupResponse) GetDeletedSubnets() []string {
+	if x != nil {
+		return x.DeletedSubnets
+	}
+	return nil
+}
+
+// RouteTable - All routes injected in the slice router
+type RouteTable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*RouteEntry `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	// Number of remote subnet routes injected in the slice router
+	RouteCount uint32 `protobuf:"varint,2,opt,name=routeCount,proto3" json:"routeCount,omitempty"`
+	// Configured capacity of the slice route table, zero if unknown
+	Capacity uint32 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// Fraction of the capacity in use, zero if the capacity is unknown
+	Utilization float64 `protobuf:"fixed64,4,opt,name=utilization,proto3" json:"utilization,omitempty"`
+}
+
+func (x *RouteTable) Reset() {
+	*x = RouteTable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteTable) ProtoMessage() {}
+
+func (x *RouteTable) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteTable.ProtoReflect.Descriptor instead.
+func (*RouteTable) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RouteTable) GetRoutes() []*RouteEntry {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *RouteTable) GetRouteCount() uint32 {
+	if x != nil {
+		return x.RouteCount
+	}
+	return 0
+}
+
+func (x *RouteTable) GetCapacity() uint32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *RouteTable) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+type EcmpUpdateInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remote slice-gw NSM subnet
+	RemoteSliceGwNsmSubnet string `protobuf:"bytes,1,opt,name=remoteSliceGwNsmSubnet,proto3" json:"remoteSliceGwNsmSubnet,omitempty"`
+	// Local NSM ip to remove
+	NsmIPToRemove string `protobuf:"bytes,8,opt,name=nsmIPToRemove,proto3" json:"nsmIPToRemove,omitempty"`
+}
+
+func (x *EcmpUpdateInfo) Reset() {
+	*x = EcmpUpdateInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EcmpUpdateInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EcmpUpdateInfo) ProtoMessage() {}
+
+func (x *EcmpUpdateInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EcmpUpdateInfo.ProtoReflect.Descriptor instead.
+func (*EcmpUpdateInfo) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EcmpUpdateInfo) GetRemoteSliceGwNsmSubnet() string {
+	if x != nil {
+		return x.RemoteSliceGwNsmSubnet
+	}
+	return ""
+}
+
+func (x *EcmpUpdateInfo) GetNsmIPToRemove() string {
+	if x != nil {
+		return x.NsmIPToRemove
+	}
+	return ""
+}
+
+// ConnectionInfo - Slice Router client connection information
+type ConnectionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Pod Name of the client
+	PodName string `protobuf:"bytes,1,opt,name=podName,proto3" json:"podName,omitempty"`
+	// Name of the nsm interface created after successful connection
+	NsmInterface string `protobuf:"bytes,2,opt,name=nsmInterface,proto3" json:"nsmInterface,omitempty"`
+	// IP address on the nsm interface on the client
+	NsmIP string `protobuf:"bytes,3,opt,name=nsmIP,proto3" json:"nsmIP,omitempty"`
+	// IP address on the nsm interface on the slice router
+	NsmPeerIP string `protobuf:"bytes,4,opt,name=nsmPeerIP,proto3" json:"nsmPeerIP,omitempty"`
+	// IPv6 address on the nsm interface on the client, set when the slice carries IPv6
+	NsmIPv6 string `protobuf:"bytes,5,opt,name=nsmIPv6,proto3" json:"nsmIPv6,omitempty"`
+	// IPv6 address on the nsm interface on the slice router, set when the slice carries IPv6
+	NsmPeerIPv6 string `protobuf:"bytes,6,opt,name=nsmPeerIPv6,proto3" json:"nsmPeerIPv6,omitempty"`
+	// Whether the slice router has a resolved IPv6 neighbor entry for the client
+	NsmIPv6Reachable bool `protobuf:"varint,7,opt,name=nsmIPv6Reachable,proto3" json:"nsmIPv6Reachable,omitempty"`
+	// When the slice router first listed the connection. A connection that disappears from a listing
+	// is seen again as a new one.
+	FirstSeen *timestamp.Timestamp `protobuf:"bytes,8,opt,name=firstSeen,proto3" json:"firstSeen,omitempty"`
+	// How long the connection has existed since it was first seen
+	Age *duration.Duration `protobuf:"bytes,9,opt,name=age,proto3" json:"age,omitempty"`
+}
+
+func (x *ConnectionInfo) Reset() {
+	*x = ConnectionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionInfo) ProtoMessage() {}
+
+func (x *ConnectionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionInfo.ProtoReflect.Descriptor instead.
+func (*ConnectionInfo) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConnectionInfo) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *ConnectionInfo) GetNsmInterface() string {
+	if x != nil {
+		return x.NsmInterface
+	}
+	return ""
+}
+
+func (x *ConnectionInfo) GetNsmIP() string {
+	if x != nil {
+		return x.NsmIP
+	}
+	return ""
+}
+
+func (x *ConnectionInfo) GetNsmPeerIP() string {
+	if x != nil {
+		return x.NsmPeerIP
+	}
+	return ""
+}
+
+func (x *ConnectionInfo) GetNsmIPv6() string {
+	if x != nil {
+		return x.NsmIPv6
+	}
+	return ""
+}
+
+func (x *ConnectionInfo) GetNsmPeerIPv6() string {
+	if x != nil {
+		return x.NsmPeerIPv6
+	}
+	return ""
+}
+
+func (x *ConnectionInfo) GetNsmIPv6Reachable() bool {
+	if x != nil {
+		return x.NsmIPv6Reachable
+	}
+	return false
+}
+
+func (x *ConnectionInfo) GetFirstSeen() *timestamp.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *ConnectionInfo) GetAge() *duration.Duration {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+// ClientConnectionRequest - Identifies the client connection to look up
+type ClientConnectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Pod Name of the client
+	PodName string `protobuf:"bytes,1,opt,name=podName,proto3" json:"podName,omitempty"`
+}
+
+func (x *ClientConnectionRequest) Reset() {
+	*x = ClientConnectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientConnectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientConnectionRequest) ProtoMessage() {}
+
+func (x *ClientConnectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientConnectionRequest.ProtoReflect.Descriptor instead.
+func (*ClientConnectionRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ClientConnectionRequest) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+// ClientConnectionInfo - Consolidated client connection information.
+// Represents all clients connected to the slice router.
+type ClientConnectionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Connection []*ConnectionInfo `protobuf:"bytes,1,rep,name=connection,proto3" json:"connection,omitempty"`
+}
+
+func (x *ClientConnectionInfo) Reset() {
+	*x = ClientConnectionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientConnectionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientConnectionInfo) ProtoMessage() {}
+
+func (x *ClientConnectionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientConnectionInfo.ProtoReflect.Descriptor instead.
+func (*ClientConnectionInfo) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ClientConnectionInfo) GetConnection() []*ConnectionInfo {
+	if x != nil {
+		return x.Connection
+	}
+	return nil
+}
+
+// DataplaneConnections - Client connections as seen by each dataplane
+type DataplaneConnections struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Connections of the nsm interfaces in the kernel
+	KernelConnections []*ConnectionInfo `protobuf:"bytes,1,rep,name=kernelConnections,proto3" json:"kernelConnections,omitempty"`
+	// Connections of the nsm interfaces in vpp
+	VppConnections []*ConnectionInfo `protobuf:"bytes,2,rep,name=vppConnections,proto3" json:"vppConnections,omitempty"`
+	// Errors hit while listing the connections of a dataplane
+	Errors []string `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *DataplaneConnections) Reset() {
+	*x = DataplaneConnections{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataplaneConnections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataplaneConnections) ProtoMessage() {}
+
+func (x *DataplaneConnections) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataplaneConnections.ProtoReflect.Descriptor instead.
+func (*DataplaneConnections) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DataplaneConnections) GetKernelConnections() []*ConnectionInfo {
+	if x != nil {
+		return x.KernelConnections
+	}
+	return nil
+}
+
+func (x *DataplaneConnections) GetVppConnections() []*ConnectionInfo {
+	if x != nil {
+		return x.VppConnections
+	}
+	return nil
+}
+
+func (x *DataplaneConnections) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// InterfaceRoutes - Remote subnets routed via an nsm interface of the slice router
+type InterfaceRoutes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the nsm interface
+	InterfaceName string `protobuf:"bytes,1,opt,name=interfaceName,proto3" json:"interfaceName,omitempty"`
+	// Pod Name of the client connected over the interface
+	PodName string `protobuf:"bytes,2,opt,name=podName,proto3" json:"podName,omitempty"`
+	// Remote subnets with a next hop reached over the interface
+	RemoteSubnets []string `protobuf:"bytes,3,rep,name=remoteSubnets,proto3" json:"remoteSubnets,omitempty"`
+}
+
+func (x *InterfaceRoutes) Reset() {
+	*x = InterfaceRoutes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterfaceRoutes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceRoutes) ProtoMessage() {}
+
+func (x *InterfaceRoutes) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceRoutes.ProtoReflect.Descriptor instead.
+func (*InterfaceRoutes) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *InterfaceRoutes) GetInterfaceName() string {
+	if x != nil {
+		return x.InterfaceName
+	}
+	return ""
+}
+
+func (x *InterfaceRoutes) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *InterfaceRoutes) GetRemoteSubnets() []string {
+	if x != nil {
+		return x.RemoteSubnets
+	}
+	return nil
+}
+
+// InterfaceRouteList - Remote subnets grouped by outgoing interface
+type InterfaceRouteList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Interfaces []*InterfaceRoutes `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *InterfaceRouteList) Reset() {
+	*x = InterfaceRouteList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterfaceRouteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceRouteList) ProtoMessage() {}
+
+func (x *InterfaceRouteList) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceRouteList.ProtoReflect.Descriptor instead.
+func (*InterfaceRouteList) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *InterfaceRouteList) GetInterfaces() []*InterfaceRoutes {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+// InterfaceCounters - Traffic counters of an nsm interface of the slice router
+type InterfaceCounters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InterfaceName string `protobuf:"bytes,1,opt,name=interfaceName,proto3" json:"interfaceName,omitempty"`
+	TxBytes       uint64 `protobuf:"varint,2,opt,name=txBytes,proto3" json:"txBytes,omitempty"`
+	TxPackets     uint64 `protobuf:"varint,3,opt,name=txPackets,proto3" json:"txPackets,omitempty"`
+	RxBytes       uint64 `protobuf:"varint,4,opt,name=rxBytes,proto3" json:"rxBytes,omitempty"`
+	RxPackets     uint64 `protobuf:"varint,5,opt,name=rxPackets,proto3" json:"rxPackets,omitempty"`
+	// Number of slice routes sharing the interface, the counters include the traffic of all of them
+	RouteCount int32 `protobuf:"varint,6,opt,name=routeCount,proto3" json:"routeCount,omitempty"`
+}
+
+func (x *InterfaceCounters) Reset() {
+	*x = InterfaceCounters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterfaceCounters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceCounters) ProtoMessage() {}
+
+func (x *InterfaceCounters) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceCounters.ProtoReflect.Descriptor instead.
+func (*InterfaceCounters) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *InterfaceCounters) GetInterfaceName() string {
+	if x != nil {
+		return x.InterfaceName
+	}
+	return ""
+}
+
+func (x *InterfaceCounters) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRouteCount() int32 {
+	if x != nil {
+		return x.RouteCount
+	}
+	return 0
+}
+
+// RouteStatistics - Traffic counters of the egress interfaces of a remote subnet route. Route dumps do
+// not carry counters, the interface counters approximate the traffic of the route.
+type RouteStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RemoteSubnet string               `protobuf:"bytes,1,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	Interfaces   []*InterfaceCounters `protobuf:"bytes,2,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *RouteStatistics) Reset() {
+	*x = RouteStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteStatistics) ProtoMessage() {}
+
+func (x *RouteStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteStatistics.ProtoReflect.Descriptor instead.
+func (*RouteStatistics) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RouteStatistics) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *RouteStatistics) GetInterfaces() []*InterfaceCounters {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+// RouteStatisticsList - Traffic counters of the slice routes
+type RouteStatisticsList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*RouteStatistics `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+}
+
+func (x *RouteStatisticsList) Reset() {
+	*x = RouteStatisticsList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteStatisticsList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteStatisticsList) ProtoMessage() {}
+
+func (x *RouteStatisticsList) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteStatisticsList.ProtoReflect.Descriptor instead.
+func (*RouteStatisticsList) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RouteStatisticsList) GetRoutes() []*RouteStatistics {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+// VppConfigSummary - Summary of the config reported by the vpp agent, used to detect
+// unexpected changes in the vpp state
+type VppConfigSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SHA-256 hash of the full vpp config
+	ConfigHash string `protobuf:"bytes,1,opt,name=configHash,proto3" json:"configHash,omitempty"`
+	// Number of interfaces in the vpp config
+	InterfaceCount int32 `protobuf:"varint,2,opt,name=interfaceCount,proto3" json:"interfaceCount,omitempty"`
+	// Number of routes in the vpp config
+	RouteCount int32 `protobuf:"varint,3,opt,name=routeCount,proto3" json:"routeCount,omitempty"`
+	// Time the config was read from the vpp agent
+	CapturedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=capturedAt,proto3" json:"capturedAt,omitempty"`
+	// Time the config hash was last seen changing
+	ChangedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
+	// Vpp agent endpoint in use
+	VppAgentEndpoint string `protobuf:"bytes,6,opt,name=vppAgentEndpoint,proto3" json:"vppAgentEndpoint,omitempty"`
+}
+
+func (x *VppConfigSummary) Reset() {
+	*x = VppConfigSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VppConfigSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VppConfigSummary) ProtoMessage() {}
+
+func (x *VppConfigSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VppConfigSummary.ProtoReflect.Descriptor instead.
+func (*VppConfigSummary) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *VppConfigSummary) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+func (x *VppConfigSummary) GetInterfaceCount() int32 {
+	if x != nil {
+		return x.InterfaceCount
+	}
+	return 0
+}
+
+func (x *VppConfigSummary) GetRouteCount() int32 {
+	if x != nil {
+		return x.RouteCount
+	}
+	return 0
+}
+
+func (x *VppConfigSummary) GetCapturedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return nil
+}
+
+func (x *VppConfigSummary) GetChangedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *VppConfigSummary) GetVppAgentEndpoint() string {
+	if x != nil {
+		return x.VppAgentEndpoint
+	}
+	return ""
+}
+
+// ErrorEntry - Error logged by the sidecar
+type ErrorEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time    *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Message string               `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ErrorEntry) Reset() {
+	*x = ErrorEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorEntry) ProtoMessage() {}
+
+func (x *ErrorEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorEntry.ProtoReflect.Descriptor instead.
+func (*ErrorEntry) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ErrorEntry) GetTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ErrorEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RouteAuditEntry - Route operation performed on the slice router dataplane
+type RouteAuditEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Operation: inject, delete or reconcile
+	Operation string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Remote subnet of the route
+	RemoteSubnet string `protobuf:"bytes,3,opt,name=remoteSubnet,proto3" json:"remoteSubnet,omitempty"`
+	// Next hop IPs of the route
+	NextHopIPList []string `protobuf:"bytes,4,rep,name=nextHopIPList,proto3" json:"nextHopIPList,omitempty"`
+	// Outcome: success, pending or failed
+	Outcome string `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	// Error returned by the dataplane when the operation failed
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Why the route was deleted, for delete operations
+	DeletionReason DeletionReason `protobuf:"varint,7,opt,name=deletionReason,proto3,enum=router.DeletionReason" json:"deletionReason,omitempty"`
+	// Position of the operation in the audit log, increasing across rollovers
+	Sequence uint64 `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// What the reconcile corrected, for reconcile operations: missing, link, weight, pod, stale or the
+	// drifted route attribute
+	Correction string `protobuf:"bytes,9,opt,name=correction,proto3" json:"correction,omitempty"`
+}
+
+func (x *RouteAuditEntry) Reset() {
+	*x = RouteAuditEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteAuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteAuditEntry) ProtoMessage() {}
+
+func (x *RouteAuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteAuditEntry.ProtoReflect.Descriptor instead.
+func (*RouteAuditEntry) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RouteAuditEntry) GetTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *RouteAuditEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *RouteAuditEntry) GetRemoteSubnet() string {
+	if x != nil {
+		return x.RemoteSubnet
+	}
+	return ""
+}
+
+func (x *RouteAuditEntry) GetNextHopIPList() []string {
+	if x != nil {
+		return x.NextHopIPList
+	}
+	return nil
+}
+
+func (x *RouteAuditEntry) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *RouteAuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RouteAuditEntry) GetDeletionReason() DeletionReason {
+	if x != nil {
+		return x.DeletionReason
+	}
+	return DeletionReason_DELETION_NONE
+}
+
+func (x *RouteAuditEntry) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *RouteAuditEntry) GetCorrection() string {
+	if x != nil {
+		return x.Correction
+	}
+	return ""
+}
+
+// RouteAuditLog - Recent route operations, oldest first
+type RouteAuditLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*RouteAuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *RouteAuditLog) Reset() {
+	*x = RouteAuditLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteAuditLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteAuditLog) ProtoMessage() {}
+
+func (x *RouteAuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteAuditLog.ProtoReflect.Descriptor instead.
+func (*RouteAuditLog) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RouteAuditLog) GetEntries() []*RouteAuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// SupportBundle - State of the sidecar collected for troubleshooting
+type SupportBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GeneratedAt *timestamp.Timestamp `protobuf:"bytes,1,opt,name=generatedAt,proto3" json:"generatedAt,omitempty"`
+	// Dataplane mode of the slice router
+	DataplaneMode string `protobuf:"bytes,2,opt,name=dataplaneMode,proto3" json:"dataplaneMode,omitempty"`
+	// Routes injected in the slice router
+	RouteTable *RouteTable `protobuf:"bytes,3,opt,name=routeTable,proto3" json:"routeTable,omitempty"`
+	// Routes installed in the kernel
+	KernelRoutes []string `protobuf:"bytes,4,rep,name=kernelRoutes,proto3" json:"kernelRoutes,omitempty"`
+	// Clients connected to the slice router
+	Connections []*ConnectionInfo `protobuf:"bytes,5,rep,name=connections,proto3" json:"connections,omitempty"`
+	// Config reported by the vpp agent, in JSON
+	VppConfig string `protobuf:"bytes,6,opt,name=vppConfig,proto3" json:"vppConfig,omitempty"`
+	// Errors recently logged by the sidecar
+	RecentErrors []*ErrorEntry `protobuf:"bytes,7,rep,name=recentErrors,proto3" json:"recentErrors,omitempty"`
+	// Errors hit while collecting the bundle
+	CollectionErrors []string `protobuf:"bytes,8,rep,name=collectionErrors,proto3" json:"collectionErrors,omitempty"`
+	// Vpp agent endpoint in use, in vpp mode
+	VppAgentEndpoint string `protobuf:"bytes,9,opt,name=vppAgentEndpoint,proto3" json:"vppAgentEndpoint,omitempty"`
+	// Schedule of the routing table reconcile
+	ReconcileStatus *ReconcileStatus `protobuf:"bytes,10,opt,name=reconcileStatus,proto3" json:"reconcileStatus,omitempty"`
+	// Level the sidecar logs messages from
+	LogLevel string `protobuf:"bytes,11,opt,name=logLevel,proto3" json:"logLevel,omitempty"`
+}
+
+func (x *SupportBundle) Reset() {
+	*x = SupportBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SupportBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupportBundle) ProtoMessage() {}
+
+func (x *SupportBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupportBundle.ProtoReflect.Descriptor instead.
+func (*SupportBundle) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SupportBundle) GetGeneratedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *SupportBundle) GetDataplaneMode() string {
+	if x != nil {
+		return x.DataplaneMode
+	}
+	return ""
+}
+
+func (x *SupportBundle) GetRouteTable() *RouteTable {
+	if x != nil {
+		return x.RouteTable
+	}
+	return nil
+}
+
+func (x *SupportBundle) GetKernelRoutes() []string {
+	if x != nil {
+		return x.KernelRoutes
+	}
+	return nil
+}
+
+func (x *SupportBundle) GetConnections() []*ConnectionInfo {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+func (x *SupportBundle) GetVppConfig() string {
+	if x != nil {
+		return x.VppConfig
+	}
+	return ""
+}
+
+func (x *SupportBundle) GetRecentErrors() []*ErrorEntry {
+	if x != nil {
+		return x.RecentErrors
+	}
+	return nil
+}
+
+func (x *SupportBundle) GetCollectionErrors() []string {
+	if x != nil {
+		return x.CollectionErrors
+	}
+	return nil
+}
+
+func (x *SupportBundle) GetVppAgentEndpoint() string {
+	if x != nil {
+		return x.VppAgentEndpoint
+	}
+	return ""
+}
+
+func (x *SupportBundle) GetReconcileStatus() *ReconcileStatus {
+	if x != nil {
+		return x.ReconcileStatus
+	}
+	return nil
+}
+
+func (x *SupportBundle) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+// ReconcileStatus - Schedule of the routing table reconcile
+type ReconcileStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Reconcile mode, inline, timer or both
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Interval between two reconciles
+	Interval *duration.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Last time the routing table was reconciled
+	LastReconcileTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=lastReconcileTime,proto3" json:"lastReconcileTime,omitempty"`
+	// Next time the routing table will be reconciled. Without the background loop the reconcile is
+	// only due at that time, and run by the first route injection after it.
+	NextReconcileTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=nextReconcileTime,proto3" json:"nextReconcileTime,omitempty"`
+	// Whether the background loop reconciles the routing table
+	Scheduled bool `protobuf:"varint,5,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// Interval between two reconciles of the fast tier routes
+	FastInterval *duration.Duration `protobuf:"bytes,6,opt,name=fastInterval,proto3" json:"fastInterval,omitempty"`
+	// Last time the fast tier routes were reconciled, by a fast or full reconcile
+	LastFastReconcileTime *timestamp.Timestamp `protobuf:"bytes,7,opt,name=lastFastReconcileTime,proto3" json:"lastFastReconcileTime,omitempty"`
+}
+
+func (x *ReconcileStatus) Reset() {
+	*x = ReconcileStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconcileStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileStatus) ProtoMessage() {}
+
+func (x *ReconcileStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileStatus.ProtoReflect.Descriptor instead.
+func (*ReconcileStatus) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReconcileStatus) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ReconcileStatus) GetInterval() *duration.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *ReconcileStatus) GetLastReconcileTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastReconcileTime
+	}
+	return nil
+}
+
+func (x *ReconcileStatus) GetNextReconcileTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.NextReconcileTime
+	}
+	return nil
+}
+
+func (x *ReconcileStatus) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
+func (x *ReconcileStatus) GetFastInterval() *duration.Duration {
+	if x != nil {
+		return x.FastInterval
+	}
+	return nil
+}
+
+func (x *ReconcileStatus) GetLastFastReconcileTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastFastReconcileTime
+	}
+	return nil
+}
+
+// ProbeNextHopRequest - Next hop to probe for reachability
+type ProbeNextHopRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NextHop string `protobuf:"bytes,1,opt,name=nextHop,proto3" json:"nextHop,omitempty"`
+	// Probe to use, icmp or tcp. Defaults to the next hop health check probe, or icmp if health checking
+	// is disabled. The tcp probe connects to the next hop health check port.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// How long to wait for the probe, defaults to the next hop health check timeout
+	Timeout *duration.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *ProbeNextHopRequest) Reset() {
+	*x = ProbeNextHopRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeNextHopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeNextHopRequest) ProtoMessage() {}
+
+func (x *ProbeNextHopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeNextHopRequest.ProtoReflect.Descriptor instead.
+func (*ProbeNextHopRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ProbeNextHopRequest) GetNextHop() string {
+	if x != nil {
+		return x.NextHop
+	}
+	return ""
+}
+
+func (x *ProbeNextHopRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ProbeNextHopRequest) GetTimeout() *duration.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// ProbeNextHopResponse - Reachability of a next hop from the slice router
+type ProbeNextHopResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NextHop string `protobuf:"bytes,1,opt,name=nextHop,proto3" json:"nextHop,omitempty"`
+	// Probe used
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// reachable is true if the probe succeeded
+	Reachable bool `protobuf:"varint,3,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	// Time taken by the probe
+	Latency *duration.Duration `protobuf:"bytes,4,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Error returned by the probe when it failed
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Neighbor entry of the next hop, in kernel mode
+	Neighbor *NextHopNeighbor `protobuf:"bytes,6,opt,name=neighbor,proto3" json:"neighbor,omitempty"`
+}
+
+func (x *ProbeNextHopResponse) Reset() {
+	*x = ProbeNextHopResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeNextHopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeNextHopResponse) ProtoMessage() {}
+
+func (x *ProbeNextHopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeNextHopResponse.ProtoReflect.Descriptor instead.
+func (*ProbeNextHopResponse) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ProbeNextHopResponse) GetNextHop() string {
+	if x != nil {
+		return x.NextHop
+	}
+	return ""
+}
+
+func (x *ProbeNextHopResponse) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ProbeNextHopResponse) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *ProbeNextHopResponse) GetLatency() *duration.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *ProbeNextHopResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProbeNextHopResponse) GetNeighbor() *NextHopNeighbor {
+	if x != nil {
+		return x.Neighbor
+	}
+	return nil
+}
+
+// Slice router sidecar service verbs
+// Capabilities - Features supported by the slice router with its dataplane mode and feature flags
+type Capabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Dataplane mode of the slice router
+	DataplaneMode string `protobuf:"bytes,1,opt,name=dataplaneMode,proto3" json:"dataplaneMode,omitempty"`
+	// Routes may load balance over many next hops
+	Ecmp bool `protobuf:"varint,2,opt,name=ecmp,proto3" json:"ecmp,omitempty"`
+	// IPv6 remote subnets and connections are handled
+	Ipv6 bool `protobuf:"varint,3,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	// Routes may be injected in custom routing tables with InjectPolicyRoute
+	PolicyRouting bool `protobuf:"varint,4,opt,name=policyRouting,proto3" json:"policyRouting,omitempty"`
+	// Next hops may name an interface, dev:<name>
+	InterfaceNextHops bool `protobuf:"varint,5,opt,name=interfaceNextHops,proto3" json:"interfaceNextHops,omitempty"`
+	// Next hops may name a client pod, pod:<name>
+	PodNextHops bool `protobuf:"varint,6,opt,name=podNextHops,proto3" json:"podNextHops,omitempty"`
+	// Routes may carry a preferred source address
+	PreferredSource bool `protobuf:"varint,7,opt,name=preferredSource,proto3" json:"preferredSource,omitempty"`
+	// Route batches may be applied atomically
+	AtomicBatches bool `protobuf:"varint,8,opt,name=atomicBatches,proto3" json:"atomicBatches,omitempty"`
+	// The routing table is periodically reconciled with the injected routes
+	RouteReconcile bool `protobuf:"varint,9,opt,name=routeReconcile,proto3" json:"routeReconcile,omitempty"`
+	// The weights of the next hops follow the state of their neighbor entries
+	NextHopWeightDecay bool `protobuf:"varint,10,opt,name=nextHopWeightDecay,proto3" json:"nextHopWeightDecay,omitempty"`
+	// Route statistics are provided by GetRouteStatistics
+	RouteStatistics bool `protobuf:"varint,11,opt,name=routeStatistics,proto3" json:"routeStatistics,omitempty"`
+	// The vpp config summary is provided by GetVppConfigSummary
+	VppConfigSummary bool `protobuf:"varint,12,opt,name=vppConfigSummary,proto3" json:"vppConfigSummary,omitempty"`
+	// Health check mode of the next hops, empty if they are not health checked
+	NextHopHealthCheck string `protobuf:"bytes,13,opt,name=nextHopHealthCheck,proto3" json:"nextHopHealthCheck,omitempty"`
+	// Prefix of the route installed to the default next hops, empty if there is none
+	DefaultNextHopPrefix string `protobuf:"bytes,14,opt,name=defaultNextHopPrefix,proto3" json:"defaultNextHopPrefix,omitempty"`
+	// The kernel routing tables are provided by GetKernelRoutes
+	KernelRoutes bool `protobuf:"varint,15,opt,name=kernelRoutes,proto3" json:"kernelRoutes,omitempty"`
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Capabilities) GetDataplaneMode() string {
+	if x != nil {
+		return x.DataplaneMode
+	}
+	return ""
+}
+
+func (x *Capabilities) GetEcmp() bool {
+	if x != nil {
+		return x.Ecmp
+	}
+	return false
+}
+
+func (x *Capabilities) GetIpv6() bool {
+	if x != nil {
+		return x.Ipv6
+	}
+	return false
+}
+
+func (x *Capabilities) GetPolicyRouting() bool {
+	if x != nil {
+		return x.PolicyRouting
+	}
+	return false
+}
+
+func (x *Capabilities) GetInterfaceNextHops() bool {
+	if x != nil {
+		return x.InterfaceNextHops
+	}
+	return false
+}
+
+func (x *Capabilities) GetPodNextHops() bool {
+	if x != nil {
+		return x.PodNextHops
+	}
+	return false
+}
+
+func (x *Capabilities) GetPreferredSource() bool {
+	if x != nil {
+		return x.PreferredSource
+	}
+	return false
+}
+
+func (x *Capabilities) GetAtomicBatches() bool {
+	if x != nil {
+		return x.AtomicBatches
+	}
+	return false
+}
+
+func (x *Capabilities) GetRouteReconcile() bool {
+	if x != nil {
+		return x.RouteReconcile
+	}
+	return false
+}
+
+func (x *Capabilities) GetNextHopWeightDecay() bool {
+	if x != nil {
+		return x.NextHopWeightDecay
+	}
+	return false
+}
+
+func (x *Capabilities) GetRouteStatistics() bool {
+	if x != nil {
+		return x.RouteStatistics
+	}
+	return false
+}
+
+func (x *Capabilities) GetVppConfigSummary() bool {
+	if x != nil {
+		return x.VppConfigSummary
+	}
+	return false
+}
+
+func (x *Capabilities) GetNextHopHealthCheck() string {
+	if x != nil {
+		return x.NextHopHealthCheck
+	}
+	return ""
+}
+
+func (x *Capabilities) GetDefaultNextHopPrefix() string {
+	if x != nil {
+		return x.DefaultNextHopPrefix
+	}
+	return ""
+}
+
+func (x *Capabilities) GetKernelRoutes() bool {
+	if x != nil {
+		return x.KernelRoutes
+	}
+	return false
+}
+
+// KernelRoutesRequest - Kernel routing table to dump
+type KernelRoutesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Routing table, the main table if zero
+	Table uint32 `protobuf:"varint,1,opt,name=table,proto3" json:"table,omitempty"`
+}
+
+func (x *KernelRoutesRequest) Reset() {
+	*x = KernelRoutesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KernelRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelRoutesRequest) ProtoMessage() {}
+
+func (x *KernelRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelRoutesRequest.ProtoReflect.Descriptor instead.
+func (*KernelRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *KernelRoutesRequest) GetTable() uint32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+// KernelNextHop - Path of a multipath kernel route as listed by netlink
+type KernelNextHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gw        string `protobuf:"bytes,1,opt,name=gw,proto3" json:"gw,omitempty"`
+	LinkIndex int32  `protobuf:"varint,2,opt,name=linkIndex,proto3" json:"linkIndex,omitempty"`
+	// Name of the egress link, empty if the link is gone
+	LinkName string `protobuf:"bytes,3,opt,name=linkName,proto3" json:"linkName,omitempty"`
+	// Hop count of the path, the kernel stores the weight minus one
+	Hops  int32    `protobuf:"varint,4,opt,name=hops,proto3" json:"hops,omitempty"`
+	Flags []string `protobuf:"bytes,5,rep,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *KernelNextHop) Reset() {
+	*x = KernelNextHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KernelNextHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelNextHop) ProtoMessage() {}
+
+func (x *KernelNextHop) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelNextHop.ProtoReflect.Descriptor instead.
+func (*KernelNextHop) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *KernelNextHop) GetGw() string {
+	if x != nil {
+		return x.Gw
+	}
+	return ""
+}
+
+func (x *KernelNextHop) GetLinkIndex() int32 {
+	if x != nil {
+		return x.LinkIndex
+	}
+	return 0
+}
+
+func (x *KernelNextHop) GetLinkName() string {
+	if x != nil {
+		return x.LinkName
+	}
+	return ""
+}
+
+func (x *KernelNextHop) GetHops() int32 {
+	if x != nil {
+		return x.Hops
+	}
+	return 0
+}
+
+func (x *KernelNextHop) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+// KernelRoute - Route of a kernel routing table as listed by netlink
+type KernelRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Destination of the route, empty for a default route
+	Dst       string `protobuf:"bytes,1,opt,name=dst,proto3" json:"dst,omitempty"`
+	Gw        string `protobuf:"bytes,2,opt,name=gw,proto3" json:"gw,omitempty"`
+	Src       string `protobuf:"bytes,3,opt,name=src,proto3" json:"src,omitempty"`
+	LinkIndex int32  `protobuf:"varint,4,opt,name=linkIndex,proto3" json:"linkIndex,omitempty"`
+	// Name of the egress link, empty if the link is gone
+	LinkName string `protobuf:"bytes,5,opt,name=linkName,proto3" json:"linkName,omitempty"`
+	Table    int32  `protobuf:"varint,6,opt,name=table,proto3" json:"table,omitempty"`
+	// Metric of the route
+	Priority int32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Protocol, scope and type of the route, named like ip route does when known
+	Protocol  string           `protobuf:"bytes,8,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Scope     string           `protobuf:"bytes,9,opt,name=scope,proto3" json:"scope,omitempty"`
+	Type      string           `protobuf:"bytes,10,opt,name=type,proto3" json:"type,omitempty"`
+	Tos       int32            `protobuf:"varint,11,opt,name=tos,proto3" json:"tos,omitempty"`
+	Flags     []string         `protobuf:"bytes,12,rep,name=flags,proto3" json:"flags,omitempty"`
+	MultiPath []*KernelNextHop `protobuf:"bytes,13,rep,name=multiPath,proto3" json:"multiPath,omitempty"`
+}
+
+func (x *KernelRoute) Reset() {
+	*x = KernelRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KernelRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelRoute) ProtoMessage() {}
+
+func (x *KernelRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelRoute.ProtoReflect.Descriptor instead.
+func (*KernelRoute) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *KernelRoute) GetDst() string {
+	if x != nil {
+		return x.Dst
+	}
+	return ""
+}
+
+func (x *KernelRoute) GetGw() string {
+	if x != nil {
+		return x.Gw
+	}
+	return ""
+}
+
+func (x *KernelRoute) GetSrc() string {
+	if x != nil {
+		return x.Src
+	}
+	return ""
+}
+
+func (x *KernelRoute) GetLinkIndex() int32 {
+	if x != nil {
+		return x.LinkIndex
+	}
+	return 0
+}
+
+func (x *KernelRoute) GetLinkName() string {
+	if x != nil {
+		return x.LinkName
+	}
+	return ""
+}
+
+func (x *KernelRoute) GetTable() int32 {
+	if x != nil {
+		return x.Table
+	}
+	return 0
+}
+
+func (x *KernelRoute) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *KernelRoute) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *KernelRoute) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *KernelRoute) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *KernelRoute) GetTos() int32 {
+	if x != nil {
+		return x.Tos
+	}
+	return 0
+}
+
+func (x *KernelRoute) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *KernelRoute) GetMultiPath() []*KernelNextHop {
+	if x != nil {
+		return x.MultiPath
+	}
+	return nil
+}
+
+// KernelRouteList - Routes of a kernel routing table as listed by netlink
+type KernelRouteList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*KernelRoute `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+}
+
+func (x *KernelRouteList) Reset() {
+	*x = KernelRouteList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KernelRouteList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelRouteList) ProtoMessage() {}
+
+func (x *KernelRouteList) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelRouteList.ProtoReflect.Descriptor instead.
+func (*KernelRouteList) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *KernelRouteList) GetRoutes() []*KernelRoute {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+// LogLevel - Level the sidecar logs messages from
+type LogLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// DEBUG, INFO, WARN, ERROR, FATAL or PANIC, not case sensitive when set
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *LogLevel) Reset() {
+	*x = LogLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_sidecar_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevel) ProtoMessage() {}
+
+func (x *LogLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_router_sidecar_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevel.ProtoReflect.Descriptor instead.
+func (*LogLevel) Descriptor() ([]byte, []int) {
+	return file_router_sidecar_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *LogLevel) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+var File_router_sidecar_proto protoreflect.FileDescriptor
+
+var file_router_sidecar_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2f, 0x0a, 0x0f,
+	0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x73, 0x67, 0x22, 0x9e, 0x03,
+	0x0a, 0x11, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x43, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x26, 0x0a,
+	0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63,
+	0x65, 0x47, 0x77, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c,
+	0x69, 0x63, 0x65, 0x47, 0x77, 0x56, 0x70, 0x6e, 0x49, 0x50, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x56, 0x70,
+	0x6e, 0x49, 0x50, 0x12, 0x4b, 0x0a, 0x14, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63,
+	0x65, 0x47, 0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65,
+	0x47, 0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x14, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x34, 0x0a, 0x15, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77,
+	0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x15, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d,
+	0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x36, 0x0a, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x6c,
+	0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x2a,
+	0x0a, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e, 0x73, 0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72,
+	0x49, 0x50, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e,
+	0x73, 0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x12, 0x32, 0x0a, 0x14, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x4e, 0x73, 0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x4c, 0x69,
+	0x73, 0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4e,
+	0x73, 0x6d, 0x47, 0x77, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x43,
+	0x0a, 0x15, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x64, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x73, 0x6d, 0x49, 0x50,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x73, 0x74, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x73,
+	0x74, 0x49, 0x50, 0x22, 0x40, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a,
+	0x0e, 0x69, 0x73, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x73, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x50, 0x72,
+	0x65, 0x73, 0x65, 0x6e, 0x74, 0x22, 0x8a, 0x01, 0x0a, 0x12, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x12, 0x32,
+	0x0a, 0x14, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x0f, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x4e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f,
+	0x70, 0x49, 0x50, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x49, 0x50, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
+	0x65, 0x41, 0x64, 0x64, 0x72, 0x22, 0x90, 0x02, 0x0a, 0x13, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x69, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12,
+	0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x4e, 0x65,
+	0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x16, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49,
+	0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x10, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x4e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x4e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x52, 0x10, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x4e,
+	0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x22, 0xa8, 0x02, 0x0a, 0x09, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x72, 0x63,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65,
+	0x64, 0x53, 0x72, 0x63, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x19, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x11, 0x72, 0x65,
+	0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x76, 0x70, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x76, 0x70, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a,
+	0x0d, 0x76, 0x70, 0x70, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x76, 0x70, 0x70, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x22, 0xaf, 0x01, 0x0a, 0x0b, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62,
+	0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x72, 0x63, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x2e, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x17, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2a, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x64, 0x53, 0x75, 0x62,
+	0x6e, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x65, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x73, 0x22, 0x4f, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x29, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x74, 0x6f, 0x6d, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x74, 0x6f,
+	0x6d, 0x69, 0x63, 0x22, 0x6f, 0x0a, 0x0b, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x73, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x4d, 0x73, 0x67, 0x22, 0x43, 0x0a, 0x12, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x60, 0x0a, 0x13, 0x45, 0x6e, 0x73,
+	0x75, 0x72, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x29, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x89, 0x01, 0x0a, 0x14,
+	0x45, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11,
+	0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x22, 0x3e, 0x0a, 0x11, 0x53, 0x79, 0x6e, 0x63, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x22, 0xa0, 0x02, 0x0a, 0x0f, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12,
+	0x2f, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x79,
+	0x6e, 0x63, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x20, 0x0a, 0x0b, 0x69, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x34,
+	0x0a, 0x15, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f,
+	0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49,
+	0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
+	0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3c, 0x0a, 0x16, 0x56, 0x70,
+	0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x22, 0x83, 0x02, 0x0a, 0x17, 0x56, 0x70, 0x70,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x6b, 0x65, 0x70, 0x74,
+	0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x11, 0x6b, 0x65, 0x70, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70,
+	0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4e, 0x65, 0x78,
+	0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x61, 0x64,
+	0x64, 0x65, 0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x61, 0x64, 0x64, 0x65, 0x64, 0x4e, 0x65, 0x78,
+	0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69,
+	0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x47,
+	0x0a, 0x12, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x5c, 0x0a, 0x0d, 0x4e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74,
+	0x48, 0x6f, 0x70, 0x49, 0x50, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x78,
+	0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x12, 0x2d, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x06, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x22, 0xd8, 0x03, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3b,
+	0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x4e,
+	0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x48, 0x6f, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a,
+	0x11, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x46, 0x0a, 0x0f, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x47, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c,
+	0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09,
+	0x76, 0x70, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x76, 0x70, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x76, 0x70,
+	0x70, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0d, 0x76, 0x70, 0x70, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x22, 0x57, 0x0a, 0x15, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62,
+	0x6e, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6e,
+	0x65, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50,
+	0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x38, 0x0a, 0x16, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x73, 0x22, 0x73, 0x0a, 0x11, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c,
+	0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x76, 0x0a, 0x12, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e,
+	0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73,
+	0x22, 0x96, 0x01, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x2a, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x74, 0x69, 0x6c, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x75, 0x74,
+	0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x6e, 0x0a, 0x0e, 0x45, 0x63, 0x6d,
+	0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x16, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53,
+	0x75, 0x62, 0x6e, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x4e, 0x73, 0x6d, 0x53, 0x75, 0x62,
+	0x6e, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x54, 0x6f, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x73, 0x6d, 0x49,
+	0x50, 0x54, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0xd1, 0x02, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
+	0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x73, 0x6d, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x73,
+	0x6d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x73,
+	0x6d, 0x49, 0x50, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x73, 0x6d, 0x49, 0x50,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x73, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x73, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x12, 0x18,
+	0x0a, 0x07, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x76, 0x36, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x76, 0x36, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x73, 0x6d, 0x50,
+	0x65, 0x65, 0x72, 0x49, 0x50, 0x76, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e,
+	0x73, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x49, 0x50, 0x76, 0x36, 0x12, 0x2a, 0x0a, 0x10, 0x6e, 0x73,
+	0x6d, 0x49, 0x50, 0x76, 0x36, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x6e, 0x73, 0x6d, 0x49, 0x50, 0x76, 0x36, 0x52, 0x65, 0x61,
+	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53,
+	0x65, 0x65, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e,
+	0x12, 0x2b, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x61, 0x67, 0x65, 0x22, 0x33, 0x0a,
+	0x17, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x4e, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0xb4, 0x01, 0x0a, 0x14, 0x44, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x44, 0x0a, 0x11, 0x6b,
+	0x65, 0x72, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x11,
+	0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x3e, 0x0a, 0x0e, 0x76, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x0e, 0x76, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x77, 0x0a, 0x0f, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x73, 0x22, 0x4d, 0x0a, 0x12, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x73, 0x22, 0xc9, 0x01, 0x0a, 0x11, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x50, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x70, 0x0a,
+	0x0f, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x22,
+	0x46, 0x0a, 0x13, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52,
+	0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x22, 0x9c, 0x02, 0x0a, 0x10, 0x56, 0x70, 0x70, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0e,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x38, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x76, 0x70,
+	0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x70, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x56, 0x0a, 0x0a, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xd5,
+	0x02, 0x0a, 0x0f, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x53, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x49,
+	0x50, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x48, 0x6f, 0x70, 0x49, 0x50, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75,
+	0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3e, 0x0a, 0x0e, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x42, 0x0a, 0x0d, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x92, 0x04, 0x0a, 0x0d, 0x53,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x3c, 0x0a, 0x0b,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x61,
+	0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x4d, 0x6f, 0x64, 0x65,
+	0x12, 0x32, 0x0a, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x6b, 0x65, 0x72, 0x6e,
+	0x65, 0x6c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x36, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x76, 0x70, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x76, 0x70, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x41, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22,
+	0x9f, 0x03, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x48,
+	0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x48, 0x0a, 0x11, 0x6e, 0x65, 0x78, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x11, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x12, 0x3d, 0x0a, 0x0c, 0x66, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x66, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x50, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x15, 0x6c, 0x61, 0x73, 0x74,
+	0x46, 0x61, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x7c, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x78, 0x74,
+	0x48, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22,
+	0xe6, 0x01, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x78, 0x74,
+	0x48, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72,
+	0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x4e,
+	0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x52, 0x08,
+	0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x22, 0xd8, 0x04, 0x0a, 0x0c, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x61, 0x74,
+	0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x65, 0x63, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x65,
+	0x63, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x70, 0x76, 0x36, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x69, 0x70, 0x76, 0x36, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a,
+	0x11, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f,
+	0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70,
+	0x6f, 0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x70, 0x6f, 0x64, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65,
+	0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x74, 0x6f, 0x6d, 0x69,
+	0x63, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x26, 0x0a,
+	0x0e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f,
+	0x6e, 0x63, 0x69, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x44, 0x65, 0x63, 0x61, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x44, 0x65, 0x63, 0x61, 0x79, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12,
+	0x2a, 0x0a, 0x10, 0x76, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x76, 0x70, 0x70, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x6e,
+	0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x32, 0x0a, 0x14, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x22, 0x0a, 0x0c, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x22, 0x2b, 0x0a, 0x13, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x22, 0x83, 0x01, 0x0a, 0x0d, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x4e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x67, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x67, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6c, 0x69, 0x6e, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x6f, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x68, 0x6f, 0x70, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0xd0, 0x02, 0x0a, 0x0b, 0x4b, 0x65, 0x72, 0x6e, 0x65,
+	0x6c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x67, 0x77, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x67, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x69,
+	0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c,
+	0x69, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x6b,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x69, 0x6e, 0x6b,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x6f, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x74, 0x6f, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x6c, 0x61, 0x67, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x61, 0x74,
+	0x68, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x2e, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x52, 0x09,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x50, 0x61, 0x74, 0x68, 0x22, 0x3e, 0x0a, 0x0f, 0x4b, 0x65, 0x72,
+	0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x06,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x22, 0x20, 0x0a, 0x08, 0x4c, 0x6f, 0x67,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x2a, 0x3b, 0x0a, 0x0f, 0x53,
+	0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x48, 0x6f, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x13,
+	0x0a, 0x0f, 0x53, 0x4c, 0x49, 0x43, 0x45, 0x5f, 0x47, 0x57, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45,
+	0x52, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4c, 0x49, 0x43, 0x45, 0x5f, 0x47, 0x57, 0x5f,
+	0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x2a, 0x46, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x4f, 0x55, 0x54, 0x45, 0x5f,
+	0x41, 0x42, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x4f, 0x55, 0x54,
+	0x45, 0x5f, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a,
+	0x0d, 0x52, 0x4f, 0x55, 0x54, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x46, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x2a, 0xa8, 0x01, 0x0a, 0x0d, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f, 0x52, 0x5f, 0x4e,
+	0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f,
+	0x52, 0x5f, 0x49, 0x4e, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x16,
+	0x0a, 0x12, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x43, 0x48,
+	0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42,
+	0x4f, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x45,
+	0x49, 0x47, 0x48, 0x42, 0x4f, 0x52, 0x5f, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x04, 0x12, 0x13,
+	0x0a, 0x0f, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f, 0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45,
+	0x44, 0x10, 0x05, 0x12, 0x16, 0x0a, 0x12, 0x4e, 0x45, 0x49, 0x47, 0x48, 0x42, 0x4f, 0x52, 0x5f,
+	0x50, 0x45, 0x52, 0x4d, 0x41, 0x4e, 0x45, 0x4e, 0x54, 0x10, 0x06, 0x2a, 0x4f, 0x0a, 0x11, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x4e, 0x43, 0x49, 0x4c, 0x45, 0x5f, 0x50, 0x52,
+	0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12,
+	0x1b, 0x0a, 0x17, 0x52, 0x45, 0x43, 0x4f, 0x4e, 0x43, 0x49, 0x4c, 0x45, 0x5f, 0x50, 0x52, 0x49,
+	0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x46, 0x41, 0x53, 0x54, 0x10, 0x01, 0x2a, 0x8b, 0x01, 0x0a,
+	0x0f, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x14, 0x52, 0x4f, 0x55, 0x54, 0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x55,
+	0x4e, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x4f,
+	0x55, 0x54, 0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x01,
+	0x12, 0x16, 0x0a, 0x12, 0x52, 0x4f, 0x55, 0x54, 0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x55,
+	0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x52, 0x4f, 0x55, 0x54,
+	0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x43, 0x54, 0x45, 0x44,
+	0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x4f, 0x55, 0x54, 0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43,
+	0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x57, 0x0a, 0x0d, 0x4e, 0x65,
+	0x78, 0x74, 0x48, 0x6f, 0x70, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x16, 0x4e,
+	0x45, 0x58, 0x54, 0x48, 0x4f, 0x50, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x5f, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x45, 0x58, 0x54, 0x48,
+	0x4f, 0x50, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11,
+	0x4e, 0x45, 0x58, 0x54, 0x48, 0x4f, 0x50, 0x5f, 0x55, 0x4e, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48,
+	0x59, 0x10, 0x02, 0x2a, 0x90, 0x01, 0x0a, 0x14, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x46, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x11,
+	0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56,
+	0x45, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x49, 0x4e,
+	0x47, 0x5f, 0x44, 0x45, 0x47, 0x52, 0x41, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f,
+	0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10,
+	0x02, 0x12, 0x19, 0x0a, 0x15, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f,
+	0x42, 0x4c, 0x41, 0x43, 0x4b, 0x48, 0x4f, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14,
+	0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x44,
+	0x52, 0x41, 0x57, 0x4e, 0x10, 0x04, 0x2a, 0x71, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x4c, 0x45,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x44,
+	0x45, 0x4c, 0x45, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x58, 0x50, 0x4c, 0x49, 0x43, 0x49, 0x54,
+	0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e,
+	0x4f, 0x54, 0x5f, 0x44, 0x45, 0x53, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17,
+	0x44, 0x45, 0x4c, 0x45, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x55, 0x54, 0x44, 0x4f, 0x57,
+	0x4e, 0x5f, 0x46, 0x4c, 0x55, 0x53, 0x48, 0x10, 0x03, 0x32, 0x9f, 0x11, 0x0a, 0x19, 0x53, 0x6c,
+	0x69, 0x63, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x19, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x72, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x47, 0x77, 0x43, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x69,
+	0x64, 0x65, 0x63, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x5c, 0x0a, 0x22, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x53, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x49, 0x6e, 0x4b, 0x65, 0x72, 0x6e, 0x65,
+	0x6c, 0x12, 0x1d, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x45, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x63, 0x6d, 0x70,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x45, 0x63, 0x6d, 0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x17,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0c, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x12, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x1a, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x14, 0x49, 0x6e, 0x6a, 0x65,
+	0x63, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x12, 0x1d, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x00,
+	0x12, 0x50, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x22, 0x00, 0x12, 0x49, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x56, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x18, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x56, 0x70, 0x70, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x22, 0x00, 0x12, 0x4c, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x42, 0x79, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a,
+	0x0c, 0x45, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x73, 0x75, 0x72, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x22, 0x00, 0x12,
+	0x43, 0x0a, 0x11, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x1a, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x72, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x44, 0x61, 0x74, 0x61, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x65, 0x4e, 0x6f, 0x77, 0x12, 0x18, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x63,
+	0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0f, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x1f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x63, 0x61, 0x6e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x4e,
+	0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x12, 0x1b, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x50, 0x72, 0x6f, 0x62, 0x65, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f,
+	0x62, 0x65, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x72,
+	0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x72, 0x2e, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e,
+	0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22,
+	0x00, 0x12, 0x39, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0b,
+	0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x10, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x1a, 0x10, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22,
+	0x00, 0x12, 0x45, 0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12,
+	0x19, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x43, 0x6c, 0x65, 0x61,
+	0x6e, 0x75, 0x70, 0x56, 0x70, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x2e, 0x56, 0x70, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6c, 0x65,
+	0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x2e, 0x56, 0x70, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6c, 0x65,
+	0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b,
+	0x0a, 0x10, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x19, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0c, 0x5a, 0x0a, 0x2e,
+	0x2f, 0x3b, 0x73, 0x69, 0x64, 0x65, 0x63, 0x61, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -638,35 +5101,183 @@ func file_router_sidecar_proto_rawDescGZIP() []byte {
 	return file_router_sidecar_proto_rawDescData
 }
 
-var file_router_sidecar_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_router_sidecar_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_router_sidecar_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_router_sidecar_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_router_sidecar_proto_goTypes = []interface{}{
-	(SliceGwHostType)(0),           // 0: router.SliceGwHostType
-	(*SidecarResponse)(nil),        // 1: router.SidecarResponse
-	(*SliceGwConContext)(nil),      // 2: router.SliceGwConContext
-	(*VerifyRouteAddRequest)(nil),  // 3: router.VerifyRouteAddRequest
-	(*VerifyRouteAddResponse)(nil), // 4: router.VerifyRouteAddResponse
-	(*EcmpUpdateInfo)(nil),         // 5: router.EcmpUpdateInfo
-	(*ConnectionInfo)(nil),         // 6: router.ConnectionInfo
-	(*ClientConnectionInfo)(nil),   // 7: router.ClientConnectionInfo
-	(*empty.Empty)(nil),            // 8: google.protobuf.Empty
+	(SliceGwHostType)(0),            // 0: router.SliceGwHostType
+	(RouteState)(0),                 // 1: router.RouteState
+	(NeighborState)(0),              // 2: router.NeighborState
+	(ReconcilePriority)(0),          // 3: router.ReconcilePriority
+	(RouteSyncAction)(0),            // 4: router.RouteSyncAction
+	(NextHopHealth)(0),              // 5: router.NextHopHealth
+	(RouteForwardingState)(0),       // 6: router.RouteForwardingState
+	(DeletionReason)(0),             // 7: router.DeletionReason
+	(*SidecarResponse)(nil),         // 8: router.SidecarResponse
+	(*SliceGwConContext)(nil),       // 9: router.SliceGwConContext
+	(*VerifyRouteAddRequest)(nil),   // 10: router.VerifyRouteAddRequest
+	(*VerifyRouteAddResponse)(nil),  // 11: router.VerifyRouteAddResponse
+	(*RouteStatusRequest)(nil),      // 12: router.RouteStatusRequest
+	(*NextHopNeighbor)(nil),         // 13: router.NextHopNeighbor
+	(*RouteStatusResponse)(nil),     // 14: router.RouteStatusResponse
+	(*RouteInfo)(nil),               // 15: router.RouteInfo
+	(*PolicyRoute)(nil),             // 16: router.PolicyRoute
+	(*ReconcileRequest)(nil),        // 17: router.ReconcileRequest
+	(*RefreshNextHopsResponse)(nil), // 18: router.RefreshNextHopsResponse
+	(*RouteBatch)(nil),              // 19: router.RouteBatch
+	(*RouteResult)(nil),             // 20: router.RouteResult
+	(*RouteBatchResponse)(nil),      // 21: router.RouteBatchResponse
+	(*EnsureRoutesRequest)(nil),     // 22: router.EnsureRoutesRequest
+	(*EnsureRoutesResponse)(nil),    // 23: router.EnsureRoutesResponse
+	(*SyncRoutesRequest)(nil),       // 24: router.SyncRoutesRequest
+	(*RouteSyncResult)(nil),         // 25: router.RouteSyncResult
+	(*VppRouteCleanupRequest)(nil),  // 26: router.VppRouteCleanupRequest
+	(*VppRouteCleanupResponse)(nil), // 27: router.VppRouteCleanupResponse
+	(*SyncRoutesResponse)(nil),      // 28: router.SyncRoutesResponse
+	(*NextHopStatus)(nil),           // 29: router.NextHopStatus
+	(*RouteEntry)(nil),              // 30: router.RouteEntry
+	(*AggregateRouteRequest)(nil),   // 31: router.AggregateRouteRequest
+	(*AggregateRouteResponse)(nil),  // 32: router.AggregateRouteResponse
+	(*RouteGroupRequest)(nil),       // 33: router.RouteGroupRequest
+	(*RouteGroupResponse)(nil),      // 34: router.RouteGroupResponse
+	(*RouteTable)(nil),              // 35: router.RouteTable
+	(*EcmpUpdateInfo)(nil),          // 36: router.EcmpUpdateInfo
+	(*ConnectionInfo)(nil),          // 37: router.ConnectionInfo
+	(*ClientConnectionRequest)(nil), // 38: router.ClientConnectionRequest
+	(*ClientConnectionInfo)(nil),    // 39: router.ClientConnectionInfo
+	(*DataplaneConnections)(nil),    // 40: router.DataplaneConnections
+	(*InterfaceRoutes)(nil),         // 41: router.InterfaceRoutes
+	(*InterfaceRouteList)(nil),      // 42: router.InterfaceRouteList
+	(*InterfaceCounters)(nil),       // 43: router.InterfaceCounters
+	(*RouteStatistics)(nil),         // 44: router.RouteStatistics
+	(*RouteStatisticsList)(nil),     // 45: router.RouteStatisticsList
+	(*VppConfigSummary)(nil),        // 46: router.VppConfigSummary
+	(*ErrorEntry)(nil),              // 47: router.ErrorEntry
+	(*RouteAuditEntry)(nil),         // 48: router.RouteAuditEntry
+	(*RouteAuditLog)(nil),           // 49: router.RouteAuditLog
+	(*SupportBundle)(nil),           // 50: router.SupportBundle
+	(*ReconcileStatus)(nil),         // 51: router.ReconcileStatus
+	(*ProbeNextHopRequest)(nil),     // 52: router.ProbeNextHopRequest
+	(*ProbeNextHopResponse)(nil),    // 53: router.ProbeNextHopResponse
+	(*Capabilities)(nil),            // 54: router.Capabilities
+	(*KernelRoutesRequest)(nil),     // 55: router.KernelRoutesRequest
+	(*KernelNextHop)(nil),           // 56: router.KernelNextHop
+	(*KernelRoute)(nil),             // 57: router.KernelRoute
+	(*KernelRouteList)(nil),         // 58: router.KernelRouteList
+	(*LogLevel)(nil),                // 59: router.LogLevel
+	(*timestamp.Timestamp)(nil),     // 60: google.protobuf.Timestamp
+	(*duration.Duration)(nil),       // 61: google.protobuf.Duration
+	(*empty.Empty)(nil),             // 62: google.protobuf.Empty
 }
 var file_router_sidecar_proto_depIdxs = []int32{
-	0, // 0: router.SliceGwConContext.localSliceGwHostType:type_name -> router.SliceGwHostType
-	6, // 1: router.ClientConnectionInfo.connection:type_name -> router.ConnectionInfo
-	2, // 2: router.SliceRouterSidecarService.UpdateSliceGwConnectionContext:input_type -> router.SliceGwConContext
-	8, // 3: router.SliceRouterSidecarService.GetSliceRouterClientConnectionInfo:input_type -> google.protobuf.Empty
-	3, // 4: router.SliceRouterSidecarService.GetRouteInKernel:input_type -> router.VerifyRouteAddRequest
-	5, // 5: router.SliceRouterSidecarService.UpdateEcmpRoutes:input_type -> router.EcmpUpdateInfo
-	1, // 6: router.SliceRouterSidecarService.UpdateSliceGwConnectionContext:output_type -> router.SidecarResponse
-	7, // 7: router.SliceRouterSidecarService.GetSliceRouterClientConnectionInfo:output_type -> router.ClientConnectionInfo
-	4, // 8: router.SliceRouterSidecarService.GetRouteInKernel:output_type -> router.VerifyRouteAddResponse
-	1, // 9: router.SliceRouterSidecarService.UpdateEcmpRoutes:output_type -> router.SidecarResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: router.SliceGwConContext.localSliceGwHostType:type_name -> router.SliceGwHostType
+	2,  // 1: router.NextHopNeighbor.state:type_name -> router.NeighborState
+	1,  // 2: router.RouteStatusResponse.state:type_name -> router.RouteState
+	13, // 3: router.RouteStatusResponse.nextHopNeighbors:type_name -> router.NextHopNeighbor
+	3,  // 4: router.RouteInfo.reconcilePriority:type_name -> router.ReconcilePriority
+	15, // 5: router.RouteBatch.routes:type_name -> router.RouteInfo
+	20, // 6: router.RouteBatchResponse.results:type_name -> router.RouteResult
+	15, // 7: router.EnsureRoutesRequest.routes:type_name -> router.RouteInfo
+	20, // 8: router.EnsureRoutesResponse.results:type_name -> router.RouteResult
+	15, // 9: router.SyncRoutesRequest.routes:type_name -> router.RouteInfo
+	4,  // 10: router.RouteSyncResult.action:type_name -> router.RouteSyncAction
+	25, // 11: router.SyncRoutesResponse.results:type_name -> router.RouteSyncResult
+	5,  // 12: router.NextHopStatus.health:type_name -> router.NextHopHealth
+	29, // 13: router.RouteEntry.nextHopStatus:type_name -> router.NextHopStatus
+	6,  // 14: router.RouteEntry.forwardingState:type_name -> router.RouteForwardingState
+	3,  // 15: router.RouteEntry.reconcilePriority:type_name -> router.ReconcilePriority
+	30, // 16: router.RouteTable.routes:type_name -> router.RouteEntry
+	60, // 17: router.ConnectionInfo.firstSeen:type_name -> google.protobuf.Timestamp
+	61, // 18: router.ConnectionInfo.age:type_name -> google.protobuf.Duration
+	37, // 19: router.ClientConnectionInfo.connection:type_name -> router.ConnectionInfo
+	37, // 20: router.DataplaneConnections.kernelConnections:type_name -> router.ConnectionInfo
+	37, // 21: router.DataplaneConnections.vppConnections:type_name -> router.ConnectionInfo
+	41, // 22: router.InterfaceRouteList.interfaces:type_name -> router.InterfaceRoutes
+	43, // 23: router.RouteStatistics.interfaces:type_name -> router.InterfaceCounters
+	44, // 24: router.RouteStatisticsList.routes:type_name -> router.RouteStatistics
+	60, // 25: router.VppConfigSummary.capturedAt:type_name -> google.protobuf.Timestamp
+	60, // 26: router.VppConfigSummary.changedAt:type_name -> google.protobuf.Timestamp
+	60, // 27: router.ErrorEntry.time:type_name -> google.protobuf.Timestamp
+	60, // 28: router.RouteAuditEntry.time:type_name -> google.protobuf.Timestamp
+	7,  // 29: router.RouteAuditEntry.deletionReason:type_name -> router.DeletionReason
+	48, // 30: router.RouteAuditLog.entries:type_name -> router.RouteAuditEntry
+	60, // 31: router.SupportBundle.generatedAt:type_name -> google.protobuf.Timestamp
+	35, // 32: router.SupportBundle.routeTable:type_name -> router.RouteTable
+	37, // 33: router.SupportBundle.connections:type_name -> router.ConnectionInfo
+	47, // 34: router.SupportBundle.recentErrors:type_name -> router.ErrorEntry
+	51, // 35: router.SupportBundle.reconcileStatus:type_name -> router.ReconcileStatus
+	61, // 36: router.ReconcileStatus.interval:type_name -> google.protobuf.Duration
+	60, // 37: router.ReconcileStatus.lastReconcileTime:type_name -> google.protobuf.Timestamp
+	60, // 38: router.ReconcileStatus.nextReconcileTime:type_name -> google.protobuf.Timestamp
+	61, // 39: router.ReconcileStatus.fastInterval:type_name -> google.protobuf.Duration
+	60, // 40: router.ReconcileStatus.lastFastReconcileTime:type_name -> google.protobuf.Timestamp
+	61, // 41: router.ProbeNextHopRequest.timeout:type_name -> google.protobuf.Duration
+	61, // 42: router.ProbeNextHopResponse.latency:type_name -> google.protobuf.Duration
+	13, // 43: router.ProbeNextHopResponse.neighbor:type_name -> router.NextHopNeighbor
+	56, // 44: router.KernelRoute.multiPath:type_name -> router.KernelNextHop
+	57, // 45: router.KernelRouteList.routes:type_name -> router.KernelRoute
+	9,  // 46: router.SliceRouterSidecarService.UpdateSliceGwConnectionContext:input_type -> router.SliceGwConContext
+	62, // 47: router.SliceRouterSidecarService.GetSliceRouterClientConnectionInfo:input_type -> google.protobuf.Empty
+	10, // 48: router.SliceRouterSidecarService.GetRouteInKernel:input_type -> router.VerifyRouteAddRequest
+	36, // 49: router.SliceRouterSidecarService.UpdateEcmpRoutes:input_type -> router.EcmpUpdateInfo
+	12, // 50: router.SliceRouterSidecarService.GetRouteStatus:input_type -> router.RouteStatusRequest
+	19, // 51: router.SliceRouterSidecarService.InjectRoutes:input_type -> router.RouteBatch
+	31, // 52: router.SliceRouterSidecarService.InjectAggregateRoute:input_type -> router.AggregateRouteRequest
+	62, // 53: router.SliceRouterSidecarService.GetRouteTable:input_type -> google.protobuf.Empty
+	38, // 54: router.SliceRouterSidecarService.GetClientConnection:input_type -> router.ClientConnectionRequest
+	62, // 55: router.SliceRouterSidecarService.GetVppConfigSummary:input_type -> google.protobuf.Empty
+	62, // 56: router.SliceRouterSidecarService.GetRoutesByInterface:input_type -> google.protobuf.Empty
+	62, // 57: router.SliceRouterSidecarService.GetRouteStatistics:input_type -> google.protobuf.Empty
+	62, // 58: router.SliceRouterSidecarService.GetSupportBundle:input_type -> google.protobuf.Empty
+	22, // 59: router.SliceRouterSidecarService.EnsureRoutes:input_type -> router.EnsureRoutesRequest
+	62, // 60: router.SliceRouterSidecarService.GetRouteAuditLog:input_type -> google.protobuf.Empty
+	16, // 61: router.SliceRouterSidecarService.InjectPolicyRoute:input_type -> router.PolicyRoute
+	62, // 62: router.SliceRouterSidecarService.GetDataplaneConnections:input_type -> google.protobuf.Empty
+	17, // 63: router.SliceRouterSidecarService.ReconcileNow:input_type -> router.ReconcileRequest
+	62, // 64: router.SliceRouterSidecarService.GetReconcileStatus:input_type -> google.protobuf.Empty
+	62, // 65: router.SliceRouterSidecarService.RefreshNextHops:input_type -> google.protobuf.Empty
+	62, // 66: router.SliceRouterSidecarService.RescanClientConnections:input_type -> google.protobuf.Empty
+	52, // 67: router.SliceRouterSidecarService.ProbeNextHop:input_type -> router.ProbeNextHopRequest
+	62, // 68: router.SliceRouterSidecarService.GetCapabilities:input_type -> google.protobuf.Empty
+	55, // 69: router.SliceRouterSidecarService.GetKernelRoutes:input_type -> router.KernelRoutesRequest
+	62, // 70: router.SliceRouterSidecarService.GetLogLevel:input_type -> google.protobuf.Empty
+	59, // 71: router.SliceRouterSidecarService.SetLogLevel:input_type -> router.LogLevel
+	24, // 72: router.SliceRouterSidecarService.SyncRoutes:input_type -> router.SyncRoutesRequest
+	26, // 73: router.SliceRouterSidecarService.CleanupVppRoute:input_type -> router.VppRouteCleanupRequest
+	33, // 74: router.SliceRouterSidecarService.InjectRouteGroup:input_type -> router.RouteGroupRequest
+	8,  // 75: router.SliceRouterSidecarService.UpdateSliceGwConnectionContext:output_type -> router.SidecarResponse
+	39, // 76: router.SliceRouterSidecarService.GetSliceRouterClientConnectionInfo:output_type -> router.ClientConnectionInfo
+	11, // 77: router.SliceRouterSidecarService.GetRouteInKernel:output_type -> router.VerifyRouteAddResponse
+	8,  // 78: router.SliceRouterSidecarService.UpdateEcmpRoutes:output_type -> router.SidecarResponse
+	14, // 79: router.SliceRouterSidecarService.GetRouteStatus:output_type -> router.RouteStatusResponse
+	21, // 80: router.SliceRouterSidecarService.InjectRoutes:output_type -> router.RouteBatchResponse
+	32, // 81: router.SliceRouterSidecarService.InjectAggregateRoute:output_type -> router.AggregateRouteResponse
+	35, // 82: router.SliceRouterSidecarService.GetRouteTable:output_type -> router.RouteTable
+	37, // 83: router.SliceRouterSidecarService.GetClientConnection:output_type -> router.ConnectionInfo
+	46, // 84: router.SliceRouterSidecarService.GetVppConfigSummary:output_type -> router.VppConfigSummary
+	42, // 85: router.SliceRouterSidecarService.GetRoutesByInterface:output_type -> router.InterfaceRouteList
+	45, // 86: router.SliceRouterSidecarService.GetRouteStatistics:output_type -> router.RouteStatisticsList
+	50, // 87: router.SliceRouterSidecarService.GetSupportBundle:output_type -> router.SupportBundle
+	23, // 88: router.SliceRouterSidecarService.EnsureRoutes:output_type -> router.EnsureRoutesResponse
+	49, // 89: router.SliceRouterSidecarService.GetRouteAuditLog:output_type -> router.RouteAuditLog
+	8,  // 90: router.SliceRouterSidecarService.InjectPolicyRoute:output_type -> router.SidecarResponse
+	40, // 91: router.SliceRouterSidecarService.GetDataplaneConnections:output_type -> router.DataplaneConnections
+	8,  // 92: router.SliceRouterSidecarService.ReconcileNow:output_type -> router.SidecarResponse
+	51, // 93: router.SliceRouterSidecarService.GetReconcileStatus:output_type -> router.ReconcileStatus
+	18, // 94: router.SliceRouterSidecarService.RefreshNextHops:output_type -> router.RefreshNextHopsResponse
+	39, // 95: router.SliceRouterSidecarService.RescanClientConnections:output_type -> router.ClientConnectionInfo
+	53, // 96: router.SliceRouterSidecarService.ProbeNextHop:output_type -> router.ProbeNextHopResponse
+	54, // 97: router.SliceRouterSidecarService.GetCapabilities:output_type -> router.Capabilities
+	58, // 98: router.SliceRouterSidecarService.GetKernelRoutes:output_type -> router.KernelRouteList
+	59, // 99: router.SliceRouterSidecarService.GetLogLevel:output_type -> router.LogLevel
+	59, // 100: router.SliceRouterSidecarService.SetLogLevel:output_type -> router.LogLevel
+	28, // 101: router.SliceRouterSidecarService.SyncRoutes:output_type -> router.SyncRoutesResponse
+	27, // 102: router.SliceRouterSidecarService.CleanupVppRoute:output_type -> router.VppRouteCleanupResponse
+	34, // 103: router.SliceRouterSidecarService.InjectRouteGroup:output_type -> router.RouteGroupResponse
+	75, // [75:104] is the sub-list for method output_type
+	46, // [46:75] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_router_sidecar_proto_init() }
@@ -687,8 +5298,260 @@ func file_router_sidecar_proto_init() {
 				return nil
 			}
 		}
-		file_router_sidecar_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SliceGwConContext); i {
+		file_router_sidecar_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SliceGwConContext); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyRouteAddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyRouteAddResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextHopNeighbor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyRoute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconcileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshNextHopsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnsureRoutesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnsureRoutesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRoutesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteSyncResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VppRouteCleanupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VppRouteCleanupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRoutesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextHopStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -699,8 +5562,8 @@ func file_router_sidecar_proto_init() {
 				return nil
 			}
 		}
-		file_router_sidecar_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyRouteAddRequest); i {
+		file_router_sidecar_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateRouteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -711,8 +5574,8 @@ func file_router_sidecar_proto_init() {
 				return nil
 			}
 		}
-		file_router_sidecar_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyRouteAddResponse); i {
+		file_router_sidecar_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggregateRouteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -723,7 +5586,43 @@ func file_router_sidecar_proto_init() {
 				return nil
 			}
 		}
-		file_router_sidecar_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_router_sidecar_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteGroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteGroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteTable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EcmpUpdateInfo); i {
 			case 0:
 				return &v.state
@@ -735,7 +5634,7 @@ func file_router_sidecar_proto_init() {
 				return nil
 			}
 		}
-		file_router_sidecar_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_router_sidecar_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConnectionInfo); i {
 			case 0:
 				return &v.state
@@ -747,7 +5646,19 @@ func file_router_sidecar_proto_init() {
 				return nil
 			}
 		}
-		file_router_sidecar_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_router_sidecar_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientConnectionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientConnectionInfo); i {
 			case 0:
 				return &v.state
@@ -759,14 +5670,254 @@ func file_router_sidecar_proto_init() {
 				return nil
 			}
 		}
+		file_router_sidecar_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataplaneConnections); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfaceRoutes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfaceRouteList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfaceCounters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteStatisticsList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VppConfigSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteAuditEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteAuditLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SupportBundle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconcileStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProbeNextHopRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProbeNextHopResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capabilities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KernelRoutesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KernelNextHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KernelRoute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KernelRouteList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_sidecar_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_router_sidecar_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      8,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,6 +31,59 @@ type SliceRouterSidecarServiceClient interface {
 	GetRouteInKernel(ctx context.Context, in *VerifyRouteAddRequest, opts ...grpc.CallOption) (*VerifyRouteAddResponse, error)
 	// Updates Ecmp routes in the router
 	UpdateEcmpRoutes(ctx context.Context, in *EcmpUpdateInfo, opts ...grpc.CallOption) (*SidecarResponse, error)
+	// Checks if the route to a remote subnet is installed in the dataplane with the expected next hop
+	GetRouteStatus(ctx context.Context, in *RouteStatusRequest, opts ...grpc.CallOption) (*RouteStatusResponse, error)
+	// Injects a batch of remote subnet routes in the slice router
+	InjectRoutes(ctx context.Context, in *RouteBatch, opts ...grpc.CallOption) (*RouteBatchResponse, error)
+	// Injects the routes to many remote subnets with the same next hops as the fewest aggregate routes
+	InjectAggregateRoute(ctx context.Context, in *AggregateRouteRequest, opts ...grpc.CallOption) (*AggregateRouteResponse, error)
+	// Provides the routes injected in the slice router along with the health of their next hops
+	GetRouteTable(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RouteTable, error)
+	// Provides connection information of a single client connected to the slice router
+	GetClientConnection(ctx context.Context, in *ClientConnectionRequest, opts ...grpc.CallOption) (*ConnectionInfo, error)
+	// Provides a summary of the config reported by the vpp agent
+	GetVppConfigSummary(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VppConfigSummary, error)
+	// Provides the remote subnets routed via each nsm interface of the slice router
+	GetRoutesByInterface(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InterfaceRouteList, error)
+	// Provides the traffic counters of the egress interfaces of each slice route, in kernel mode
+	GetRouteStatistics(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RouteStatisticsList, error)
+	// Collects the state of the sidecar for support bundles
+	GetSupportBundle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SupportBundle, error)
+	// Brings the slice router routes to the desired set, removing the routes not in the set
+	EnsureRoutes(ctx context.Context, in *EnsureRoutesRequest, opts ...grpc.CallOption) (*EnsureRoutesResponse, error)
+	// Provides the recent route operations performed on the slice router dataplane
+	GetRouteAuditLog(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RouteAuditLog, error)
+	// Injects a route in a custom routing table along with the ip rule selecting the table
+	InjectPolicyRoute(ctx context.Context, in *PolicyRoute, opts ...grpc.CallOption) (*SidecarResponse, error)
+	// Provides the client connections seen by both the kernel and vpp dataplanes, whatever the mode
+	GetDataplaneConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DataplaneConnections, error)
+	// Reconciles the routing table with the injected routes now, optionally limited to some prefixes
+	ReconcileNow(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*SidecarResponse, error)
+	// Provides when the routing table was last reconciled and when it will next be
+	GetReconcileStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReconcileStatus, error)
+	// Enumerates the client connections again and reprograms the routes whose next hop pods moved
+	RefreshNextHops(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RefreshNextHopsResponse, error)
+	// Derives the client connections from the dataplane again, bypassing and refreshing the connection cache
+	RescanClientConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClientConnectionInfo, error)
+	// Probes the reachability of a next hop from the slice router
+	ProbeNextHop(ctx context.Context, in *ProbeNextHopRequest, opts ...grpc.CallOption) (*ProbeNextHopResponse, error)
+	// Provides the features supported by the slice router with its dataplane mode and feature flags
+	GetCapabilities(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Capabilities, error)
+	// Provides the routes of a kernel routing table exactly as listed by netlink, in kernel mode
+	GetKernelRoutes(ctx context.Context, in *KernelRoutesRequest, opts ...grpc.CallOption) (*KernelRouteList, error)
+	// Provides the level the sidecar logs messages from
+	GetLogLevel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*LogLevel, error)
+	// Changes the level the sidecar logs messages from until it restarts, returning the new level
+	SetLogLevel(ctx context.Context, in *LogLevel, opts ...grpc.CallOption) (*LogLevel, error)
+	// Converges the slice router routes to the desired set, adding, deleting and correcting routes,
+	// and returns the change made to each route
+	SyncRoutes(ctx context.Context, in *SyncRoutesRequest, opts ...grpc.CallOption) (*SyncRoutesResponse, error)
+	// Deletes the vpp route entries of a prefix that are not via the next hops of its route and adds
+	// the missing ones, for the vpp dataplane only
+	CleanupVppRoute(ctx context.Context, in *VppRouteCleanupRequest, opts ...grpc.CallOption) (*VppRouteCleanupResponse, error)
+	// Injects a named group of routes sharing their next hops as one unit: all the routes of the
+	// group are installed or none is
+	InjectRouteGroup(ctx context.Context, in *RouteGroupRequest, opts ...grpc.CallOption) (*RouteGroupResponse, error)
 }
 
 type sliceRouterSidecarServiceClient struct {
@@ -77,6 +130,231 @@ func (c *sliceRouterSidecarServiceClient) UpdateEcmpRoutes(ctx context.Context,
 	return out, nil
 }
 
+func (c *sliceRouterSidecarServiceClient) GetRouteStatus(ctx context.Context, in *RouteStatusRequest, opts ...grpc.CallOption) (*RouteStatusResponse, error) {
+	out := new(RouteStatusResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetRouteStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) InjectRoutes(ctx context.Context, in *RouteBatch, opts ...grpc.CallOption) (*RouteBatchResponse, error) {
+	out := new(RouteBatchResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/InjectRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) InjectAggregateRoute(ctx context.Context, in *AggregateRouteRequest, opts ...grpc.CallOption) (*AggregateRouteResponse, error) {
+	out := new(AggregateRouteResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/InjectAggregateRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetRouteTable(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RouteTable, error) {
+	out := new(RouteTable)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetRouteTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetClientConnection(ctx context.Context, in *ClientConnectionRequest, opts ...grpc.CallOption) (*ConnectionInfo, error) {
+	out := new(ConnectionInfo)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetClientConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetVppConfigSummary(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VppConfigSummary, error) {
+	out := new(VppConfigSummary)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetVppConfigSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetRoutesByInterface(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*InterfaceRouteList, error) {
+	out := new(InterfaceRouteList)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetRoutesByInterface", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetRouteStatistics(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RouteStatisticsList, error) {
+	out := new(RouteStatisticsList)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetRouteStatistics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetSupportBundle(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SupportBundle, error) {
+	out := new(SupportBundle)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetSupportBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) EnsureRoutes(ctx context.Context, in *EnsureRoutesRequest, opts ...grpc.CallOption) (*EnsureRoutesResponse, error) {
+	out := new(EnsureRoutesResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/EnsureRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetRouteAuditLog(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RouteAuditLog, error) {
+	out := new(RouteAuditLog)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetRouteAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) InjectPolicyRoute(ctx context.Context, in *PolicyRoute, opts ...grpc.CallOption) (*SidecarResponse, error) {
+	out := new(SidecarResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/InjectPolicyRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetDataplaneConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DataplaneConnections, error) {
+	out := new(DataplaneConnections)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetDataplaneConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) ReconcileNow(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*SidecarResponse, error) {
+	out := new(SidecarResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/ReconcileNow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetReconcileStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReconcileStatus, error) {
+	out := new(ReconcileStatus)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetReconcileStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) RefreshNextHops(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RefreshNextHopsResponse, error) {
+	out := new(RefreshNextHopsResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/RefreshNextHops", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) RescanClientConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClientConnectionInfo, error) {
+	out := new(ClientConnectionInfo)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/RescanClientConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) ProbeNextHop(ctx context.Context, in *ProbeNextHopRequest, opts ...grpc.CallOption) (*ProbeNextHopResponse, error) {
+	out := new(ProbeNextHopResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/ProbeNextHop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetCapabilities(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Capabilities, error) {
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetKernelRoutes(ctx context.Context, in *KernelRoutesRequest, opts ...grpc.CallOption) (*KernelRouteList, error) {
+	out := new(KernelRouteList)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetKernelRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) GetLogLevel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*LogLevel, error) {
+	out := new(LogLevel)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/GetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) SetLogLevel(ctx context.Context, in *LogLevel, opts ...grpc.CallOption) (*LogLevel, error) {
+	out := new(LogLevel)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) SyncRoutes(ctx context.Context, in *SyncRoutesRequest, opts ...grpc.CallOption) (*SyncRoutesResponse, error) {
+	out := new(SyncRoutesResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/SyncRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) CleanupVppRoute(ctx context.Context, in *VppRouteCleanupRequest, opts ...grpc.CallOption) (*VppRouteCleanupResponse, error) {
+	out := new(VppRouteCleanupResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/CleanupVppRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sliceRouterSidecarServiceClient) InjectRouteGroup(ctx context.Context, in *RouteGroupRequest, opts ...grpc.CallOption) (*RouteGroupResponse, error) {
+	out := new(RouteGroupResponse)
+	err := c.cc.Invoke(ctx, "/router.SliceRouterSidecarService/InjectRouteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SliceRouterSidecarServiceServer is the server API for SliceRouterSidecarService service.
 // All implementations must embed UnimplementedSliceRouterSidecarServiceServer
 // for forward compatibility
@@ -89,6 +367,59 @@ type SliceRouterSidecarServiceServer interface {
 	GetRouteInKernel(context.Context, *VerifyRouteAddRequest) (*VerifyRouteAddResponse, error)
 	// Updates Ecmp routes in the router
 	UpdateEcmpRoutes(context.Context, *EcmpUpdateInfo) (*SidecarResponse, error)
+	// Checks if the route to a remote subnet is installed in the dataplane with the expected next hop
+	GetRouteStatus(context.Context, *RouteStatusRequest) (*RouteStatusResponse, error)
+	// Injects a batch of remote subnet routes in the slice router
+	InjectRoutes(context.Context, *RouteBatch) (*RouteBatchResponse, error)
+	// Injects the routes to many remote subnets with the same next hops as the fewest aggregate routes
+	InjectAggregateRoute(context.Context, *AggregateRouteRequest) (*AggregateRouteResponse, error)
+	// Provides the routes injected in the slice router along with the health of their next hops
+	GetRouteTable(context.Context, *empty.Empty) (*RouteTable, error)
+	// Provides connection information of a single client connected to the slice router
+	GetClientConnection(context.Context, *ClientConnectionRequest) (*ConnectionInfo, error)
+	// Provides a summary of the config reported by the vpp agent
+	GetVppConfigSummary(context.Context, *empty.Empty) (*VppConfigSummary, error)
+	// Provides the remote subnets routed via each nsm interface of the slice router
+	GetRoutesByInterface(context.Context, *empty.Empty) (*InterfaceRouteList, error)
+	// Provides the traffic counters of the egress interfaces of each slice route, in kernel mode
+	GetRouteStatistics(context.Context, *empty.Empty) (*RouteStatisticsList, error)
+	// Collects the state of the sidecar for support bundles
+	GetSupportBundle(context.Context, *empty.Empty) (*SupportBundle, error)
+	// Brings the slice router routes to the desired set, removing the routes not in the set
+	EnsureRoutes(context.Context, *EnsureRoutesRequest) (*EnsureRoutesResponse, error)
+	// Provides the recent route operations performed on the slice router dataplane
+	GetRouteAuditLog(context.Context, *empty.Empty) (*RouteAuditLog, error)
+	// Injects a route in a custom routing table along with the ip rule selecting the table
+	InjectPolicyRoute(context.Context, *PolicyRoute) (*SidecarResponse, error)
+	// Provides the client connections seen by both the kernel and vpp dataplanes, whatever the mode
+	GetDataplaneConnections(context.Context, *empty.Empty) (*DataplaneConnections, error)
+	// Reconciles the routing table with the injected routes now, optionally limited to some prefixes
+	ReconcileNow(context.Context, *ReconcileRequest) (*SidecarResponse, error)
+	// Provides when the routing table was last reconciled and when it will next be
+	GetReconcileStatus(context.Context, *empty.Empty) (*ReconcileStatus, error)
+	// Enumerates the client connections again and reprograms the routes whose next hop pods moved
+	RefreshNextHops(context.Context, *empty.Empty) (*RefreshNextHopsResponse, error)
+	// Derives the client connections from the dataplane again, bypassing and refreshing the connection cache
+	RescanClientConnections(context.Context, *empty.Empty) (*ClientConnectionInfo, error)
+	// Probes the reachability of a next hop from the slice router
+	ProbeNextHop(context.Context, *ProbeNextHopRequest) (*ProbeNextHopResponse, error)
+	// Provides the features supported by the slice router with its dataplane mode and feature flags
+	GetCapabilities(context.Context, *empty.Empty) (*Capabilities, error)
+	// Provides the routes of a kernel routing table exactly as listed by netlink, in kernel mode
+	GetKernelRoutes(context.Context, *KernelRoutesRequest) (*KernelRouteList, error)
+	// Provides the level the sidecar logs messages from
+	GetLogLevel(context.Context, *empty.Empty) (*LogLevel, error)
+	// Changes the level the sidecar logs messages from until it restarts, returning the new level
+	SetLogLevel(context.Context, *LogLevel) (*LogLevel, error)
+	// Converges the slice router routes to the desired set, adding, deleting and correcting routes,
+	// and returns the change made to each route
+	SyncRoutes(context.Context, *SyncRoutesRequest) (*SyncRoutesResponse, error)
+	// Deletes the vpp route entries of a prefix that are not via the next hops of its route and adds
+	// the missing ones, for the vpp dataplane only
+	CleanupVppRoute(context.Context, *VppRouteCleanupRequest) (*VppRouteCleanupResponse, error)
+	// Injects a named group of routes sharing their next hops as one unit: all the routes of the
+	// group are installed or none is
+	InjectRouteGroup(context.Context, *RouteGroupRequest) (*RouteGroupResponse, error)
 	mustEmbedUnimplementedSliceRouterSidecarServiceServer()
 }
 
@@ -108,6 +439,81 @@ func (UnimplementedSliceRouterSidecarServiceServer) GetRouteInKernel(context.Con
 func (UnimplementedSliceRouterSidecarServiceServer) UpdateEcmpRoutes(context.Context, *EcmpUpdateInfo) (*SidecarResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEcmpRoutes not implemented")
 }
+func (UnimplementedSliceRouterSidecarServiceServer) GetRouteStatus(context.Context, *RouteStatusRequest) (*RouteStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRouteStatus not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) InjectRoutes(context.Context, *RouteBatch) (*RouteBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectRoutes not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) InjectAggregateRoute(context.Context, *AggregateRouteRequest) (*AggregateRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectAggregateRoute not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetRouteTable(context.Context, *empty.Empty) (*RouteTable, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRouteTable not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetClientConnection(context.Context, *ClientConnectionRequest) (*ConnectionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientConnection not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetVppConfigSummary(context.Context, *empty.Empty) (*VppConfigSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVppConfigSummary not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetRoutesByInterface(context.Context, *empty.Empty) (*InterfaceRouteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoutesByInterface not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetRouteStatistics(context.Context, *empty.Empty) (*RouteStatisticsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRouteStatistics not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetSupportBundle(context.Context, *empty.Empty) (*SupportBundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportBundle not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) EnsureRoutes(context.Context, *EnsureRoutesRequest) (*EnsureRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnsureRoutes not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetRouteAuditLog(context.Context, *empty.Empty) (*RouteAuditLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRouteAuditLog not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) InjectPolicyRoute(context.Context, *PolicyRoute) (*SidecarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectPolicyRoute not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetDataplaneConnections(context.Context, *empty.Empty) (*DataplaneConnections, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataplaneConnections not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) ReconcileNow(context.Context, *ReconcileRequest) (*SidecarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileNow not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetReconcileStatus(context.Context, *empty.Empty) (*ReconcileStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReconcileStatus not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) RefreshNextHops(context.Context, *empty.Empty) (*RefreshNextHopsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshNextHops not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) RescanClientConnections(context.Context, *empty.Empty) (*ClientConnectionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescanClientConnections not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) ProbeNextHop(context.Context, *ProbeNextHopRequest) (*ProbeNextHopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeNextHop not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetCapabilities(context.Context, *empty.Empty) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetKernelRoutes(context.Context, *KernelRoutesRequest) (*KernelRouteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKernelRoutes not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) GetLogLevel(context.Context, *empty.Empty) (*LogLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevel not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) SetLogLevel(context.Context, *LogLevel) (*LogLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) SyncRoutes(context.Context, *SyncRoutesRequest) (*SyncRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncRoutes not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) CleanupVppRoute(context.Context, *VppRouteCleanupRequest) (*VppRouteCleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupVppRoute not implemented")
+}
+func (UnimplementedSliceRouterSidecarServiceServer) InjectRouteGroup(context.Context, *RouteGroupRequest) (*RouteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectRouteGroup not implemented")
+}
 func (UnimplementedSliceRouterSidecarServiceServer) mustEmbedUnimplementedSliceRouterSidecarServiceServer() {
 }
 
@@ -194,28 +600,578 @@ func _SliceRouterSidecarService_UpdateEcmpRoutes_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
-// SliceRouterSidecarService_ServiceDesc is the grpc.ServiceDesc for SliceRouterSidecarService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var SliceRouterSidecarService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "router.SliceRouterSidecarService",
-	HandlerType: (*SliceRouterSidecarServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "UpdateSliceGwConnectionContext",
-			Handler:    _SliceRouterSidecarService_UpdateSliceGwConnectionContext_Handler,
-		},
-		{
-			MethodName: "GetSliceRouterClientConnectionInfo",
-			Handler:    _SliceRouterSidecarService_GetSliceRouterClientConnectionInfo_Handler,
-		},
-		{
-			MethodName: "GetRouteInKernel",
-			Handler:    _SliceRouterSidecarService_GetRouteInKernel_Handler,
-		},
-		{
-			MethodName: "UpdateEcmpRoutes",
-			Handler:    _SliceRouterSidecarService_UpdateEcmpRoutes_Handler,
+func _SliceRouterSidecarService_GetRouteStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetRouteStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteStatus(ctx, req.(*RouteStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_InjectRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).InjectRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/InjectRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).InjectRoutes(ctx, req.(*RouteBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_InjectAggregateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).InjectAggregateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/InjectAggregateRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).InjectAggregateRoute(ctx, req.(*AggregateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetRouteTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetRouteTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteTable(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetClientConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetClientConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetClientConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetClientConnection(ctx, req.(*ClientConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetVppConfigSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetVppConfigSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetVppConfigSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetVppConfigSummary(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetRoutesByInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetRoutesByInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetRoutesByInterface",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetRoutesByInterface(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetRouteStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetRouteStatistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteStatistics(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetSupportBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetSupportBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetSupportBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetSupportBundle(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_EnsureRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnsureRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).EnsureRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/EnsureRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).EnsureRoutes(ctx, req.(*EnsureRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetRouteAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetRouteAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetRouteAuditLog(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_InjectPolicyRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyRoute)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).InjectPolicyRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/InjectPolicyRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).InjectPolicyRoute(ctx, req.(*PolicyRoute))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetDataplaneConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetDataplaneConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetDataplaneConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetDataplaneConnections(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_ReconcileNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).ReconcileNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/ReconcileNow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).ReconcileNow(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetReconcileStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetReconcileStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetReconcileStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetReconcileStatus(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_RefreshNextHops_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).RefreshNextHops(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/RefreshNextHops",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).RefreshNextHops(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_RescanClientConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).RescanClientConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/RescanClientConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).RescanClientConnections(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_ProbeNextHop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeNextHopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).ProbeNextHop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/ProbeNextHop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).ProbeNextHop(ctx, req.(*ProbeNextHopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetCapabilities(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetKernelRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KernelRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetKernelRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetKernelRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetKernelRoutes(ctx, req.(*KernelRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_GetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).GetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/GetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).GetLogLevel(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevel)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).SetLogLevel(ctx, req.(*LogLevel))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_SyncRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).SyncRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/SyncRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).SyncRoutes(ctx, req.(*SyncRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_CleanupVppRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VppRouteCleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).CleanupVppRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/CleanupVppRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).CleanupVppRoute(ctx, req.(*VppRouteCleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SliceRouterSidecarService_InjectRouteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SliceRouterSidecarServiceServer).InjectRouteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.SliceRouterSidecarService/InjectRouteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SliceRouterSidecarServiceServer).InjectRouteGroup(ctx, req.(*RouteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SliceRouterSidecarService_ServiceDesc is the grpc.ServiceDesc for SliceRouterSidecarService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SliceRouterSidecarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "router.SliceRouterSidecarService",
+	HandlerType: (*SliceRouterSidecarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpdateSliceGwConnectionContext",
+			Handler:    _SliceRouterSidecarService_UpdateSliceGwConnectionContext_Handler,
+		},
+		{
+			MethodName: "GetSliceRouterClientConnectionInfo",
+			Handler:    _SliceRouterSidecarService_GetSliceRouterClientConnectionInfo_Handler,
+		},
+		{
+			MethodName: "GetRouteInKernel",
+			Handler:    _SliceRouterSidecarService_GetRouteInKernel_Handler,
+		},
+		{
+			MethodName: "UpdateEcmpRoutes",
+			Handler:    _SliceRouterSidecarService_UpdateEcmpRoutes_Handler,
+		},
+		{
+			MethodName: "GetRouteStatus",
+			Handler:    _SliceRouterSidecarService_GetRouteStatus_Handler,
+		},
+		{
+			MethodName: "InjectRoutes",
+			Handler:    _SliceRouterSidecarService_InjectRoutes_Handler,
+		},
+		{
+			MethodName: "InjectAggregateRoute",
+			Handler:    _SliceRouterSidecarService_InjectAggregateRoute_Handler,
+		},
+		{
+			MethodName: "GetRouteTable",
+			Handler:    _SliceRouterSidecarService_GetRouteTable_Handler,
+		},
+		{
+			MethodName: "GetClientConnection",
+			Handler:    _SliceRouterSidecarService_GetClientConnection_Handler,
+		},
+		{
+			MethodName: "GetVppConfigSummary",
+			Handler:    _SliceRouterSidecarService_GetVppConfigSummary_Handler,
+		},
+		{
+			MethodName: "GetRoutesByInterface",
+			Handler:    _SliceRouterSidecarService_GetRoutesByInterface_Handler,
+		},
+		{
+			MethodName: "GetRouteStatistics",
+			Handler:    _SliceRouterSidecarService_GetRouteStatistics_Handler,
+		},
+		{
+			MethodName: "GetSupportBundle",
+			Handler:    _SliceRouterSidecarService_GetSupportBundle_Handler,
+		},
+		{
+			MethodName: "EnsureRoutes",
+			Handler:    _SliceRouterSidecarService_EnsureRoutes_Handler,
+		},
+		{
+			MethodName: "GetRouteAuditLog",
+			Handler:    _SliceRouterSidecarService_GetRouteAuditLog_Handler,
+		},
+		{
+			MethodName: "InjectPolicyRoute",
+			Handler:    _SliceRouterSidecarService_InjectPolicyRoute_Handler,
+		},
+		{
+			MethodName: "GetDataplaneConnections",
+			Handler:    _SliceRouterSidecarService_GetDataplaneConnections_Handler,
+		},
+		{
+			MethodName: "ReconcileNow",
+			Handler:    _SliceRouterSidecarService_ReconcileNow_Handler,
+		},
+		{
+			MethodName: "GetReconcileStatus",
+			Handler:    _SliceRouterSidecarService_GetReconcileStatus_Handler,
+		},
+		{
+			MethodName: "RefreshNextHops",
+			Handler:    _SliceRouterSidecarService_RefreshNextHops_Handler,
+		},
+		{
+			MethodName: "RescanClientConnections",
+			Handler:    _SliceRouterSidecarService_RescanClientConnections_Handler,
+		},
+		{
+			MethodName: "ProbeNextHop",
+			Handler:    _SliceRouterSidecarService_ProbeNextHop_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _SliceRouterSidecarService_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetKernelRoutes",
+			Handler:    _SliceRouterSidecarService_GetKernelRoutes_Handler,
+		},
+		{
+			MethodName: "GetLogLevel",
+			Handler:    _SliceRouterSidecarService_GetLogLevel_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _SliceRouterSidecarService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "SyncRoutes",
+			Handler:    _SliceRouterSidecarService_SyncRoutes_Handler,
+		},
+		{
+			MethodName: "CleanupVppRoute",
+			Handler:    _SliceRouterSidecarService_CleanupVppRoute_Handler,
+		},
+		{
+			MethodName: "InjectRouteGroup",
+			Handler:    _SliceRouterSidecarService_InjectRouteGroup_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},